@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	"github.com/9triver/iarnet-global/internal/fakenode"
+	"github.com/9triver/iarnet-global/internal/util"
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	registryAddr := flag.String("registry", "127.0.0.1:50010", "Global registry RPC address (comma-separated replica addresses in HA mode)")
+	domainID := flag.String("domain", "", "Domain to register into")
+	nodeID := flag.String("id", "", "Node ID (generated when empty)")
+	nodeName := flag.String("name", "", "Node name (defaults to the node ID)")
+	joinToken := flag.String("join-token", "", "Join token of the domain (required when the registry enforces join tokens)")
+	listen := flag.String("listen", "127.0.0.1:0", "SchedulerService listen address")
+	advertise := flag.String("advertise", "", "Address reported to the registry (defaults to the listen address)")
+	cpu := flag.Int64("cpu", 8000, "CPU capacity in millicores")
+	memory := flag.Int64("memory", 16<<30, "Memory capacity in bytes")
+	gpu := flag.Int64("gpu", 0, "GPU count")
+	extended := flag.String("extended", "", "Extended resources, e.g. camera=1,npu=2")
+	labels := flag.String("labels", "", "Node labels, e.g. zone=east,arch=arm64")
+	tags := flag.String("tags", "", "Resource tags (cpu,gpu,memory,camera); derived from capacity when empty")
+	head := flag.Bool("head", false, "Report as head node")
+	slow := flag.Duration("slow", 0, "Delay before answering DeployComponent")
+	rejectRate := flag.Float64("reject-rate", 0, "Probability (0-1) of rejecting a deployment")
+	rejectMessage := flag.String("reject-message", "", "Error returned for rejected deployments")
+	crashAfter := flag.Int("crash-after", 0, "Crash after this many successful deployments (0 = never)")
+	flap := flag.Duration("flap", 0, "Alternately pause and resume heartbeats with this period")
+	flag.Parse()
+
+	util.InitLogger()
+
+	extendedResources, err := parseInts(*extended)
+	if err != nil {
+		log.Fatalf("Invalid -extended: %v", err)
+	}
+	nodeLabels, err := parsePairs(*labels)
+	if err != nil {
+		log.Fatalf("Invalid -labels: %v", err)
+	}
+
+	node, err := fakenode.New(fakenode.Options{
+		RegistryAddr:  *registryAddr,
+		DomainID:      *domainID,
+		NodeID:        *nodeID,
+		NodeName:      *nodeName,
+		JoinToken:     *joinToken,
+		ListenAddr:    *listen,
+		AdvertiseAddr: *advertise,
+		Capacity: &registry.ResourceInfo{
+			CPU:      *cpu,
+			Memory:   *memory,
+			GPU:      *gpu,
+			Extended: extendedResources,
+		},
+		Labels: nodeLabels,
+		Tags:   splitList(*tags),
+		IsHead: *head,
+		Faults: fakenode.Faults{
+			Slow:              *slow,
+			RejectRate:        *rejectRate,
+			RejectMessage:     *rejectMessage,
+			CrashAfterDeploys: *crashAfter,
+			FlapPeriod:        *flap,
+		},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := node.Start(ctx); err != nil {
+		logrus.Fatalf("Failed to start fake node: %v", err)
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	<-sigCh
+	node.Stop()
+}
+
+func splitList(value string) []string {
+	result := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func parsePairs(value string) (map[string]string, error) {
+	result := make(map[string]string)
+	for _, item := range splitList(value) {
+		key, val, ok := strings.Cut(item, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("expected key=value, got %q", item)
+		}
+		result[key] = val
+	}
+	return result, nil
+}
+
+func parseInts(value string) (map[string]int64, error) {
+	pairs, err := parsePairs(value)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]int64, len(pairs))
+	for key, val := range pairs {
+		n, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quantity for %s: %w", key, err)
+		}
+		result[key] = n
+	}
+	return result, nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/9triver/iarnet-global/internal/simulator"
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	topologyFile := flag.String("topology", "topology.yaml", "Path to the virtual cluster topology")
+	traceFile := flag.String("trace", "trace.yaml", "Path to the deploy/stop event trace")
+	strategies := flag.String("strategy", "iarnet", "Comma-separated scheduling strategies to compare (available: "+strings.Join(simulator.Strategies(), ", ")+")")
+	horizon := flag.Duration("horizon", 0, "Simulated time to stop at (0 = after the last event)")
+	seed := flag.Int64("seed", 1, "Random seed for randomized strategies")
+	jsonOutput := flag.Bool("json", false, "Print reports as JSON")
+	verbose := flag.Bool("v", false, "Print scheduler logs")
+	flag.Parse()
+
+	if !*verbose {
+		logrus.SetLevel(logrus.ErrorLevel)
+	}
+
+	topology, err := simulator.LoadTopology(*topologyFile)
+	if err != nil {
+		log.Fatalf("Load topology: %v", err)
+	}
+	trace, err := simulator.LoadTrace(*traceFile)
+	if err != nil {
+		log.Fatalf("Load trace: %v", err)
+	}
+
+	reports := make([]*simulator.Report, 0)
+	for _, name := range strings.Split(*strategies, ",") {
+		strategy, err := simulator.NewStrategy(strings.TrimSpace(name), *seed)
+		if err != nil {
+			log.Fatal(err)
+		}
+		report, err := simulator.Run(context.Background(), simulator.Options{
+			Topology: topology,
+			Trace:    trace,
+			Strategy: strategy,
+			Horizon:  *horizon,
+		})
+		if err != nil {
+			log.Fatalf("Simulate %s: %v", strategy.Name(), err)
+		}
+		reports = append(reports, report)
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(reports); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if err := simulator.WriteTable(os.Stdout, reports); err != nil {
+		log.Fatal(err)
+	}
+}
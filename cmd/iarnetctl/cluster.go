@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/9triver/iarnet-global/internal/ctl"
+	"github.com/9triver/iarnet-global/internal/ha"
+)
+
+// clusterStatus 显示所连接副本的 Raft 状态与集群成员（需以 HA 模式运行）
+func clusterStatus(e *env, args []string) error {
+	fs := e.flags()
+	if _, err := e.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+	client, err := e.connect()
+	if err != nil {
+		return err
+	}
+
+	data, err := client.Get(background(), "/cluster/status", nil)
+	if err != nil {
+		return err
+	}
+	return e.print(data, func() (*ctl.Table, error) {
+		status := ha.Status{}
+		if err := json.Unmarshal(data, &status); err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(e.out, "Replica %s: %s (term %d, applied %d/%d)\n\n",
+			status.ID, status.State, status.Term, status.AppliedIndex, status.LastIndex)
+
+		t := &ctl.Table{Header: []string{"ID", "ROLE", "VOTER", "RAFT", "HTTP", "RPC"}}
+		for _, peer := range status.Peers {
+			role := "follower"
+			if peer.Leader {
+				role = "leader"
+			}
+			t.Append(peer.ID, role, strconv.FormatBool(peer.Voter), peer.RaftAddr, peer.HTTPAddr, peer.RPCAddr)
+		}
+		return t, nil
+	})
+}
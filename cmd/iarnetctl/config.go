@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/9triver/iarnet-global/internal/ctl"
+)
+
+// configSetContext 新增或更新上下文，--server/--token/--tenant 写入上下文
+func configSetContext(e *env, args []string) error {
+	fs := e.flags()
+	positional, err := e.parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	name := positional[0]
+	current := ctl.Context{Name: name, Server: ctl.DefaultServer}
+	if existing, err := e.config.Context(name); err == nil && existing.Name == name {
+		current = *existing
+	}
+	if e.server != "" {
+		current.Server = e.server
+	}
+	if e.token != "" {
+		current.Token = e.token
+	}
+	if e.tenant != "" {
+		current.Tenant = e.tenant
+	}
+
+	e.config.SetContext(current)
+	if err := e.config.Save(e.configPath); err != nil {
+		return err
+	}
+	fmt.Fprintf(e.out, "Context %q saved to %s\n", name, e.configPath)
+	return nil
+}
+
+// configUseContext 切换当前上下文
+func configUseContext(e *env, args []string) error {
+	fs := e.flags()
+	positional, err := e.parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	name := positional[0]
+	if _, err := e.config.Context(name); err != nil {
+		return err
+	}
+	e.config.CurrentContext = name
+	if err := e.config.Save(e.configPath); err != nil {
+		return err
+	}
+	fmt.Fprintf(e.out, "Switched to context %q\n", name)
+	return nil
+}
+
+// configGetContexts 列出上下文，访问令牌不会输出
+func configGetContexts(e *env, args []string) error {
+	fs := e.flags()
+	if _, err := e.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	redacted := *e.config
+	redacted.Contexts = make([]ctl.Context, len(e.config.Contexts))
+	for i, c := range e.config.Contexts {
+		if c.Token != "" {
+			c.Token = "REDACTED"
+		}
+		redacted.Contexts[i] = c
+	}
+	data, err := json.Marshal(redacted)
+	if err != nil {
+		return err
+	}
+
+	return e.print(data, func() (*ctl.Table, error) {
+		t := &ctl.Table{Header: []string{"CURRENT", "NAME", "SERVER", "TENANT", "TOKEN"}}
+		for _, c := range e.config.Contexts {
+			current := ""
+			if c.Name == e.config.CurrentContext {
+				current = "*"
+			}
+			token := ""
+			if c.Token != "" {
+				token = "set"
+			}
+			t.Append(current, c.Name, c.Server, c.Tenant, token)
+		}
+		return t, nil
+	})
+}
+
+// configCurrentContext 输出当前上下文名称
+func configCurrentContext(e *env, args []string) error {
+	fs := e.flags()
+	if _, err := e.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+	if e.config.CurrentContext == "" {
+		return fmt.Errorf("current context is not set")
+	}
+	fmt.Fprintln(e.out, e.config.CurrentContext)
+	return nil
+}
+
+// configDeleteContext 删除上下文
+func configDeleteContext(e *env, args []string) error {
+	fs := e.flags()
+	positional, err := e.parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	if err := e.config.DeleteContext(positional[0]); err != nil {
+		return err
+	}
+	if err := e.config.Save(e.configPath); err != nil {
+		return err
+	}
+	fmt.Fprintf(e.out, "Context %q deleted\n", positional[0])
+	return nil
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+
+	"github.com/9triver/iarnet-global/internal/ctl"
+	resourcepb "github.com/9triver/iarnet-global/internal/proto/resource"
+	schedulerpb "github.com/9triver/iarnet-global/internal/proto/scheduler"
+	schedulerAPI "github.com/9triver/iarnet-global/internal/transport/http/scheduler"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// deploy 通过全局调度器部署 component
+// 请求从 -f 指定的 DeployComponentRequest JSON 文件读取（"-" 表示标准输入），命令行参数覆盖文件中的对应字段
+func deploy(e *env, args []string) error {
+	fs := e.flags()
+	file := fs.String("f", "", "DeployComponentRequest in JSON, \"-\" reads from stdin")
+	runtime := fs.String("runtime", "", "Runtime environment, e.g. python")
+	cpu := fs.Int64("cpu", 0, "CPU request in millicores")
+	memory := fs.Int64("memory", 0, "Memory request in bytes")
+	gpu := fs.Int64("gpu", 0, "GPU request")
+	selectors := stringList{}
+	fs.Var(&selectors, "selector", "Node label selector, e.g. region=east (repeatable)")
+	priority := fs.Int("priority", 0, "Queue priority, higher is scheduled first")
+	maxWait := fs.Int64("max-wait", 0, "Seconds to wait in the queue when no capacity is available")
+	restartable := fs.Bool("restartable", false, "Reschedule the component automatically when its node is lost")
+	labels := fs.String("labels", "", "Component labels, e.g. app=web")
+	project := fs.String("project", "", "Deploy as this project (admin view only)")
+	dryRun := fs.Bool("dry-run", false, "Only simulate the placement and show the ranked candidates")
+	if _, err := e.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	req, err := readDeployRequest(*file)
+	if err != nil {
+		return err
+	}
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	if set["runtime"] {
+		req.RuntimeEnv = *runtime
+	}
+	if set["cpu"] || set["memory"] || set["gpu"] || set["selector"] {
+		if req.ResourceRequest == nil {
+			req.ResourceRequest = &resourcepb.Info{}
+		}
+		if set["cpu"] {
+			req.ResourceRequest.Cpu = *cpu
+		}
+		if set["memory"] {
+			req.ResourceRequest.Memory = *memory
+		}
+		if set["gpu"] {
+			req.ResourceRequest.Gpu = *gpu
+		}
+		req.ResourceRequest.Tags = append(req.ResourceRequest.Tags, selectors...)
+	}
+	if set["priority"] {
+		req.Priority = int32(*priority)
+	}
+	if set["max-wait"] {
+		req.MaxWaitSeconds = *maxWait
+	}
+	if set["restartable"] {
+		req.Restartable = *restartable
+	}
+	if set["labels"] {
+		if req.Labels, err = parseLabels(*labels); err != nil {
+			return err
+		}
+	}
+
+	raw, err := protojson.Marshal(req)
+	if err != nil {
+		return err
+	}
+	client, err := e.connect()
+	if err != nil {
+		return err
+	}
+
+	if *dryRun {
+		data, err := client.Post(background(), "/scheduler/simulate", schedulerAPI.SimulatePlacementRequest{Tenant: *project, Request: raw})
+		if err != nil {
+			return err
+		}
+		return e.print(data, func() (*ctl.Table, error) {
+			return simulationTable(e.out, data)
+		})
+	}
+
+	data, err := client.Post(background(), "/scheduler/deployments", schedulerAPI.DeployRequest{Tenant: *project, Request: raw})
+	if err != nil {
+		return err
+	}
+	resp := schedulerAPI.DeployResponse{}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return err
+	}
+	if resp.QueuePosition > 0 {
+		return e.printMessage(data, "Deployment %s queued (position %d)", resp.DeploymentID, resp.QueuePosition)
+	}
+	return e.printMessage(data, "Deployment %s %s on node %s (%s)", resp.DeploymentID, resp.Status, resp.NodeName, resp.NodeID)
+}
+
+// status 查看部署，指定 ID 时只显示该部署
+func status(e *env, args []string) error {
+	fs := e.flags()
+	nodeID := fs.String("node", "", "Only show deployments on this node")
+	domainID := fs.String("domain", "", "Only show deployments in this domain")
+	state := fs.String("status", "", "Only show deployments in this status")
+	active := fs.Bool("active", false, "Only show deployments that have not finished")
+	project := fs.String("project", "", "Only show deployments of this project (admin view only)")
+	positional, err := e.parse(fs, args, 0, 1)
+	if err != nil {
+		return err
+	}
+	client, err := e.connect()
+	if err != nil {
+		return err
+	}
+
+	if len(positional) == 1 {
+		data, err := client.Get(background(), "/scheduler/deployments/"+url.PathEscape(positional[0]), nil)
+		if err != nil {
+			return err
+		}
+		return e.print(data, func() (*ctl.Table, error) {
+			item := schedulerAPI.DeploymentItem{}
+			if err := json.Unmarshal(data, &item); err != nil {
+				return nil, err
+			}
+			return deploymentTable([]schedulerAPI.DeploymentItem{item}), nil
+		})
+	}
+
+	query := url.Values{}
+	for key, value := range map[string]string{"node_id": *nodeID, "domain_id": *domainID, "status": *state, "tenant": *project} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+	if *active {
+		query.Set("active", "true")
+	}
+	data, err := client.Get(background(), "/scheduler/deployments", query)
+	if err != nil {
+		return err
+	}
+	return e.print(data, func() (*ctl.Table, error) {
+		resp := schedulerAPI.GetDeploymentsResponse{}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return nil, err
+		}
+		return deploymentTable(resp.Deployments), nil
+	})
+}
+
+// stop 停止部署
+func stop(e *env, args []string) error {
+	fs := e.flags()
+	reason := fs.String("reason", "", "Reason recorded on the deployment")
+	positional, err := e.parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	client, err := e.connect()
+	if err != nil {
+		return err
+	}
+
+	path := "/scheduler/deployments/" + url.PathEscape(positional[0]) + "/stop"
+	data, err := client.Post(background(), path, schedulerAPI.StopDeploymentRequest{Reason: *reason})
+	if err != nil {
+		return err
+	}
+	return e.printMessage(data, "Deployment %s stopped", positional[0])
+}
+
+// readDeployRequest 读取部署请求，path 为空时返回空请求
+func readDeployRequest(path string) (*schedulerpb.DeployComponentRequest, error) {
+	req := &schedulerpb.DeployComponentRequest{}
+	if path == "" {
+		return req, nil
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request: %w", err)
+	}
+	if err := protojson.Unmarshal(data, req); err != nil {
+		return nil, fmt.Errorf("failed to parse request %s: %w", path, err)
+	}
+	return req, nil
+}
+
+// deploymentTable 部署列表表格
+func deploymentTable(deployments []schedulerAPI.DeploymentItem) *ctl.Table {
+	t := &ctl.Table{Header: []string{"ID", "TENANT", "STATUS", "NODE", "DOMAIN", "PRIORITY", "RESTARTS", "CREATED", "ERROR"}}
+	for _, d := range deployments {
+		node := d.NodeName
+		if node == "" {
+			node = d.NodeID
+		}
+		t.Append(d.ID, d.Tenant, d.Status, node, d.DomainID, strconv.Itoa(int(d.Priority)), strconv.Itoa(d.Restarts), d.CreatedAt, d.Error)
+	}
+	return t
+}
+
+// simulationTable 输出模拟调度结论及候选节点排名
+func simulationTable(w io.Writer, data json.RawMessage) (*ctl.Table, error) {
+	resp := schedulerAPI.SimulatePlacementResponse{}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, err
+	}
+	switch {
+	case resp.Placeable:
+		fmt.Fprintf(w, "Placeable for tenant %s, %d candidate(s):\n", resp.Tenant, len(resp.Candidates))
+	case resp.PreemptionNodeID != "":
+		fmt.Fprintf(w, "Not placeable: %s\nWould preempt %v on node %s\n", resp.PlacementError, resp.PreemptionVictims, resp.PreemptionNodeID)
+	case resp.WouldQueue:
+		fmt.Fprintf(w, "Not placeable: %s\nWould wait in the queue\n", resp.PlacementError)
+	default:
+		fmt.Fprintf(w, "Not placeable: %s\n", resp.PlacementError)
+	}
+
+	t := &ctl.Table{Header: []string{"RANK", "NODE", "NAME", "DOMAIN", "SCORE", "AFFINITY", "TOPOLOGY", "PREFERRED"}}
+	for _, c := range resp.Candidates {
+		t.Append(strconv.Itoa(int(c.Rank)), c.NodeID, c.NodeName, c.DomainID, strconv.FormatInt(c.Score, 10),
+			strconv.FormatInt(c.AffinityScore, 10), strconv.FormatInt(c.TopologyScore, 10), strconv.FormatBool(c.Preferred))
+	}
+	if len(resp.Candidates) == 0 {
+		t = &ctl.Table{Header: []string{"DOMAIN", "NODE", "REASON"}}
+		for _, d := range resp.Domains {
+			if d.RejectionReason != "" {
+				t.Append(d.DomainID, "", d.RejectionReason)
+				continue
+			}
+			for _, n := range d.Nodes {
+				t.Append(d.DomainID, n.NodeID, n.RejectionReason)
+			}
+		}
+	}
+	return t, nil
+}
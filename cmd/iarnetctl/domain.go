@@ -0,0 +1,236 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/9triver/iarnet-global/internal/ctl"
+	registryAPI "github.com/9triver/iarnet-global/internal/transport/http/registry"
+)
+
+// domainList 列出域
+func domainList(e *env, args []string) error {
+	fs := e.flags()
+	if _, err := e.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+	client, err := e.connect()
+	if err != nil {
+		return err
+	}
+
+	data, err := client.Get(background(), "/registry/domains", nil)
+	if err != nil {
+		return err
+	}
+	return e.print(data, func() (*ctl.Table, error) {
+		resp := registryAPI.GetDomainsResponse{}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return nil, err
+		}
+		t := &ctl.Table{Header: []string{"ID", "NAME", "NODES", "ONLINE", "SUSPECT", "OWNER", "LABELS", "CREATED"}}
+		for _, d := range resp.Domains {
+			t.Append(d.ID, d.Name, strconv.Itoa(d.NodeCount), strconv.Itoa(d.OnlineNodes), strconv.Itoa(d.SuspectNodes),
+				d.Owner, formatLabels(d.Labels), d.CreatedAt)
+		}
+		return t, nil
+	})
+}
+
+// domainGet 查看域及其节点
+func domainGet(e *env, args []string) error {
+	fs := e.flags()
+	positional, err := e.parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	client, err := e.connect()
+	if err != nil {
+		return err
+	}
+
+	data, err := client.Get(background(), "/registry/domains/"+url.PathEscape(positional[0]), nil)
+	if err != nil {
+		return err
+	}
+	return e.print(data, func() (*ctl.Table, error) {
+		resp := registryAPI.GetDomainResponse{}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(e.out, "ID:          %s\n", resp.ID)
+		fmt.Fprintf(e.out, "Name:        %s\n", resp.Name)
+		fmt.Fprintf(e.out, "Description: %s\n", resp.Description)
+		fmt.Fprintf(e.out, "Kind:        %s\n", orDash(resp.Kind))
+		fmt.Fprintf(e.out, "Parent:      %s\n", orDash(resp.ParentID))
+		fmt.Fprintf(e.out, "Children:    %s\n", orDash(strings.Join(resp.Children, ",")))
+		fmt.Fprintf(e.out, "Owner:       %s\n", orDash(resp.Owner))
+		fmt.Fprintf(e.out, "Labels:      %s\n", orDash(formatLabels(resp.Labels)))
+		fmt.Fprintf(e.out, "Created:     %s\n", resp.CreatedAt)
+		fmt.Fprintf(e.out, "Nodes:\n")
+		return nodeTable(resp.ID, resp.Nodes), nil
+	})
+}
+
+// domainCreate 创建域
+func domainCreate(e *env, args []string) error {
+	fs := e.flags()
+	description := fs.String("description", "", "Domain description")
+	labels := fs.String("labels", "", "Domain labels, e.g. region=east,tier=edge")
+	parent := fs.String("parent", "", "Parent domain ID")
+	kind := fs.String("kind", "", "Hierarchy level of the domain, e.g. region or site")
+	positional, err := e.parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	req := registryAPI.CreateDomainRequest{Name: positional[0], Description: *description, ParentID: *parent, Kind: *kind}
+	if req.Labels, err = parseLabels(*labels); err != nil {
+		return err
+	}
+	client, err := e.connect()
+	if err != nil {
+		return err
+	}
+
+	data, err := client.Post(background(), "/registry/domains", req)
+	if err != nil {
+		return err
+	}
+	resp := registryAPI.CreateDomainResponse{}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return err
+	}
+	return e.printMessage(data, "Domain %s created (id: %s)", resp.Name, resp.ID)
+}
+
+// domainUpdate 更新域名称、描述与标签
+func domainUpdate(e *env, args []string) error {
+	fs := e.flags()
+	name := fs.String("name", "", "New domain name")
+	description := fs.String("description", "", "New domain description")
+	labels := fs.String("labels", "", "Replace domain labels, e.g. region=east (use --labels= to clear)")
+	positional, err := e.parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	labelsSet := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "labels" {
+			labelsSet = true
+		}
+	})
+	if *name == "" && *description == "" && !labelsSet {
+		return fmt.Errorf("nothing to update, specify --name, --description or --labels")
+	}
+	client, err := e.connect()
+	if err != nil {
+		return err
+	}
+
+	domainID := positional[0]
+	path := "/registry/domains/" + url.PathEscape(domainID)
+	var data json.RawMessage
+	if *name != "" || *description != "" {
+		if data, err = client.Put(background(), path, registryAPI.UpdateDomainRequest{Name: *name, Description: *description}); err != nil {
+			return err
+		}
+	}
+	if labelsSet {
+		parsed, err := parseLabels(*labels)
+		if err != nil {
+			return err
+		}
+		if data, err = client.Put(background(), path+"/labels", registryAPI.SetLabelsRequest{Labels: parsed}); err != nil {
+			return err
+		}
+	}
+	return e.printMessage(data, "Domain %s updated", domainID)
+}
+
+// domainTree 以树形展示域层级及各级汇总
+func domainTree(e *env, args []string) error {
+	fs := e.flags()
+	if _, err := e.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+	client, err := e.connect()
+	if err != nil {
+		return err
+	}
+
+	data, err := client.Get(background(), "/registry/domains/tree", nil)
+	if err != nil {
+		return err
+	}
+	return e.print(data, func() (*ctl.Table, error) {
+		resp := registryAPI.GetDomainTreeResponse{}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return nil, err
+		}
+		t := &ctl.Table{Header: []string{"DOMAIN", "ID", "KIND", "NODES", "ONLINE", "CPU AVAIL", "MEM AVAIL", "GPU AVAIL"}}
+		var walk func(items []registryAPI.DomainTreeItem, depth int)
+		walk = func(items []registryAPI.DomainTreeItem, depth int) {
+			for _, d := range items {
+				available := d.Capacity.Available
+				t.Append(strings.Repeat("  ", depth)+d.Name, d.ID, orDash(d.Kind), strconv.Itoa(d.TotalNodes),
+					strconv.Itoa(d.OnlineNodes), strconv.FormatInt(available.CPU, 10),
+					strconv.FormatInt(available.Memory, 10), strconv.FormatInt(available.GPU, 10))
+				walk(d.Children, depth+1)
+			}
+		}
+		walk(resp.Domains, 0)
+		return t, nil
+	})
+}
+
+// domainSetParent 设置域的上级域与层级类型
+func domainSetParent(e *env, args []string) error {
+	fs := e.flags()
+	parent := fs.String("parent", "", "Parent domain ID (empty for a top-level domain)")
+	kind := fs.String("kind", "", "Hierarchy level of the domain, e.g. region or site")
+	positional, err := e.parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	client, err := e.connect()
+	if err != nil {
+		return err
+	}
+
+	path := "/registry/domains/" + url.PathEscape(positional[0]) + "/parent"
+	data, err := client.Put(background(), path, registryAPI.SetParentRequest{ParentID: *parent, Kind: *kind})
+	if err != nil {
+		return err
+	}
+	return e.printMessage(data, "Domain %s parent set to %s", positional[0], orDash(*parent))
+}
+
+// domainDelete 删除域
+func domainDelete(e *env, args []string) error {
+	fs := e.flags()
+	positional, err := e.parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	client, err := e.connect()
+	if err != nil {
+		return err
+	}
+
+	data, err := client.Delete(background(), "/registry/domains/"+url.PathEscape(positional[0]))
+	if err != nil {
+		return err
+	}
+	return e.printMessage(data, "Domain %s deleted", positional[0])
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/ctl"
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	registryAPI "github.com/9triver/iarnet-global/internal/transport/http/registry"
+)
+
+// joinTokenList 列出加入令牌
+func joinTokenList(e *env, args []string) error {
+	fs := e.flags()
+	domainID := fs.String("domain", "", "Only list tokens of this domain")
+	if _, err := e.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+	client, err := e.connect()
+	if err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	if *domainID != "" {
+		query.Set("domain_id", *domainID)
+	}
+	data, err := client.Get(background(), "/registry/join-tokens", query)
+	if err != nil {
+		return err
+	}
+	return e.print(data, func() (*ctl.Table, error) {
+		resp := registryAPI.GetJoinTokensResponse{}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return nil, err
+		}
+		t := &ctl.Table{Header: []string{"ID", "DOMAIN", "USES", "USABLE", "EXPIRES", "DESCRIPTION", "CREATED"}}
+		for _, token := range resp.Tokens {
+			t.Append(token.ID, string(token.DomainID), formatUses(token.Uses, token.MaxUses), strconv.FormatBool(token.Usable),
+				orNever(token.ExpiresAt), token.Description, token.CreatedAt)
+		}
+		return t, nil
+	})
+}
+
+// joinTokenCreate 创建加入令牌，令牌明文只输出一次
+func joinTokenCreate(e *env, args []string) error {
+	fs := e.flags()
+	domainID := fs.String("domain", "", "Domain the token grants access to (required)")
+	ttl := fs.Duration("ttl", 24*time.Hour, "Token lifetime, 0 for no expiry")
+	maxUses := fs.Int("max-uses", 0, "Maximum number of nodes that may join with the token, 0 for unlimited")
+	description := fs.String("description", "", "Token description")
+	if _, err := e.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+	if *domainID == "" {
+		return fmt.Errorf("--domain is required")
+	}
+	client, err := e.connect()
+	if err != nil {
+		return err
+	}
+
+	data, err := client.Post(background(), "/registry/join-tokens", registryAPI.CreateJoinTokenRequest{
+		DomainID:    registry.DomainID(*domainID),
+		Description: *description,
+		TTLSeconds:  int(ttl.Seconds()),
+		MaxUses:     *maxUses,
+	})
+	if err != nil {
+		return err
+	}
+	if e.format != ctl.FormatTable {
+		return e.print(data, nil)
+	}
+
+	resp := registryAPI.CreateJoinTokenResponse{}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return err
+	}
+	fmt.Fprintf(e.out, "Join token %s created for domain %s (uses: %s, expires: %s)\n",
+		resp.ID, resp.DomainID, formatUses(resp.Uses, resp.MaxUses), orNever(resp.ExpiresAt))
+	fmt.Fprintf(e.out, "Token (shown only once): %s\n", resp.Token)
+	return nil
+}
+
+// joinTokenRevoke 吊销加入令牌
+func joinTokenRevoke(e *env, args []string) error {
+	fs := e.flags()
+	positional, err := e.parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	client, err := e.connect()
+	if err != nil {
+		return err
+	}
+
+	data, err := client.Delete(background(), "/registry/join-tokens/"+url.PathEscape(positional[0]))
+	if err != nil {
+		return err
+	}
+	return e.printMessage(data, "Join token %s revoked", positional[0])
+}
+
+func formatUses(uses, maxUses int) string {
+	if maxUses == 0 {
+		return strconv.Itoa(uses) + "/unlimited"
+	}
+	return fmt.Sprintf("%d/%d", uses, maxUses)
+}
+
+func orNever(s string) string {
+	if s == "" {
+		return "never"
+	}
+	return s
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/ctl"
+	"github.com/9triver/iarnet-global/internal/util"
+	"gopkg.in/yaml.v2"
+)
+
+// logsResponse GET /logs 的响应（日志按时间倒序）
+type logsResponse struct {
+	Logs  []util.LogEntry `json:"logs"`
+	Total int             `json:"total"`
+}
+
+// logTail 输出最近的日志，-f 时持续轮询新日志
+func logTail(e *env, args []string) error {
+	fs := e.flags()
+	lines := fs.Int("n", 50, "Number of recent entries to show (max 1000)")
+	level := fs.String("level", "", "Only show entries of this level")
+	follow := fs.Bool("f", false, "Keep polling for new entries")
+	interval := fs.Duration("interval", 2*time.Second, "Polling interval with -f")
+	if _, err := e.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+	if *interval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+	client, err := e.connect()
+	if err != nil {
+		return err
+	}
+
+	var last time.Time
+	fetch := func(limit int) error {
+		query := url.Values{"limit": {strconv.Itoa(limit)}}
+		if *level != "" {
+			query.Set("level", *level)
+		}
+		data, err := client.Get(background(), "/logs", query)
+		if err != nil {
+			return err
+		}
+		resp := logsResponse{}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return err
+		}
+		// 接口按时间倒序返回，按时间正序输出尚未输出过的日志
+		for i := len(resp.Logs) - 1; i >= 0; i-- {
+			entry := resp.Logs[i]
+			if !entry.Timestamp.After(last) {
+				continue
+			}
+			last = entry.Timestamp
+			if err := e.printLog(entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := fetch(*lines); err != nil {
+		return err
+	}
+	if !*follow {
+		return nil
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-interrupt:
+			return nil
+		case <-ticker.C:
+			if err := fetch(1000); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// printLog 输出单条日志：表格模式为文本行，json 每行一条，yaml 每条一个文档
+func (e *env) printLog(entry util.LogEntry) error {
+	switch e.format {
+	case ctl.FormatJSON:
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(e.out, "%s\n", data)
+		return err
+	case ctl.FormatYAML:
+		data, err := yaml.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(e.out, "---\n%s", data)
+		return err
+	default:
+		line := fmt.Sprintf("%s %-5s %s", entry.Timestamp.Format(time.RFC3339), strings.ToUpper(entry.Level), entry.Message)
+		for _, k := range sortedKeys(entry.Fields) {
+			line += fmt.Sprintf(" %s=%v", k, entry.Fields[k])
+		}
+		_, err := fmt.Fprintln(e.out, line)
+		return err
+	}
+}
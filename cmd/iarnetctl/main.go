@@ -0,0 +1,290 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/9triver/iarnet-global/internal/ctl"
+)
+
+const usage = `iarnetctl controls the iarnet global scheduler.
+
+Usage:
+  iarnetctl <command> [subcommand] [flags] [args]
+
+Commands:
+  config      Manage contexts (server URL and credentials)
+  domain      List, create, update and delete domains
+  node        List, describe, cordon, uncordon and evict nodes
+  join-token  Create, list and revoke domain join tokens
+  deploy      Deploy a component through the global scheduler
+  status      Show deployments
+  stop        Stop a deployment
+  log         Tail global scheduler logs
+  cluster     Show HA replica status
+
+Global flags (accepted by every command):
+  -o, --output   Output format: table, json or yaml (default table)
+  --context      Context to use instead of the current context
+  --config       Context file (default $IARNETCTL_CONFIG or ~/.iarnet/config)
+  --server       Override the server URL of the context (comma-separated replicas in HA mode)
+  --token        Override the project access token of the context
+  --tenant       Override the tenant claim of the context
+
+Run "iarnetctl <command> -h" for details.
+`
+
+// command 子命令
+type command struct {
+	usage string
+	run   func(env *env, args []string) error
+}
+
+var commands = map[string]map[string]command{
+	"config": {
+		"set-context":     {"set-context NAME [--server URL] [--token TOKEN] [--tenant TENANT]", configSetContext},
+		"use-context":     {"use-context NAME", configUseContext},
+		"get-contexts":    {"get-contexts", configGetContexts},
+		"current-context": {"current-context", configCurrentContext},
+		"delete-context":  {"delete-context NAME", configDeleteContext},
+	},
+	"domain": {
+		"list":   {"list", domainList},
+		"get":    {"get DOMAIN_ID", domainGet},
+		"tree":   {"tree", domainTree},
+		"create": {"create NAME [--description TEXT] [--labels k=v,...] [--parent DOMAIN_ID] [--kind KIND]", domainCreate},
+		"update": {"update DOMAIN_ID [--name NAME] [--description TEXT] [--labels k=v,...]", domainUpdate},
+		"parent": {"parent DOMAIN_ID [--parent DOMAIN_ID] [--kind KIND]", domainSetParent},
+		"delete": {"delete DOMAIN_ID", domainDelete},
+	},
+	"node": {
+		"list":     {"list [--domain DOMAIN_ID]", nodeList},
+		"describe": {"describe NODE_ID", nodeDescribe},
+		"cordon":   {"cordon NODE_ID", nodeCordon},
+		"uncordon": {"uncordon NODE_ID", nodeUncordon},
+		"evict":    {"evict NODE_ID [--cordon=false]", nodeEvict},
+	},
+	"join-token": {
+		"list":   {"list [--domain DOMAIN_ID]", joinTokenList},
+		"create": {"create --domain DOMAIN_ID [--ttl 24h] [--max-uses N] [--description TEXT]", joinTokenCreate},
+		"revoke": {"revoke TOKEN_ID", joinTokenRevoke},
+	},
+	"log": {
+		"tail": {"tail [-n 50] [--level LEVEL] [-f] [--interval 2s]", logTail},
+	},
+	"cluster": {
+		"status": {"status", clusterStatus},
+	},
+}
+
+// topLevel 不带子命令的命令
+var topLevel = map[string]command{
+	"deploy": {"deploy [-f request.json] [--runtime ENV] [--cpu M] [--memory BYTES] [--gpu N] [--selector EXPR]... [--priority N] [--max-wait SECONDS] [--restartable] [--labels k=v,...] [--project PROJECT] [--dry-run]", deploy},
+	"status": {"status [DEPLOYMENT_ID] [--node NODE_ID] [--domain DOMAIN_ID] [--status STATUS] [--active] [--project PROJECT]", status},
+	"stop":   {"stop DEPLOYMENT_ID [--reason TEXT]", stop},
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		var apiErr *ctl.APIError
+		if errors.As(err, &apiErr) {
+			fmt.Fprintf(os.Stderr, "Error from server: %v\n", apiErr)
+		} else {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func run(args []string, out io.Writer) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		fmt.Fprint(out, usage)
+		return nil
+	}
+
+	name := args[0]
+	if cmd, ok := topLevel[name]; ok {
+		return cmd.run(&env{out: out, name: name, usage: cmd.usage}, args[1:])
+	}
+
+	group, ok := commands[name]
+	if !ok {
+		return fmt.Errorf("unknown command %q, run `iarnetctl --help` for usage", name)
+	}
+	if len(args) < 2 || args[1] == "-h" || args[1] == "--help" {
+		fmt.Fprintf(out, "Usage:\n")
+		for _, sub := range sortedKeys(group) {
+			fmt.Fprintf(out, "  iarnetctl %s %s\n", name, group[sub].usage)
+		}
+		return nil
+	}
+	cmd, ok := group[args[1]]
+	if !ok {
+		return fmt.Errorf("unknown command %q for %q", args[1], name)
+	}
+	return cmd.run(&env{out: out, name: name + " " + args[1], usage: name + " " + cmd.usage}, args[2:])
+}
+
+// env 单次命令执行的环境：全局参数、输出与客户端
+type env struct {
+	out   io.Writer
+	name  string
+	usage string
+
+	configPath string
+	context    string
+	server     string
+	token      string
+	tenant     string
+	output     string
+
+	format ctl.Format
+	config *ctl.Config
+	client *ctl.Client
+}
+
+// flags 创建带有全局参数的 FlagSet
+func (e *env) flags() *flag.FlagSet {
+	fs := flag.NewFlagSet(e.name, flag.ContinueOnError)
+	fs.SetOutput(e.out)
+	fs.Usage = func() {
+		fmt.Fprintf(e.out, "Usage:\n  iarnetctl %s\n\nFlags:\n", e.usage)
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&e.configPath, "config", ctl.DefaultConfigPath(), "Context file")
+	fs.StringVar(&e.context, "context", "", "Context to use (default: current context)")
+	fs.StringVar(&e.server, "server", "", "Override the server URL")
+	fs.StringVar(&e.token, "token", "", "Override the project access token")
+	fs.StringVar(&e.tenant, "tenant", "", "Override the tenant claim")
+	fs.StringVar(&e.output, "output", "table", "Output format: table, json or yaml")
+	fs.StringVar(&e.output, "o", "table", "Shorthand for --output")
+	return fs
+}
+
+// parse 解析参数（允许参数与位置参数交错），检查位置参数数量并读取上下文文件
+func (e *env) parse(fs *flag.FlagSet, args []string, minArgs, maxArgs int) ([]string, error) {
+	positional := make([]string, 0)
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+	if len(positional) < minArgs || (maxArgs >= 0 && len(positional) > maxArgs) {
+		fs.Usage()
+		return nil, fmt.Errorf("wrong number of arguments for %q", e.name)
+	}
+
+	format, err := ctl.ParseFormat(e.output)
+	if err != nil {
+		return nil, err
+	}
+	e.format = format
+
+	cfg, err := ctl.LoadConfig(e.configPath)
+	if err != nil {
+		return nil, err
+	}
+	e.config = cfg
+	return positional, nil
+}
+
+// connect 按上下文与覆盖参数创建客户端
+func (e *env) connect() (*ctl.Client, error) {
+	if e.client != nil {
+		return e.client, nil
+	}
+	current, err := e.config.Context(e.context)
+	if err != nil {
+		return nil, err
+	}
+	if e.server != "" {
+		current.Server = e.server
+	}
+	if e.token != "" {
+		current.Token = e.token
+	}
+	if e.tenant != "" {
+		current.Tenant = e.tenant
+	}
+	e.client = ctl.NewClient(current)
+	return e.client, nil
+}
+
+// print 按输出格式打印接口数据
+func (e *env) print(data json.RawMessage, table func() (*ctl.Table, error)) error {
+	return ctl.Print(e.out, e.format, data, table)
+}
+
+// printMessage 在表格模式下输出提示信息，json/yaml 模式下输出接口数据
+func (e *env) printMessage(data json.RawMessage, format string, args ...any) error {
+	if e.format == ctl.FormatTable {
+		_, err := fmt.Fprintf(e.out, format+"\n", args...)
+		return err
+	}
+	return e.print(data, nil)
+}
+
+func background() context.Context {
+	return context.Background()
+}
+
+// parseLabels 解析 k=v,k2=v2 形式的标签
+func parseLabels(s string) (map[string]string, error) {
+	labels := make(map[string]string)
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(item, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid label %q, expected key=value", item)
+		}
+		labels[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return labels, nil
+}
+
+// formatLabels 以 k=v,k2=v2 形式输出标签（按键排序）
+func formatLabels(labels map[string]string) string {
+	parts := make([]string, 0, len(labels))
+	for _, k := range sortedKeys(labels) {
+		parts = append(parts, k+"="+labels[k])
+	}
+	return strings.Join(parts, ",")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// stringList 可重复指定的字符串参数
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, ",") }
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
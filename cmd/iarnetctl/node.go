@@ -0,0 +1,289 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/9triver/iarnet-global/internal/ctl"
+	domainscheduler "github.com/9triver/iarnet-global/internal/domain/scheduler"
+	registryAPI "github.com/9triver/iarnet-global/internal/transport/http/registry"
+	schedulerAPI "github.com/9triver/iarnet-global/internal/transport/http/scheduler"
+)
+
+// domainNode 节点及其所属域
+type domainNode struct {
+	DomainID string `json:"domain_id"`
+	registryAPI.NodeItem
+}
+
+// nodeDetail node describe 的输出
+type nodeDetail struct {
+	domainNode
+	Deployments []schedulerAPI.DeploymentItem `json:"deployments"`
+}
+
+// nodeList 列出节点，未指定域时列出所有可见域的节点
+func nodeList(e *env, args []string) error {
+	fs := e.flags()
+	domainID := fs.String("domain", "", "Only list nodes of this domain")
+	if _, err := e.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+	client, err := e.connect()
+	if err != nil {
+		return err
+	}
+
+	nodes, err := listNodes(client, *domainID)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(nodes)
+	if err != nil {
+		return err
+	}
+	return e.print(data, func() (*ctl.Table, error) {
+		t := nodeTable("", nil)
+		for _, n := range nodes {
+			appendNode(t, n.DomainID, n.NodeItem)
+		}
+		return t, nil
+	})
+}
+
+// nodeDescribe 查看节点详情及其上的部署
+func nodeDescribe(e *env, args []string) error {
+	fs := e.flags()
+	positional, err := e.parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	client, err := e.connect()
+	if err != nil {
+		return err
+	}
+
+	node, err := findNode(client, positional[0])
+	if err != nil {
+		return err
+	}
+	detail := nodeDetail{domainNode: *node}
+	deployments, err := client.Get(background(), "/scheduler/deployments", url.Values{"node_id": {node.ID}})
+	if err == nil {
+		resp := schedulerAPI.GetDeploymentsResponse{}
+		if err := json.Unmarshal(deployments, &resp); err != nil {
+			return err
+		}
+		detail.Deployments = resp.Deployments
+	}
+	data, err := json.Marshal(detail)
+	if err != nil {
+		return err
+	}
+
+	return e.print(data, func() (*ctl.Table, error) {
+		fmt.Fprintf(e.out, "ID:          %s\n", node.ID)
+		fmt.Fprintf(e.out, "Name:        %s\n", node.Name)
+		fmt.Fprintf(e.out, "Domain:      %s\n", node.DomainID)
+		fmt.Fprintf(e.out, "Address:     %s\n", orDash(node.Address))
+		fmt.Fprintf(e.out, "Status:      %s\n", node.Status)
+		fmt.Fprintf(e.out, "Schedulable: %v\n", !node.Cordoned)
+		fmt.Fprintf(e.out, "Head:        %v\n", node.IsHead)
+		fmt.Fprintf(e.out, "Suspicion:   %.2f\n", node.Suspicion)
+		if node.Reachable != nil {
+			reachable := strconv.FormatBool(*node.Reachable)
+			if node.RTTMs != nil {
+				reachable += fmt.Sprintf(" (rtt %.1fms)", *node.RTTMs)
+			}
+			fmt.Fprintf(e.out, "Reachable:   %s\n", reachable)
+		}
+		fmt.Fprintf(e.out, "Labels:      %s\n", orDash(formatLabels(node.Labels)))
+		cpu, memory, gpu := nodeResources(node.NodeItem)
+		fmt.Fprintf(e.out, "Capacity:    cpu=%s memory=%s gpu=%s\n", orDash(cpu), orDash(memory), orDash(gpu))
+		fmt.Fprintf(e.out, "Last seen:   %s\n", node.LastSeen)
+		fmt.Fprintf(e.out, "Deployments:\n")
+		return deploymentTable(detail.Deployments), nil
+	})
+}
+
+// nodeCordon 将节点标记为不可调度
+func nodeCordon(e *env, args []string) error {
+	return setCordon(e, args, true)
+}
+
+// nodeUncordon 恢复节点可调度
+func nodeUncordon(e *env, args []string) error {
+	return setCordon(e, args, false)
+}
+
+func setCordon(e *env, args []string, cordoned bool) error {
+	fs := e.flags()
+	positional, err := e.parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	client, err := e.connect()
+	if err != nil {
+		return err
+	}
+
+	data, err := cordonNode(client, positional[0], cordoned)
+	if err != nil {
+		return err
+	}
+	if cordoned {
+		return e.printMessage(data, "Node %s cordoned", positional[0])
+	}
+	return e.printMessage(data, "Node %s uncordoned", positional[0])
+}
+
+// nodeEvict 将节点上运行中的 component 迁移到其他节点，默认先将节点标记为不可调度
+func nodeEvict(e *env, args []string) error {
+	fs := e.flags()
+	cordon := fs.Bool("cordon", true, "Cordon the node before evicting so that nothing new is placed on it")
+	positional, err := e.parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	client, err := e.connect()
+	if err != nil {
+		return err
+	}
+
+	nodeID := positional[0]
+	if *cordon {
+		if _, err := cordonNode(client, nodeID, true); err != nil {
+			return err
+		}
+	}
+	data, err := client.Post(background(), "/scheduler/nodes/"+url.PathEscape(nodeID)+"/evict", nil)
+	if err != nil {
+		return err
+	}
+
+	return e.print(data, func() (*ctl.Table, error) {
+		result := domainscheduler.EvictResult{}
+		if err := json.Unmarshal(data, &result); err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(e.out, "Node %s: %d migrated, %d failed\n", nodeID, len(result.Migrated), len(result.Failed))
+		t := &ctl.Table{Header: []string{"DEPLOYMENT", "TENANT", "RESULT", "NEW DEPLOYMENT", "NODE", "ERROR"}}
+		for _, d := range result.Migrated {
+			t.Append(d.DeploymentID, d.Tenant, "migrated", d.NewDeploymentID, d.NodeID, d.Error)
+		}
+		for _, d := range result.Failed {
+			t.Append(d.DeploymentID, d.Tenant, "failed", "", "", d.Error)
+		}
+		return t, nil
+	})
+}
+
+// cordonNode 查找节点所属域并设置不可调度标记
+func cordonNode(client *ctl.Client, nodeID string, cordoned bool) (json.RawMessage, error) {
+	node, err := findNode(client, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/registry/domains/%s/nodes/%s/cordon", url.PathEscape(node.DomainID), url.PathEscape(node.ID))
+	return client.Put(background(), path, registryAPI.SetCordonRequest{Cordoned: cordoned})
+}
+
+// listNodes 列出域内节点，domainID 为空时遍历所有可见的域
+func listNodes(client *ctl.Client, domainID string) ([]domainNode, error) {
+	domainIDs := []string{domainID}
+	if domainID == "" {
+		data, err := client.Get(background(), "/registry/domains", nil)
+		if err != nil {
+			return nil, err
+		}
+		resp := registryAPI.GetDomainsResponse{}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return nil, err
+		}
+		domainIDs = domainIDs[:0]
+		for _, d := range resp.Domains {
+			domainIDs = append(domainIDs, d.ID)
+		}
+	}
+
+	nodes := make([]domainNode, 0)
+	for _, id := range domainIDs {
+		data, err := client.Get(background(), "/registry/domains/"+url.PathEscape(id)+"/nodes", nil)
+		if err != nil {
+			return nil, err
+		}
+		resp := registryAPI.GetDomainNodesResponse{}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return nil, err
+		}
+		for _, n := range resp.Nodes {
+			nodes = append(nodes, domainNode{DomainID: id, NodeItem: n})
+		}
+	}
+	return nodes, nil
+}
+
+// findNode 在所有可见的域中查找节点
+func findNode(client *ctl.Client, nodeID string) (*domainNode, error) {
+	nodes, err := listNodes(client, "")
+	if err != nil {
+		return nil, err
+	}
+	for i := range nodes {
+		if nodes[i].ID == nodeID {
+			return &nodes[i], nil
+		}
+	}
+	return nil, fmt.Errorf("node %q not found", nodeID)
+}
+
+// nodeTable 创建节点表格，domainID 非空时填充 nodes
+func nodeTable(domainID string, nodes []registryAPI.NodeItem) *ctl.Table {
+	t := &ctl.Table{Header: []string{"ID", "NAME", "DOMAIN", "STATUS", "SCHEDULING", "CPU", "MEMORY", "GPU", "ADDRESS", "LAST SEEN"}}
+	for _, n := range nodes {
+		appendNode(t, domainID, n)
+	}
+	return t
+}
+
+func appendNode(t *ctl.Table, domainID string, n registryAPI.NodeItem) {
+	scheduling := "enabled"
+	if n.Cordoned {
+		scheduling = "disabled"
+	}
+	cpu, memory, gpu := nodeResources(n)
+	t.Append(n.ID, n.Name, domainID, n.Status, scheduling, cpu, memory, gpu, n.Address, n.LastSeen)
+}
+
+// nodeResources 节点总资源：CPU 核数、内存与 GPU 数量
+func nodeResources(n registryAPI.NodeItem) (cpu, memory, gpu string) {
+	if n.ResourceTags == nil {
+		return "", "", ""
+	}
+	if n.ResourceTags.CPU != nil {
+		cpu = strconv.FormatInt(*n.ResourceTags.CPU, 10)
+	}
+	if n.ResourceTags.Memory != nil {
+		memory = formatBytes(*n.ResourceTags.Memory)
+	}
+	if n.ResourceTags.GPU != nil {
+		gpu = strconv.FormatInt(*n.ResourceTags.GPU, 10)
+	}
+	return cpu, memory, gpu
+}
+
+// formatBytes 以二进制单位输出字节数
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ci", float64(n)/float64(div), "KMGTPE"[exp])
+}
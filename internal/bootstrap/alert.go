@@ -0,0 +1,55 @@
+package bootstrap
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/alert"
+	"github.com/sirupsen/logrus"
+)
+
+// bootstrapAlert 初始化告警模块（可选），需要在容量时序数据与 webhook 模块之后初始化
+func bootstrapAlert(ig *IarnetGlobal) error {
+	cfg := ig.Config.Alerts
+	if !cfg.Enabled {
+		return nil
+	}
+
+	rules := make([]alert.Rule, 0, len(cfg.Rules))
+	for _, r := range cfg.Rules {
+		rules = append(rules, alert.Rule{
+			Name:     r.Name,
+			Type:     alert.RuleType(r.Type),
+			DomainID: r.Domain,
+			Resource: r.Resource,
+			Within:   time.Duration(r.WithinHours) * time.Hour,
+			For:      time.Duration(r.ForSeconds) * time.Second,
+		})
+	}
+	if len(rules) == 0 {
+		rules = alert.DefaultRules(ig.MetricsService != nil)
+	}
+
+	sinks := []alert.Sink{alert.LogSink{}}
+	if cfg.WebhookURL != "" {
+		sinks = append(sinks, alert.NewWebhookSink(cfg.WebhookURL, time.Duration(cfg.WebhookTimeoutSeconds)*time.Second))
+	}
+	// 启用 webhooks 时告警同时以 alert.* 事件投递，享有签名、重试与投递记录
+	if ig.WebhookService != nil {
+		sinks = append(sinks, ig.WebhookService)
+	}
+
+	alertService, err := alert.NewService(ig.DomainManager, ig.MetricsService, alert.Options{
+		Rules:              rules,
+		Sinks:              sinks,
+		EvaluationInterval: time.Duration(cfg.EvaluationIntervalSeconds) * time.Second,
+		IsLeader:           ig.isLeader(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create alert service: %w", err)
+	}
+
+	ig.AlertService = alertService
+	logrus.Infof("Alert module initialized (%d rule(s))", len(rules))
+	return nil
+}
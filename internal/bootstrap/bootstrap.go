@@ -8,7 +8,7 @@ import (
 )
 
 // Initialize 初始化所有模块
-// 按照依赖顺序初始化：Registry -> Transport
+// 按照依赖顺序初始化：HA -> Registry -> Tenant -> Scheduler -> Metrics -> Webhook -> Alert -> Transport
 func Initialize(cfg *config.Config) (*IarnetGlobal, error) {
 	ig := &IarnetGlobal{
 		Config:          cfg,
@@ -16,12 +16,45 @@ func Initialize(cfg *config.Config) (*IarnetGlobal, error) {
 		HTTPServer:      nil,
 	}
 
+	// 0. 初始化 HA 副本（可选）
+	if err := bootstrapHA(ig); err != nil {
+		return nil, fmt.Errorf("failed to initialize HA replica: %w", err)
+	}
+
 	// 1. 初始化 Registry 模块
 	if err := bootstrapRegistry(ig); err != nil {
 		return nil, fmt.Errorf("failed to initialize registry module: %w", err)
 	}
 
-	// 2. 初始化 Transport 层
+	// 2. 初始化 Tenant 模块
+	if err := bootstrapTenant(ig); err != nil {
+		return nil, fmt.Errorf("failed to initialize tenant module: %w", err)
+	}
+
+	// 3. 初始化 Scheduler 模块
+	if err := bootstrapScheduler(ig); err != nil {
+		return nil, fmt.Errorf("failed to initialize scheduler module: %w", err)
+	}
+
+	// 4. 初始化容量时序数据模块（可选）
+	if err := bootstrapMetrics(ig); err != nil {
+		return nil, fmt.Errorf("failed to initialize metrics module: %w", err)
+	}
+
+	// 5. 初始化 webhook 模块（可选）
+	if err := bootstrapWebhook(ig); err != nil {
+		return nil, fmt.Errorf("failed to initialize webhook module: %w", err)
+	}
+
+	// 6. 初始化告警模块（可选）
+	if err := bootstrapAlert(ig); err != nil {
+		return nil, fmt.Errorf("failed to initialize alert module: %w", err)
+	}
+
+	// 注册需要在 HA 副本间复制的状态
+	registerReplicatedState(ig)
+
+	// 7. 初始化 Transport 层
 	if err := bootstrapTransport(ig); err != nil {
 		return nil, fmt.Errorf("failed to initialize transport layer: %w", err)
 	}
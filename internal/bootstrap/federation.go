@@ -0,0 +1,46 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/deployment"
+	"github.com/9triver/iarnet-global/internal/domain/federation"
+	"github.com/9triver/iarnet-global/internal/intra/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// bootstrapFederation 初始化跨实例联邦（可选），需要在调度服务创建之前完成
+func bootstrapFederation(ig *IarnetGlobal, tracker *deployment.Tracker) error {
+	cfg := ig.Config.Federation
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.InstanceID == "" {
+		return fmt.Errorf("federation.instance_id is required")
+	}
+
+	dbConfig := ig.Config.Database
+	federationRepo, err := repository.NewFederationRepo(dbConfig.SchedulerDBPath, dbConfig.MaxOpenConns, dbConfig.MaxIdleConns, dbConfig.ConnMaxLifetimeSeconds)
+	if err != nil {
+		return fmt.Errorf("failed to initialize federation repository: %w", err)
+	}
+	federationService := federation.NewService(federationRepo, ig.DomainManager, tracker, federation.Options{
+		InstanceID:       cfg.InstanceID,
+		MaxHops:          cfg.MaxHops,
+		ExchangeInterval: time.Duration(cfg.ExchangeIntervalSeconds) * time.Second,
+		SummaryTTL:       time.Duration(cfg.SummaryTTLSeconds) * time.Second,
+		CallTimeout:      time.Duration(cfg.CallTimeoutSeconds) * time.Second,
+		Tenants:          ig.TenantService,
+		IsLeader:         ig.isLeader(),
+	})
+	if err := federationService.LoadPeers(context.Background()); err != nil {
+		return fmt.Errorf("failed to load federation peers from repository: %w", err)
+	}
+
+	ig.FederationRepo = federationRepo
+	ig.FederationService = federationService
+	logrus.Infof("Federation module initialized (instance: %s)", cfg.InstanceID)
+	return nil
+}
@@ -0,0 +1,82 @@
+package bootstrap
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/ha"
+	"github.com/9triver/iarnet-global/internal/intra/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// bootstrapHA 创建 HA 副本（未启用时跳过）
+// 需在其他模块之前创建，以便各模块的后台任务只在 leader 上运行
+func bootstrapHA(ig *IarnetGlobal) error {
+	haCfg := ig.Config.HA
+	if !haCfg.Enabled {
+		return nil
+	}
+
+	peers := make([]ha.Peer, 0, len(haCfg.Peers))
+	for _, peer := range haCfg.Peers {
+		peers = append(peers, ha.Peer{
+			ID:       peer.ID,
+			RaftAddr: peer.RaftAddr,
+			HTTPAddr: peer.HTTPAddr,
+			RPCAddr:  peer.RPCAddr,
+		})
+	}
+
+	dbConfig := ig.Config.Database
+	raftRepo, err := repository.NewRaftRepo(haCfg.RaftDBPath, dbConfig.MaxOpenConns, dbConfig.MaxIdleConns, dbConfig.ConnMaxLifetimeSeconds)
+	if err != nil {
+		return fmt.Errorf("failed to initialize raft repository: %w", err)
+	}
+
+	replica, err := ha.NewReplica(ha.Options{
+		ID:           haCfg.NodeID,
+		BindAddr:     haCfg.BindAddr,
+		Peers:        peers,
+		Bootstrap:    haCfg.Bootstrap,
+		DataDir:      haCfg.SnapshotDir,
+		Store:        raftRepo,
+		SyncInterval: time.Duration(haCfg.SyncIntervalMs) * time.Millisecond,
+	})
+	if err != nil {
+		raftRepo.Close()
+		return fmt.Errorf("failed to create HA replica: %w", err)
+	}
+
+	ig.Cluster = replica
+	ig.RaftRepo = raftRepo
+	logrus.Infof("HA mode enabled: replica=%s, peers=%d", haCfg.NodeID, len(peers))
+	return nil
+}
+
+// registerReplicatedState 注册需要在副本间复制的状态
+func registerReplicatedState(ig *IarnetGlobal) {
+	if ig.Cluster == nil {
+		return
+	}
+	ig.Cluster.Register("registry", ig.RegistryService)
+	if ig.DeploymentTracker != nil {
+		ig.Cluster.Register("deployments", ig.DeploymentTracker)
+	}
+	if ig.FederationService != nil {
+		ig.Cluster.Register("federation", ig.FederationService)
+	}
+	ig.Cluster.OnLeadershipChange(func(leader bool) {
+		if leader {
+			// 节点此前向旧 leader 发送心跳，接管后重新开始计时
+			ig.DomainManager.ResetLiveness()
+		}
+	})
+}
+
+// isLeader 返回 leader 判断函数，未启用 HA 时返回 nil（视为单副本）
+func (ig *IarnetGlobal) isLeader() func() bool {
+	if ig.Cluster == nil {
+		return nil
+	}
+	return ig.Cluster.IsLeader
+}
@@ -5,8 +5,17 @@ import (
 	"fmt"
 
 	"github.com/9triver/iarnet-global/internal/config"
+	"github.com/9triver/iarnet-global/internal/domain/alert"
+	"github.com/9triver/iarnet-global/internal/domain/audit"
+	"github.com/9triver/iarnet-global/internal/domain/deployment"
+	"github.com/9triver/iarnet-global/internal/domain/federation"
+	"github.com/9triver/iarnet-global/internal/domain/metrics"
+	"github.com/9triver/iarnet-global/internal/domain/quota"
 	"github.com/9triver/iarnet-global/internal/domain/registry"
 	domainscheduler "github.com/9triver/iarnet-global/internal/domain/scheduler"
+	"github.com/9triver/iarnet-global/internal/domain/tenant"
+	"github.com/9triver/iarnet-global/internal/domain/webhook"
+	"github.com/9triver/iarnet-global/internal/ha"
 	"github.com/9triver/iarnet-global/internal/intra/repository"
 	"github.com/9triver/iarnet-global/internal/transport/http"
 	"github.com/9triver/iarnet-global/internal/transport/rpc"
@@ -18,10 +27,33 @@ type IarnetGlobal struct {
 	Config *config.Config
 
 	// 领域服务
-	RegistryService  registry.Service
-	DomainManager    *registry.Manager
-	DomainRepo       repository.DomainRepo
-	SchedulerService domainscheduler.Service
+	RegistryService   registry.Service
+	DomainManager     *registry.Manager
+	NodeProber        *registry.Prober
+	DomainRepo        repository.DomainRepo
+	SchedulerService  domainscheduler.Service
+	DeploymentTracker *deployment.Tracker
+	DeploymentRepo    repository.DeploymentRepo
+	QuotaService      quota.Service
+	QuotaRepo         repository.QuotaRepo
+	AuditService      audit.Service
+	AuditRepo         repository.AuditRepo
+	TenantService     tenant.Service
+	ProjectRepo       repository.ProjectRepo
+	// 跨实例联邦（未启用时为空）
+	FederationService federation.Service
+	FederationRepo    repository.FederationRepo
+	// 容量时序数据（未启用时为空）
+	MetricsService metrics.Service
+	MetricsRepo    repository.MetricsRepo
+	// 告警（未启用时为空）
+	AlertService alert.Service
+	// webhook（未启用时为空）
+	WebhookService webhook.Service
+	WebhookRepo    repository.WebhookRepo
+	// HA 副本（未启用时为空）
+	Cluster  *ha.Replica
+	RaftRepo repository.RaftRepo
 	// Transport 层
 	HTTPServer *http.Server
 	RPCManager *rpc.Manager
@@ -29,6 +61,13 @@ type IarnetGlobal struct {
 
 // Start 启动所有服务
 func (ig *IarnetGlobal) Start(ctx context.Context) error {
+	// 启动 HA 副本（先于其他模块，复制日志在启动时即开始应用）
+	if ig.Cluster != nil {
+		if err := ig.Cluster.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start HA replica: %w", err)
+		}
+	}
+
 	// 启动 Registry Manager（启动节点超时检测）
 	if ig.DomainManager != nil {
 		if err := ig.DomainManager.Start(ctx); err != nil {
@@ -37,6 +76,46 @@ func (ig *IarnetGlobal) Start(ctx context.Context) error {
 		logrus.Info("Registry manager started")
 	}
 
+	// 启动节点地址主动探测器（可选）
+	if ig.NodeProber != nil {
+		ig.NodeProber.Start(ctx)
+	}
+
+	// 启动调度等待队列
+	if ig.SchedulerService != nil {
+		if err := ig.SchedulerService.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start scheduler: %w", err)
+		}
+	}
+
+	// 启动跨实例联邦摘要交换
+	if ig.FederationService != nil {
+		if err := ig.FederationService.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start federation: %w", err)
+		}
+	}
+
+	// 启动容量采样
+	if ig.MetricsService != nil {
+		if err := ig.MetricsService.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start metrics: %w", err)
+		}
+	}
+
+	// 启动 webhook 投递
+	if ig.WebhookService != nil {
+		if err := ig.WebhookService.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start webhooks: %w", err)
+		}
+	}
+
+	// 启动告警规则评估
+	if ig.AlertService != nil {
+		if err := ig.AlertService.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start alerting: %w", err)
+		}
+	}
+
 	// 启动 RPC 服务器
 	if ig.RPCManager != nil {
 		if err := ig.RPCManager.Start(); err != nil {
@@ -72,12 +151,89 @@ func (ig *IarnetGlobal) Stop() error {
 		logrus.Info("RPC server stopped")
 	}
 
+	// 停止跨实例联邦摘要交换
+	if ig.FederationService != nil {
+		ig.FederationService.Stop()
+	}
+
+	// 停止告警规则评估
+	if ig.AlertService != nil {
+		ig.AlertService.Stop()
+	}
+
+	// 停止 webhook 投递（未完成的投递在下次启动后继续）
+	if ig.WebhookService != nil {
+		ig.WebhookService.Stop()
+	}
+
+	// 停止容量采样（写入尚未结束的降采样窗口）
+	if ig.MetricsService != nil {
+		ig.MetricsService.Stop()
+	}
+
+	// 停止调度等待队列（等待已出队的部署下发完成）
+	if ig.SchedulerService != nil {
+		ig.SchedulerService.Stop()
+	}
+
+	// 停止节点地址主动探测器
+	if ig.NodeProber != nil {
+		ig.NodeProber.Stop()
+	}
+
 	// 停止 Registry Manager
 	if ig.DomainManager != nil {
 		ig.DomainManager.Stop()
 		logrus.Info("Registry manager stopped")
 	}
 
+	// 停止 HA 副本
+	if ig.Cluster != nil {
+		ig.Cluster.Stop()
+	}
+	if ig.RaftRepo != nil {
+		if err := ig.RaftRepo.Close(); err != nil {
+			logrus.Warnf("Failed to close raft repository: %v", err)
+		}
+	}
+
+	// 关闭调度数据库连接
+	if ig.DeploymentRepo != nil {
+		if err := ig.DeploymentRepo.Close(); err != nil {
+			logrus.Warnf("Failed to close deployment repository: %v", err)
+		}
+	}
+	if ig.QuotaRepo != nil {
+		if err := ig.QuotaRepo.Close(); err != nil {
+			logrus.Warnf("Failed to close quota repository: %v", err)
+		}
+	}
+	if ig.AuditRepo != nil {
+		if err := ig.AuditRepo.Close(); err != nil {
+			logrus.Warnf("Failed to close audit repository: %v", err)
+		}
+	}
+	if ig.FederationRepo != nil {
+		if err := ig.FederationRepo.Close(); err != nil {
+			logrus.Warnf("Failed to close federation repository: %v", err)
+		}
+	}
+	if ig.WebhookRepo != nil {
+		if err := ig.WebhookRepo.Close(); err != nil {
+			logrus.Warnf("Failed to close webhook repository: %v", err)
+		}
+	}
+	if ig.MetricsRepo != nil {
+		if err := ig.MetricsRepo.Close(); err != nil {
+			logrus.Warnf("Failed to close metrics repository: %v", err)
+		}
+	}
+	if ig.ProjectRepo != nil {
+		if err := ig.ProjectRepo.Close(); err != nil {
+			logrus.Warnf("Failed to close project repository: %v", err)
+		}
+	}
+
 	logrus.Info("All services stopped")
 	return nil
 }
@@ -0,0 +1,36 @@
+package bootstrap
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/metrics"
+	"github.com/9triver/iarnet-global/internal/intra/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// bootstrapMetrics 初始化容量时序数据模块（可选）
+func bootstrapMetrics(ig *IarnetGlobal) error {
+	cfg := ig.Config.Metrics
+	if !cfg.Enabled {
+		return nil
+	}
+
+	dbConfig := ig.Config.Database
+	metricsRepo, err := repository.NewMetricsRepo(cfg.DBPath, dbConfig.MaxOpenConns, dbConfig.MaxIdleConns, dbConfig.ConnMaxLifetimeSeconds)
+	if err != nil {
+		return fmt.Errorf("failed to initialize metrics repository: %w", err)
+	}
+
+	ig.MetricsRepo = metricsRepo
+	ig.MetricsService = metrics.NewService(metricsRepo, ig.DomainManager, metrics.Options{
+		SampleInterval:   time.Duration(cfg.SampleIntervalSeconds) * time.Second,
+		RawRetention:     time.Duration(cfg.RawRetentionHours) * time.Hour,
+		MinuteRetention:  time.Duration(cfg.MinuteRetentionDays) * 24 * time.Hour,
+		HourRetention:    time.Duration(cfg.HourRetentionDays) * 24 * time.Hour,
+		ForecastLookback: time.Duration(cfg.ForecastLookbackDays) * 24 * time.Hour,
+		ForecastHorizon:  time.Duration(cfg.ForecastHorizonDays) * 24 * time.Hour,
+	})
+	logrus.Infof("Metrics module initialized (db: %s)", cfg.DBPath)
+	return nil
+}
@@ -3,9 +3,9 @@ package bootstrap
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/9triver/iarnet-global/internal/domain/registry"
-	domainscheduler "github.com/9triver/iarnet-global/internal/domain/scheduler"
 	"github.com/9triver/iarnet-global/internal/intra/repository"
 	"github.com/sirupsen/logrus"
 )
@@ -13,7 +13,23 @@ import (
 // bootstrapRegistry 初始化 Registry 模块
 func bootstrapRegistry(ig *IarnetGlobal) error {
 	// 创建 Registry Manager
-	manager := registry.NewManager()
+	healthCfg := ig.Config.Registry.HealthCheck
+	detectorCfg := ig.Config.Registry.FailureDetector
+	manager := registry.NewManager(registry.ManagerOptions{
+		HealthPolicy: registry.HealthPolicy{
+			Timeout:       time.Duration(healthCfg.TimeoutSeconds) * time.Second,
+			CleanupFactor: healthCfg.CleanupFactor,
+		},
+		CheckInterval: time.Duration(healthCfg.CheckIntervalSeconds) * time.Second,
+		FailureDetector: registry.FailureDetectorOptions{
+			SuspectThreshold: detectorCfg.SuspectThreshold,
+			OfflineThreshold: detectorCfg.OfflineThreshold,
+			WindowSize:       detectorCfg.WindowSize,
+			MinStdDeviation:  time.Duration(detectorCfg.MinStdDeviationMs) * time.Millisecond,
+			AcceptablePause:  time.Duration(detectorCfg.AcceptablePauseSeconds) * time.Second,
+		},
+		IsLeader: ig.isLeader(),
+	})
 	dbConfig := ig.Config.Database
 	// 初始化 Domain Repository
 	var domainRepo repository.DomainRepo
@@ -30,10 +46,21 @@ func bootstrapRegistry(ig *IarnetGlobal) error {
 		return fmt.Errorf("failed to load domains from repository: %w", err)
 	}
 
+	// 创建节点地址主动探测器（可选）
+	if proberCfg := ig.Config.Registry.Prober; proberCfg.Enabled {
+		ig.NodeProber = registry.NewProber(manager, registry.ProberOptions{
+			Mode:             registry.ProbeMode(proberCfg.Mode),
+			Interval:         time.Duration(proberCfg.IntervalSeconds) * time.Second,
+			Timeout:          time.Duration(proberCfg.TimeoutSeconds) * time.Second,
+			FailureThreshold: proberCfg.FailureThreshold,
+			MaxConcurrency:   proberCfg.MaxConcurrency,
+			IsLeader:         ig.isLeader(),
+		})
+	}
+
 	ig.RegistryService = service
 	ig.DomainManager = manager
 	ig.DomainRepo = domainRepo
-	ig.SchedulerService = domainscheduler.NewService(manager)
 	logrus.Info("Registry module initialized")
 	return nil
 }
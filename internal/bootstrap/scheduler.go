@@ -0,0 +1,94 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/audit"
+	"github.com/9triver/iarnet-global/internal/domain/deployment"
+	"github.com/9triver/iarnet-global/internal/domain/quota"
+	domainscheduler "github.com/9triver/iarnet-global/internal/domain/scheduler"
+	"github.com/9triver/iarnet-global/internal/intra/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// bootstrapScheduler 初始化调度模块（部署跟踪、配额准入、审计事件、跨实例联邦）
+func bootstrapScheduler(ig *IarnetGlobal) error {
+	dbConfig := ig.Config.Database
+	ctx := context.Background()
+
+	// 初始化部署记录 Repository
+	deploymentRepo, err := repository.NewDeploymentRepo(dbConfig.SchedulerDBPath, dbConfig.MaxOpenConns, dbConfig.MaxIdleConns, dbConfig.ConnMaxLifetimeSeconds)
+	if err != nil {
+		return fmt.Errorf("failed to initialize deployment repository: %w", err)
+	}
+	tracker := deployment.NewTracker(deploymentRepo)
+	if err := tracker.Load(ctx); err != nil {
+		return fmt.Errorf("failed to load deployments from repository: %w", err)
+	}
+
+	// 初始化配额 Repository
+	quotaRepo, err := repository.NewQuotaRepo(dbConfig.SchedulerDBPath, dbConfig.MaxOpenConns, dbConfig.MaxIdleConns, dbConfig.ConnMaxLifetimeSeconds)
+	if err != nil {
+		return fmt.Errorf("failed to initialize quota repository: %w", err)
+	}
+	quotaService := quota.NewService(quotaRepo, tracker)
+	if err := quotaService.LoadQuotas(ctx); err != nil {
+		return fmt.Errorf("failed to load quotas from repository: %w", err)
+	}
+
+	// 初始化审计事件 Repository
+	auditRepo, err := repository.NewAuditRepo(dbConfig.SchedulerDBPath, dbConfig.MaxOpenConns, dbConfig.MaxIdleConns, dbConfig.ConnMaxLifetimeSeconds)
+	if err != nil {
+		return fmt.Errorf("failed to initialize audit repository: %w", err)
+	}
+	auditService := audit.NewService(auditRepo)
+
+	// 初始化跨实例联邦（可选）
+	if err := bootstrapFederation(ig, tracker); err != nil {
+		return err
+	}
+
+	ig.DeploymentRepo = deploymentRepo
+	ig.DeploymentTracker = tracker
+	ig.QuotaRepo = quotaRepo
+	ig.QuotaService = quotaService
+	ig.AuditRepo = auditRepo
+	ig.AuditService = auditService
+	schedulerOpts := domainscheduler.Options{
+		Tracker: tracker,
+		Quotas:  quotaService,
+		Tenants: ig.TenantService,
+		Topology: domainscheduler.TopologyOptions{
+			Enabled:          ig.Config.Scheduler.Topology.Enabled,
+			SameNodeWeight:   ig.Config.Scheduler.Topology.SameNodeWeight,
+			SameDomainWeight: ig.Config.Scheduler.Topology.SameDomainWeight,
+			LatencyWeight:    ig.Config.Scheduler.Topology.LatencyWeight,
+		},
+		Queue: domainscheduler.QueueOptions{
+			Enabled:       ig.Config.Scheduler.Queue.Enabled,
+			RetryInterval: time.Duration(ig.Config.Scheduler.Queue.RetryIntervalSeconds) * time.Second,
+			MaxWait:       time.Duration(ig.Config.Scheduler.Queue.MaxWaitSeconds) * time.Second,
+		},
+		Preemption: domainscheduler.PreemptionOptions{
+			Enabled:     ig.Config.Scheduler.Preemption.Enabled,
+			StopTimeout: time.Duration(ig.Config.Scheduler.Preemption.StopTimeoutSeconds) * time.Second,
+		},
+		Reschedule: domainscheduler.RescheduleOptions{
+			Enabled:        ig.Config.Scheduler.Reschedule.Enabled,
+			Interval:       time.Duration(ig.Config.Scheduler.Reschedule.IntervalSeconds) * time.Second,
+			InitialBackoff: time.Duration(ig.Config.Scheduler.Reschedule.InitialBackoffSeconds) * time.Second,
+			MaxBackoff:     time.Duration(ig.Config.Scheduler.Reschedule.MaxBackoffSeconds) * time.Second,
+			MaxRestarts:    ig.Config.Scheduler.Reschedule.MaxRestarts,
+		},
+		Audit:    auditService,
+		IsLeader: ig.isLeader(),
+	}
+	if ig.FederationService != nil {
+		schedulerOpts.Federation = ig.FederationService
+	}
+	ig.SchedulerService = domainscheduler.NewService(ig.DomainManager, schedulerOpts)
+	logrus.Info("Scheduler module initialized")
+	return nil
+}
@@ -0,0 +1,37 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/9triver/iarnet-global/internal/domain/tenant"
+	"github.com/9triver/iarnet-global/internal/intra/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// bootstrapTenant 初始化 Tenant 模块（项目、域归属与访问令牌）
+func bootstrapTenant(ig *IarnetGlobal) error {
+	// 匿名请求只有只读权限，创建域与项目都需要管理员令牌，未配置时无法完成初始化
+	if ig.Config.Tenancy.AdminToken == "" {
+		return fmt.Errorf("tenancy.admin_token is required: set it to a long random string (e.g. openssl rand -hex 32) and use it as the bearer token for iarnetctl and the web UI")
+	}
+
+	dbConfig := ig.Config.Database
+	projectRepo, err := repository.NewProjectRepo(dbConfig.DomainDBPath, dbConfig.MaxOpenConns, dbConfig.MaxIdleConns, dbConfig.ConnMaxLifetimeSeconds)
+	if err != nil {
+		return fmt.Errorf("failed to initialize project repository: %w", err)
+	}
+
+	service := tenant.NewService(ig.DomainManager, projectRepo, tenant.ServiceOptions{
+		RequireAuth: ig.Config.Tenancy.RequireAuth,
+		AdminToken:  ig.Config.Tenancy.AdminToken,
+	})
+	if err := service.LoadProjects(context.Background()); err != nil {
+		return fmt.Errorf("failed to load projects from repository: %w", err)
+	}
+
+	ig.ProjectRepo = projectRepo
+	ig.TenantService = service
+	logrus.Info("Tenant module initialized")
+	return nil
+}
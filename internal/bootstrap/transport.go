@@ -12,20 +12,36 @@ import (
 func bootstrapTransport(ig *IarnetGlobal) error {
 	// 创建 HTTP 服务器
 	ig.HTTPServer = http.NewServer(http.Options{
-		Port:            ig.Config.Transport.HTTP.Port,
-		Config:          ig.Config,
-		RegistryService: ig.RegistryService,
+		Port:              ig.Config.Transport.HTTP.Port,
+		Config:            ig.Config,
+		RegistryService:   ig.RegistryService,
+		QuotaService:      ig.QuotaService,
+		AuditService:      ig.AuditService,
+		TenantService:     ig.TenantService,
+		SchedulerService:  ig.SchedulerService,
+		FederationService: ig.FederationService,
+		MetricsService:    ig.MetricsService,
+		AlertService:      ig.AlertService,
+		WebhookService:    ig.WebhookService,
+		Cluster:           ig.Cluster,
 	})
 
 	// 构建 RPC 服务器地址
 	registryAddr := fmt.Sprintf("0.0.0.0:%d", ig.Config.Transport.RPC.Registry.Port)
 
 	// 创建 RPC 服务器管理器
-	ig.RPCManager = rpc.NewManager(rpc.Options{
-		RegistryAddr:     registryAddr,
-		RegistryService:  ig.DomainManager,
-		SchedulerService: ig.SchedulerService,
-	})
+	rpcOpts := rpc.Options{
+		RegistryAddr:      registryAddr,
+		RegistryService:   ig.DomainManager,
+		SchedulerService:  ig.SchedulerService,
+		FederationService: ig.FederationService,
+		Cluster:           ig.Cluster,
+	}
+	if ig.Config.Registry.RequireJoinToken {
+		rpcOpts.JoinTokens = ig.RegistryService
+		logrus.Info("Node registration requires a join token")
+	}
+	ig.RPCManager = rpc.NewManager(rpcOpts)
 
 	logrus.Info("Transport layer initialized")
 	return nil
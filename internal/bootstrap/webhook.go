@@ -0,0 +1,65 @@
+package bootstrap
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/webhook"
+	"github.com/9triver/iarnet-global/internal/intra/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// bootstrapWebhook 初始化 webhook 模块（可选），订阅注册中心与调度事件，需要在告警模块之前初始化
+func bootstrapWebhook(ig *IarnetGlobal) error {
+	cfg := ig.Config.Webhooks
+	if !cfg.Enabled {
+		return nil
+	}
+
+	sinks := make([]webhook.SinkSpec, 0, len(cfg.Sinks))
+	for _, c := range cfg.Sinks {
+		sinks = append(sinks, webhook.SinkSpec{
+			Name:         c.Name,
+			URL:          c.URL,
+			Secret:       c.Secret,
+			Template:     webhook.Template(c.Template),
+			BodyTemplate: c.BodyTemplate,
+			Headers:      c.Headers,
+			Events:       c.Events,
+			Domains:      c.Domains,
+			Tenants:      c.Tenants,
+			MinCount:     c.MinCount,
+			Window:       time.Duration(c.WindowSeconds) * time.Second,
+		})
+	}
+
+	dbConfig := ig.Config.Database
+	webhookRepo, err := repository.NewWebhookRepo(dbConfig.SchedulerDBPath, dbConfig.MaxOpenConns, dbConfig.MaxIdleConns, dbConfig.ConnMaxLifetimeSeconds)
+	if err != nil {
+		return fmt.Errorf("failed to initialize webhook repository: %w", err)
+	}
+	webhookService, err := webhook.NewService(webhookRepo, webhook.Options{
+		Sinks:          sinks,
+		MaxAttempts:    cfg.MaxAttempts,
+		InitialBackoff: time.Duration(cfg.InitialBackoffSeconds) * time.Second,
+		MaxBackoff:     time.Duration(cfg.MaxBackoffSeconds) * time.Second,
+		Timeout:        time.Duration(cfg.TimeoutSeconds) * time.Second,
+		Workers:        cfg.Workers,
+		Retention:      time.Duration(cfg.RetentionDays) * 24 * time.Hour,
+		IsLeader:       ig.isLeader(),
+	})
+	if err != nil {
+		webhookRepo.Close()
+		return fmt.Errorf("failed to create webhook service: %w", err)
+	}
+
+	ig.DomainManager.SubscribeEvents(webhookService.PublishRegistryEvent)
+	if ig.AuditService != nil {
+		ig.AuditService.Subscribe(webhookService.PublishAuditEvent)
+	}
+
+	ig.WebhookRepo = webhookRepo
+	ig.WebhookService = webhookService
+	logrus.Infof("Webhook module initialized (%d sink(s))", len(sinks))
+	return nil
+}
@@ -11,11 +11,209 @@ type Config struct {
 
 	// Transport 配置
 	Transport TransportConfig `yaml:"transport"` // Transport configuration
+
+	// Registry 配置
+	Registry RegistryConfig `yaml:"registry"` // Registry configuration
+
+	// Tenancy 配置
+	Tenancy TenancyConfig `yaml:"tenancy"` // Multi-tenancy configuration
+
+	// Scheduler 配置
+	Scheduler SchedulerConfig `yaml:"scheduler"` // Global scheduler configuration
+
+	// HA 配置
+	HA HAConfig `yaml:"ha"` // High availability configuration
+
+	// Federation 配置
+	Federation FederationConfig `yaml:"federation"` // Federation with peer iarnet-global instances
+
+	// Metrics 配置
+	Metrics MetricsConfig `yaml:"metrics"` // Historical capacity and utilisation time series
+
+	// Alerts 配置
+	Alerts AlertsConfig `yaml:"alerts"` // Alert rules and notification sinks
+
+	// Webhooks 配置
+	Webhooks WebhooksConfig `yaml:"webhooks"` // Webhook sinks for registry, scheduler and alert events
+}
+
+// MetricsConfig 容量时序数据配置
+// 启用后周期性采样各节点与各域的资源使用情况，原始数据按 1m、1h 窗口降采样，通过 /metrics/capacity 接口查询
+type MetricsConfig struct {
+	Enabled               bool   `yaml:"enabled"`                 // 是否启用
+	DBPath                string `yaml:"db_path"`                 // 时序数据库路径，默认 <data_dir>/metrics.db
+	SampleIntervalSeconds int    `yaml:"sample_interval_seconds"` // 采样周期（秒），即原始数据的分辨率
+	RawRetentionHours     int    `yaml:"raw_retention_hours"`     // 原始数据保留时间（小时）
+	MinuteRetentionDays   int    `yaml:"minute_retention_days"`   // 1m 数据保留时间（天）
+	HourRetentionDays     int    `yaml:"hour_retention_days"`     // 1h 数据保留时间（天）
+	ForecastLookbackDays  int    `yaml:"forecast_lookback_days"`  // 资源耗尽预测参考的历史数据范围（天）
+	ForecastHorizonDays   int    `yaml:"forecast_horizon_days"`   // 资源耗尽预测的范围（天）
+}
+
+// AlertsConfig 告警配置
+// 启用后周期性评估告警规则，告警触发与恢复时写入日志，并在配置了 webhook_url 时以 JSON POST 通知；
+// 当前告警通过 /alerts 接口查询
+type AlertsConfig struct {
+	Enabled                   bool              `yaml:"enabled"`                     // 是否启用
+	EvaluationIntervalSeconds int               `yaml:"evaluation_interval_seconds"` // 规则评估周期（秒）
+	WebhookURL                string            `yaml:"webhook_url"`                 // 告警通知地址，为空时只写入日志；启用 webhooks 时告警同时以 alert.* 事件投递
+	WebhookTimeoutSeconds     int               `yaml:"webhook_timeout_seconds"`     // 告警通知超时时间（秒）
+	Rules                     []AlertRuleConfig `yaml:"rules"`                       // 告警规则，为空时使用默认规则
+}
+
+// AlertRuleConfig 告警规则配置
+type AlertRuleConfig struct {
+	Name        string `yaml:"name"`         // 规则名称，需唯一
+	Type        string `yaml:"type"`         // capacity_exhaustion：预计在 within_hours 内耗尽；head_node_offline：head 节点离线超过 for_seconds
+	Domain      string `yaml:"domain"`       // 域 ID，为空时对全部域生效
+	Resource    string `yaml:"resource"`     // capacity_exhaustion 规则关注的资源（cpu / memory / gpu），为空时关注全部资源
+	WithinHours int    `yaml:"within_hours"` // capacity_exhaustion 规则的预测范围（小时），默认 24
+	ForSeconds  int    `yaml:"for_seconds"`  // head_node_offline 规则的持续时间（秒），默认 300
+}
+
+// WebhooksConfig webhook 配置
+// 启用后将注册中心事件（domain.*、node.*）、调度事件（deployment.*、preemption.* 等）与告警事件（alert.*）
+// 按订阅条件投递到各个 webhook 目标，失败时按指数退避重试，超过最大次数后进入死信；
+// 投递记录保存在调度数据库中，通过 /webhooks 接口查询与重试
+type WebhooksConfig struct {
+	Enabled               bool                `yaml:"enabled"`                 // 是否启用
+	MaxAttempts           int                 `yaml:"max_attempts"`            // 最大投递次数（含首次投递）
+	InitialBackoffSeconds int                 `yaml:"initial_backoff_seconds"` // 首次重试间隔（秒），之后每次翻倍
+	MaxBackoffSeconds     int                 `yaml:"max_backoff_seconds"`     // 最大重试间隔（秒）
+	TimeoutSeconds        int                 `yaml:"timeout_seconds"`         // 单次投递超时时间（秒）
+	Workers               int                 `yaml:"workers"`                 // 并发投递数
+	RetentionDays         int                 `yaml:"retention_days"`          // 已投递与死信记录的保留时间（天）
+	Sinks                 []WebhookSinkConfig `yaml:"sinks"`                   // webhook 目标
+}
+
+// WebhookSinkConfig webhook 目标配置
+type WebhookSinkConfig struct {
+	Name          string            `yaml:"name"`           // 目标名称，需唯一
+	URL           string            `yaml:"url"`            // 投递地址
+	Secret        string            `yaml:"secret"`         // 非空时以 HMAC-SHA256 签名请求（X-Iarnet-Signature）
+	Template      string            `yaml:"template"`       // generic / slack / feishu / dingtalk / custom，默认 generic
+	BodyTemplate  string            `yaml:"body_template"`  // template 为 custom 时的请求体模板（Go text/template，渲染结果需为 JSON）
+	Headers       map[string]string `yaml:"headers"`        // 额外的请求头
+	Events        []string          `yaml:"events"`         // 订阅的事件类型，支持 "node.*" 与 "*"，为空时订阅全部事件
+	Domains       []string          `yaml:"domains"`        // 只投递涉及这些域的事件
+	Tenants       []string          `yaml:"tenants"`        // 只投递涉及这些租户的事件
+	MinCount      int               `yaml:"min_count"`      // 同一类型、同一域的事件在 window_seconds 内累计达到该次数才投递一次
+	WindowSeconds int               `yaml:"window_seconds"` // min_count 的统计窗口（秒）
+}
+
+// FederationConfig 跨实例联邦配置
+// 启用后与对等的 iarnet-global 实例周期性交换容量摘要，本地域无法容纳的部署请求转发到摘要显示可以容纳的对等实例；
+// 对等实例通过 /federation/peers 接口管理
+type FederationConfig struct {
+	Enabled                 bool   `yaml:"enabled"`                   // 是否启用
+	InstanceID              string `yaml:"instance_id"`               // 本实例 ID，用于防止转发成环，默认使用主机名（HA 副本需配置相同的 ID）
+	MaxHops                 int    `yaml:"max_hops"`                  // 请求最多被转发的次数
+	ExchangeIntervalSeconds int    `yaml:"exchange_interval_seconds"` // 摘要交换周期（秒）
+	SummaryTTLSeconds       int    `yaml:"summary_ttl_seconds"`       // 摘要有效期（秒），过期的对等实例不参与转发
+	CallTimeoutSeconds      int    `yaml:"call_timeout_seconds"`      // 调用对等实例的超时时间（秒）
+}
+
+// HAConfig 高可用配置
+// 启用后多个副本通过 Raft 复制注册中心与部署记录，只有 leader 处理请求并执行调度，
+// follower 将 HTTP 请求转发给 leader，gRPC 请求返回 Unavailable 并在 x-iarnet-leader 中携带 leader 地址
+type HAConfig struct {
+	Enabled        bool           `yaml:"enabled"`          // 是否启用
+	NodeID         string         `yaml:"node_id"`          // 本副本 ID，必须出现在 peers 中
+	BindAddr       string         `yaml:"bind_addr"`        // Raft 监听地址，默认使用本副本的 raft_addr
+	Bootstrap      bool           `yaml:"bootstrap"`        // 本地没有 Raft 状态时以 peers 初始化集群
+	RaftDBPath     string         `yaml:"raft_db_path"`     // Raft 日志数据库路径
+	SnapshotDir    string         `yaml:"snapshot_dir"`     // Raft 快照目录
+	SyncIntervalMs int            `yaml:"sync_interval_ms"` // leader 复制状态变化的周期（毫秒）
+	Peers          []HAPeerConfig `yaml:"peers"`            // 集群全部副本（包括本副本）
+}
+
+// HAPeerConfig 集群副本配置
+type HAPeerConfig struct {
+	ID       string `yaml:"id"`        // 副本 ID
+	RaftAddr string `yaml:"raft_addr"` // Raft 通信地址 host:port
+	HTTPAddr string `yaml:"http_addr"` // HTTP 地址 host:port，follower 向其转发请求
+	RPCAddr  string `yaml:"rpc_addr"`  // Registry RPC 地址 host:port，返回给客户端用于重定向
+}
+
+// SchedulerConfig 全局调度器配置
+type SchedulerConfig struct {
+	Topology   TopologyConfig   `yaml:"topology"`   // 网络拓扑感知调度配置
+	Queue      QueueConfig      `yaml:"queue"`      // 部署等待队列配置
+	Preemption PreemptionConfig `yaml:"preemption"` // 抢占配置
+	Reschedule RescheduleConfig `yaml:"reschedule"` // 失联部署自动重新调度配置
+}
+
+// RescheduleConfig 失联部署自动重新调度配置
+// 启用后，所在节点离线或被清理的运行中部署被标记为 lost，restartable 的部署按原始请求重新放置，失败时指数退避
+type RescheduleConfig struct {
+	Enabled               bool `yaml:"enabled"`                 // 是否启用
+	IntervalSeconds       int  `yaml:"interval_seconds"`        // 检查周期（秒）
+	InitialBackoffSeconds int  `yaml:"initial_backoff_seconds"` // 重启失败后的首次等待时间（秒），之后每次翻倍
+	MaxBackoffSeconds     int  `yaml:"max_backoff_seconds"`     // 退避等待时间上限（秒）
+	MaxRestarts           int  `yaml:"max_restarts"`            // 请求未指定 max_restarts 时的最大重启次数
+}
+
+// PreemptionConfig 抢占配置
+// 启用后，priority 大于 0 的请求在没有可用容量时可停止同一节点上优先级更低的 component，
+// 被抢占的 component 会重新调度或重新排队，整个过程记录审计事件
+type PreemptionConfig struct {
+	Enabled            bool `yaml:"enabled"`              // 是否启用
+	StopTimeoutSeconds int  `yaml:"stop_timeout_seconds"` // 通知节点停止 component 的超时时间（秒）
+}
+
+// QueueConfig 部署等待队列配置
+// 启用后，携带 max_wait_seconds 的请求在暂无可用容量时排队，容量变化时按优先级重试
+type QueueConfig struct {
+	Enabled              bool `yaml:"enabled"`                // 是否启用
+	RetryIntervalSeconds int  `yaml:"retry_interval_seconds"` // 周期性重试间隔（秒）
+	MaxWaitSeconds       int  `yaml:"max_wait_seconds"`       // 单个请求允许的最长等待时间（秒），超出时截断
+}
+
+// TopologyConfig 网络拓扑感知调度配置
+// 启用后，携带上游地址的请求按 同节点 > 同域 > 低延迟域 的顺序打分
+type TopologyConfig struct {
+	Enabled          bool  `yaml:"enabled"`            // 是否启用
+	SameNodeWeight   int64 `yaml:"same_node_weight"`   // 与上游同节点的得分
+	SameDomainWeight int64 `yaml:"same_domain_weight"` // 与上游同域的得分
+	LatencyWeight    int64 `yaml:"latency_weight"`     // 低延迟域的最高得分（依赖主动探测的 RTT，负数表示关闭）
+}
+
+// TenancyConfig 多租户配置
+type TenancyConfig struct {
+	RequireAuth bool   `yaml:"require_auth"` // 所有请求必须携带有效的访问令牌（否则匿名请求以默认租户访问公共域）
+	AdminToken  string `yaml:"admin_token"`  // 管理员令牌（必填），创建域与项目、修改全局配置等写操作需要携带
+}
+
+// RegistryConfig 注册中心配置
+type RegistryConfig struct {
+	HealthCheck     HealthCheckConfig     `yaml:"health_check"`     // 节点健康检查策略（全局默认值，域可单独覆盖）
+	FailureDetector FailureDetectorConfig `yaml:"failure_detector"` // phi-accrual 故障检测配置
+	Prober          ProberConfig          `yaml:"prober"`           // 节点地址主动探测配置
+	// RequireJoinToken 新节点注册（包括通过心跳自动注册）是否必须携带所属域的加入令牌
+	RequireJoinToken bool `yaml:"require_join_token"`
+}
+
+// HealthCheckConfig 节点健康检查策略配置
+type HealthCheckConfig struct {
+	TimeoutSeconds       int     `yaml:"timeout_seconds"`        // 节点超时时间（秒），超时后标记为离线
+	CleanupFactor        float64 `yaml:"cleanup_factor"`         // 清理倍数，离线超过 超时时间×倍数 后删除节点
+	CheckIntervalSeconds int     `yaml:"check_interval_seconds"` // 超时检测周期（秒）
+}
+
+// FailureDetectorConfig phi-accrual 故障检测配置
+// 节点 phi 超过 suspect_threshold 进入 suspect 状态，超过 offline_threshold 标记为离线
+type FailureDetectorConfig struct {
+	SuspectThreshold       float64 `yaml:"suspect_threshold"`        // 怀疑阈值
+	OfflineThreshold       float64 `yaml:"offline_threshold"`        // 离线阈值
+	WindowSize             int     `yaml:"window_size"`              // 保留的心跳间隔样本数
+	MinStdDeviationMs      int     `yaml:"min_std_deviation_ms"`     // 最小标准差（毫秒），为 0 时取心跳间隔的 1/4
+	AcceptablePauseSeconds int     `yaml:"acceptable_pause_seconds"` // 可容忍的额外停顿（秒），为 0 时取心跳间隔的 1/2，小于 0 表示不容忍
 }
 
 // DatabaseConfig 数据库配置
 type DatabaseConfig struct {
 	DomainDBPath           string `yaml:"domain_db_path"`            // Domain 数据库路径
+	SchedulerDBPath        string `yaml:"scheduler_db_path"`         // 调度数据库路径（部署记录、配额）
 	MaxOpenConns           int    `yaml:"max_open_conns"`            // 最大打开连接数
 	MaxIdleConns           int    `yaml:"max_idle_conns"`            // 最大空闲连接数
 	ConnMaxLifetimeSeconds int    `yaml:"conn_max_lifetime_seconds"` // 连接最大生存时间（秒）
@@ -41,3 +239,13 @@ type RPCConfig struct {
 type RPCRegistryConfig struct {
 	Port int `yaml:"port"` // e.g., 50010 - Registry RPC server port
 }
+
+// ProberConfig 节点地址主动探测配置
+type ProberConfig struct {
+	Enabled          bool   `yaml:"enabled"`           // 是否启用主动探测
+	Mode             string `yaml:"mode"`              // 探测方式：grpc（gRPC 健康检查，未实现时回退 TCP）/ tcp
+	IntervalSeconds  int    `yaml:"interval_seconds"`  // 探测周期（秒）
+	TimeoutSeconds   int    `yaml:"timeout_seconds"`   // 单次探测超时（秒）
+	FailureThreshold int    `yaml:"failure_threshold"` // 连续失败多少次后标记为不可达
+	MaxConcurrency   int    `yaml:"max_concurrency"`   // 最大并发探测数
+}
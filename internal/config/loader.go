@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"path/filepath"
 
 	"gopkg.in/yaml.v2"
 )
@@ -31,6 +32,11 @@ func ApplyDefaults(cfg *Config) {
 		cfg.DataDir = "./data"
 	}
 
+	// 数据库路径默认值
+	if cfg.Database.SchedulerDBPath == "" {
+		cfg.Database.SchedulerDBPath = filepath.Join(cfg.DataDir, "scheduler.db")
+	}
+
 	// HTTP 配置默认值
 	if cfg.Transport.HTTP.Port == 0 {
 		cfg.Transport.HTTP.Port = 8080 // 默认 HTTP 端口
@@ -40,4 +46,163 @@ func ApplyDefaults(cfg *Config) {
 	if cfg.Transport.RPC.Registry.Port == 0 {
 		cfg.Transport.RPC.Registry.Port = 50010 // 默认 Registry RPC 端口
 	}
+
+	// 健康检查策略默认值
+	if cfg.Registry.HealthCheck.TimeoutSeconds == 0 {
+		cfg.Registry.HealthCheck.TimeoutSeconds = 90 // 默认 90 秒超时
+	}
+	if cfg.Registry.HealthCheck.CleanupFactor == 0 {
+		cfg.Registry.HealthCheck.CleanupFactor = 2 // 默认离线 2 倍超时时间后清理
+	}
+	if cfg.Registry.HealthCheck.CheckIntervalSeconds == 0 {
+		cfg.Registry.HealthCheck.CheckIntervalSeconds = 10 // 默认每 10 秒检查一次
+	}
+
+	// 故障检测默认值
+	if cfg.Registry.FailureDetector.SuspectThreshold == 0 {
+		cfg.Registry.FailureDetector.SuspectThreshold = 3
+	}
+	if cfg.Registry.FailureDetector.OfflineThreshold == 0 {
+		cfg.Registry.FailureDetector.OfflineThreshold = 8
+	}
+	if cfg.Registry.FailureDetector.WindowSize == 0 {
+		cfg.Registry.FailureDetector.WindowSize = 100
+	}
+	// min_std_deviation_ms / acceptable_pause_seconds 为 0 时按节点的建议心跳间隔缩放，不在此填充
+
+	// 主动探测默认值
+	if cfg.Registry.Prober.Mode == "" {
+		cfg.Registry.Prober.Mode = "grpc"
+	}
+	if cfg.Registry.Prober.IntervalSeconds == 0 {
+		cfg.Registry.Prober.IntervalSeconds = 15
+	}
+	if cfg.Registry.Prober.TimeoutSeconds == 0 {
+		cfg.Registry.Prober.TimeoutSeconds = 3
+	}
+	if cfg.Registry.Prober.FailureThreshold == 0 {
+		cfg.Registry.Prober.FailureThreshold = 2
+	}
+	if cfg.Registry.Prober.MaxConcurrency == 0 {
+		cfg.Registry.Prober.MaxConcurrency = 16
+	}
+
+	// 拓扑感知调度默认值
+	if cfg.Scheduler.Topology.SameNodeWeight == 0 {
+		cfg.Scheduler.Topology.SameNodeWeight = 100
+	}
+	if cfg.Scheduler.Topology.SameDomainWeight == 0 {
+		cfg.Scheduler.Topology.SameDomainWeight = 50
+	}
+	if cfg.Scheduler.Topology.LatencyWeight == 0 {
+		cfg.Scheduler.Topology.LatencyWeight = 20
+	}
+
+	// 部署等待队列默认值
+	if cfg.Scheduler.Queue.RetryIntervalSeconds == 0 {
+		cfg.Scheduler.Queue.RetryIntervalSeconds = 30
+	}
+	if cfg.Scheduler.Queue.MaxWaitSeconds == 0 {
+		cfg.Scheduler.Queue.MaxWaitSeconds = 3600
+	}
+
+	// 抢占默认值
+	if cfg.Scheduler.Preemption.StopTimeoutSeconds == 0 {
+		cfg.Scheduler.Preemption.StopTimeoutSeconds = 10
+	}
+
+	// 失联部署重新调度默认值
+	if cfg.Scheduler.Reschedule.IntervalSeconds == 0 {
+		cfg.Scheduler.Reschedule.IntervalSeconds = 10
+	}
+	if cfg.Scheduler.Reschedule.InitialBackoffSeconds == 0 {
+		cfg.Scheduler.Reschedule.InitialBackoffSeconds = 5
+	}
+	if cfg.Scheduler.Reschedule.MaxBackoffSeconds == 0 {
+		cfg.Scheduler.Reschedule.MaxBackoffSeconds = 300
+	}
+	if cfg.Scheduler.Reschedule.MaxRestarts == 0 {
+		cfg.Scheduler.Reschedule.MaxRestarts = 3
+	}
+
+	// 高可用默认值
+	if cfg.HA.RaftDBPath == "" {
+		cfg.HA.RaftDBPath = filepath.Join(cfg.DataDir, "raft.db")
+	}
+	if cfg.HA.SnapshotDir == "" {
+		cfg.HA.SnapshotDir = filepath.Join(cfg.DataDir, "raft")
+	}
+	if cfg.HA.SyncIntervalMs == 0 {
+		cfg.HA.SyncIntervalMs = 500
+	}
+
+	// 联邦默认值
+	if cfg.Federation.InstanceID == "" {
+		if hostname, err := os.Hostname(); err == nil {
+			cfg.Federation.InstanceID = hostname
+		}
+	}
+	if cfg.Federation.MaxHops == 0 {
+		cfg.Federation.MaxHops = 1
+	}
+	if cfg.Federation.ExchangeIntervalSeconds == 0 {
+		cfg.Federation.ExchangeIntervalSeconds = 30
+	}
+	if cfg.Federation.SummaryTTLSeconds == 0 {
+		cfg.Federation.SummaryTTLSeconds = 3 * cfg.Federation.ExchangeIntervalSeconds
+	}
+	if cfg.Federation.CallTimeoutSeconds == 0 {
+		cfg.Federation.CallTimeoutSeconds = 10
+	}
+
+	// 容量时序数据默认值
+	if cfg.Metrics.DBPath == "" {
+		cfg.Metrics.DBPath = filepath.Join(cfg.DataDir, "metrics.db")
+	}
+	if cfg.Metrics.SampleIntervalSeconds == 0 {
+		cfg.Metrics.SampleIntervalSeconds = 15
+	}
+	if cfg.Metrics.RawRetentionHours == 0 {
+		cfg.Metrics.RawRetentionHours = 6
+	}
+	if cfg.Metrics.MinuteRetentionDays == 0 {
+		cfg.Metrics.MinuteRetentionDays = 7
+	}
+	if cfg.Metrics.HourRetentionDays == 0 {
+		cfg.Metrics.HourRetentionDays = 90
+	}
+	if cfg.Metrics.ForecastLookbackDays == 0 {
+		cfg.Metrics.ForecastLookbackDays = 7
+	}
+	if cfg.Metrics.ForecastHorizonDays == 0 {
+		cfg.Metrics.ForecastHorizonDays = 14
+	}
+
+	// 告警默认值
+	if cfg.Alerts.EvaluationIntervalSeconds == 0 {
+		cfg.Alerts.EvaluationIntervalSeconds = 60
+	}
+	if cfg.Alerts.WebhookTimeoutSeconds == 0 {
+		cfg.Alerts.WebhookTimeoutSeconds = 10
+	}
+
+	// webhook 默认值
+	if cfg.Webhooks.MaxAttempts == 0 {
+		cfg.Webhooks.MaxAttempts = 8
+	}
+	if cfg.Webhooks.InitialBackoffSeconds == 0 {
+		cfg.Webhooks.InitialBackoffSeconds = 5
+	}
+	if cfg.Webhooks.MaxBackoffSeconds == 0 {
+		cfg.Webhooks.MaxBackoffSeconds = 600
+	}
+	if cfg.Webhooks.TimeoutSeconds == 0 {
+		cfg.Webhooks.TimeoutSeconds = 10
+	}
+	if cfg.Webhooks.Workers == 0 {
+		cfg.Webhooks.Workers = 4
+	}
+	if cfg.Webhooks.RetentionDays == 0 {
+		cfg.Webhooks.RetentionDays = 7
+	}
 }
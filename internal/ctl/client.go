@@ -0,0 +1,171 @@
+package ctl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/tenant"
+)
+
+// Client 全局调度器 HTTP 接口的客户端
+// HA 模式下可配置多个副本地址，当前地址不可达或暂无 leader（503）时依次尝试其他地址
+type Client struct {
+	servers []string
+	current int
+	token   string
+	tenant  string
+	http    *http.Client
+}
+
+// APIError 接口返回的错误
+type APIError struct {
+	Code    int
+	Message string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s (HTTP %d)", e.Message, e.Code)
+}
+
+// envelope 接口统一的响应结构
+type envelope struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+	Error   string          `json:"error"`
+}
+
+// NewClient 按上下文创建客户端，服务地址可用逗号分隔多个副本
+func NewClient(ctx *Context) *Client {
+	servers := make([]string, 0)
+	for _, server := range strings.Split(ctx.Server, ",") {
+		server = strings.TrimRight(strings.TrimSpace(server), "/")
+		if server == "" {
+			continue
+		}
+		if !strings.Contains(server, "://") {
+			server = "http://" + server
+		}
+		servers = append(servers, server)
+	}
+	if len(servers) == 0 {
+		servers = append(servers, DefaultServer)
+	}
+	return &Client{
+		servers: servers,
+		token:   ctx.Token,
+		tenant:  ctx.Tenant,
+		http:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Get 发送 GET 请求，返回响应中的 data
+func (c *Client) Get(ctx context.Context, path string, query url.Values) (json.RawMessage, error) {
+	return c.Do(ctx, http.MethodGet, path, query, nil)
+}
+
+// Post 发送 POST 请求，body 为空时不携带请求体
+func (c *Client) Post(ctx context.Context, path string, body any) (json.RawMessage, error) {
+	return c.Do(ctx, http.MethodPost, path, nil, body)
+}
+
+// Put 发送 PUT 请求
+func (c *Client) Put(ctx context.Context, path string, body any) (json.RawMessage, error) {
+	return c.Do(ctx, http.MethodPut, path, nil, body)
+}
+
+// Delete 发送 DELETE 请求
+func (c *Client) Delete(ctx context.Context, path string) (json.RawMessage, error) {
+	return c.Do(ctx, http.MethodDelete, path, nil, nil)
+}
+
+// Do 发送请求并解析统一响应结构，非 2xx 响应返回 *APIError
+func (c *Client) Do(ctx context.Context, method, path string, query url.Values, body any) (json.RawMessage, error) {
+	var data []byte
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		data = encoded
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < len(c.servers); attempt++ {
+		server := c.servers[(c.current+attempt)%len(c.servers)]
+		result, err := c.send(ctx, server, method, path, query, data)
+		if err == nil {
+			c.current = (c.current + attempt) % len(c.servers)
+			return result, nil
+		}
+		// 服务端已处理的错误直接返回，不可达或暂无 leader 时尝试下一个副本
+		var apiErr *APIError
+		if (errors.As(err, &apiErr) && apiErr.Code != http.StatusServiceUnavailable) || ctx.Err() != nil {
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// send 向指定服务地址发送一次请求
+func (c *Client) send(ctx context.Context, server, method, path string, query url.Values, data []byte) (json.RawMessage, error) {
+	target := server + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if data != nil {
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, err
+	}
+	if data != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set(tenant.AuthorizationMetadataKey, "Bearer "+c.token)
+	}
+	if c.tenant != "" {
+		req.Header.Set(tenant.TenantMetadataKey, c.tenant)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	result := envelope{}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		if resp.StatusCode >= 300 {
+			return nil, &APIError{Code: resp.StatusCode, Message: strings.TrimSpace(string(raw))}
+		}
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if resp.StatusCode >= 300 {
+		msg := result.Error
+		if msg == "" {
+			msg = result.Message
+		}
+		return nil, &APIError{Code: resp.StatusCode, Message: msg}
+	}
+	return result.Data, nil
+}
@@ -0,0 +1,124 @@
+package ctl
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ConfigEnv 指定上下文文件路径的环境变量
+const ConfigEnv = "IARNETCTL_CONFIG"
+
+// DefaultServer 未配置上下文时使用的 HTTP 地址
+const DefaultServer = "http://127.0.0.1:8080"
+
+// Config iarnetctl 上下文文件，格式与 kubeconfig 类似：多个具名上下文及当前使用的上下文
+type Config struct {
+	CurrentContext string    `yaml:"current-context" json:"current-context"`
+	Contexts       []Context `yaml:"contexts" json:"contexts"`
+}
+
+// Context 连接全局调度器的上下文
+// Token 与 Tenant 均为空时以不受限的管理视图访问
+type Context struct {
+	Name   string `yaml:"name" json:"name"`
+	Server string `yaml:"server" json:"server"`                     // HTTP 地址，例如 http://127.0.0.1:8080
+	Token  string `yaml:"token,omitempty" json:"token,omitempty"`   // 项目访问令牌（Authorization: Bearer）
+	Tenant string `yaml:"tenant,omitempty" json:"tenant,omitempty"` // 租户声明（X-Iarnet-Tenant）
+}
+
+// DefaultConfigPath 返回上下文文件路径：优先使用 IARNETCTL_CONFIG，否则为 ~/.iarnet/config
+func DefaultConfigPath() string {
+	if path := os.Getenv(ConfigEnv); path != "" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".iarnet", "config")
+	}
+	return filepath.Join(home, ".iarnet", "config")
+}
+
+// LoadConfig 读取上下文文件，文件不存在时返回空配置
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	cfg := &Config{}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Save 写入上下文文件，文件包含访问令牌，权限为 0600
+func (c *Config) Save(path string) error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write config %s: %w", path, err)
+	}
+	return nil
+}
+
+// Context 按名称查找上下文，name 为空时返回当前上下文；没有任何上下文时返回连接本机的默认上下文
+func (c *Config) Context(name string) (*Context, error) {
+	if name == "" {
+		name = c.CurrentContext
+	}
+	if name == "" {
+		if len(c.Contexts) == 0 {
+			return &Context{Name: "default", Server: DefaultServer}, nil
+		}
+		return nil, errors.New("no current context is set, run `iarnetctl config use-context NAME`")
+	}
+	for i := range c.Contexts {
+		if c.Contexts[i].Name == name {
+			ctx := c.Contexts[i]
+			return &ctx, nil
+		}
+	}
+	return nil, fmt.Errorf("context %q not found", name)
+}
+
+// SetContext 新增或替换同名上下文；当前没有上下文时将其设为当前上下文
+func (c *Config) SetContext(ctx Context) {
+	for i := range c.Contexts {
+		if c.Contexts[i].Name == ctx.Name {
+			c.Contexts[i] = ctx
+			return
+		}
+	}
+	c.Contexts = append(c.Contexts, ctx)
+	if c.CurrentContext == "" {
+		c.CurrentContext = ctx.Name
+	}
+}
+
+// DeleteContext 删除上下文，删除的是当前上下文时清空当前上下文
+func (c *Config) DeleteContext(name string) error {
+	for i := range c.Contexts {
+		if c.Contexts[i].Name != name {
+			continue
+		}
+		c.Contexts = append(c.Contexts[:i], c.Contexts[i+1:]...)
+		if c.CurrentContext == name {
+			c.CurrentContext = ""
+		}
+		return nil
+	}
+	return fmt.Errorf("context %q not found", name)
+}
@@ -0,0 +1,134 @@
+package ctl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Format 输出格式
+type Format string
+
+const (
+	FormatTable Format = "table"
+	FormatJSON  Format = "json"
+	FormatYAML  Format = "yaml"
+)
+
+// ParseFormat 解析 -o 参数
+func ParseFormat(s string) (Format, error) {
+	switch Format(strings.ToLower(s)) {
+	case "", FormatTable:
+		return FormatTable, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	case FormatYAML, "yml":
+		return FormatYAML, nil
+	default:
+		return "", fmt.Errorf("unknown output format %q (available: table, json, yaml)", s)
+	}
+}
+
+// Table 表格输出
+type Table struct {
+	Header []string
+	Rows   [][]string
+}
+
+// Append 追加一行
+func (t *Table) Append(cells ...string) {
+	t.Rows = append(t.Rows, cells)
+}
+
+// Write 以对齐的列输出表格
+func (t *Table) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(t.Header, "\t"))
+	for _, row := range t.Rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			if cell == "" {
+				cell = "-"
+			}
+			cells[i] = cell
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+// Print 按格式输出接口返回的数据：json/yaml 保留接口原始字段，table 由 table 构造
+func Print(w io.Writer, format Format, data json.RawMessage, table func() (*Table, error)) error {
+	switch format {
+	case FormatJSON:
+		out := bytes.Buffer{}
+		if len(data) == 0 {
+			data = json.RawMessage("null")
+		}
+		if err := json.Indent(&out, data, "", "  "); err != nil {
+			return err
+		}
+		out.WriteByte('\n')
+		_, err := w.Write(out.Bytes())
+		return err
+	case FormatYAML:
+		value, err := decodeGeneric(data)
+		if err != nil {
+			return err
+		}
+		out, err := yaml.Marshal(value)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(out)
+		return err
+	default:
+		t, err := table()
+		if err != nil {
+			return err
+		}
+		return t.Write(w)
+	}
+}
+
+// decodeGeneric 将 JSON 解码为通用结构，整数保持为整数以免 YAML 输出科学计数法
+func decodeGeneric(data json.RawMessage) (any, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return normalizeNumbers(value), nil
+}
+
+func normalizeNumbers(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		for k, item := range v {
+			v[k] = normalizeNumbers(item)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = normalizeNumbers(item)
+		}
+		return v
+	default:
+		return value
+	}
+}
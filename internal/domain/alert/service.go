@@ -0,0 +1,439 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/metrics"
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	"github.com/9triver/iarnet-global/internal/util"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultEvaluationInterval 默认的规则评估周期
+	DefaultEvaluationInterval = time.Minute
+	// DefaultHistorySize 默认保留的已恢复告警数量
+	DefaultHistorySize = 200
+)
+
+// Options 告警服务配置
+type Options struct {
+	// Rules 告警规则
+	Rules []Rule
+	// Sinks 告警通知目标
+	Sinks []Sink
+	// EvaluationInterval 规则评估周期
+	EvaluationInterval time.Duration
+	// HistorySize 保留的已恢复告警数量
+	HistorySize int
+	// IsLeader HA 模式下只有 leader 评估规则并发送通知，为空时视为单副本部署
+	IsLeader func() bool
+}
+
+func (o Options) withDefaults() Options {
+	if o.EvaluationInterval <= 0 {
+		o.EvaluationInterval = DefaultEvaluationInterval
+	}
+	if o.HistorySize <= 0 {
+		o.HistorySize = DefaultHistorySize
+	}
+	for i := range o.Rules {
+		switch o.Rules[i].Type {
+		case RuleCapacityExhaustion:
+			if o.Rules[i].Within <= 0 {
+				o.Rules[i].Within = DefaultCapacityWithin
+			}
+		case RuleHeadNodeOffline:
+			if o.Rules[i].For <= 0 {
+				o.Rules[i].For = DefaultOfflineFor
+			}
+		}
+	}
+	return o
+}
+
+// Service 告警服务：周期性评估告警规则，告警触发与恢复时通知各个 Sink
+type Service interface {
+	// Start 启动周期性的规则评估
+	Start(ctx context.Context) error
+	// Stop 停止规则评估
+	Stop()
+	// Rules 返回告警规则
+	Rules() []Rule
+	// ListAlerts 返回告警，state 为空时返回告警中与最近恢复的全部告警
+	ListAlerts(state State) []*Alert
+	// Evaluate 立即评估一次全部规则
+	Evaluate(ctx context.Context)
+}
+
+type service struct {
+	manager *registry.Manager
+	metrics metrics.Service
+	opts    Options
+
+	mu sync.RWMutex
+	// active 告警中的告警
+	active map[alertKey]*Alert
+	// history 最近恢复的告警，按恢复时间正序
+	history []*Alert
+
+	headsMu sync.Mutex
+	// lostHeads 由注册中心事件记录的失效 head 节点（节点 ID -> 最后已知状态）
+	lostHeads map[registry.NodeID]*lostHead
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+// NewService 创建告警服务，metricsService 为空时不支持 capacity_exhaustion 规则
+func NewService(manager *registry.Manager, metricsService metrics.Service, opts Options) (Service, error) {
+	opts = opts.withDefaults()
+	names := make(map[string]bool, len(opts.Rules))
+	for _, rule := range opts.Rules {
+		if err := validateRule(rule, metricsService != nil); err != nil {
+			return nil, err
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("%w: duplicate rule name %q", ErrInvalidRule, rule.Name)
+		}
+		names[rule.Name] = true
+	}
+
+	s := &service{
+		manager:   manager,
+		metrics:   metricsService,
+		opts:      opts,
+		active:    make(map[alertKey]*Alert),
+		lostHeads: make(map[registry.NodeID]*lostHead),
+		stopCh:    make(chan struct{}),
+	}
+	// 离线的 head 节点超过清理时间后会从注册中心移除，通过事件保留其最后已知状态，
+	// 否则持续时间长于清理时间的规则永远不会触发
+	manager.SubscribeEvents(s.onRegistryEvent)
+	return s, nil
+}
+
+// onRegistryEvent 记录 head 节点的失效、移除与恢复，在注册中心持有锁时调用，不能再调用管理器的方法
+func (s *service) onRegistryEvent(event *registry.Event) {
+	s.headsMu.Lock()
+	defer s.headsMu.Unlock()
+
+	switch event.Type {
+	case registry.EventHeadNodeLost:
+		if head, ok := s.lostHeads[event.NodeID]; ok {
+			head.removed = head.removed || event.Details["status"] == "removed"
+			return
+		}
+		s.lostHeads[event.NodeID] = &lostHead{
+			domainID: event.DomainID,
+			name:     event.Details["node_name"],
+			status:   event.Details["status"],
+			lastSeen: event.Time,
+			removed:  event.Details["status"] == "removed",
+		}
+	case registry.EventNodeRemoved:
+		if head, ok := s.lostHeads[event.NodeID]; ok {
+			head.removed = true
+		}
+	case registry.EventHeadNodeRecovered, registry.EventNodeRegistered:
+		delete(s.lostHeads, event.NodeID)
+	case registry.EventDomainDeleted:
+		for id, head := range s.lostHeads {
+			if head.domainID == event.DomainID {
+				delete(s.lostHeads, id)
+			}
+		}
+	}
+}
+
+func validateRule(rule Rule, withCapacity bool) error {
+	if rule.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidRule)
+	}
+	switch rule.Type {
+	case RuleCapacityExhaustion:
+		if !withCapacity {
+			return fmt.Errorf("%w: rule %q requires capacity metrics to be enabled", ErrInvalidRule, rule.Name)
+		}
+		switch rule.Resource {
+		case "", metrics.ResourceCPU, metrics.ResourceMemory, metrics.ResourceGPU:
+		default:
+			return fmt.Errorf("%w: rule %q has unknown resource %q", ErrInvalidRule, rule.Name, rule.Resource)
+		}
+	case RuleHeadNodeOffline:
+		if rule.Resource != "" {
+			return fmt.Errorf("%w: rule %q does not take a resource", ErrInvalidRule, rule.Name)
+		}
+	default:
+		return fmt.Errorf("%w: rule %q has unknown type %q", ErrInvalidRule, rule.Name, rule.Type)
+	}
+	return nil
+}
+
+func (s *service) leading() bool {
+	return s.opts.IsLeader == nil || s.opts.IsLeader()
+}
+
+func (s *service) Start(ctx context.Context) error {
+	go s.run(ctx)
+	logrus.Infof("Alerting started (%d rule(s), %d sink(s), evaluation interval: %v)",
+		len(s.opts.Rules), len(s.opts.Sinks), s.opts.EvaluationInterval)
+	return nil
+}
+
+func (s *service) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+	})
+}
+
+func (s *service) run(ctx context.Context) {
+	ticker := time.NewTicker(s.opts.EvaluationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.Evaluate(ctx)
+		}
+	}
+}
+
+func (s *service) Rules() []Rule {
+	rules := make([]Rule, len(s.opts.Rules))
+	copy(rules, s.opts.Rules)
+	return rules
+}
+
+func (s *service) ListAlerts(state State) []*Alert {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	alerts := make([]*Alert, 0, len(s.active)+len(s.history))
+	if state == "" || state == StateFiring {
+		for _, a := range s.active {
+			copied := *a
+			alerts = append(alerts, &copied)
+		}
+		sort.Slice(alerts, func(i, j int) bool { return alerts[i].StartsAt.Before(alerts[j].StartsAt) })
+	}
+	if state == "" || state == StateResolved {
+		// 最近恢复的排在前面
+		for i := len(s.history) - 1; i >= 0; i-- {
+			copied := *s.history[i]
+			alerts = append(alerts, &copied)
+		}
+	}
+	return alerts
+}
+
+// Evaluate 评估全部规则，与上次评估结果比较后通知触发与恢复的告警
+// 失去 leader 身份的副本清空告警状态，成为 leader 后重新评估，已在告警中的情况会再次通知
+func (s *service) Evaluate(ctx context.Context) {
+	if !s.leading() {
+		s.mu.Lock()
+		s.active = make(map[alertKey]*Alert)
+		s.mu.Unlock()
+		return
+	}
+
+	now := time.Now()
+	domains := s.manager.GetAllDomains()
+	conditions := make(map[alertKey]*condition)
+	// evaluated 本次成功评估的规则，评估失败的规则保持原有告警状态
+	evaluated := make(map[string]bool, len(s.opts.Rules))
+	for _, rule := range s.opts.Rules {
+		var err error
+		switch rule.Type {
+		case RuleCapacityExhaustion:
+			err = s.evaluateCapacity(ctx, rule, domains, now, conditions)
+		case RuleHeadNodeOffline:
+			s.evaluateHeadNode(rule, domains, now, conditions)
+		}
+		if err != nil {
+			logrus.Warnf("Failed to evaluate alert rule %s: %v", rule.Name, err)
+			continue
+		}
+		evaluated[rule.Name] = true
+	}
+
+	notify := s.reconcile(now, conditions, evaluated)
+	for _, a := range notify {
+		s.notify(ctx, a)
+	}
+}
+
+// evaluateCapacity 预测规则范围内各个域的资源耗尽时间
+func (s *service) evaluateCapacity(ctx context.Context, rule Rule, domains []*registry.Domain, now time.Time, conditions map[alertKey]*condition) error {
+	for _, domain := range domains {
+		if rule.DomainID != "" && domain.ID != rule.DomainID {
+			continue
+		}
+		forecast, err := s.metrics.Forecast(ctx, domain.ID, metrics.ForecastOptions{Horizon: rule.Within})
+		if err != nil {
+			return err
+		}
+		for _, r := range forecast.Resources {
+			if rule.Resource != "" && r.Resource != rule.Resource {
+				continue
+			}
+			if r.ExhaustsAt == nil || r.ExhaustsAt.Sub(now) > rule.Within {
+				continue
+			}
+			var message string
+			if r.Status == metrics.ForecastExhausted {
+				message = fmt.Sprintf("domain %s has no %s available (total %d)", domain.Name, r.Resource, r.Total)
+			} else {
+				message = fmt.Sprintf("domain %s is projected to run out of %s in %s (available %d of %d, trend %.1f/h)",
+					domain.Name, r.Resource, r.ExhaustsAt.Sub(now).Round(time.Minute), r.Available, r.Total, r.TrendPerHour)
+			}
+			key := alertKey{rule: rule.Name, domainID: domain.ID, resource: r.Resource}
+			conditions[key] = &condition{
+				key:        key,
+				typ:        rule.Type,
+				domainName: domain.Name,
+				message:    message,
+				exhaustsAt: r.ExhaustsAt,
+			}
+		}
+	}
+	return nil
+}
+
+// evaluateHeadNode 检查规则范围内的 head 节点是否离线或异常超过规则的持续时间
+// 已被注册中心清理的 head 节点按最后已知状态继续评估，直到节点重新注册或域有了新的在线 head 节点
+func (s *service) evaluateHeadNode(rule Rule, domains []*registry.Domain, now time.Time, conditions map[alertKey]*condition) {
+	names := make(map[string]string, len(domains))
+	for _, domain := range domains {
+		names[domain.ID] = domain.Name
+	}
+	heads := s.manager.GetHeadNodes()
+	present := make(map[registry.NodeID]*registry.Node, len(heads))
+	headed := make(map[string]bool)
+	for _, node := range heads {
+		present[node.ID] = node
+		if node.IsAlive() {
+			headed[node.DomainID] = true
+		}
+		if rule.DomainID != "" && node.DomainID != rule.DomainID {
+			continue
+		}
+		if node.Status != registry.NodeStatusOffline && node.Status != registry.NodeStatusError {
+			continue
+		}
+		down := now.Sub(node.LastSeen)
+		if down < rule.For {
+			continue
+		}
+		key := alertKey{rule: rule.Name, domainID: node.DomainID, nodeID: node.ID}
+		conditions[key] = &condition{
+			key:        key,
+			typ:        rule.Type,
+			domainName: names[node.DomainID],
+			message: fmt.Sprintf("head node %s of domain %s has been %s for %s",
+				node.Name, names[node.DomainID], node.Status, down.Round(time.Second)),
+		}
+	}
+
+	s.headsMu.Lock()
+	defer s.headsMu.Unlock()
+	for id, head := range s.lostHeads {
+		if node, ok := present[id]; ok {
+			head.lastSeen = node.LastSeen
+			head.status = string(node.Status)
+			continue
+		}
+		if headed[head.domainID] {
+			delete(s.lostHeads, id)
+			continue
+		}
+		if !head.removed || (rule.DomainID != "" && head.domainID != rule.DomainID) {
+			continue
+		}
+		down := now.Sub(head.lastSeen)
+		if down < rule.For {
+			continue
+		}
+		message := fmt.Sprintf("head node %s of domain %s has been %s for %s and was removed from the registry",
+			head.name, names[head.domainID], head.status, down.Round(time.Second))
+		if head.status == "removed" {
+			message = fmt.Sprintf("head node %s of domain %s was removed from the registry %s ago",
+				head.name, names[head.domainID], down.Round(time.Second))
+		}
+		key := alertKey{rule: rule.Name, domainID: head.domainID, nodeID: id}
+		conditions[key] = &condition{
+			key:        key,
+			typ:        rule.Type,
+			domainName: names[head.domainID],
+			message:    message,
+		}
+	}
+}
+
+// reconcile 更新告警状态，返回需要通知的告警（新触发与已恢复）
+func (s *service) reconcile(now time.Time, conditions map[alertKey]*condition, evaluated map[string]bool) []*Alert {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	notify := make([]*Alert, 0)
+	for key, c := range conditions {
+		if a, ok := s.active[key]; ok {
+			a.Message = c.message
+			a.ExhaustsAt = c.exhaustsAt
+			a.UpdatedAt = now
+			continue
+		}
+		a := &Alert{
+			ID:         util.GenIDWith("alert."),
+			Rule:       key.rule,
+			Type:       c.typ,
+			DomainID:   key.domainID,
+			DomainName: c.domainName,
+			Resource:   key.resource,
+			NodeID:     key.nodeID,
+			State:      StateFiring,
+			Message:    c.message,
+			StartsAt:   now,
+			UpdatedAt:  now,
+			ExhaustsAt: c.exhaustsAt,
+		}
+		s.active[key] = a
+		copied := *a
+		notify = append(notify, &copied)
+	}
+
+	for key, a := range s.active {
+		if _, ok := conditions[key]; ok || !evaluated[key.rule] {
+			continue
+		}
+		delete(s.active, key)
+		resolvedAt := now
+		a.State = StateResolved
+		a.UpdatedAt = now
+		a.ResolvedAt = &resolvedAt
+		s.history = append(s.history, a)
+		copied := *a
+		notify = append(notify, &copied)
+	}
+	if over := len(s.history) - s.opts.HistorySize; over > 0 {
+		s.history = append([]*Alert(nil), s.history[over:]...)
+	}
+	return notify
+}
+
+// notify 通知全部 Sink，单个 Sink 失败不影响其他 Sink
+func (s *service) notify(ctx context.Context, a *Alert) {
+	for _, sink := range s.opts.Sinks {
+		if err := sink.Notify(ctx, a); err != nil {
+			logrus.Warnf("Failed to notify alert %s (%s): %v", a.ID, a.State, err)
+		}
+	}
+}
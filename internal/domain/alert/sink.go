@@ -0,0 +1,75 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultWebhookTimeout 默认的 webhook 通知超时时间
+const DefaultWebhookTimeout = 10 * time.Second
+
+// Sink 告警通知目标，告警触发与恢复时各调用一次
+type Sink interface {
+	Notify(ctx context.Context, alert *Alert) error
+}
+
+// LogSink 将告警写入日志
+type LogSink struct{}
+
+func (LogSink) Notify(_ context.Context, alert *Alert) error {
+	entry := logrus.WithFields(logrus.Fields{
+		"alert":  alert.ID,
+		"rule":   alert.Rule,
+		"domain": alert.DomainID,
+	})
+	if alert.State == StateFiring {
+		entry.Warnf("Alert firing: %s", alert.Message)
+	} else {
+		entry.Infof("Alert resolved: %s", alert.Message)
+	}
+	return nil
+}
+
+// WebhookSink 以 JSON POST 通知告警
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink 创建 webhook 通知目标，timeout 为 0 时使用默认值
+func NewWebhookSink(url string, timeout time.Duration) *WebhookSink {
+	if timeout <= 0 {
+		timeout = DefaultWebhookTimeout
+	}
+	return &WebhookSink{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+func (s *WebhookSink) Notify(ctx context.Context, alert *Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post alert to %s: %w", s.url, err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s returned status %d", s.url, resp.StatusCode)
+	}
+	return nil
+}
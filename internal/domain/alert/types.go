@@ -0,0 +1,106 @@
+package alert
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrInvalidRule 无效的告警规则
+	ErrInvalidRule = errors.New("invalid alert rule")
+)
+
+// RuleType 告警规则类型
+type RuleType string
+
+const (
+	// RuleCapacityExhaustion 域的某项资源预计在 Within 内耗尽
+	RuleCapacityExhaustion RuleType = "capacity_exhaustion"
+	// RuleHeadNodeOffline 域的 head 节点离线或异常超过 For
+	RuleHeadNodeOffline RuleType = "head_node_offline"
+)
+
+const (
+	// DefaultCapacityWithin capacity_exhaustion 规则默认的预测范围
+	DefaultCapacityWithin = 24 * time.Hour
+	// DefaultOfflineFor head_node_offline 规则默认的持续时间
+	DefaultOfflineFor = 5 * time.Minute
+)
+
+// Rule 告警规则
+type Rule struct {
+	Name string   `json:"name"`
+	Type RuleType `json:"type"`
+	// DomainID 为空时对全部域生效
+	DomainID string `json:"domain_id,omitempty"`
+	// Resource capacity_exhaustion 规则关注的资源，为空时关注全部资源
+	Resource string `json:"resource,omitempty"`
+	// Within capacity_exhaustion 规则的预测范围
+	Within time.Duration `json:"within,omitempty"`
+	// For head_node_offline 规则的持续时间
+	For time.Duration `json:"for,omitempty"`
+}
+
+// DefaultRules 未配置规则时使用的默认规则，withCapacity 为 false（未启用容量时序数据）时不包含容量规则
+func DefaultRules(withCapacity bool) []Rule {
+	rules := make([]Rule, 0, 2)
+	if withCapacity {
+		rules = append(rules, Rule{Name: "domain-capacity-24h", Type: RuleCapacityExhaustion, Within: DefaultCapacityWithin})
+	}
+	return append(rules, Rule{Name: "head-node-offline", Type: RuleHeadNodeOffline, For: DefaultOfflineFor})
+}
+
+// State 告警状态
+type State string
+
+const (
+	// StateFiring 告警中
+	StateFiring State = "firing"
+	// StateResolved 已恢复
+	StateResolved State = "resolved"
+)
+
+// Alert 告警
+type Alert struct {
+	ID         string     `json:"id"`
+	Rule       string     `json:"rule"`
+	Type       RuleType   `json:"type"`
+	DomainID   string     `json:"domain_id"`
+	DomainName string     `json:"domain_name,omitempty"`
+	Resource   string     `json:"resource,omitempty"`
+	NodeID     string     `json:"node_id,omitempty"`
+	State      State      `json:"state"`
+	Message    string     `json:"message"`
+	StartsAt   time.Time  `json:"starts_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	// ExhaustsAt capacity_exhaustion 告警最近一次评估的预计耗尽时间
+	ExhaustsAt *time.Time `json:"exhausts_at,omitempty"`
+}
+
+// alertKey 同一规则、同一域、同一资源（或 head 节点）只保留一条告警
+type alertKey struct {
+	rule     string
+	domainID string
+	resource string
+	nodeID   string
+}
+
+// condition 一次评估中满足规则的情况
+type condition struct {
+	key        alertKey
+	typ        RuleType
+	domainName string
+	message    string
+	exhaustsAt *time.Time
+}
+
+// lostHead 失效的 head 节点，节点被注册中心清理后仍据此评估 head_node_offline 规则
+type lostHead struct {
+	domainID string
+	name     string
+	status   string
+	// lastSeen 最后一次收到心跳的时间，节点仍在注册中心时每次评估更新
+	lastSeen time.Time
+	removed  bool
+}
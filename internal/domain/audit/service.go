@@ -0,0 +1,132 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/intra/repository"
+	"github.com/9triver/iarnet-global/internal/util"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultListLimit 未指定数量时返回的最大事件数
+const DefaultListLimit = 100
+
+// Service 审计事件服务
+type Service interface {
+	// Record 记录审计事件，持久化失败只记录日志，不影响调用方流程
+	Record(ctx context.Context, event *Event)
+	// List 按时间倒序查询审计事件
+	List(ctx context.Context, filter Filter) ([]*Event, error)
+	// Subscribe 注册事件回调，每条记录的事件在持久化后同步调用，回调不能阻塞
+	Subscribe(fn func(*Event))
+}
+
+type service struct {
+	repo repository.AuditRepo
+
+	mu        sync.RWMutex
+	listeners []func(*Event)
+}
+
+// NewService 创建审计事件服务
+func NewService(repo repository.AuditRepo) Service {
+	return &service{
+		repo: repo,
+	}
+}
+
+func (s *service) Record(ctx context.Context, event *Event) {
+	if event.ID == "" {
+		event.ID = util.GenIDWith("audit.")
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"audit":      event.Type,
+		"tenant":     event.Tenant,
+		"deployment": event.DeploymentID,
+		"node":       event.NodeID,
+	}).Info(event.Message)
+
+	details := ""
+	if len(event.Details) > 0 {
+		data, err := json.Marshal(event.Details)
+		if err != nil {
+			logrus.Warnf("Failed to encode audit event details: %v", err)
+		} else {
+			details = string(data)
+		}
+	}
+
+	dao := &repository.AuditEventDAO{
+		ID:           event.ID,
+		Type:         string(event.Type),
+		Tenant:       event.Tenant,
+		DeploymentID: event.DeploymentID,
+		DomainID:     event.DomainID,
+		NodeID:       event.NodeID,
+		Message:      event.Message,
+		Details:      details,
+		CreatedAt:    event.CreatedAt,
+	}
+	if err := s.repo.CreateEvent(ctx, dao); err != nil {
+		logrus.Warnf("Failed to persist audit event %s (%s): %v", event.ID, event.Type, err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, fn := range s.listeners {
+		fn(event)
+	}
+}
+
+func (s *service) Subscribe(fn func(*Event)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, fn)
+}
+
+func (s *service) List(ctx context.Context, filter Filter) ([]*Event, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultListLimit
+	}
+
+	daos, err := s.repo.ListEvents(ctx, repository.AuditEventQuery{
+		Type:         string(filter.Type),
+		Tenant:       filter.Tenant,
+		DeploymentID: filter.DeploymentID,
+		Since:        filter.Since,
+		Limit:        limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+
+	events := make([]*Event, 0, len(daos))
+	for _, dao := range daos {
+		event := &Event{
+			ID:           dao.ID,
+			Type:         EventType(dao.Type),
+			Tenant:       dao.Tenant,
+			DeploymentID: dao.DeploymentID,
+			DomainID:     dao.DomainID,
+			NodeID:       dao.NodeID,
+			Message:      dao.Message,
+			CreatedAt:    dao.CreatedAt,
+		}
+		if dao.Details != "" {
+			if err := json.Unmarshal([]byte(dao.Details), &event.Details); err != nil {
+				logrus.Warnf("Failed to decode details of audit event %s: %v", dao.ID, err)
+			}
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
@@ -0,0 +1,73 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+)
+
+// EventType 审计事件类型
+type EventType string
+
+const (
+	// EventPreemptionPlanned 为高优先级部署选出了需要抢占的低优先级 component
+	EventPreemptionPlanned EventType = "preemption.planned"
+	// EventComponentPreempted 低优先级 component 已被目标节点停止
+	EventComponentPreempted EventType = "preemption.victim_stopped"
+	// EventPreemptionStopFailed 停止被抢占的 component 失败
+	EventPreemptionStopFailed EventType = "preemption.stop_failed"
+	// EventVictimRescheduled 被抢占的 component 已重新调度到其他节点
+	EventVictimRescheduled EventType = "preemption.victim_rescheduled"
+	// EventVictimRequeued 被抢占的 component 重新进入等待队列
+	EventVictimRequeued EventType = "preemption.victim_requeued"
+	// EventVictimFailed 被抢占的 component 无法重新调度
+	EventVictimFailed EventType = "preemption.victim_failed"
+	// EventComponentMigrated component 已迁移到其他节点
+	EventComponentMigrated EventType = "deployment.migrated"
+	// EventGroupRolledBack 成组部署中有成员失败，已部署的成员被回滚
+	EventGroupRolledBack EventType = "group.rolled_back"
+	// EventDeploymentLost component 所在节点离线或被清理
+	EventDeploymentLost EventType = "deployment.lost"
+	// EventDeploymentRestarted 失联的 component 已重新调度到其他节点
+	EventDeploymentRestarted EventType = "deployment.restarted"
+	// EventDeploymentRecovered 失联节点恢复，尚未重启的 component 恢复运行
+	EventDeploymentRecovered EventType = "deployment.recovered"
+	// EventStaleInstanceRemoved 失联节点恢复后，已重启部署遗留的旧实例被移除
+	EventStaleInstanceRemoved EventType = "deployment.stale_removed"
+	// EventRestartGaveUp 失联的 component 超过最大重启次数，不再重试
+	EventRestartGaveUp EventType = "deployment.restart_failed"
+	// EventDeploymentFailed 部署下发失败
+	EventDeploymentFailed EventType = "deployment.failed"
+	// EventDeploymentForwarded 本地域无法容纳请求，已转发到对等实例
+	EventDeploymentForwarded EventType = "federation.forwarded"
+)
+
+// EventID 审计事件 ID
+type EventID = string
+
+// Event 审计事件
+type Event struct {
+	ID   EventID   `json:"id" yaml:"id"`
+	Type EventType `json:"type" yaml:"type"`
+	// Tenant 事件涉及的租户（被抢占的 component 所属租户）
+	Tenant string `json:"tenant,omitempty" yaml:"tenant,omitempty"`
+	// DeploymentID 事件涉及的部署
+	DeploymentID string            `json:"deployment_id,omitempty" yaml:"deployment_id,omitempty"`
+	DomainID     registry.DomainID `json:"domain_id,omitempty" yaml:"domain_id,omitempty"`
+	NodeID       registry.NodeID   `json:"node_id,omitempty" yaml:"node_id,omitempty"`
+	// Message 事件描述
+	Message string `json:"message" yaml:"message"`
+	// Details 附加信息，例如抢占方部署 ID、优先级
+	Details   map[string]string `json:"details,omitempty" yaml:"details,omitempty"`
+	CreatedAt time.Time         `json:"created_at" yaml:"created_at"`
+}
+
+// Filter 审计事件过滤条件，空字段表示不过滤
+type Filter struct {
+	Type         EventType
+	Tenant       string
+	DeploymentID string
+	Since        time.Time
+	// Limit 最多返回的事件数，0 表示使用默认值
+	Limit int
+}
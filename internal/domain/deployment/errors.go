@@ -0,0 +1,10 @@
+package deployment
+
+import "errors"
+
+var (
+	// ErrDeploymentNotFound 部署记录不存在
+	ErrDeploymentNotFound = errors.New("deployment not found")
+	// ErrDeploymentAlreadyExists 部署记录已存在
+	ErrDeploymentAlreadyExists = errors.New("deployment already exists")
+)
@@ -0,0 +1,90 @@
+package deployment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/9triver/iarnet-global/internal/intra/repository"
+)
+
+// replicaDeploymentPrefix HA 模式下复制状态的条目键前缀
+const replicaDeploymentPrefix = "deployment/"
+
+// ExportState 导出需要在 HA 副本间复制的部署记录（条目键 -> JSON）
+func (t *Tracker) ExportState(ctx context.Context) (map[string][]byte, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	state := make(map[string][]byte, len(t.deployments))
+	for id, d := range t.deployments {
+		dao, err := toDAO(d)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(dao)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode deployment %s: %w", id, err)
+		}
+		state[replicaDeploymentPrefix+id] = data
+	}
+	return state, nil
+}
+
+// ApplyState 应用 leader 复制的部署记录变化，值为空的条目表示删除
+func (t *Tracker) ApplyState(ctx context.Context, changes map[string][]byte) error {
+	for key, data := range changes {
+		id, ok := strings.CutPrefix(key, replicaDeploymentPrefix)
+		if !ok {
+			continue
+		}
+
+		if data == nil {
+			if err := t.Delete(ctx, id); err != nil && err != ErrDeploymentNotFound {
+				return fmt.Errorf("failed to apply %s: %w", key, err)
+			}
+			continue
+		}
+
+		dao := &repository.DeploymentDAO{}
+		if err := json.Unmarshal(data, dao); err != nil {
+			return fmt.Errorf("failed to decode %s: %w", key, err)
+		}
+		d, err := fromDAO(dao)
+		if err != nil {
+			return fmt.Errorf("failed to decode %s: %w", key, err)
+		}
+		if err := t.repo.SaveDeployment(ctx, dao); err != nil {
+			return fmt.Errorf("failed to persist deployment to repository: %w", err)
+		}
+
+		t.mu.Lock()
+		t.deployments[d.ID] = d
+		t.mu.Unlock()
+	}
+	return nil
+}
+
+// RestoreState 以 leader 的完整部署记录替换本地记录
+func (t *Tracker) RestoreState(ctx context.Context, state map[string][]byte) error {
+	local, err := t.ExportState(ctx)
+	if err != nil {
+		return err
+	}
+
+	changes := make(map[string][]byte, len(state))
+	for key := range local {
+		if _, ok := state[key]; !ok {
+			changes[key] = nil
+		}
+	}
+	for key, value := range state {
+		if existing, ok := local[key]; ok && bytes.Equal(existing, value) {
+			continue
+		}
+		changes[key] = value
+	}
+	return t.ApplyState(ctx, changes)
+}
@@ -0,0 +1,310 @@
+package deployment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	"github.com/9triver/iarnet-global/internal/intra/repository"
+	schedulerpb "github.com/9triver/iarnet-global/internal/proto/scheduler"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+)
+
+// Tracker 部署跟踪器
+// 记录全局调度器转发的每一个 component 部署，内存中维护最新状态并写穿到 repository
+type Tracker struct {
+	mu          sync.RWMutex
+	deployments map[DeploymentID]*Deployment
+	repo        repository.DeploymentRepo
+}
+
+// NewTracker 创建部署跟踪器
+func NewTracker(repo repository.DeploymentRepo) *Tracker {
+	return &Tracker{
+		deployments: make(map[DeploymentID]*Deployment),
+		repo:        repo,
+	}
+}
+
+// Load 从 repository 加载所有部署记录
+func (t *Tracker) Load(ctx context.Context) error {
+	daos, err := t.repo.GetAllDeployments(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load deployments from repository: %w", err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, dao := range daos {
+		d, err := fromDAO(dao)
+		if err != nil {
+			logrus.Warnf("Skipping invalid deployment record %s: %v", dao.ID, err)
+			continue
+		}
+		t.deployments[d.ID] = d
+	}
+
+	logrus.Infof("Loaded %d deployment(s) from database", len(t.deployments))
+	return nil
+}
+
+// Create 新增部署记录
+func (t *Tracker) Create(ctx context.Context, d *Deployment) error {
+	now := time.Now()
+	if d.CreatedAt.IsZero() {
+		d.CreatedAt = now
+	}
+	d.UpdatedAt = now
+
+	t.mu.Lock()
+	if _, exists := t.deployments[d.ID]; exists {
+		t.mu.Unlock()
+		return ErrDeploymentAlreadyExists
+	}
+	t.deployments[d.ID] = d.Clone()
+	t.mu.Unlock()
+
+	if err := t.persist(ctx, d); err != nil {
+		t.mu.Lock()
+		delete(t.deployments, d.ID)
+		t.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Update 更新部署记录并返回更新后的副本
+func (t *Tracker) Update(ctx context.Context, id DeploymentID, updateFn func(*Deployment)) (*Deployment, error) {
+	t.mu.Lock()
+	d, ok := t.deployments[id]
+	if !ok {
+		t.mu.Unlock()
+		return nil, ErrDeploymentNotFound
+	}
+	updateFn(d)
+	d.UpdatedAt = time.Now()
+	updated := d.Clone()
+	t.mu.Unlock()
+
+	if err := t.persist(ctx, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// Delete 删除部署记录
+func (t *Tracker) Delete(ctx context.Context, id DeploymentID) error {
+	t.mu.Lock()
+	if _, ok := t.deployments[id]; !ok {
+		t.mu.Unlock()
+		return ErrDeploymentNotFound
+	}
+	delete(t.deployments, id)
+	t.mu.Unlock()
+
+	return t.repo.DeleteDeployment(ctx, id)
+}
+
+// Get 获取部署记录（返回副本）
+func (t *Tracker) Get(id DeploymentID) (*Deployment, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	d, ok := t.deployments[id]
+	if !ok {
+		return nil, ErrDeploymentNotFound
+	}
+	return d.Clone(), nil
+}
+
+// GetByComponent 根据 component ID 获取部署记录（返回副本）
+func (t *Tracker) GetByComponent(componentID string) (*Deployment, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, d := range t.deployments {
+		if d.ComponentID == componentID {
+			return d.Clone(), nil
+		}
+	}
+	return nil, ErrDeploymentNotFound
+}
+
+// List 列出满足条件的部署记录（返回副本）
+func (t *Tracker) List(filter Filter) []*Deployment {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	result := make([]*Deployment, 0)
+	for _, d := range t.deployments {
+		if filter.Matches(d) {
+			result = append(result, d.Clone())
+		}
+	}
+	return result
+}
+
+// Pending 按排队顺序返回等待中的部署（返回副本）
+func (t *Tracker) Pending() []*Deployment {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.pendingUnsafe(true)
+}
+
+// QueuePosition 返回部署在等待队列中的位置（从 1 开始），不在队列中时返回 0
+func (t *Tracker) QueuePosition(id DeploymentID) int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for i, d := range t.pendingUnsafe(false) {
+		if d.ID == id {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// pendingUnsafe 按排队顺序返回等待中的部署（调用者需持有锁）
+func (t *Tracker) pendingUnsafe(clone bool) []*Deployment {
+	pending := make([]*Deployment, 0)
+	for _, d := range t.deployments {
+		if d.Status != StatusPending {
+			continue
+		}
+		if clone {
+			d = d.Clone()
+		}
+		pending = append(pending, d)
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return queuedBefore(pending[i], pending[j])
+	})
+	return pending
+}
+
+// Usage 汇总满足条件的活跃部署占用的资源
+func (t *Tracker) Usage(filter Filter) Usage {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	filter.ActiveOnly = true
+	usage := Usage{}
+	for _, d := range t.deployments {
+		if filter.Matches(d) {
+			usage.add(d)
+		}
+	}
+	return usage
+}
+
+// persist 将部署记录写入 repository
+func (t *Tracker) persist(ctx context.Context, d *Deployment) error {
+	dao, err := toDAO(d)
+	if err != nil {
+		return err
+	}
+	if err := t.repo.SaveDeployment(ctx, dao); err != nil {
+		return fmt.Errorf("failed to persist deployment to repository: %w", err)
+	}
+	return nil
+}
+
+func toDAO(d *Deployment) (*repository.DeploymentDAO, error) {
+	resources, err := json.Marshal(d.Resources)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode deployment resources: %w", err)
+	}
+
+	var stale []byte
+	if len(d.StaleInstances) > 0 {
+		stale, err = json.Marshal(d.StaleInstances)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode stale instances: %w", err)
+		}
+	}
+
+	var request []byte
+	if d.Request != nil {
+		request, err = proto.Marshal(d.Request)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode deployment request: %w", err)
+		}
+	}
+
+	return &repository.DeploymentDAO{
+		ID:          d.ID,
+		ComponentID: d.ComponentID,
+		Tenant:      d.Tenant,
+		DomainID:    d.DomainID,
+		NodeID:      d.NodeID,
+		NodeName:    d.NodeName,
+		ProviderID:  d.ProviderID,
+		Status:      string(d.Status),
+		Error:       d.Error,
+		Resources:   string(resources),
+		Request:     request,
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+
+		Restarts:      d.Restarts,
+		NextRestartAt: d.NextRestartAt,
+
+		PeerID:   d.PeerID,
+		RemoteID: d.RemoteID,
+
+		StaleInstances: string(stale),
+	}, nil
+}
+
+func fromDAO(dao *repository.DeploymentDAO) (*Deployment, error) {
+	d := &Deployment{
+		ID:          dao.ID,
+		ComponentID: dao.ComponentID,
+		Tenant:      dao.Tenant,
+		DomainID:    dao.DomainID,
+		NodeID:      dao.NodeID,
+		NodeName:    dao.NodeName,
+		ProviderID:  dao.ProviderID,
+		Status:      Status(dao.Status),
+		Error:       dao.Error,
+		CreatedAt:   dao.CreatedAt,
+		UpdatedAt:   dao.UpdatedAt,
+
+		Restarts:      dao.Restarts,
+		NextRestartAt: dao.NextRestartAt,
+
+		PeerID:   dao.PeerID,
+		RemoteID: dao.RemoteID,
+	}
+
+	if dao.Resources != "" {
+		resources := &registry.ResourceInfo{}
+		if err := json.Unmarshal([]byte(dao.Resources), resources); err != nil {
+			return nil, fmt.Errorf("failed to decode deployment resources: %w", err)
+		}
+		d.Resources = resources
+	}
+
+	if dao.StaleInstances != "" {
+		if err := json.Unmarshal([]byte(dao.StaleInstances), &d.StaleInstances); err != nil {
+			return nil, fmt.Errorf("failed to decode stale instances: %w", err)
+		}
+	}
+
+	if len(dao.Request) > 0 {
+		request := &schedulerpb.DeployComponentRequest{}
+		if err := proto.Unmarshal(dao.Request, request); err != nil {
+			return nil, fmt.Errorf("failed to decode deployment request: %w", err)
+		}
+		d.Request = request
+	}
+
+	return d, nil
+}
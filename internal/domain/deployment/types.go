@@ -0,0 +1,196 @@
+package deployment
+
+import (
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	schedulerpb "github.com/9triver/iarnet-global/internal/proto/scheduler"
+	"google.golang.org/protobuf/proto"
+)
+
+// DeploymentID 全局调度器分配的部署 ID
+type DeploymentID = string
+
+// Status 部署状态
+type Status string
+
+const (
+	// StatusPending 暂无可用容量，在全局调度器队列中等待
+	StatusPending Status = "pending"
+	// StatusDeploying 已通过准入，正在转发到目标节点
+	StatusDeploying Status = "deploying"
+	// StatusRunning 目标节点已确认部署
+	StatusRunning Status = "running"
+	// StatusStopped 已停止
+	StatusStopped Status = "stopped"
+	// StatusFailed 部署失败
+	StatusFailed Status = "failed"
+	// StatusLost 所在节点已离线或被清理，等待自动重启或人工处理
+	StatusLost Status = "lost"
+)
+
+// IsActive 部署是否仍占用资源（计入配额）
+func (s Status) IsActive() bool {
+	return s == StatusDeploying || s == StatusRunning
+}
+
+// Deployment 全局调度器转发的 component 部署记录
+type Deployment struct {
+	// ID 全局部署 ID
+	ID DeploymentID `json:"id" yaml:"id"`
+	// ComponentID 目标节点返回的 component ID
+	ComponentID string `json:"component_id" yaml:"component_id"`
+	// Tenant 发起部署的调用方/租户
+	Tenant string `json:"tenant" yaml:"tenant"`
+	// DomainID 部署所在域
+	DomainID registry.DomainID `json:"domain_id" yaml:"domain_id"`
+	// NodeID 部署所在节点
+	NodeID registry.NodeID `json:"node_id" yaml:"node_id"`
+	// NodeName 部署所在节点名称
+	NodeName string `json:"node_name" yaml:"node_name"`
+	// ProviderID 实际部署的 provider
+	ProviderID string `json:"provider_id,omitempty" yaml:"provider_id,omitempty"`
+	// Status 部署状态
+	Status Status `json:"status" yaml:"status"`
+	// Error 失败原因
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+	// Resources 部署请求的资源
+	Resources *registry.ResourceInfo `json:"resources,omitempty" yaml:"resources,omitempty"`
+	// Request 原始部署请求
+	Request *schedulerpb.DeployComponentRequest `json:"-" yaml:"-"`
+	// Restarts 节点失联后自动重启的尝试次数
+	Restarts int `json:"restarts,omitempty" yaml:"restarts,omitempty"`
+	// NextRestartAt 下一次自动重启的时间（退避），零值表示不等待
+	NextRestartAt time.Time `json:"next_restart_at,omitempty" yaml:"next_restart_at,omitempty"`
+	// PeerID 部署转发到的对等实例，为空表示部署在本实例的节点上；此时 DomainID 为对等实例的虚拟域
+	PeerID string `json:"peer_id,omitempty" yaml:"peer_id,omitempty"`
+	// RemoteID 对等实例返回的部署 ID
+	RemoteID string `json:"remote_id,omitempty" yaml:"remote_id,omitempty"`
+	// StaleInstances 重启前遗留在失联节点上的旧实例，节点恢复后移除
+	StaleInstances []StaleInstance `json:"stale_instances,omitempty" yaml:"stale_instances,omitempty"`
+	// CreatedAt 创建时间
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt time.Time `json:"updated_at" yaml:"updated_at"`
+}
+
+// StaleInstance 失联节点上的旧实例
+// 节点可能只是暂时失联（网络分区、心跳抖动），恢复后旧实例仍在运行，需要在部署重启后移除以免重复运行
+type StaleInstance struct {
+	ComponentID string          `json:"component_id" yaml:"component_id"`
+	NodeID      registry.NodeID `json:"node_id" yaml:"node_id"`
+	ProviderID  string          `json:"provider_id,omitempty" yaml:"provider_id,omitempty"`
+	// RetiredAt 部署不再使用该实例（重启到其他节点或放弃重启）的时间
+	RetiredAt time.Time `json:"retired_at" yaml:"retired_at"`
+}
+
+// Clone 深拷贝部署记录
+func (d *Deployment) Clone() *Deployment {
+	if d == nil {
+		return nil
+	}
+	copy := *d
+	copy.Resources = d.Resources.Clone()
+	copy.StaleInstances = append([]StaleInstance(nil), d.StaleInstances...)
+	if d.Request != nil {
+		copy.Request = proto.Clone(d.Request).(*schedulerpb.DeployComponentRequest)
+	}
+	return &copy
+}
+
+// Labels 返回部署请求携带的 component 标签
+func (d *Deployment) Labels() registry.Labels {
+	if d.Request == nil {
+		return nil
+	}
+	return registry.Labels(d.Request.Labels)
+}
+
+// Priority 排队优先级，数值越大越优先
+func (d *Deployment) Priority() int32 {
+	if d.Request == nil {
+		return 0
+	}
+	return d.Request.Priority
+}
+
+// Restartable 所在节点失联后是否自动重新调度
+func (d *Deployment) Restartable() bool {
+	return d.Request != nil && d.Request.Restartable
+}
+
+// Federated 部署是否转发到了对等实例
+func (d *Deployment) Federated() bool {
+	return d.PeerID != ""
+}
+
+// Deadline 排队截止时间，未设置最长等待时间时返回零值
+func (d *Deployment) Deadline() time.Time {
+	if d.Request == nil || d.Request.MaxWaitSeconds <= 0 {
+		return time.Time{}
+	}
+	return d.CreatedAt.Add(time.Duration(d.Request.MaxWaitSeconds) * time.Second)
+}
+
+// queuedBefore 排队顺序：优先级高者在前，同优先级先到先得
+func queuedBefore(a, b *Deployment) bool {
+	if a.Priority() != b.Priority() {
+		return a.Priority() > b.Priority()
+	}
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+	return a.ID < b.ID
+}
+
+// Filter 部署记录过滤条件，空字段表示不过滤
+type Filter struct {
+	Tenant     string
+	DomainID   registry.DomainID
+	NodeID     registry.NodeID
+	Status     Status
+	ActiveOnly bool
+}
+
+// Matches 判断部署记录是否满足过滤条件
+func (f Filter) Matches(d *Deployment) bool {
+	if f.Tenant != "" && d.Tenant != f.Tenant {
+		return false
+	}
+	if f.DomainID != "" && d.DomainID != f.DomainID {
+		return false
+	}
+	if f.NodeID != "" && d.NodeID != f.NodeID {
+		return false
+	}
+	if f.Status != "" && d.Status != f.Status {
+		return false
+	}
+	if f.ActiveOnly && !d.Status.IsActive() {
+		return false
+	}
+	return true
+}
+
+// Usage 部署占用的资源汇总
+type Usage struct {
+	Resources  registry.ResourceInfo `json:"resources"`
+	Components int64                 `json:"components"`
+}
+
+// add 累加一个部署的资源占用
+func (u *Usage) add(d *Deployment) {
+	u.Components++
+	if d.Resources == nil {
+		return
+	}
+	u.Resources.CPU += d.Resources.CPU
+	u.Resources.Memory += d.Resources.Memory
+	u.Resources.GPU += d.Resources.GPU
+	for name, value := range d.Resources.Extended {
+		if u.Resources.Extended == nil {
+			u.Resources.Extended = make(map[string]int64)
+		}
+		u.Resources.Extended[name] += value
+	}
+}
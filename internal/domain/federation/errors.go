@@ -0,0 +1,24 @@
+package federation
+
+import "errors"
+
+var (
+	// ErrPeerNotFound 对等实例不存在
+	ErrPeerNotFound = errors.New("federation peer not found")
+	// ErrPeerAlreadyExists 同名对等实例已存在
+	ErrPeerAlreadyExists = errors.New("federation peer already exists")
+	// ErrInvalidPeer 无效的对等实例配置
+	ErrInvalidPeer = errors.New("invalid federation peer")
+	// ErrPeerInUse 仍有部署转发到该对等实例
+	ErrPeerInUse = errors.New("federation peer still has deployments")
+	// ErrInvalidToken 联邦令牌无效
+	ErrInvalidToken = errors.New("invalid federation token")
+	// ErrInboundDisabled 不接受该对等实例转发的请求
+	ErrInboundDisabled = errors.New("inbound federation is disabled for this peer")
+	// ErrLoopDetected 转发路径中已包含本实例
+	ErrLoopDetected = errors.New("federation loop detected")
+	// ErrTooManyHops 转发次数超出上限
+	ErrTooManyHops = errors.New("federation hop limit exceeded")
+	// ErrLimitExceeded 超出为对等实例开放的资源上限
+	ErrLimitExceeded = errors.New("federation limit exceeded")
+)
@@ -0,0 +1,186 @@
+package federation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/deployment"
+	federationpb "github.com/9triver/iarnet-global/internal/proto/federation"
+	schedulerpb "github.com/9triver/iarnet-global/internal/proto/scheduler"
+	"github.com/sirupsen/logrus"
+)
+
+// Start 启动周期性的摘要交换与远端部署状态同步
+func (s *service) Start(ctx context.Context) error {
+	go s.run(ctx)
+	logrus.Infof("Federation started (instance: %s, exchange interval: %v, max hops: %d)",
+		s.opts.InstanceID, s.opts.ExchangeInterval, s.opts.MaxHops)
+	return nil
+}
+
+// Stop 停止后台处理
+func (s *service) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+	})
+}
+
+func (s *service) run(ctx context.Context) {
+	ticker := time.NewTicker(s.opts.ExchangeInterval)
+	defer ticker.Stop()
+
+	s.exchangeAll(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.exchangeAll(ctx)
+		}
+	}
+}
+
+// exchangeAll 与全部对等实例交换摘要，并同步转发到对等实例的部署状态
+func (s *service) exchangeAll(ctx context.Context) {
+	if !s.leading() {
+		return
+	}
+
+	s.mu.RLock()
+	peers := make([]*Peer, 0, len(s.peers))
+	for _, peer := range s.peers {
+		if peer.token != "" {
+			peers = append(peers, peer.Clone())
+		}
+	}
+	s.mu.RUnlock()
+
+	for _, peer := range peers {
+		if err := s.exchangeWith(ctx, peer); err != nil {
+			logrus.Warnf("Failed to exchange capacity summary with peer %s (%s): %v", peer.Name, peer.Address, err)
+			s.recordExchange(peer.ID, nil, err)
+		}
+	}
+	s.syncDeployments(ctx)
+}
+
+// exchangeWith 向对等实例发送本实例为其开放的摘要，并记录对方返回的摘要
+func (s *service) exchangeWith(ctx context.Context, peer *Peer) error {
+	client, callCtx, done, err := s.federationClient(ctx, peer.ID)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	resp, err := client.ExchangeSummary(callCtx, &federationpb.ExchangeSummaryRequest{
+		Summary: s.LocalSummary(peer.ID).ToProto(),
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return errors.New(resp.Error)
+	}
+	summary := SummaryFromProto(resp.Summary)
+	if summary == nil {
+		return fmt.Errorf("peer returned no capacity summary")
+	}
+	if summary.InstanceID == s.opts.InstanceID {
+		return fmt.Errorf("peer reports the same instance id %s as this instance", s.opts.InstanceID)
+	}
+	s.recordExchange(peer.ID, summary, nil)
+	return nil
+}
+
+// ExchangeSummary 处理对等实例发起的摘要交换
+func (s *service) ExchangeSummary(ctx context.Context, remote *CapacitySummary) (*CapacitySummary, error) {
+	token := incomingValue(ctx, TokenMetadataKey)
+	if token == "" {
+		return nil, ErrInvalidToken
+	}
+	peer, err := s.authenticatePeer(token)
+	if err != nil {
+		return nil, err
+	}
+	if remote != nil && remote.InstanceID != s.opts.InstanceID {
+		s.recordExchange(peer.ID, remote, nil)
+	}
+	return s.LocalSummary(peer.ID), nil
+}
+
+// recordExchange 记录一次摘要交换的结果
+func (s *service) recordExchange(peerID PeerID, summary *CapacitySummary, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.peers[peerID]; !ok {
+		return
+	}
+	state, ok := s.states[peerID]
+	if !ok {
+		state = &peerState{}
+		s.states[peerID] = state
+	}
+	if err != nil {
+		state.lastError = err.Error()
+		return
+	}
+	state.summary = summary
+	state.exchangedAt = time.Now()
+	state.lastError = ""
+}
+
+// syncDeployments 查询转发到对等实例的运行中或失联部署，以对端的状态为准更新本地记录
+// 失联的部署由对端负责重启，重启成功后恢复为运行中；对端不可达时保持原状态，等待下一次同步
+func (s *service) syncDeployments(ctx context.Context) {
+	for _, d := range s.tracker.List(deployment.Filter{}) {
+		if !d.Federated() || d.RemoteID == "" {
+			continue
+		}
+		if d.Status != deployment.StatusRunning && d.Status != deployment.StatusLost {
+			continue
+		}
+		resp, err := s.remoteStatus(ctx, d)
+		if err != nil {
+			logrus.Debugf("Failed to query status of deployment %s on peer %s: %v", d.ID, d.PeerID, err)
+			continue
+		}
+
+		status, reason := d.Status, d.Error
+		switch {
+		case !resp.Success:
+			status, reason = deployment.StatusFailed, fmt.Sprintf("deployment is no longer known to peer %s: %s", d.PeerID, resp.Error)
+		case resp.Status == schedulerpb.ComponentStatus_COMPONENT_STATUS_RUNNING:
+			status, reason = deployment.StatusRunning, ""
+		case resp.Status == schedulerpb.ComponentStatus_COMPONENT_STATUS_STOPPED:
+			status, reason = deployment.StatusStopped, resp.Error
+		case resp.Status == schedulerpb.ComponentStatus_COMPONENT_STATUS_ERROR:
+			status, reason = deployment.StatusFailed, resp.Error
+		case resp.Status == schedulerpb.ComponentStatus_COMPONENT_STATUS_LOST:
+			status, reason = deployment.StatusLost, fmt.Sprintf("lost on peer %s: %s", d.PeerID, resp.Error)
+		}
+		if status == d.Status {
+			continue
+		}
+
+		previous := d.Status
+		_, err = s.tracker.Update(ctx, d.ID, func(remote *deployment.Deployment) {
+			if remote.Status == previous {
+				remote.Status = status
+				remote.Error = reason
+				if resp.NodeId != "" {
+					remote.NodeID = resp.NodeId
+				}
+			}
+		})
+		if err != nil {
+			logrus.Errorf("Failed to update deployment %s: %v", d.ID, err)
+			continue
+		}
+		logrus.Infof("Deployment %s on peer %s is now %s", d.ID, d.PeerID, status)
+	}
+}
@@ -0,0 +1,189 @@
+package federation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/deployment"
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	federationpb "github.com/9triver/iarnet-global/internal/proto/federation"
+	schedulerpb "github.com/9triver/iarnet-global/internal/proto/scheduler"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// Candidates 按优先级返回可以转发的对等实例
+// 跳过未允许出站、没有令牌、摘要过期或显示容量不足、超出出站上限，以及已在转发路径中的实例；
+// 转发路径加上本实例后超出跳数上限时不再转发
+func (s *service) Candidates(ctx context.Context, requested *registry.ResourceInfo) []PeerID {
+	path := incomingPath(ctx)
+	if len(path)+1 > s.opts.MaxHops {
+		return nil
+	}
+	visited := make(map[string]bool, len(path)+1)
+	for _, instance := range path {
+		visited[instance] = true
+	}
+	visited[s.opts.InstanceID] = true
+
+	type candidate struct {
+		peer    *Peer
+		summary *CapacitySummary
+	}
+	now := time.Now()
+	candidates := make([]candidate, 0)
+
+	s.mu.RLock()
+	for _, peer := range s.peers {
+		if !peer.AllowOutbound || peer.token == "" {
+			continue
+		}
+		state, ok := s.states[peer.ID]
+		if !ok || state.summary == nil || now.Sub(state.exchangedAt) > s.opts.SummaryTTL {
+			continue
+		}
+		if visited[state.summary.InstanceID] || !state.summary.Fits(requested) {
+			continue
+		}
+		candidates = append(candidates, candidate{peer: peer.Clone(), summary: state.summary})
+	}
+	s.mu.RUnlock()
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].peer.Priority != candidates[j].peer.Priority {
+			return candidates[i].peer.Priority > candidates[j].peer.Priority
+		}
+		return candidates[i].summary.Available.CPU > candidates[j].summary.Available.CPU
+	})
+
+	result := make([]PeerID, 0, len(candidates))
+	for _, c := range candidates {
+		if s.AdmitOutbound(c.peer.ID, requested) != nil {
+			continue
+		}
+		result = append(result, c.peer.ID)
+	}
+	return result
+}
+
+// Deploy 将部署请求转发到对等实例，转发路径追加本实例
+func (s *service) Deploy(ctx context.Context, peerID PeerID, req *schedulerpb.DeployComponentRequest) (*schedulerpb.DeployComponentResponse, error) {
+	path := append(incomingPath(ctx), s.opts.InstanceID)
+
+	client, callCtx, done, err := s.schedulerClient(ctx, peerID, path)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	return client.DeployComponent(callCtx, req)
+}
+
+// StopRemote 通知对等实例停止转发过去的部署
+func (s *service) StopRemote(ctx context.Context, d *deployment.Deployment, reason string) error {
+	client, callCtx, done, err := s.schedulerClient(ctx, d.PeerID, nil)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	resp, err := client.StopComponent(callCtx, &schedulerpb.StopComponentRequest{
+		DeploymentId: d.RemoteID,
+		Reason:       reason,
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return errors.New(resp.Error)
+	}
+	return nil
+}
+
+// RemoveRemote 通知对等实例移除转发过去的部署
+func (s *service) RemoveRemote(ctx context.Context, d *deployment.Deployment) error {
+	client, callCtx, done, err := s.schedulerClient(ctx, d.PeerID, nil)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	resp, err := client.RemoveComponent(callCtx, &schedulerpb.RemoveComponentRequest{
+		DeploymentId: d.RemoteID,
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return errors.New(resp.Error)
+	}
+	return nil
+}
+
+// remoteStatus 查询转发到对等实例的部署状态
+func (s *service) remoteStatus(ctx context.Context, d *deployment.Deployment) (*schedulerpb.GetDeploymentStatusResponse, error) {
+	client, callCtx, done, err := s.schedulerClient(ctx, d.PeerID, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	return client.GetDeploymentStatus(callCtx, &schedulerpb.GetDeploymentStatusRequest{
+		DeploymentId: d.RemoteID,
+	})
+}
+
+// schedulerClient 连接对等实例的调度服务，返回携带联邦令牌（及转发路径）的调用 context
+func (s *service) schedulerClient(ctx context.Context, peerID PeerID, path []string) (schedulerpb.SchedulerServiceClient, context.Context, func(), error) {
+	conn, callCtx, done, err := s.dial(ctx, peerID, path)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return schedulerpb.NewSchedulerServiceClient(conn), callCtx, done, nil
+}
+
+// federationClient 连接对等实例的联邦服务
+func (s *service) federationClient(ctx context.Context, peerID PeerID) (federationpb.FederationServiceClient, context.Context, func(), error) {
+	conn, callCtx, done, err := s.dial(ctx, peerID, nil)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return federationpb.NewFederationServiceClient(conn), callCtx, done, nil
+}
+
+func (s *service) dial(ctx context.Context, peerID PeerID, path []string) (*grpc.ClientConn, context.Context, func(), error) {
+	s.mu.RLock()
+	peer, ok := s.peers[peerID]
+	var address, token string
+	if ok {
+		address, token = peer.Address, peer.token
+	}
+	s.mu.RUnlock()
+
+	if !ok {
+		return nil, nil, nil, fmt.Errorf("%w: %s", ErrPeerNotFound, peerID)
+	}
+	if token == "" {
+		return nil, nil, nil, fmt.Errorf("no federation token configured for peer %s", peerID)
+	}
+
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to dial peer %s (%s): %w", peerID, address, err)
+	}
+
+	md := metadata.Pairs(TokenMetadataKey, token)
+	if len(path) > 0 {
+		md.Set(PathMetadataKey, strings.Join(path, ","))
+	}
+	callCtx, cancel := context.WithTimeout(metadata.NewOutgoingContext(ctx, md), s.opts.CallTimeout)
+	return conn, callCtx, func() {
+		cancel()
+		conn.Close()
+	}, nil
+}
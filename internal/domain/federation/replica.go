@@ -0,0 +1,95 @@
+package federation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/9triver/iarnet-global/internal/intra/repository"
+)
+
+// replicaPeerPrefix HA 模式下复制状态的条目键前缀
+const replicaPeerPrefix = "peer/"
+
+// ExportState 导出需要在 HA 副本间复制的对等实例配置（条目键 -> JSON）
+// 摘要交换结果是运行时状态，由新 leader 重新交换
+func (s *service) ExportState(ctx context.Context) (map[string][]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	state := make(map[string][]byte, len(s.peers))
+	for id, peer := range s.peers {
+		dao, err := toDAO(peer)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(dao)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode federation peer %s: %w", id, err)
+		}
+		state[replicaPeerPrefix+id] = data
+	}
+	return state, nil
+}
+
+// ApplyState 应用 leader 复制的对等实例变化，值为空的条目表示删除
+func (s *service) ApplyState(ctx context.Context, changes map[string][]byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, data := range changes {
+		id, ok := strings.CutPrefix(key, replicaPeerPrefix)
+		if !ok {
+			continue
+		}
+
+		if data == nil {
+			if _, ok := s.peers[id]; !ok {
+				continue
+			}
+			if err := s.repo.DeletePeer(ctx, id); err != nil {
+				return fmt.Errorf("failed to apply %s: %w", key, err)
+			}
+			delete(s.peers, id)
+			delete(s.states, id)
+			continue
+		}
+
+		dao := &repository.FederationPeerDAO{}
+		if err := json.Unmarshal(data, dao); err != nil {
+			return fmt.Errorf("failed to decode %s: %w", key, err)
+		}
+		peer, err := fromDAO(dao)
+		if err != nil {
+			return fmt.Errorf("failed to decode %s: %w", key, err)
+		}
+		if err := s.saveUnsafe(ctx, peer, s.repo.SavePeer); err != nil {
+			return fmt.Errorf("failed to apply %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// RestoreState 以 leader 的完整对等实例配置替换本地配置
+func (s *service) RestoreState(ctx context.Context, state map[string][]byte) error {
+	local, err := s.ExportState(ctx)
+	if err != nil {
+		return err
+	}
+
+	changes := make(map[string][]byte, len(state))
+	for key := range local {
+		if _, ok := state[key]; !ok {
+			changes[key] = nil
+		}
+	}
+	for key, value := range state {
+		if existing, ok := local[key]; ok && bytes.Equal(existing, value) {
+			continue
+		}
+		changes[key] = value
+	}
+	return s.ApplyState(ctx, changes)
+}
@@ -0,0 +1,546 @@
+package federation
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/deployment"
+	"github.com/9triver/iarnet-global/internal/domain/quota"
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	"github.com/9triver/iarnet-global/internal/domain/tenant"
+	"github.com/9triver/iarnet-global/internal/intra/repository"
+	schedulerpb "github.com/9triver/iarnet-global/internal/proto/scheduler"
+	"github.com/9triver/iarnet-global/internal/util"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// DefaultMaxHops 默认的最大转发次数：只转发一次，对等实例不再继续转发
+	DefaultMaxHops = 1
+	// DefaultExchangeInterval 默认的容量摘要交换周期
+	DefaultExchangeInterval = 30 * time.Second
+	// DefaultCallTimeout 调用对等实例的默认超时时间
+	DefaultCallTimeout = 10 * time.Second
+
+	// tokenPrefix 联邦令牌前缀
+	tokenPrefix = "igf_"
+)
+
+// Options 联邦服务配置
+type Options struct {
+	// InstanceID 本实例 ID，用于防环；同一 HA 集群的副本必须相同，不同实例之间必须唯一
+	InstanceID string
+	// MaxHops 请求最多被转发的次数
+	MaxHops int
+	// ExchangeInterval 与对等实例交换容量摘要的周期
+	ExchangeInterval time.Duration
+	// SummaryTTL 对等实例的容量摘要超过该时间未更新时不再据此转发，默认为交换周期的 3 倍
+	SummaryTTL time.Duration
+	// CallTimeout 调用对等实例的超时时间
+	CallTimeout time.Duration
+	// Tenants 非空时摘要只统计对等实例有权使用的域
+	Tenants tenant.Service
+	// IsLeader HA 模式下只有 leader 交换摘要与同步远端部署状态，为空时视为单副本部署
+	IsLeader func() bool
+}
+
+func (o Options) withDefaults() Options {
+	if o.MaxHops <= 0 {
+		o.MaxHops = DefaultMaxHops
+	}
+	if o.ExchangeInterval <= 0 {
+		o.ExchangeInterval = DefaultExchangeInterval
+	}
+	if o.SummaryTTL <= 0 {
+		o.SummaryTTL = 3 * o.ExchangeInterval
+	}
+	if o.CallTimeout <= 0 {
+		o.CallTimeout = DefaultCallTimeout
+	}
+	return o
+}
+
+// Service 联邦服务：管理对等实例、交换容量摘要，并在本地域无法满足请求时转发到对等实例
+type Service interface {
+	// InstanceID 本实例 ID
+	InstanceID() string
+	// CreatePeer 添加对等实例，返回为其签发的联邦令牌（只返回一次）
+	CreatePeer(ctx context.Context, spec PeerSpec) (*Peer, string, error)
+	// UpdatePeer 更新对等实例配置
+	UpdatePeer(ctx context.Context, id PeerID, spec PeerSpec) (*Peer, error)
+	// RotateToken 为对等实例重新签发联邦令牌，旧令牌立即失效
+	RotateToken(ctx context.Context, id PeerID) (string, error)
+	// DeletePeer 删除对等实例，仍有部署转发到该实例时拒绝
+	DeletePeer(ctx context.Context, id PeerID) error
+	// GetPeer 获取对等实例状态
+	GetPeer(ctx context.Context, id PeerID) (*PeerStatus, error)
+	// ListPeers 列出全部对等实例状态
+	ListPeers(ctx context.Context) []*PeerStatus
+	// LoadPeers 从 repository 加载对等实例
+	LoadPeers(ctx context.Context) error
+	// LocalSummary 本实例的容量摘要，peerID 非空时按为该对等实例开放的范围与上限裁剪
+	LocalSummary(peerID PeerID) *CapacitySummary
+	// ExchangeSummary 处理对等实例发起的摘要交换：记录对方摘要并返回本实例为其开放的摘要
+	ExchangeSummary(ctx context.Context, remote *CapacitySummary) (*CapacitySummary, error)
+
+	// Authenticate 解析对等实例转发请求携带的联邦令牌，返回以对等实例 ID 为租户的身份
+	// 请求未携带联邦令牌时返回 nil
+	Authenticate(ctx context.Context) (*tenant.Identity, error)
+	// IsPeer 租户是否为对等实例
+	IsPeer(tenantID string) bool
+	// AdmitInbound 检查对等实例转发的部署是否超出为其开放的上限，tenantID 不是对等实例时不做限制
+	AdmitInbound(tenantID string, requested *registry.ResourceInfo) error
+	// Candidates 按优先级返回摘要显示可以容纳 requested 的对等实例
+	Candidates(ctx context.Context, requested *registry.ResourceInfo) []PeerID
+	// AdmitOutbound 检查转发到对等实例的部署是否超出出站上限
+	AdmitOutbound(peerID PeerID, requested *registry.ResourceInfo) error
+	// Deploy 将部署请求转发到对等实例
+	Deploy(ctx context.Context, peerID PeerID, req *schedulerpb.DeployComponentRequest) (*schedulerpb.DeployComponentResponse, error)
+	// StopRemote 停止转发到对等实例的部署
+	StopRemote(ctx context.Context, d *deployment.Deployment, reason string) error
+	// RemoveRemote 移除转发到对等实例的部署
+	RemoveRemote(ctx context.Context, d *deployment.Deployment) error
+
+	// ExportState / ApplyState / RestoreState 在 HA 副本间复制对等实例配置
+	ExportState(ctx context.Context) (map[string][]byte, error)
+	ApplyState(ctx context.Context, changes map[string][]byte) error
+	RestoreState(ctx context.Context, state map[string][]byte) error
+
+	// Start 启动周期性的摘要交换与远端部署状态同步
+	Start(ctx context.Context) error
+	// Stop 停止后台处理
+	Stop()
+}
+
+type service struct {
+	mu       sync.RWMutex
+	peers    map[PeerID]*Peer
+	states   map[PeerID]*peerState
+	repo     repository.FederationRepo
+	manager  *registry.Manager
+	tracker  *deployment.Tracker
+	opts     Options
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+// NewService 创建联邦服务
+func NewService(repo repository.FederationRepo, manager *registry.Manager, tracker *deployment.Tracker, opts Options) Service {
+	return &service{
+		peers:   make(map[PeerID]*Peer),
+		states:  make(map[PeerID]*peerState),
+		repo:    repo,
+		manager: manager,
+		tracker: tracker,
+		opts:    opts.withDefaults(),
+		stopCh:  make(chan struct{}),
+	}
+}
+
+func (s *service) InstanceID() string {
+	return s.opts.InstanceID
+}
+
+// leading 本副本是否负责后台处理（单副本部署始终为 true）
+func (s *service) leading() bool {
+	return s.opts.IsLeader == nil || s.opts.IsLeader()
+}
+
+func (s *service) LoadPeers(ctx context.Context) error {
+	daos, err := s.repo.GetAllPeers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load federation peers from repository: %w", err)
+	}
+
+	peers := make(map[PeerID]*Peer, len(daos))
+	for _, dao := range daos {
+		peer, err := fromDAO(dao)
+		if err != nil {
+			return err
+		}
+		peers[peer.ID] = peer
+	}
+
+	s.mu.Lock()
+	s.peers = peers
+	s.mu.Unlock()
+
+	logrus.Infof("Loaded %d federation peer(s) from database", len(daos))
+	return nil
+}
+
+func (s *service) CreatePeer(ctx context.Context, spec PeerSpec) (*Peer, string, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, "", err
+	}
+	token, err := generateToken()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate federation token: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findByNameUnsafe(spec.Name) != nil {
+		return nil, "", ErrPeerAlreadyExists
+	}
+
+	now := time.Now()
+	peer := &Peer{
+		ID:        util.GenIDWith("peer."),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	applySpec(peer, spec)
+	peer.inboundTokenHash = hashToken(token)
+
+	dao, err := toDAO(peer)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.repo.CreatePeer(ctx, dao); err != nil {
+		return nil, "", fmt.Errorf("failed to persist federation peer to repository: %w", err)
+	}
+	s.peers[peer.ID] = peer
+
+	logrus.Infof("Federation peer created: id=%s, name=%s, address=%s", peer.ID, peer.Name, peer.Address)
+	return peer.Clone(), token, nil
+}
+
+func (s *service) UpdatePeer(ctx context.Context, id PeerID, spec PeerSpec) (*Peer, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	peer, ok := s.peers[id]
+	if !ok {
+		return nil, ErrPeerNotFound
+	}
+	if other := s.findByNameUnsafe(spec.Name); other != nil && other.ID != id {
+		return nil, ErrPeerAlreadyExists
+	}
+
+	updated := peer.Clone()
+	applySpec(updated, spec)
+	updated.UpdatedAt = time.Now()
+	if err := s.saveUnsafe(ctx, updated, s.repo.UpdatePeer); err != nil {
+		return nil, err
+	}
+
+	logrus.Infof("Federation peer updated: id=%s", id)
+	return updated.Clone(), nil
+}
+
+func (s *service) RotateToken(ctx context.Context, id PeerID) (string, error) {
+	token, err := generateToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate federation token: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	peer, ok := s.peers[id]
+	if !ok {
+		return "", ErrPeerNotFound
+	}
+
+	updated := peer.Clone()
+	updated.inboundTokenHash = hashToken(token)
+	updated.UpdatedAt = time.Now()
+	if err := s.saveUnsafe(ctx, updated, s.repo.UpdatePeer); err != nil {
+		return "", err
+	}
+
+	logrus.Infof("Federation token rotated for peer %s", id)
+	return token, nil
+}
+
+func (s *service) DeletePeer(ctx context.Context, id PeerID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.peers[id]; !ok {
+		return ErrPeerNotFound
+	}
+	if n := len(s.tracker.List(deployment.Filter{DomainID: id})); n > 0 {
+		return fmt.Errorf("%w: %d deployment(s) were forwarded to peer %s", ErrPeerInUse, n, id)
+	}
+	if err := s.repo.DeletePeer(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete federation peer from repository: %w", err)
+	}
+	delete(s.peers, id)
+	delete(s.states, id)
+
+	logrus.Infof("Federation peer deleted: id=%s", id)
+	return nil
+}
+
+func (s *service) GetPeer(ctx context.Context, id PeerID) (*PeerStatus, error) {
+	s.mu.RLock()
+	peer, ok := s.peers[id]
+	var st *PeerStatus
+	if ok {
+		st = s.statusUnsafe(peer)
+	}
+	s.mu.RUnlock()
+
+	if !ok {
+		return nil, ErrPeerNotFound
+	}
+	s.fillUsage(st)
+	return st, nil
+}
+
+func (s *service) ListPeers(ctx context.Context) []*PeerStatus {
+	s.mu.RLock()
+	result := make([]*PeerStatus, 0, len(s.peers))
+	for _, peer := range s.peers {
+		result = append(result, s.statusUnsafe(peer))
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	for _, st := range result {
+		s.fillUsage(st)
+	}
+	return result
+}
+
+// statusUnsafe 组装对等实例状态（调用者需持有锁）
+func (s *service) statusUnsafe(peer *Peer) *PeerStatus {
+	st := &PeerStatus{Peer: peer.Clone()}
+	if state, ok := s.states[peer.ID]; ok {
+		st.Summary = state.summary
+		st.LastExchangeAt = state.exchangedAt
+		st.LastError = state.lastError
+	}
+	return st
+}
+
+// fillUsage 填充对等实例的入站、出站资源占用
+func (s *service) fillUsage(st *PeerStatus) {
+	st.InboundUsage = s.tracker.Usage(deployment.Filter{Tenant: st.ID})
+	st.OutboundUsage = s.tracker.Usage(deployment.Filter{DomainID: st.ID})
+}
+
+// Authenticate 解析对等实例的联邦令牌，并检查转发路径是否成环或超出跳数上限
+func (s *service) Authenticate(ctx context.Context) (*tenant.Identity, error) {
+	token := incomingValue(ctx, TokenMetadataKey)
+	if token == "" {
+		return nil, nil
+	}
+	peer, err := s.authenticatePeer(token)
+	if err != nil {
+		return nil, err
+	}
+
+	path := incomingPath(ctx)
+	for _, instance := range path {
+		if instance == s.opts.InstanceID {
+			return nil, fmt.Errorf("%w: request already passed through instance %s (path: %s)",
+				ErrLoopDetected, s.opts.InstanceID, strings.Join(path, " -> "))
+		}
+	}
+	if len(path) > s.opts.MaxHops {
+		return nil, fmt.Errorf("%w: request was forwarded %d time(s), at most %d allowed", ErrTooManyHops, len(path), s.opts.MaxHops)
+	}
+	return &tenant.Identity{Tenant: peer.ID, Authenticated: true}, nil
+}
+
+// authenticatePeer 按联邦令牌查找允许入站的对等实例
+func (s *service) authenticatePeer(token string) (*Peer, error) {
+	hash := hashToken(token)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, peer := range s.peers {
+		if peer.inboundTokenHash != hash {
+			continue
+		}
+		if !peer.AllowInbound {
+			return nil, fmt.Errorf("%w: %s", ErrInboundDisabled, peer.Name)
+		}
+		return peer.Clone(), nil
+	}
+	return nil, ErrInvalidToken
+}
+
+func (s *service) IsPeer(tenantID string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.peers[tenantID]
+	return ok
+}
+
+func (s *service) AdmitInbound(tenantID string, requested *registry.ResourceInfo) error {
+	s.mu.RLock()
+	peer, ok := s.peers[tenantID]
+	s.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+	return checkLimit(peer.ID, "inbound", peer.InboundLimit, s.tracker.Usage(deployment.Filter{Tenant: peer.ID}), requested)
+}
+
+func (s *service) AdmitOutbound(peerID PeerID, requested *registry.ResourceInfo) error {
+	s.mu.RLock()
+	peer, ok := s.peers[peerID]
+	s.mu.RUnlock()
+	if !ok {
+		return ErrPeerNotFound
+	}
+	return checkLimit(peer.ID, "outbound", peer.OutboundLimit, s.tracker.Usage(deployment.Filter{DomainID: peer.ID}), requested)
+}
+
+// findByNameUnsafe 按名称查找对等实例（调用者需持有锁）
+func (s *service) findByNameUnsafe(name string) *Peer {
+	for _, peer := range s.peers {
+		if peer.Name == name {
+			return peer
+		}
+	}
+	return nil
+}
+
+// saveUnsafe 持久化对等实例并更新内存（调用者需持有锁）
+func (s *service) saveUnsafe(ctx context.Context, peer *Peer, save func(context.Context, *repository.FederationPeerDAO) error) error {
+	dao, err := toDAO(peer)
+	if err != nil {
+		return err
+	}
+	if err := save(ctx, dao); err != nil {
+		return fmt.Errorf("failed to persist federation peer to repository: %w", err)
+	}
+	s.peers[peer.ID] = peer
+	return nil
+}
+
+// applySpec 将参数写入对等实例，Token 为空时保留原令牌
+func applySpec(peer *Peer, spec PeerSpec) {
+	peer.Name = strings.TrimSpace(spec.Name)
+	peer.Address = strings.TrimSpace(spec.Address)
+	peer.AllowInbound = spec.AllowInbound
+	peer.AllowOutbound = spec.AllowOutbound
+	peer.Priority = spec.Priority
+	peer.InboundLimit = spec.InboundLimit
+	peer.OutboundLimit = spec.OutboundLimit
+	if spec.Token != "" {
+		peer.token = spec.Token
+	}
+	peer.HasToken = peer.token != ""
+}
+
+// incomingValue 读取请求 metadata 中的单个值
+func incomingValue(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// incomingPath 读取转发请求已经过的实例 ID
+func incomingPath(ctx context.Context) []string {
+	value := incomingValue(ctx, PathMetadataKey)
+	if value == "" {
+		return nil
+	}
+	path := make([]string, 0)
+	for _, instance := range strings.Split(value, ",") {
+		if instance = strings.TrimSpace(instance); instance != "" {
+			path = append(path, instance)
+		}
+	}
+	return path
+}
+
+// generateToken 生成联邦令牌
+func generateToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return tokenPrefix + hex.EncodeToString(buf), nil
+}
+
+// hashToken 计算联邦令牌摘要，仓库中只保存摘要
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func toDAO(peer *Peer) (*repository.FederationPeerDAO, error) {
+	inbound, err := json.Marshal(peer.InboundLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode inbound limit: %w", err)
+	}
+	outbound, err := json.Marshal(peer.OutboundLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode outbound limit: %w", err)
+	}
+	return &repository.FederationPeerDAO{
+		ID:               peer.ID,
+		Name:             peer.Name,
+		Address:          peer.Address,
+		OutboundToken:    peer.token,
+		InboundTokenHash: peer.inboundTokenHash,
+		AllowInbound:     peer.AllowInbound,
+		AllowOutbound:    peer.AllowOutbound,
+		Priority:         peer.Priority,
+		InboundLimit:     string(inbound),
+		OutboundLimit:    string(outbound),
+		CreatedAt:        peer.CreatedAt,
+		UpdatedAt:        peer.UpdatedAt,
+	}, nil
+}
+
+func fromDAO(dao *repository.FederationPeerDAO) (*Peer, error) {
+	peer := &Peer{
+		ID:               dao.ID,
+		Name:             dao.Name,
+		Address:          dao.Address,
+		AllowInbound:     dao.AllowInbound,
+		AllowOutbound:    dao.AllowOutbound,
+		Priority:         dao.Priority,
+		HasToken:         dao.OutboundToken != "",
+		CreatedAt:        dao.CreatedAt,
+		UpdatedAt:        dao.UpdatedAt,
+		token:            dao.OutboundToken,
+		inboundTokenHash: dao.InboundTokenHash,
+	}
+	if err := decodeLimits(dao.InboundLimit, &peer.InboundLimit); err != nil {
+		return nil, fmt.Errorf("failed to decode inbound limit of peer %s: %w", dao.ID, err)
+	}
+	if err := decodeLimits(dao.OutboundLimit, &peer.OutboundLimit); err != nil {
+		return nil, fmt.Errorf("failed to decode outbound limit of peer %s: %w", dao.ID, err)
+	}
+	return peer, nil
+}
+
+func decodeLimits(data string, limits *quota.Limits) error {
+	if data == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(data), limits)
+}
@@ -0,0 +1,187 @@
+package federation
+
+import (
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/deployment"
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	federationpb "github.com/9triver/iarnet-global/internal/proto/federation"
+)
+
+// LocalSummary 汇总本实例可调度节点的容量
+// 为对等实例生成摘要时只统计其有权使用的域，并将可用资源裁剪到入站上限的剩余部分
+func (s *service) LocalSummary(peerID PeerID) *CapacitySummary {
+	var peer *Peer
+	if peerID != "" {
+		s.mu.RLock()
+		peer = s.peers[peerID].Clone()
+		s.mu.RUnlock()
+	}
+
+	summary := &CapacitySummary{
+		InstanceID:       s.opts.InstanceID,
+		Total:            &registry.ResourceInfo{},
+		Available:        &registry.ResourceInfo{},
+		MaxNodeAvailable: &registry.ResourceInfo{},
+		GeneratedAt:      time.Now(),
+	}
+	tags := make(map[string]bool)
+	for _, domain := range s.manager.GetAllDomains() {
+		if peer != nil && s.opts.Tenants != nil && !s.opts.Tenants.CanUseDomain(peer.ID, domain.ID) {
+			continue
+		}
+		nodes, err := s.manager.GetNodesByDomain(domain.ID)
+		if err != nil {
+			continue
+		}
+		count := 0
+		for _, node := range nodes {
+			if !schedulable(node) {
+				continue
+			}
+			count++
+			summary.Total.Add(node.ResourceCapacity.Total)
+			summary.Available.Add(node.ResourceCapacity.Available)
+			maxResources(summary.MaxNodeAvailable, node.ResourceCapacity.Available)
+			addResourceTags(tags, node.ResourceTags)
+		}
+		if count > 0 {
+			summary.Domains++
+			summary.Nodes += count
+		}
+	}
+	summary.ResourceTags = resourceTagNames(tags)
+
+	if peer != nil {
+		if !peer.AllowInbound {
+			summary.Available = &registry.ResourceInfo{}
+			summary.MaxNodeAvailable = &registry.ResourceInfo{}
+		} else {
+			usage := s.tracker.Usage(deployment.Filter{Tenant: peer.ID})
+			for _, name := range []string{registry.ResourceCPU, registry.ResourceMemory, registry.ResourceGPU} {
+				if left := remaining(peer.InboundLimit, usage, name); left >= 0 {
+					capResource(summary.Available, name, left)
+					capResource(summary.MaxNodeAvailable, name, left)
+				}
+			}
+		}
+	}
+	return summary
+}
+
+// schedulable 节点是否可以接收转发的部署
+func schedulable(node *registry.Node) bool {
+	return node.IsAlive() && node.Status != registry.NodeStatusSuspect && !node.Cordoned &&
+		node.Address != "" && node.IsReachable() &&
+		node.ResourceCapacity != nil && node.ResourceCapacity.Available != nil
+}
+
+// maxResources 将 dst 中的各项资源更新为与 other 中对应资源的较大值
+func maxResources(dst *registry.ResourceInfo, other *registry.ResourceInfo) {
+	if other == nil {
+		return
+	}
+	for _, name := range other.Names() {
+		if value := other.Get(name); value > dst.Get(name) {
+			setResource(dst, name, value)
+		}
+	}
+}
+
+// capResource 将指定资源裁剪到 limit
+func capResource(ri *registry.ResourceInfo, name string, limit int64) {
+	if ri.Get(name) > limit {
+		setResource(ri, name, limit)
+	}
+}
+
+func setResource(ri *registry.ResourceInfo, name string, value int64) {
+	switch name {
+	case registry.ResourceCPU:
+		ri.CPU = value
+	case registry.ResourceMemory:
+		ri.Memory = value
+	case registry.ResourceGPU:
+		ri.GPU = value
+	default:
+		if ri.Extended == nil {
+			ri.Extended = make(map[string]int64)
+		}
+		ri.Extended[name] = value
+	}
+}
+
+func addResourceTags(tags map[string]bool, rt *registry.ResourceTags) {
+	if rt == nil {
+		return
+	}
+	for _, name := range []string{"cpu", "gpu", "memory", "camera"} {
+		if rt.HasResource(name) {
+			tags[name] = true
+		}
+	}
+}
+
+// ToProto 将容量摘要转换为 proto
+func (c *CapacitySummary) ToProto() *federationpb.CapacitySummary {
+	if c == nil {
+		return nil
+	}
+	return &federationpb.CapacitySummary{
+		InstanceId:       c.InstanceID,
+		Domains:          int32(c.Domains),
+		Nodes:            int32(c.Nodes),
+		Total:            resourcesToProto(c.Total),
+		Available:        resourcesToProto(c.Available),
+		MaxNodeAvailable: resourcesToProto(c.MaxNodeAvailable),
+		ResourceTags:     c.ResourceTags,
+		Timestamp:        c.GeneratedAt.Unix(),
+	}
+}
+
+// SummaryFromProto 将 proto 转换为容量摘要
+func SummaryFromProto(pb *federationpb.CapacitySummary) *CapacitySummary {
+	if pb == nil {
+		return nil
+	}
+	return &CapacitySummary{
+		InstanceID:       pb.InstanceId,
+		Domains:          int(pb.Domains),
+		Nodes:            int(pb.Nodes),
+		Total:            resourcesFromProto(pb.Total),
+		Available:        resourcesFromProto(pb.Available),
+		MaxNodeAvailable: resourcesFromProto(pb.MaxNodeAvailable),
+		ResourceTags:     pb.ResourceTags,
+		GeneratedAt:      time.Unix(pb.Timestamp, 0),
+	}
+}
+
+func resourcesToProto(ri *registry.ResourceInfo) *federationpb.Resources {
+	if ri == nil {
+		return &federationpb.Resources{}
+	}
+	return &federationpb.Resources{
+		Cpu:      ri.CPU,
+		Memory:   ri.Memory,
+		Gpu:      ri.GPU,
+		Extended: ri.Clone().Extended,
+	}
+}
+
+func resourcesFromProto(pb *federationpb.Resources) *registry.ResourceInfo {
+	if pb == nil {
+		return &registry.ResourceInfo{}
+	}
+	ri := &registry.ResourceInfo{
+		CPU:    pb.Cpu,
+		Memory: pb.Memory,
+		GPU:    pb.Gpu,
+	}
+	if len(pb.Extended) > 0 {
+		ri.Extended = make(map[string]int64, len(pb.Extended))
+		for name, value := range pb.Extended {
+			ri.Extended[name] = value
+		}
+	}
+	return ri
+}
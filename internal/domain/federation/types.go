@@ -0,0 +1,223 @@
+package federation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/deployment"
+	"github.com/9triver/iarnet-global/internal/domain/quota"
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+)
+
+// PeerID 对等实例 ID，同时作为其虚拟域 ID（本实例转发的部署）与租户标识（对等实例转发来的部署）
+type PeerID = string
+
+const (
+	// TokenMetadataKey 对等实例调用时携带联邦令牌的 metadata 键
+	TokenMetadataKey = "x-iarnet-federation-token"
+	// PathMetadataKey 转发请求已经过的实例 ID（逗号分隔），用于防止转发成环
+	PathMetadataKey = "x-iarnet-federation-path"
+)
+
+// Peer 对等的 iarnet-global 实例
+// 本实例把对等实例视为一个虚拟域：本地域无法满足部署请求时转发给它；对等实例也可以凭本实例签发的令牌把请求转发过来
+type Peer struct {
+	ID   PeerID `json:"id" yaml:"id"`
+	Name string `json:"name" yaml:"name"`
+	// Address 对等实例的 Registry RPC 地址（host:port）
+	Address string `json:"address" yaml:"address"`
+	// AllowInbound 是否接受对等实例转发的请求
+	AllowInbound bool `json:"allow_inbound" yaml:"allow_inbound"`
+	// AllowOutbound 是否向对等实例转发请求
+	AllowOutbound bool `json:"allow_outbound" yaml:"allow_outbound"`
+	// Priority 多个对等实例都能容纳请求时优先选择数值大者
+	Priority int `json:"priority" yaml:"priority"`
+	// InboundLimit 对等实例转发到本实例的部署可占用的资源上限，0 表示不限制
+	InboundLimit quota.Limits `json:"inbound_limit" yaml:"inbound_limit"`
+	// OutboundLimit 本实例转发到对等实例的部署可占用的资源上限，0 表示不限制
+	OutboundLimit quota.Limits `json:"outbound_limit" yaml:"outbound_limit"`
+	// HasToken 是否已配置对等实例为本实例签发的令牌（未配置时无法向其转发）
+	HasToken  bool      `json:"has_token" yaml:"has_token"`
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" yaml:"updated_at"`
+
+	// token 对等实例为本实例签发的令牌，调用对等实例时出示
+	token string
+	// inboundTokenHash 本实例为对等实例签发的令牌摘要
+	inboundTokenHash string
+}
+
+// Clone 复制对等实例
+func (p *Peer) Clone() *Peer {
+	if p == nil {
+		return nil
+	}
+	copy := *p
+	return &copy
+}
+
+// PeerSpec 创建或更新对等实例的参数
+type PeerSpec struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	// Token 对等实例为本实例签发的令牌，更新时为空表示保持不变
+	Token         string       `json:"token,omitempty"`
+	AllowInbound  bool         `json:"allow_inbound"`
+	AllowOutbound bool         `json:"allow_outbound"`
+	Priority      int          `json:"priority"`
+	InboundLimit  quota.Limits `json:"inbound_limit"`
+	OutboundLimit quota.Limits `json:"outbound_limit"`
+}
+
+// Validate 验证对等实例参数
+func (s PeerSpec) Validate() error {
+	if strings.TrimSpace(s.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidPeer)
+	}
+	if strings.TrimSpace(s.Address) == "" {
+		return fmt.Errorf("%w: address is required", ErrInvalidPeer)
+	}
+	if err := s.InboundLimit.Validate(); err != nil {
+		return fmt.Errorf("%w: inbound limit: %v", ErrInvalidPeer, err)
+	}
+	if err := s.OutboundLimit.Validate(); err != nil {
+		return fmt.Errorf("%w: outbound limit: %v", ErrInvalidPeer, err)
+	}
+	return nil
+}
+
+// CapacitySummary 实例的聚合容量摘要，不包含单个域与节点的细节
+type CapacitySummary struct {
+	InstanceID string `json:"instance_id"`
+	// Domains 可调度的域数量
+	Domains int `json:"domains"`
+	// Nodes 可调度的节点数量
+	Nodes int `json:"nodes"`
+	// Total 可调度节点的总资源
+	Total *registry.ResourceInfo `json:"total"`
+	// Available 可用资源（已按对接收方开放的上限裁剪）
+	Available *registry.ResourceInfo `json:"available"`
+	// MaxNodeAvailable 单个节点在各项资源上的最大可用量，用于判断单个 component 能否放置
+	MaxNodeAvailable *registry.ResourceInfo `json:"max_node_available"`
+	// ResourceTags 可调度节点支持的资源类型
+	ResourceTags []string  `json:"resource_tags,omitempty"`
+	GeneratedAt  time.Time `json:"generated_at"`
+}
+
+// Fits 摘要显示实例可能容纳 requested：总可用量与单节点最大可用量都足够
+// 各项资源的单节点最大值可能来自不同节点，结果是乐观估计，最终以对端调度结果为准
+func (s *CapacitySummary) Fits(requested *registry.ResourceInfo) bool {
+	if s == nil || s.Available == nil || s.MaxNodeAvailable == nil {
+		return false
+	}
+	if fits, _ := s.Available.Fits(requested); !fits {
+		return false
+	}
+	fits, _ := s.MaxNodeAvailable.Fits(requested)
+	return fits
+}
+
+// resourceTagNames 将资源标签转换为排序后的名称列表
+func resourceTagNames(tags map[string]bool) []string {
+	names := make([]string, 0, len(tags))
+	for name := range tags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PeerStatus 对等实例及其容量摘要交换、资源占用情况
+type PeerStatus struct {
+	*Peer
+	// Summary 最近一次从对等实例获得的容量摘要
+	Summary *CapacitySummary `json:"summary,omitempty"`
+	// LastExchangeAt 最近一次成功交换摘要的时间
+	LastExchangeAt time.Time `json:"last_exchange_at,omitempty"`
+	// LastError 最近一次交换失败的原因
+	LastError string `json:"last_error,omitempty"`
+	// InboundUsage 对等实例转发到本实例的活跃部署占用
+	InboundUsage deployment.Usage `json:"inbound_usage"`
+	// OutboundUsage 本实例转发到对等实例的活跃部署占用
+	OutboundUsage deployment.Usage `json:"outbound_usage"`
+}
+
+// peerState 对等实例的运行时状态（不持久化，不在 HA 副本间复制）
+type peerState struct {
+	summary     *CapacitySummary
+	exchangedAt time.Time
+	lastError   string
+}
+
+// LimitExceededError 超出为对等实例开放的资源上限
+type LimitExceededError struct {
+	Peer      PeerID
+	Direction string
+	Resource  string
+	Limit     int64
+	Used      int64
+	Requested int64
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("federation limit exceeded: %s limit of peer %s: %s limit %d, used %d, requested %d",
+		e.Direction, e.Peer, e.Resource, e.Limit, e.Used, e.Requested)
+}
+
+// Unwrap 使 errors.Is(err, ErrLimitExceeded) 成立
+func (e *LimitExceededError) Unwrap() error {
+	return ErrLimitExceeded
+}
+
+// checkLimit 判断追加 requested 后是否超出上限
+func checkLimit(peerID PeerID, direction string, limits quota.Limits, usage deployment.Usage, requested *registry.ResourceInfo) error {
+	if requested == nil {
+		requested = &registry.ResourceInfo{}
+	}
+	checks := []struct {
+		resource  string
+		limit     int64
+		used      int64
+		requested int64
+	}{
+		{registry.ResourceCPU, limits.CPU, usage.Resources.CPU, requested.CPU},
+		{registry.ResourceMemory, limits.Memory, usage.Resources.Memory, requested.Memory},
+		{registry.ResourceGPU, limits.GPU, usage.Resources.GPU, requested.GPU},
+		{"components", limits.Components, usage.Components, 1},
+	}
+	for _, c := range checks {
+		if c.limit > 0 && c.used+c.requested > c.limit {
+			return &LimitExceededError{
+				Peer:      peerID,
+				Direction: direction,
+				Resource:  c.resource,
+				Limit:     c.limit,
+				Used:      c.used,
+				Requested: c.requested,
+			}
+		}
+	}
+	return nil
+}
+
+// remaining 上限扣除用量后的剩余资源，resource 未设置上限时返回 -1
+func remaining(limits quota.Limits, usage deployment.Usage, resource string) int64 {
+	var limit, used int64
+	switch resource {
+	case registry.ResourceCPU:
+		limit, used = limits.CPU, usage.Resources.CPU
+	case registry.ResourceMemory:
+		limit, used = limits.Memory, usage.Resources.Memory
+	case registry.ResourceGPU:
+		limit, used = limits.GPU, usage.Resources.GPU
+	}
+	if limit <= 0 {
+		return -1
+	}
+	if used >= limit {
+		return 0
+	}
+	return limit - used
+}
@@ -0,0 +1,135 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	"github.com/9triver/iarnet-global/internal/intra/repository"
+)
+
+// seriesKey 时序数据序列
+type seriesKey struct {
+	scope Scope
+	id    string
+}
+
+// bucketKey 降采样窗口所属的序列与分辨率
+type bucketKey struct {
+	seriesKey
+	resolution Resolution
+}
+
+// sampledPoint 一次采样得到的原始数据点
+type sampledPoint struct {
+	key   seriesKey
+	point *Point
+}
+
+// bucket 降采样窗口内的累计值
+type bucket struct {
+	start     time.Time
+	samples   int64
+	total     Amount
+	used      Amount
+	available Amount
+	peak      Amount
+}
+
+// add 累加一个原始数据点
+func (b *bucket) add(p *Point) {
+	b.samples++
+	b.total.add(p.Total)
+	b.used.add(p.Used)
+	b.available.add(p.Available)
+	b.peak.max(p.Used)
+}
+
+// addWeighted 按样本数累加一个降采样数据点
+func (b *bucket) addWeighted(p *Point) {
+	n := max(p.Samples, 1)
+	for _, part := range []struct {
+		dst *Amount
+		src Amount
+	}{
+		{&b.total, p.Total},
+		{&b.used, p.Used},
+		{&b.available, p.Available},
+	} {
+		part.dst.add(part.src.scale(n))
+	}
+	b.samples += n
+	b.peak.max(p.PeakUsed)
+}
+
+// point 以窗口内的平均值生成数据点
+func (b *bucket) point() *Point {
+	return &Point{
+		Time:      b.start,
+		Samples:   b.samples,
+		Total:     b.total.div(b.samples),
+		Used:      b.used.div(b.samples),
+		Available: b.available.div(b.samples),
+		PeakUsed:  b.peak,
+	}
+}
+
+func pointOf(at time.Time, capacity *registry.ResourceCapacity) *Point {
+	used := amountOf(capacity.Used)
+	return &Point{
+		Time:      at,
+		Samples:   1,
+		Total:     amountOf(capacity.Total),
+		Used:      used,
+		Available: amountOf(capacity.Available),
+		PeakUsed:  used,
+	}
+}
+
+func toDAO(key seriesKey, resolution Resolution, p *Point) *repository.MetricPointDAO {
+	dao := &repository.MetricPointDAO{
+		Scope:           string(key.scope),
+		SeriesID:        key.id,
+		Resolution:      string(resolution),
+		Timestamp:       p.Time,
+		Samples:         p.Samples,
+		TotalCPU:        p.Total.CPU,
+		TotalMemory:     p.Total.Memory,
+		TotalGPU:        p.Total.GPU,
+		UsedCPU:         p.Used.CPU,
+		UsedMemory:      p.Used.Memory,
+		UsedGPU:         p.Used.GPU,
+		AvailableCPU:    p.Available.CPU,
+		AvailableMemory: p.Available.Memory,
+		AvailableGPU:    p.Available.GPU,
+		PeakCPU:         p.PeakUsed.CPU,
+		PeakMemory:      p.PeakUsed.Memory,
+		PeakGPU:         p.PeakUsed.GPU,
+	}
+	if len(p.Total.Extended)+len(p.Used.Extended)+len(p.Available.Extended)+len(p.PeakUsed.Extended) > 0 {
+		dao.Extended = &repository.MetricExtendedDAO{
+			Total:     p.Total.Extended,
+			Used:      p.Used.Extended,
+			Available: p.Available.Extended,
+			Peak:      p.PeakUsed.Extended,
+		}
+	}
+	return dao
+}
+
+func fromDAO(dao *repository.MetricPointDAO) *Point {
+	p := &Point{
+		Time:      dao.Timestamp,
+		Samples:   dao.Samples,
+		Total:     Amount{CPU: dao.TotalCPU, Memory: dao.TotalMemory, GPU: dao.TotalGPU},
+		Used:      Amount{CPU: dao.UsedCPU, Memory: dao.UsedMemory, GPU: dao.UsedGPU},
+		Available: Amount{CPU: dao.AvailableCPU, Memory: dao.AvailableMemory, GPU: dao.AvailableGPU},
+		PeakUsed:  Amount{CPU: dao.PeakCPU, Memory: dao.PeakMemory, GPU: dao.PeakGPU},
+	}
+	if ext := dao.Extended; ext != nil {
+		p.Total.Extended = ext.Total
+		p.Used.Extended = ext.Used
+		p.Available.Extended = ext.Available
+		p.PeakUsed.Extended = ext.Peak
+	}
+	return p
+}
@@ -0,0 +1,269 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	// DefaultForecastLookback 默认参考的历史数据范围
+	DefaultForecastLookback = 7 * 24 * time.Hour
+	// DefaultForecastHorizon 默认的预测范围
+	DefaultForecastHorizon = 14 * 24 * time.Hour
+
+	// minForecastSamples 拟合趋势所需的最少数据点数量
+	minForecastSamples = 6
+	// minSeasonalSpan 至少覆盖两天的小时级数据才拟合按天的周期性
+	minSeasonalSpan = 48 * time.Hour
+)
+
+// ForecastStatus 资源预测结论
+type ForecastStatus string
+
+const (
+	// ForecastInsufficientData 历史数据不足，无法预测
+	ForecastInsufficientData ForecastStatus = "insufficient_data"
+	// ForecastNoCapacity 域内没有该资源
+	ForecastNoCapacity ForecastStatus = "no_capacity"
+	// ForecastExhausted 当前已无可用资源
+	ForecastExhausted ForecastStatus = "exhausted"
+	// ForecastExhausting 预计在预测范围内耗尽
+	ForecastExhausting ForecastStatus = "exhausting"
+	// ForecastStable 预测范围内不会耗尽
+	ForecastStable ForecastStatus = "stable"
+)
+
+// 参与预测的资源
+const (
+	ResourceCPU    = "cpu"
+	ResourceMemory = "memory"
+	ResourceGPU    = "gpu"
+)
+
+// ForecastOptions 预测参数，零值使用服务配置
+type ForecastOptions struct {
+	Lookback time.Duration
+	Horizon  time.Duration
+}
+
+// Forecast 域的资源耗尽预测
+type Forecast struct {
+	DomainID    string             `json:"domain_id"`
+	GeneratedAt time.Time          `json:"generated_at"`
+	Resolution  Resolution         `json:"resolution"`
+	Samples     int                `json:"samples"`
+	Horizon     time.Duration      `json:"horizon"`
+	Resources   []ResourceForecast `json:"resources"`
+}
+
+// ResourceForecast 单项资源的预测
+// 以可用资源为对象拟合线性趋势，数据覆盖两天以上时叠加按小时的日周期分量，预测值降到 0 的时间即耗尽时间
+type ResourceForecast struct {
+	Resource string         `json:"resource"`
+	Status   ForecastStatus `json:"status"`
+	// Total / Available 当前的总量与可用量
+	Total     int64 `json:"total"`
+	Available int64 `json:"available"`
+	// TrendPerHour 可用资源每小时的变化量，负数表示在被消耗
+	TrendPerHour float64 `json:"trend_per_hour"`
+	// Seasonal 是否拟合了日周期分量
+	Seasonal bool `json:"seasonal"`
+	// ExhaustsAt 预计耗尽时间，不会耗尽时为空
+	ExhaustsAt *time.Time `json:"exhausts_at,omitempty"`
+}
+
+// Soonest 返回最早耗尽的资源，没有资源会耗尽时返回 nil
+func (f *Forecast) Soonest() *ResourceForecast {
+	var soonest *ResourceForecast
+	for i := range f.Resources {
+		r := &f.Resources[i]
+		if r.ExhaustsAt == nil {
+			continue
+		}
+		if soonest == nil || r.ExhaustsAt.Before(*soonest.ExhaustsAt) {
+			soonest = r
+		}
+	}
+	return soonest
+}
+
+func (s *service) Forecast(ctx context.Context, domainID string, opts ForecastOptions) (*Forecast, error) {
+	if domainID == "" {
+		return nil, fmt.Errorf("%w: domain id is required", ErrInvalidQuery)
+	}
+	if opts.Lookback <= 0 {
+		opts.Lookback = s.opts.ForecastLookback
+	}
+	if opts.Horizon <= 0 {
+		opts.Horizon = s.opts.ForecastHorizon
+	}
+
+	current, err := s.manager.DomainCapacity(domainID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	// 优先使用小时级数据；刚启用时小时级数据不足，退回到分钟级数据
+	var series *Series
+	for _, resolution := range []Resolution{ResolutionHour, ResolutionMinute} {
+		from := now.Add(-min(opts.Lookback, s.opts.retention(resolution)))
+		var err error
+		series, err = s.Query(ctx, Query{
+			Scope:      ScopeDomain,
+			SeriesID:   domainID,
+			Resolution: resolution,
+			From:       from,
+			To:         now,
+			Limit:      int(opts.Lookback/resolution.Window()) + 1,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(series.Points) >= minForecastSamples {
+			break
+		}
+	}
+
+	forecast := &Forecast{
+		DomainID:    domainID,
+		GeneratedAt: now,
+		Resolution:  series.Resolution,
+		Samples:     len(series.Points),
+		Horizon:     opts.Horizon,
+	}
+	for _, resource := range []string{ResourceCPU, ResourceMemory, ResourceGPU} {
+		forecast.Resources = append(forecast.Resources, forecastResource(resource, amountOf(current.Total), amountOf(current.Available), series, now, opts.Horizon))
+	}
+	return forecast, nil
+}
+
+// forecastResource 预测单项资源，当前已耗尽时不再参考历史数据
+func forecastResource(resource string, total, available Amount, series *Series, now time.Time, horizon time.Duration) ResourceForecast {
+	result := ResourceForecast{
+		Resource:  resource,
+		Status:    ForecastInsufficientData,
+		Total:     pick(total, resource),
+		Available: pick(available, resource),
+	}
+	points := series.Points
+
+	switch {
+	case result.Total <= 0:
+		result.Status = ForecastNoCapacity
+		return result
+	case result.Available <= 0:
+		result.Status = ForecastExhausted
+		result.ExhaustsAt = &now
+		return result
+	case len(points) < minForecastSamples:
+		return result
+	}
+
+	// 以第一个数据点为原点，横轴单位为小时
+	origin := points[0].Time
+	xs := make([]float64, len(points))
+	ys := make([]float64, len(points))
+	for i, p := range points {
+		xs[i] = p.Time.Sub(origin).Hours()
+		ys[i] = float64(pick(p.Available, resource))
+	}
+	intercept, slope := linearFit(xs, ys)
+	result.TrendPerHour = slope
+
+	var season []float64
+	if series.Resolution == ResolutionHour && points[len(points)-1].Time.Sub(origin) >= minSeasonalSpan {
+		season = dailySeason(points, xs, ys, intercept, slope)
+		result.Seasonal = season != nil
+	}
+
+	predict := func(t time.Time) float64 {
+		y := intercept + slope*t.Sub(origin).Hours()
+		if season != nil {
+			y += season[t.UTC().Hour()]
+		}
+		return y
+	}
+
+	end := now.Add(horizon)
+	if season == nil {
+		// 纯线性趋势直接求解与 0 的交点
+		if slope < 0 {
+			at := origin.Add(time.Duration(-intercept / slope * float64(time.Hour)))
+			if at.Before(now) {
+				at = now
+			}
+			if !at.After(end) {
+				result.ExhaustsAt = &at
+			}
+		}
+	} else {
+		for t := now.Truncate(time.Hour).Add(time.Hour); !t.After(end); t = t.Add(time.Hour) {
+			if predict(t) <= 0 {
+				at := t
+				result.ExhaustsAt = &at
+				break
+			}
+		}
+	}
+
+	if result.ExhaustsAt != nil {
+		result.Status = ForecastExhausting
+	} else {
+		result.Status = ForecastStable
+	}
+	return result
+}
+
+// linearFit 最小二乘拟合 y = intercept + slope*x
+func linearFit(xs, ys []float64) (intercept, slope float64) {
+	n := float64(len(xs))
+	var sumX, sumY, sumXY, sumXX float64
+	for i := range xs {
+		sumX += xs[i]
+		sumY += ys[i]
+		sumXY += xs[i] * ys[i]
+		sumXX += xs[i] * xs[i]
+	}
+	denominator := n*sumXX - sumX*sumX
+	if math.Abs(denominator) < 1e-9 {
+		return sumY / n, 0
+	}
+	slope = (n*sumXY - sumX*sumY) / denominator
+	intercept = (sumY - slope*sumX) / n
+	return intercept, slope
+}
+
+// dailySeason 按 UTC 小时统计去趋势后的平均残差，作为日周期分量；有小时缺少数据时返回 nil
+func dailySeason(points []*Point, xs, ys []float64, intercept, slope float64) []float64 {
+	sums := make([]float64, 24)
+	counts := make([]int, 24)
+	for i, p := range points {
+		hour := p.Time.UTC().Hour()
+		sums[hour] += ys[i] - (intercept + slope*xs[i])
+		counts[hour]++
+	}
+	season := make([]float64, 24)
+	for hour := range season {
+		if counts[hour] == 0 {
+			return nil
+		}
+		season[hour] = sums[hour] / float64(counts[hour])
+	}
+	return season
+}
+
+func pick(a Amount, resource string) int64 {
+	switch resource {
+	case ResourceCPU:
+		return a.CPU
+	case ResourceMemory:
+		return a.Memory
+	case ResourceGPU:
+		return a.GPU
+	default:
+		return 0
+	}
+}
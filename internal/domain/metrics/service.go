@@ -0,0 +1,337 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	"github.com/9triver/iarnet-global/internal/intra/repository"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultSampleInterval 默认采样周期
+	DefaultSampleInterval = 15 * time.Second
+	// DefaultRawRetention 原始采样默认保留时间
+	DefaultRawRetention = 6 * time.Hour
+	// DefaultMinuteRetention 分钟级数据默认保留时间
+	DefaultMinuteRetention = 7 * 24 * time.Hour
+	// DefaultHourRetention 小时级数据默认保留时间
+	DefaultHourRetention = 90 * 24 * time.Hour
+
+	// DefaultQueryLimit 未指定数量时最多返回的数据点数量
+	DefaultQueryLimit = 2000
+	// DefaultQueryRange 未指定起始时间时的查询范围
+	DefaultQueryRange = time.Hour
+
+	// autoMaxPoints 自动选择分辨率时期望的最大数据点数量
+	autoMaxPoints = 1000
+	// pruneInterval 清理过期数据的周期
+	pruneInterval = 10 * time.Minute
+)
+
+// Options 时序数据服务配置
+type Options struct {
+	// SampleInterval 采样周期，即原始数据的分辨率
+	SampleInterval time.Duration
+	// RawRetention / MinuteRetention / HourRetention 各分辨率数据的保留时间
+	RawRetention    time.Duration
+	MinuteRetention time.Duration
+	HourRetention   time.Duration
+	// ForecastLookback 资源耗尽预测参考的历史数据范围
+	ForecastLookback time.Duration
+	// ForecastHorizon 资源耗尽预测的范围
+	ForecastHorizon time.Duration
+}
+
+func (o Options) withDefaults() Options {
+	if o.SampleInterval <= 0 {
+		o.SampleInterval = DefaultSampleInterval
+	}
+	if o.RawRetention <= 0 {
+		o.RawRetention = DefaultRawRetention
+	}
+	if o.MinuteRetention <= 0 {
+		o.MinuteRetention = DefaultMinuteRetention
+	}
+	if o.HourRetention <= 0 {
+		o.HourRetention = DefaultHourRetention
+	}
+	if o.ForecastLookback <= 0 {
+		o.ForecastLookback = DefaultForecastLookback
+	}
+	if o.ForecastHorizon <= 0 {
+		o.ForecastHorizon = DefaultForecastHorizon
+	}
+	return o
+}
+
+// retention 分辨率对应的保留时间
+func (o Options) retention(r Resolution) time.Duration {
+	switch r {
+	case ResolutionMinute:
+		return o.MinuteRetention
+	case ResolutionHour:
+		return o.HourRetention
+	default:
+		return o.RawRetention
+	}
+}
+
+// Service 容量时序数据服务：周期性采样各节点与各域的资源使用情况，降采样后持久化并提供范围查询
+type Service interface {
+	// Start 启动后台采样与过期数据清理
+	Start(ctx context.Context) error
+	// Stop 停止采样，并写入尚未结束的降采样窗口
+	Stop()
+	// Query 查询时序数据
+	Query(ctx context.Context, q Query) (*Series, error)
+	// Forecast 根据域的历史数据预测各项资源的耗尽时间
+	Forecast(ctx context.Context, domainID string, opts ForecastOptions) (*Forecast, error)
+}
+
+type service struct {
+	repo    repository.MetricsRepo
+	manager *registry.Manager
+	opts    Options
+
+	mu sync.Mutex
+	// buckets 尚未结束的降采样窗口
+	buckets   map[bucketKey]*bucket
+	lastPrune time.Time
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// NewService 创建时序数据服务
+func NewService(repo repository.MetricsRepo, manager *registry.Manager, opts Options) Service {
+	return &service{
+		repo:    repo,
+		manager: manager,
+		opts:    opts.withDefaults(),
+		buckets: make(map[bucketKey]*bucket),
+		stopCh:  make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+func (s *service) Start(ctx context.Context) error {
+	go s.run(ctx)
+	logrus.Infof("Capacity metrics started (sample interval: %v, retention: raw %v, 1m %v, 1h %v)",
+		s.opts.SampleInterval, s.opts.RawRetention, s.opts.MinuteRetention, s.opts.HourRetention)
+	return nil
+}
+
+func (s *service) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+		<-s.done
+	})
+}
+
+func (s *service) run(ctx context.Context) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.opts.SampleInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.flushAll()
+			return
+		case <-s.stopCh:
+			s.flushAll()
+			return
+		case now := <-ticker.C:
+			s.sample(ctx, now)
+			if now.Sub(s.lastPrune) >= pruneInterval {
+				s.prune(ctx, now)
+				s.lastPrune = now
+			}
+		}
+	}
+}
+
+// sample 采样一次：写入原始数据点，并将其累加到降采样窗口，已结束的窗口一并写入
+func (s *service) sample(ctx context.Context, now time.Time) {
+	now = now.Truncate(time.Second)
+	snapshot := s.manager.CapacitySnapshot()
+
+	raw := make([]sampledPoint, 0, len(snapshot.Nodes)+len(snapshot.Domains))
+	for id, capacity := range snapshot.Nodes {
+		raw = append(raw, sampledPoint{key: seriesKey{ScopeNode, id}, point: pointOf(now, capacity)})
+	}
+	for id, capacity := range snapshot.Domains {
+		raw = append(raw, sampledPoint{key: seriesKey{ScopeDomain, id}, point: pointOf(now, capacity)})
+	}
+
+	s.mu.Lock()
+	// 先写出已结束的窗口（包括不再上报的节点），再累加本次采样
+	batch := s.expireBucketsLocked(now)
+	for _, p := range raw {
+		batch = append(batch, toDAO(p.key, ResolutionRaw, p.point))
+		for _, resolution := range rollups {
+			key := bucketKey{seriesKey: p.key, resolution: resolution}
+			b, ok := s.buckets[key]
+			if !ok {
+				b = &bucket{start: now.Truncate(resolution.Window())}
+				s.buckets[key] = b
+			}
+			b.add(p.point)
+		}
+	}
+	s.mu.Unlock()
+
+	if err := s.repo.SavePoints(ctx, batch); err != nil {
+		logrus.Warnf("Failed to persist %d capacity metric point(s): %v", len(batch), err)
+	}
+}
+
+// expireBucketsLocked 取出窗口已结束的降采样数据点，调用者需持有锁
+func (s *service) expireBucketsLocked(now time.Time) []*repository.MetricPointDAO {
+	expired := make([]*repository.MetricPointDAO, 0)
+	for key, b := range s.buckets {
+		if now.Before(b.start.Add(key.resolution.Window())) {
+			continue
+		}
+		expired = append(expired, toDAO(key.seriesKey, key.resolution, b.point()))
+		delete(s.buckets, key)
+	}
+	return expired
+}
+
+// flushAll 写入全部尚未结束的窗口，重启后同一窗口的后续数据按样本数加权合并
+func (s *service) flushAll() {
+	s.mu.Lock()
+	batch := make([]*repository.MetricPointDAO, 0, len(s.buckets))
+	for key, b := range s.buckets {
+		batch = append(batch, toDAO(key.seriesKey, key.resolution, b.point()))
+	}
+	s.buckets = make(map[bucketKey]*bucket)
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := s.repo.SavePoints(ctx, batch); err != nil {
+		logrus.Warnf("Failed to persist %d pending capacity metric point(s): %v", len(batch), err)
+	}
+}
+
+// prune 删除超过保留时间的数据点
+func (s *service) prune(ctx context.Context, now time.Time) {
+	for _, resolution := range []Resolution{ResolutionRaw, ResolutionMinute, ResolutionHour} {
+		deleted, err := s.repo.DeleteBefore(ctx, string(resolution), now.Add(-s.opts.retention(resolution)))
+		if err != nil {
+			logrus.Warnf("Failed to prune %s capacity metrics: %v", resolution, err)
+			continue
+		}
+		if deleted > 0 {
+			logrus.Debugf("Pruned %d %s capacity metric point(s)", deleted, resolution)
+		}
+	}
+}
+
+func (s *service) Query(ctx context.Context, q Query) (*Series, error) {
+	if q.Scope != ScopeNode && q.Scope != ScopeDomain {
+		return nil, fmt.Errorf("%w: unknown scope %q", ErrInvalidQuery, q.Scope)
+	}
+	if q.SeriesID == "" {
+		return nil, fmt.Errorf("%w: id is required", ErrInvalidQuery)
+	}
+	now := time.Now()
+	if q.To.IsZero() {
+		q.To = now
+	}
+	if q.From.IsZero() {
+		q.From = q.To.Add(-DefaultQueryRange)
+	}
+	if !q.From.Before(q.To) {
+		return nil, fmt.Errorf("%w: from must be before to", ErrInvalidQuery)
+	}
+	if q.Resolution == "" {
+		q.Resolution = s.chooseResolution(now, q.From, q.To)
+	}
+	if q.Limit <= 0 {
+		q.Limit = DefaultQueryLimit
+	}
+
+	daos, err := s.repo.QueryPoints(ctx, repository.MetricPointQuery{
+		Scope:      string(q.Scope),
+		SeriesID:   q.SeriesID,
+		Resolution: string(q.Resolution),
+		From:       q.From,
+		To:         q.To,
+		Limit:      q.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query capacity metrics: %w", err)
+	}
+
+	points := make([]*Point, 0, len(daos)+1)
+	for _, dao := range daos {
+		points = append(points, fromDAO(dao))
+	}
+	if pending := s.pendingPoint(q); pending != nil {
+		points = mergePending(points, pending)
+		if len(points) > q.Limit {
+			points = points[len(points)-q.Limit:]
+		}
+	}
+
+	return &Series{
+		Scope:      q.Scope,
+		SeriesID:   q.SeriesID,
+		Resolution: q.Resolution,
+		From:       q.From,
+		To:         q.To,
+		Points:     points,
+	}, nil
+}
+
+// chooseResolution 选择保留时间覆盖查询起点、且数据点数量不超过 autoMaxPoints 的最细分辨率
+func (s *service) chooseResolution(now, from, to time.Time) Resolution {
+	span := to.Sub(from)
+	for _, resolution := range []Resolution{ResolutionRaw, ResolutionMinute} {
+		window := resolution.Window()
+		if window == 0 {
+			window = s.opts.SampleInterval
+		}
+		if now.Sub(from) <= s.opts.retention(resolution) && span/window <= autoMaxPoints {
+			return resolution
+		}
+	}
+	return ResolutionHour
+}
+
+// pendingPoint 返回查询范围内尚未结束的降采样窗口
+func (s *service) pendingPoint(q Query) *Point {
+	if q.Resolution == ResolutionRaw {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.buckets[bucketKey{seriesKey: seriesKey{q.Scope, q.SeriesID}, resolution: q.Resolution}]
+	if !ok || b.start.Before(q.From) || b.start.After(q.To) {
+		return nil
+	}
+	return b.point()
+}
+
+// mergePending 将未结束的窗口追加到结果末尾，与已持久化的同一窗口（重启前写入的部分）加权合并
+func mergePending(points []*Point, pending *Point) []*Point {
+	if n := len(points); n > 0 && points[n-1].Time.Equal(pending.Time) {
+		last := points[n-1]
+		b := &bucket{start: last.Time}
+		b.addWeighted(last)
+		b.addWeighted(pending)
+		points[n-1] = b.point()
+		return points
+	}
+	return append(points, pending)
+}
@@ -0,0 +1,168 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+)
+
+var (
+	ErrInvalidQuery = errors.New("invalid metrics query")
+)
+
+// Resolution 时序数据的分辨率
+type Resolution string
+
+const (
+	// ResolutionRaw 原始采样
+	ResolutionRaw Resolution = "raw"
+	// ResolutionMinute 按分钟降采样
+	ResolutionMinute Resolution = "1m"
+	// ResolutionHour 按小时降采样
+	ResolutionHour Resolution = "1h"
+)
+
+// rollups 降采样的分辨率，按窗口从小到大排列
+var rollups = []Resolution{ResolutionMinute, ResolutionHour}
+
+// Window 降采样窗口长度，原始采样返回 0
+func (r Resolution) Window() time.Duration {
+	switch r {
+	case ResolutionMinute:
+		return time.Minute
+	case ResolutionHour:
+		return time.Hour
+	default:
+		return 0
+	}
+}
+
+// ParseResolution 解析分辨率，空字符串表示由查询范围自动选择
+func ParseResolution(s string) (Resolution, error) {
+	switch r := Resolution(s); r {
+	case "", ResolutionRaw, ResolutionMinute, ResolutionHour:
+		return r, nil
+	default:
+		return "", fmt.Errorf("%w: unknown resolution %q", ErrInvalidQuery, s)
+	}
+}
+
+// Scope 时序数据的统计对象
+type Scope string
+
+const (
+	// ScopeNode 单个节点
+	ScopeNode Scope = "node"
+	// ScopeDomain 域内全部存活节点的汇总
+	ScopeDomain Scope = "domain"
+)
+
+// Amount 资源量（CPU millicores、内存 bytes、GPU 数量与扩展资源）
+type Amount struct {
+	CPU      int64            `json:"cpu"`
+	Memory   int64            `json:"memory"`
+	GPU      int64            `json:"gpu"`
+	Extended map[string]int64 `json:"extended,omitempty"`
+}
+
+func amountOf(info *registry.ResourceInfo) Amount {
+	if info == nil {
+		return Amount{}
+	}
+	a := Amount{CPU: info.CPU, Memory: info.Memory, GPU: info.GPU}
+	for name, value := range info.Extended {
+		if a.Extended == nil {
+			a.Extended = make(map[string]int64, len(info.Extended))
+		}
+		a.Extended[name] = value
+	}
+	return a
+}
+
+func (a *Amount) add(other Amount) {
+	a.CPU += other.CPU
+	a.Memory += other.Memory
+	a.GPU += other.GPU
+	for name, value := range other.Extended {
+		if a.Extended == nil {
+			a.Extended = make(map[string]int64, len(other.Extended))
+		}
+		a.Extended[name] += value
+	}
+}
+
+func (a *Amount) max(other Amount) {
+	a.CPU = max(a.CPU, other.CPU)
+	a.Memory = max(a.Memory, other.Memory)
+	a.GPU = max(a.GPU, other.GPU)
+	for name, value := range other.Extended {
+		if a.Extended == nil {
+			a.Extended = make(map[string]int64, len(other.Extended))
+		}
+		if current, ok := a.Extended[name]; !ok || value > current {
+			a.Extended[name] = value
+		}
+	}
+}
+
+// scale 各项资源乘以 n，用于按样本数加权
+func (a Amount) scale(n int64) Amount {
+	scaled := Amount{CPU: a.CPU * n, Memory: a.Memory * n, GPU: a.GPU * n}
+	for name, value := range a.Extended {
+		if scaled.Extended == nil {
+			scaled.Extended = make(map[string]int64, len(a.Extended))
+		}
+		scaled.Extended[name] = value * n
+	}
+	return scaled
+}
+
+func (a Amount) div(n int64) Amount {
+	if n <= 0 {
+		return a
+	}
+	divided := Amount{CPU: a.CPU / n, Memory: a.Memory / n, GPU: a.GPU / n}
+	for name, value := range a.Extended {
+		if divided.Extended == nil {
+			divided.Extended = make(map[string]int64, len(a.Extended))
+		}
+		divided.Extended[name] = value / n
+	}
+	return divided
+}
+
+// Point 时序数据点
+// 降采样数据点的时间为窗口起点，各项资源为窗口内的平均值，PeakUsed 为窗口内已使用资源的最大值
+type Point struct {
+	Time      time.Time `json:"time"`
+	Samples   int64     `json:"samples"`
+	Total     Amount    `json:"total"`
+	Used      Amount    `json:"used"`
+	Available Amount    `json:"available"`
+	PeakUsed  Amount    `json:"peak_used"`
+}
+
+// Query 时序数据范围查询
+type Query struct {
+	Scope    Scope
+	SeriesID string
+	// Resolution 为空时按查询范围自动选择
+	Resolution Resolution
+	// From / To 查询范围，From 为零值时取 To 之前 1 小时，To 为零值时取当前时间
+	From time.Time
+	To   time.Time
+	// Limit 最多返回的数据点数量，超出时保留最新的数据点，为 0 时使用默认值
+	Limit int
+}
+
+// Series 查询结果
+type Series struct {
+	Scope      Scope      `json:"scope"`
+	SeriesID   string     `json:"id"`
+	Resolution Resolution `json:"resolution"`
+	From       time.Time  `json:"from"`
+	To         time.Time  `json:"to"`
+	Points     []*Point   `json:"points"`
+}
@@ -0,0 +1,44 @@
+package quota
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+)
+
+var (
+	// ErrQuotaNotFound 配额不存在
+	ErrQuotaNotFound = errors.New("quota not found")
+	// ErrQuotaAlreadyExists 相同作用域的配额已存在
+	ErrQuotaAlreadyExists = errors.New("quota already exists")
+	// ErrInvalidQuota 无效的配额
+	ErrInvalidQuota = errors.New("invalid quota")
+	// ErrQuotaExceeded 超出配额
+	ErrQuotaExceeded = errors.New("quota exceeded")
+)
+
+// ExceededError 超出配额的详细信息
+type ExceededError struct {
+	QuotaID   QuotaID
+	Tenant    string
+	DomainID  registry.DomainID
+	Resource  string
+	Limit     int64
+	Used      int64
+	Requested int64
+}
+
+func (e *ExceededError) Error() string {
+	scope := "all domains"
+	if e.DomainID != "" {
+		scope = fmt.Sprintf("domain %s", e.DomainID)
+	}
+	return fmt.Sprintf("quota exceeded: tenant %q on %s: %s limit %d, used %d, requested %d (quota %s)",
+		e.Tenant, scope, e.Resource, e.Limit, e.Used, e.Requested, e.QuotaID)
+}
+
+// Unwrap 使 errors.Is(err, ErrQuotaExceeded) 成立
+func (e *ExceededError) Unwrap() error {
+	return ErrQuotaExceeded
+}
@@ -0,0 +1,294 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/deployment"
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	"github.com/9triver/iarnet-global/internal/intra/repository"
+	"github.com/9triver/iarnet-global/internal/util"
+	"github.com/sirupsen/logrus"
+)
+
+// Service 配额服务
+type Service interface {
+	// CreateQuota 创建配额
+	CreateQuota(ctx context.Context, domainID registry.DomainID, tenant string, limits Limits) (*Quota, error)
+	// UpdateQuota 更新配额上限
+	UpdateQuota(ctx context.Context, id QuotaID, limits Limits) (*Quota, error)
+	// DeleteQuota 删除配额
+	DeleteQuota(ctx context.Context, id QuotaID) error
+	// GetQuota 获取配额及用量（tenant 为空时默认配额不计算用量）
+	GetQuota(ctx context.Context, id QuotaID, tenant string) (*Status, error)
+	// ListQuotas 列出配额及用量
+	ListQuotas(ctx context.Context, filter Filter) ([]*Status, error)
+	// LoadQuotas 从 repository 加载配额
+	LoadQuotas(ctx context.Context) error
+	// Check 检查租户在指定域上追加 requested 资源是否超出配额
+	// domainID 为空时仅检查跨域的全局配额
+	Check(tenant string, domainID registry.DomainID, requested *registry.ResourceInfo) error
+}
+
+type service struct {
+	mu      sync.RWMutex
+	quotas  map[QuotaID]*Quota
+	repo    repository.QuotaRepo
+	tracker *deployment.Tracker
+}
+
+// NewService 创建配额服务
+func NewService(repo repository.QuotaRepo, tracker *deployment.Tracker) Service {
+	return &service{
+		quotas:  make(map[QuotaID]*Quota),
+		repo:    repo,
+		tracker: tracker,
+	}
+}
+
+func (s *service) LoadQuotas(ctx context.Context) error {
+	daos, err := s.repo.GetAllQuotas(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load quotas from repository: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, dao := range daos {
+		s.quotas[dao.ID] = &Quota{
+			ID:       dao.ID,
+			DomainID: dao.DomainID,
+			Tenant:   dao.Tenant,
+			Limits: Limits{
+				CPU:        dao.CPU,
+				Memory:     dao.Memory,
+				GPU:        dao.GPU,
+				Components: dao.MaxComponents,
+			},
+			CreatedAt: dao.CreatedAt,
+			UpdatedAt: dao.UpdatedAt,
+		}
+	}
+
+	logrus.Infof("Loaded %d quota(s) from database", len(daos))
+	return nil
+}
+
+func (s *service) CreateQuota(ctx context.Context, domainID registry.DomainID, tenant string, limits Limits) (*Quota, error) {
+	if err := limits.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findUnsafe(domainID, tenant) != nil {
+		return nil, ErrQuotaAlreadyExists
+	}
+
+	now := time.Now()
+	q := &Quota{
+		ID:        util.GenIDWith("quota."),
+		DomainID:  domainID,
+		Tenant:    tenant,
+		Limits:    limits,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.repo.CreateQuota(ctx, toDAO(q)); err != nil {
+		return nil, fmt.Errorf("failed to persist quota to repository: %w", err)
+	}
+	s.quotas[q.ID] = q
+
+	logrus.Infof("Quota created: id=%s, domain=%s, tenant=%s", q.ID, domainID, tenant)
+	return q.Clone(), nil
+}
+
+func (s *service) UpdateQuota(ctx context.Context, id QuotaID, limits Limits) (*Quota, error) {
+	if err := limits.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	q, ok := s.quotas[id]
+	if !ok {
+		return nil, ErrQuotaNotFound
+	}
+
+	updated := q.Clone()
+	updated.Limits = limits
+	updated.UpdatedAt = time.Now()
+	if err := s.repo.UpdateQuota(ctx, toDAO(updated)); err != nil {
+		return nil, fmt.Errorf("failed to persist quota to repository: %w", err)
+	}
+	s.quotas[id] = updated
+
+	logrus.Infof("Quota updated: id=%s", id)
+	return updated.Clone(), nil
+}
+
+func (s *service) DeleteQuota(ctx context.Context, id QuotaID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.quotas[id]; !ok {
+		return ErrQuotaNotFound
+	}
+	if err := s.repo.DeleteQuota(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete quota from repository: %w", err)
+	}
+	delete(s.quotas, id)
+
+	logrus.Infof("Quota deleted: id=%s", id)
+	return nil
+}
+
+func (s *service) GetQuota(ctx context.Context, id QuotaID, tenant string) (*Status, error) {
+	s.mu.RLock()
+	q, ok := s.quotas[id]
+	if ok {
+		q = q.Clone()
+	}
+	s.mu.RUnlock()
+
+	if !ok {
+		return nil, ErrQuotaNotFound
+	}
+	return s.status(q, tenant), nil
+}
+
+func (s *service) ListQuotas(ctx context.Context, filter Filter) ([]*Status, error) {
+	s.mu.RLock()
+	quotas := make([]*Quota, 0, len(s.quotas))
+	for _, q := range s.quotas {
+		if filter.DomainID != "" && q.DomainID != "" && q.DomainID != filter.DomainID {
+			continue
+		}
+		if filter.Tenant != "" {
+			// 只返回对该租户实际生效的配额：默认配额被同作用域的租户专属配额覆盖
+			if q.Tenant != "" && q.Tenant != filter.Tenant {
+				continue
+			}
+			if q.IsDefault() && s.findUnsafe(q.DomainID, filter.Tenant) != nil {
+				continue
+			}
+		}
+		quotas = append(quotas, q.Clone())
+	}
+	s.mu.RUnlock()
+
+	result := make([]*Status, 0, len(quotas))
+	for _, q := range quotas {
+		result = append(result, s.status(q, filter.Tenant))
+	}
+	return result, nil
+}
+
+func (s *service) Check(tenant string, domainID registry.DomainID, requested *registry.ResourceInfo) error {
+	if requested == nil {
+		requested = &registry.ResourceInfo{}
+	}
+
+	s.mu.RLock()
+	effective := make([]*Quota, 0, 2)
+	if q := s.effectiveUnsafe("", tenant); q != nil {
+		effective = append(effective, q.Clone())
+	}
+	if domainID != "" {
+		if q := s.effectiveUnsafe(domainID, tenant); q != nil {
+			effective = append(effective, q.Clone())
+		}
+	}
+	s.mu.RUnlock()
+
+	for _, q := range effective {
+		usage := s.tracker.Usage(q.usageFilter(tenant))
+		if err := exceeds(q, tenant, usage, requested); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// status 组装配额状态，默认配额仅在指定租户时计算用量
+func (s *service) status(q *Quota, tenant string) *Status {
+	st := &Status{Quota: q}
+	if !q.IsDefault() {
+		tenant = q.Tenant
+	}
+	if tenant == "" {
+		return st
+	}
+	usage := s.tracker.Usage(q.usageFilter(tenant))
+	st.Tenant = tenant
+	st.Usage = &usage
+	return st
+}
+
+// findUnsafe 查找指定作用域的配额（调用者需持有锁）
+func (s *service) findUnsafe(domainID registry.DomainID, tenant string) *Quota {
+	for _, q := range s.quotas {
+		if q.DomainID == domainID && q.Tenant == tenant {
+			return q
+		}
+	}
+	return nil
+}
+
+// effectiveUnsafe 返回对租户生效的配额：租户专属配额优先，其次为默认配额（调用者需持有锁）
+func (s *service) effectiveUnsafe(domainID registry.DomainID, tenant string) *Quota {
+	if q := s.findUnsafe(domainID, tenant); q != nil {
+		return q
+	}
+	return s.findUnsafe(domainID, "")
+}
+
+// exceeds 判断追加 requested 后是否超出配额
+func exceeds(q *Quota, tenant string, usage deployment.Usage, requested *registry.ResourceInfo) error {
+	checks := []struct {
+		resource  string
+		limit     int64
+		used      int64
+		requested int64
+	}{
+		{registry.ResourceCPU, q.Limits.CPU, usage.Resources.CPU, requested.CPU},
+		{registry.ResourceMemory, q.Limits.Memory, usage.Resources.Memory, requested.Memory},
+		{registry.ResourceGPU, q.Limits.GPU, usage.Resources.GPU, requested.GPU},
+		{"components", q.Limits.Components, usage.Components, 1},
+	}
+
+	for _, c := range checks {
+		if c.limit > 0 && c.used+c.requested > c.limit {
+			return &ExceededError{
+				QuotaID:   q.ID,
+				Tenant:    tenant,
+				DomainID:  q.DomainID,
+				Resource:  c.resource,
+				Limit:     c.limit,
+				Used:      c.used,
+				Requested: c.requested,
+			}
+		}
+	}
+	return nil
+}
+
+func toDAO(q *Quota) *repository.QuotaDAO {
+	return &repository.QuotaDAO{
+		ID:            q.ID,
+		DomainID:      q.DomainID,
+		Tenant:        q.Tenant,
+		CPU:           q.Limits.CPU,
+		Memory:        q.Limits.Memory,
+		GPU:           q.Limits.GPU,
+		MaxComponents: q.Limits.Components,
+		CreatedAt:     q.CreatedAt,
+		UpdatedAt:     q.UpdatedAt,
+	}
+}
@@ -0,0 +1,83 @@
+package quota
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/deployment"
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+)
+
+// QuotaID 配额 ID
+type QuotaID = string
+
+// Limits 配额上限，0 表示不限制
+type Limits struct {
+	// CPU 单位：millicores
+	CPU int64 `json:"cpu" yaml:"cpu"`
+	// Memory 单位：bytes
+	Memory int64 `json:"memory" yaml:"memory"`
+	// GPU 数量
+	GPU int64 `json:"gpu" yaml:"gpu"`
+	// Components 活跃 component 数量
+	Components int64 `json:"components" yaml:"components"`
+}
+
+// Validate 验证配额上限
+func (l Limits) Validate() error {
+	if l.CPU < 0 || l.Memory < 0 || l.GPU < 0 || l.Components < 0 {
+		return fmt.Errorf("%w: limits must not be negative", ErrInvalidQuota)
+	}
+	return nil
+}
+
+// Quota 资源配额
+// DomainID 为空表示限制租户在所有域上的总用量；
+// Tenant 为空表示对每个租户单独生效的默认配额，同一作用域下租户专属配额优先
+type Quota struct {
+	ID        QuotaID           `json:"id" yaml:"id"`
+	DomainID  registry.DomainID `json:"domain_id" yaml:"domain_id"`
+	Tenant    string            `json:"tenant" yaml:"tenant"`
+	Limits    Limits            `json:"limits" yaml:"limits"`
+	CreatedAt time.Time         `json:"created_at" yaml:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at" yaml:"updated_at"`
+}
+
+// Clone 复制配额
+func (q *Quota) Clone() *Quota {
+	if q == nil {
+		return nil
+	}
+	copy := *q
+	return &copy
+}
+
+// IsDefault 是否为对所有租户生效的默认配额
+func (q *Quota) IsDefault() bool {
+	return q.Tenant == ""
+}
+
+// usageFilter 返回计算指定租户在该配额作用域内用量的过滤条件
+func (q *Quota) usageFilter(tenant string) deployment.Filter {
+	return deployment.Filter{
+		Tenant:   tenant,
+		DomainID: q.DomainID,
+	}
+}
+
+// Status 配额及当前用量
+type Status struct {
+	Quota *Quota `json:"quota"`
+	// Tenant 用量所属租户（默认配额在未指定租户时为空）
+	Tenant string `json:"tenant,omitempty"`
+	// Usage 当前用量（默认配额在未指定租户时为空）
+	Usage *deployment.Usage `json:"usage,omitempty"`
+}
+
+// Filter 配额查询条件，空字段表示不过滤
+type Filter struct {
+	// Tenant 指定后返回对该租户生效的配额（含默认配额）及其用量
+	Tenant string
+	// DomainID 指定后返回作用于该域的配额（含全局配额）
+	DomainID registry.DomainID
+}
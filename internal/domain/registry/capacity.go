@@ -0,0 +1,109 @@
+package registry
+
+// nodeCapacity 节点计入域汇总容量的部分，只有存活（在线或疑似失效）且已上报容量的节点计入
+func nodeCapacity(node *Node) *ResourceCapacity {
+	if node == nil || !node.IsAlive() {
+		return nil
+	}
+	return node.ResourceCapacity
+}
+
+// applyDomainCapacityUnsafe 以增量方式更新域的汇总容量：扣除 prev 并累加 next，调用者需持有锁
+// 每次生成新的容量对象，已被读取的旧对象不会被修改
+func applyDomainCapacityUnsafe(domain *Domain, prev, next *ResourceCapacity) {
+	if prev == nil && next == nil {
+		return
+	}
+	capacity := domain.Capacity.Clone()
+	if capacity == nil {
+		capacity = &ResourceCapacity{}
+	}
+	for _, part := range []struct {
+		dst        **ResourceInfo
+		prev, next *ResourceInfo
+	}{
+		{&capacity.Total, capacityTotal(prev), capacityTotal(next)},
+		{&capacity.Used, capacityUsed(prev), capacityUsed(next)},
+		{&capacity.Available, capacityAvailable(prev), capacityAvailable(next)},
+	} {
+		if *part.dst == nil {
+			*part.dst = &ResourceInfo{}
+		}
+		(*part.dst).Sub(part.prev)
+		(*part.dst).Add(part.next)
+		for name, value := range (*part.dst).Extended {
+			if value == 0 {
+				delete((*part.dst).Extended, name)
+			}
+		}
+	}
+	domain.Capacity = capacity
+}
+
+func capacityTotal(c *ResourceCapacity) *ResourceInfo {
+	if c == nil {
+		return nil
+	}
+	return c.Total
+}
+
+func capacityUsed(c *ResourceCapacity) *ResourceInfo {
+	if c == nil {
+		return nil
+	}
+	return c.Used
+}
+
+func capacityAvailable(c *ResourceCapacity) *ResourceInfo {
+	if c == nil {
+		return nil
+	}
+	return c.Available
+}
+
+// DomainCapacity 返回域内存活节点的资源汇总（总量、已使用与可用），域不存在时返回错误
+func (m *Manager) DomainCapacity(domainID DomainID) (*ResourceCapacity, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	domain, ok := m.domains[domainID]
+	if !ok {
+		return nil, ErrDomainNotFound
+	}
+	if domain.Capacity == nil {
+		return &ResourceCapacity{Total: &ResourceInfo{}, Used: &ResourceInfo{}, Available: &ResourceInfo{}}, nil
+	}
+	return domain.Capacity.Clone(), nil
+}
+
+// CapacitySnapshot 某一时刻的容量快照，均为副本
+type CapacitySnapshot struct {
+	// Nodes 存活且已上报容量的节点
+	Nodes map[NodeID]*ResourceCapacity
+	// Domains 全部域的存活节点资源汇总
+	Domains map[DomainID]*ResourceCapacity
+}
+
+// CapacitySnapshot 在同一把锁内获取节点与域的容量，保证两者一致
+func (m *Manager) CapacitySnapshot() *CapacitySnapshot {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	snapshot := &CapacitySnapshot{
+		Nodes:   make(map[NodeID]*ResourceCapacity, len(m.nodes)),
+		Domains: make(map[DomainID]*ResourceCapacity, len(m.domains)),
+	}
+	for id, node := range m.nodes {
+		if capacity := nodeCapacity(node); capacity != nil {
+			snapshot.Nodes[id] = capacity.Clone()
+		}
+	}
+	for id, domain := range m.domains {
+		capacity := domain.Capacity.Clone()
+		if capacity == nil {
+			capacity = &ResourceCapacity{}
+		}
+		snapshot.Domains[id] = capacity
+	}
+	return snapshot
+}
@@ -19,4 +19,18 @@ var (
 	ErrHeadNodeOffline = errors.New("head node is offline")
 	// ErrInvalidResourceTags 无效的资源标签
 	ErrInvalidResourceTags = errors.New("invalid resource tags")
+	// ErrInvalidHealthPolicy 无效的健康检查策略
+	ErrInvalidHealthPolicy = errors.New("invalid health policy")
+	// ErrInvalidLabels 无效的标签
+	ErrInvalidLabels = errors.New("invalid labels")
+	// ErrInvalidSelector 无效的标签选择器
+	ErrInvalidSelector = errors.New("invalid label selector")
+	// ErrJoinTokenNotFound 加入令牌不存在
+	ErrJoinTokenNotFound = errors.New("join token not found")
+	// ErrInvalidHierarchy 无效的域层级（上级域不存在、形成环或层级类型无效）
+	ErrInvalidHierarchy = errors.New("invalid domain hierarchy")
+	// ErrDomainHasChildren 域仍有下级域
+	ErrDomainHasChildren = errors.New("domain still has child domains")
+	// ErrInvalidJoinToken 加入令牌无效、已过期或已用尽
+	ErrInvalidJoinToken = errors.New("invalid join token")
 )
@@ -0,0 +1,124 @@
+package registry
+
+import (
+	"fmt"
+	"time"
+)
+
+// EventType 注册中心事件类型
+type EventType string
+
+const (
+	// EventDomainCreated 域已创建
+	EventDomainCreated EventType = "domain.created"
+	// EventDomainDeleted 域已删除
+	EventDomainDeleted EventType = "domain.deleted"
+	// EventHeadNodeLost 域的 head 节点离线、异常或被移除
+	EventHeadNodeLost EventType = "domain.head_node_lost"
+	// EventHeadNodeRecovered 域的 head 节点恢复在线
+	EventHeadNodeRecovered EventType = "domain.head_node_recovered"
+	// EventNodeRegistered 节点已注册
+	EventNodeRegistered EventType = "node.registered"
+	// EventNodeStatusChanged 节点状态变化，Details 中包含 from / to
+	EventNodeStatusChanged EventType = "node.status_changed"
+	// EventNodeRemoved 节点已移除（例如长时间离线被清理）
+	EventNodeRemoved EventType = "node.removed"
+)
+
+// Event 注册中心事件
+type Event struct {
+	Type     EventType         `json:"type"`
+	DomainID DomainID          `json:"domain_id,omitempty"`
+	NodeID   NodeID            `json:"node_id,omitempty"`
+	Message  string            `json:"message"`
+	Details  map[string]string `json:"details,omitempty"`
+	Time     time.Time         `json:"time"`
+}
+
+// SubscribeEvents 注册事件回调
+// 回调在持有管理器锁时同步调用，不能阻塞，也不能再调用管理器的方法
+func (m *Manager) SubscribeEvents(fn func(*Event)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.listeners = append(m.listeners, fn)
+}
+
+// emitUnsafe 通知事件回调，调用者需持有锁
+func (m *Manager) emitUnsafe(event *Event) {
+	if len(m.listeners) == 0 {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	for _, fn := range m.listeners {
+		fn(event)
+	}
+}
+
+// domainNameUnsafe 返回域名称，域不存在时返回 ID
+func (m *Manager) domainNameUnsafe(domainID DomainID) string {
+	if domain, ok := m.domains[domainID]; ok {
+		return domain.Name
+	}
+	return domainID
+}
+
+// nodeStatusChangedUnsafe 节点状态变化时发出事件，head 节点失效与恢复时额外发出域事件
+func (m *Manager) nodeStatusChangedUnsafe(node *Node, prev NodeStatus) {
+	if node.Status == prev {
+		return
+	}
+	m.emitUnsafe(&Event{
+		Type:     EventNodeStatusChanged,
+		DomainID: node.DomainID,
+		NodeID:   node.ID,
+		Message:  fmt.Sprintf("node %s of domain %s changed from %s to %s", node.Name, m.domainNameUnsafe(node.DomainID), prev, node.Status),
+		Details:  map[string]string{"from": string(prev), "to": string(node.Status), "node_name": node.Name},
+	})
+	if !node.IsHead {
+		return
+	}
+
+	wasDown := prev == NodeStatusOffline || prev == NodeStatusError
+	isDown := node.Status == NodeStatusOffline || node.Status == NodeStatusError
+	switch {
+	case isDown && !wasDown:
+		m.emitUnsafe(&Event{
+			Type:     EventHeadNodeLost,
+			DomainID: node.DomainID,
+			NodeID:   node.ID,
+			Message:  fmt.Sprintf("domain %s lost its head node %s (%s)", m.domainNameUnsafe(node.DomainID), node.Name, node.Status),
+			Details:  map[string]string{"status": string(node.Status), "node_name": node.Name},
+		})
+	case wasDown && !isDown:
+		m.emitUnsafe(&Event{
+			Type:     EventHeadNodeRecovered,
+			DomainID: node.DomainID,
+			NodeID:   node.ID,
+			Message:  fmt.Sprintf("head node %s of domain %s recovered (%s)", node.Name, m.domainNameUnsafe(node.DomainID), node.Status),
+			Details:  map[string]string{"status": string(node.Status), "node_name": node.Name},
+		})
+	}
+}
+
+// nodeRemovedUnsafe 节点移除时发出事件，调用者需在从域中移除节点之前调用
+func (m *Manager) nodeRemovedUnsafe(node *Node, reason string) {
+	m.emitUnsafe(&Event{
+		Type:     EventNodeRemoved,
+		DomainID: node.DomainID,
+		NodeID:   node.ID,
+		Message:  fmt.Sprintf("node %s was removed from domain %s: %s", node.Name, m.domainNameUnsafe(node.DomainID), reason),
+		Details:  map[string]string{"reason": reason, "node_name": node.Name},
+	})
+	// 仍在线的 head 节点被移除时域同样失去 head 节点；已离线的 head 节点在离线时已发出事件
+	if node.IsHead && node.IsAlive() {
+		m.emitUnsafe(&Event{
+			Type:     EventHeadNodeLost,
+			DomainID: node.DomainID,
+			NodeID:   node.ID,
+			Message:  fmt.Sprintf("domain %s lost its head node %s (removed)", m.domainNameUnsafe(node.DomainID), node.Name),
+			Details:  map[string]string{"status": "removed", "node_name": node.Name},
+		})
+	}
+}
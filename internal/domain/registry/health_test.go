@@ -0,0 +1,91 @@
+package registry
+
+import (
+	"testing"
+	"time"
+)
+
+// TestCheckNodeTimeoutsDefaultSettings 按默认配置逐个检测周期推进时间：
+// 节点停止心跳后必须先被观察到 suspect，再在策略超时时间附近标记离线
+func TestCheckNodeTimeoutsDefaultSettings(t *testing.T) {
+	policy := DefaultHealthPolicy()
+	interval := policy.RecommendedInterval()
+
+	// 检测周期与心跳的相位不同，检测结果应一致
+	for offset := time.Duration(0); offset < DefaultCheckInterval; offset += time.Second {
+		m := NewManager(ManagerOptions{})
+		if err := m.AddDomain(&Domain{ID: "domain.test", Name: "test"}); err != nil {
+			t.Fatal(err)
+		}
+		start := time.Unix(1_700_000_000, 0)
+		node := &Node{ID: "node.test", DomainID: "domain.test", Name: "n1", Status: NodeStatusOnline, LastSeen: start}
+		if err := m.AddNode(node); err != nil {
+			t.Fatal(err)
+		}
+
+		// 规律心跳 10 次
+		var last time.Time
+		for i := 0; i < 10; i++ {
+			last = start.Add(time.Duration(i) * interval)
+			if err := m.recordHeartbeatAt(node.ID, last); err != nil {
+				t.Fatal(err)
+			}
+			m.nodes[node.ID].LastSeen = last
+		}
+
+		var suspectAt, offlineAt time.Duration
+		for tick := last.Add(offset); offlineAt == 0 && tick.Sub(last) <= 2*policy.Timeout; tick = tick.Add(DefaultCheckInterval) {
+			m.checkNodeTimeouts(tick)
+			switch m.nodes[node.ID].Status {
+			case NodeStatusSuspect:
+				if suspectAt == 0 {
+					suspectAt = tick.Sub(last)
+				}
+			case NodeStatusOffline:
+				offlineAt = tick.Sub(last)
+			}
+		}
+
+		if suspectAt == 0 {
+			t.Fatalf("offset %v: node went offline at %v without a suspect observation", offset, offlineAt)
+		}
+		if offlineAt == 0 {
+			t.Fatalf("offset %v: node never went offline", offset)
+		}
+		if suspectAt <= 2*interval {
+			t.Errorf("offset %v: node suspected after %v, want more than two heartbeat intervals (%v)", offset, suspectAt, 2*interval)
+		}
+		if offlineAt < policy.Timeout-DefaultCheckInterval || offlineAt > policy.Timeout+DefaultCheckInterval {
+			t.Errorf("offset %v: node offline after %v, want within one check interval of the %v timeout", offset, offlineAt, policy.Timeout)
+		}
+	}
+}
+
+// TestCheckNodeTimeoutsRegularHeartbeats 心跳正常的节点不会被怀疑
+func TestCheckNodeTimeoutsRegularHeartbeats(t *testing.T) {
+	m := NewManager(ManagerOptions{})
+	if err := m.AddDomain(&Domain{ID: "domain.test", Name: "test"}); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Unix(1_700_000_000, 0)
+	node := &Node{ID: "node.test", DomainID: "domain.test", Name: "n1", Status: NodeStatusOnline, LastSeen: start}
+	if err := m.AddNode(node); err != nil {
+		t.Fatal(err)
+	}
+
+	interval := DefaultHealthPolicy().RecommendedInterval()
+	next := start
+	for now := start; now.Sub(start) < 30*time.Minute; now = now.Add(DefaultCheckInterval) {
+		for !next.After(now) {
+			if err := m.recordHeartbeatAt(node.ID, next); err != nil {
+				t.Fatal(err)
+			}
+			m.nodes[node.ID].LastSeen = next
+			next = next.Add(interval)
+		}
+		m.checkNodeTimeouts(now)
+		if status := m.nodes[node.ID].Status; status != NodeStatusOnline {
+			t.Fatalf("node became %s after %v despite regular heartbeats", status, now.Sub(start))
+		}
+	}
+}
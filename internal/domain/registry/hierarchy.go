@@ -0,0 +1,294 @@
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// 放置约束按层级分散时的内置层级，其余层级名对应域的层级类型（如 region、site）
+const (
+	// LevelNode 以节点为单位
+	LevelNode = "node"
+	// LevelDomain 以节点直接所属的域为单位
+	LevelDomain = "domain"
+)
+
+// maxDomainDepth 域层级深度上限，避免异常的复制数据形成环时无限遍历
+const maxDomainDepth = 64
+
+// ValidateDomainKind 校验域的层级类型：由小写字母、数字、- 与 _ 组成，不能使用内置层级名
+func ValidateDomainKind(kind string) error {
+	if kind == "" {
+		return nil
+	}
+	if kind == LevelNode || kind == LevelDomain {
+		return fmt.Errorf("%w: kind %q is reserved", ErrInvalidHierarchy, kind)
+	}
+	if len(kind) > 63 {
+		return fmt.Errorf("%w: kind %q is too long", ErrInvalidHierarchy, kind)
+	}
+	for _, c := range kind {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' && c != '_' {
+			return fmt.Errorf("%w: invalid kind %q", ErrInvalidHierarchy, kind)
+		}
+	}
+	return nil
+}
+
+// DomainTreeNode 域层级树的节点
+// 节点数、容量与资源标签汇总了该域及全部下级域
+type DomainTreeNode struct {
+	ID       DomainID `json:"id"`
+	Name     string   `json:"name"`
+	Kind     string   `json:"kind,omitempty"`
+	ParentID DomainID `json:"parent_id,omitempty"`
+	Labels   Labels   `json:"labels,omitempty"`
+	// Nodes 直接属于该域的节点数量
+	Nodes int `json:"nodes"`
+	// TotalNodes 该域及全部下级域的节点数量
+	TotalNodes int `json:"total_nodes"`
+	// OnlineNodes 该域及全部下级域的在线节点数量
+	OnlineNodes int `json:"online_nodes"`
+	// ResourceTags 在线节点支持的资源标签
+	ResourceTags *ResourceTags `json:"resource_tags"`
+	// Capacity 存活节点的资源汇总
+	Capacity *ResourceCapacity `json:"capacity"`
+	Children []*DomainTreeNode `json:"children,omitempty"`
+}
+
+// SetDomainParent 设置域的上级域与层级类型，parentID 为空表示顶层域
+func (m *Manager) SetDomainParent(domainID, parentID DomainID, kind string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	domain, ok := m.domains[domainID]
+	if !ok {
+		return ErrDomainNotFound
+	}
+	if parentID != "" {
+		if _, ok := m.domains[parentID]; !ok {
+			return fmt.Errorf("%w: parent domain %s not found", ErrInvalidHierarchy, parentID)
+		}
+		for _, ancestor := range m.pathUnsafe(parentID) {
+			if ancestor.ID == domainID {
+				return fmt.Errorf("%w: domain %s cannot be placed under its own descendant %s", ErrInvalidHierarchy, domainID, parentID)
+			}
+		}
+	}
+
+	domain.ParentID = parentID
+	domain.Kind = kind
+	domain.UpdatedAt = time.Now()
+	m.notifyCapacityChanged()
+	return nil
+}
+
+// pathUnsafe 返回从该域到顶层域的路径（包含该域本身），调用者需持有锁
+func (m *Manager) pathUnsafe(domainID DomainID) []*Domain {
+	path := make([]*Domain, 0, 4)
+	for id := domainID; id != "" && len(path) < maxDomainDepth; {
+		domain, ok := m.domains[id]
+		if !ok {
+			break
+		}
+		path = append(path, domain)
+		id = domain.ParentID
+	}
+	return path
+}
+
+// DomainPath 返回从该域到顶层域的域 ID 路径（包含该域本身）
+func (m *Manager) DomainPath(domainID DomainID) []DomainID {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	path := m.pathUnsafe(domainID)
+	ids := make([]DomainID, 0, len(path))
+	for _, domain := range path {
+		ids = append(ids, domain.ID)
+	}
+	return ids
+}
+
+// ChildDomains 返回直接下级域的 ID
+func (m *Manager) ChildDomains(domainID DomainID) []DomainID {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	children := make([]DomainID, 0)
+	for id, domain := range m.domains {
+		if domain.ParentID == domainID {
+			children = append(children, id)
+		}
+	}
+	sort.Strings(children)
+	return children
+}
+
+// IsWithin 域是否为 ancestorID 本身或其下级域
+func (m *Manager) IsWithin(domainID, ancestorID DomainID) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, domain := range m.pathUnsafe(domainID) {
+		if domain.ID == ancestorID {
+			return true
+		}
+	}
+	return false
+}
+
+// DomainAtLevel 返回域在指定层级上所属的单元：LevelDomain 为域本身，其他层级为路径上第一个该类型的域
+func (m *Manager) DomainAtLevel(domainID DomainID, level string) (DomainID, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	path := m.pathUnsafe(domainID)
+	if len(path) == 0 {
+		return "", false
+	}
+	if level == LevelDomain {
+		return path[0].ID, true
+	}
+	for _, domain := range path {
+		if domain.Kind == level {
+			return domain.ID, true
+		}
+	}
+	return "", false
+}
+
+// ResolveDomain 按 ID 或名称查找域，名称对应多个域时返回错误
+func (m *Manager) ResolveDomain(ref string) (DomainID, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if _, ok := m.domains[ref]; ok {
+		return ref, nil
+	}
+	var found DomainID
+	for id, domain := range m.domains {
+		if domain.Name != ref {
+			continue
+		}
+		if found != "" {
+			return "", fmt.Errorf("domain name %q is ambiguous, use the domain id", ref)
+		}
+		found = id
+	}
+	if found == "" {
+		return "", fmt.Errorf("%w: %s", ErrDomainNotFound, ref)
+	}
+	return found, nil
+}
+
+// DomainTree 返回域层级树，同级域按名称排序
+// visible 非空时只包含返回 true 的域，不可见域的下级域挂到最近的可见上级域下，汇总也只统计可见域
+func (m *Manager) DomainTree(visible func(DomainID) bool) []*DomainTreeNode {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	items := make(map[DomainID]*DomainTreeNode, len(m.domains))
+	for id, domain := range m.domains {
+		if visible != nil && !visible(id) {
+			continue
+		}
+		items[id] = m.treeNodeUnsafe(domain)
+	}
+
+	roots := make([]*DomainTreeNode, 0)
+	for id, item := range items {
+		var parent *DomainTreeNode
+		for _, ancestor := range m.pathUnsafe(id)[1:] {
+			if p, ok := items[ancestor.ID]; ok {
+				parent = p
+				break
+			}
+		}
+		if parent == nil {
+			item.ParentID = ""
+			roots = append(roots, item)
+			continue
+		}
+		item.ParentID = parent.ID
+		parent.Children = append(parent.Children, item)
+	}
+
+	for _, root := range roots {
+		rollUp(root)
+	}
+	sortTree(roots)
+	return roots
+}
+
+// treeNodeUnsafe 统计直接属于该域的节点，调用者需持有锁
+func (m *Manager) treeNodeUnsafe(domain *Domain) *DomainTreeNode {
+	item := &DomainTreeNode{
+		ID:           domain.ID,
+		Name:         domain.Name,
+		Kind:         domain.Kind,
+		Labels:       domain.Labels.Clone(),
+		ResourceTags: NewEmptyResourceTags(),
+		Capacity: &ResourceCapacity{
+			Total:     &ResourceInfo{},
+			Used:      &ResourceInfo{},
+			Available: &ResourceInfo{},
+		},
+	}
+	for _, nodeID := range domain.NodeIDs {
+		node, ok := m.nodes[nodeID]
+		if !ok {
+			continue
+		}
+		item.Nodes++
+		item.TotalNodes++
+		if node.Status != NodeStatusOnline {
+			continue
+		}
+		item.OnlineNodes++
+		mergeResourceTags(item.ResourceTags, node.ResourceTags)
+	}
+	if capacity := domain.Capacity; capacity != nil {
+		item.Capacity.Total.Add(capacity.Total)
+		item.Capacity.Used.Add(capacity.Used)
+		item.Capacity.Available.Add(capacity.Available)
+	}
+	return item
+}
+
+// rollUp 将下级域的节点数、容量与资源标签累加到上级域
+func rollUp(item *DomainTreeNode) {
+	for _, child := range item.Children {
+		rollUp(child)
+		item.TotalNodes += child.TotalNodes
+		item.OnlineNodes += child.OnlineNodes
+		mergeResourceTags(item.ResourceTags, child.ResourceTags)
+		item.Capacity.Total.Add(child.Capacity.Total)
+		item.Capacity.Used.Add(child.Capacity.Used)
+		item.Capacity.Available.Add(child.Capacity.Available)
+	}
+}
+
+func sortTree(items []*DomainTreeNode) {
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Name != items[j].Name {
+			return items[i].Name < items[j].Name
+		}
+		return items[i].ID < items[j].ID
+	})
+	for _, item := range items {
+		sortTree(item.Children)
+	}
+}
+
+// mergeResourceTags 汇总资源标签（任意一方支持即支持）
+func mergeResourceTags(dst, src *ResourceTags) {
+	if src == nil {
+		return
+	}
+	dst.CPU = dst.CPU || src.CPU
+	dst.GPU = dst.GPU || src.GPU
+	dst.Memory = dst.Memory || src.Memory
+	dst.Camera = dst.Camera || src.Camera
+}
@@ -0,0 +1,179 @@
+package registry
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/intra/repository"
+	"github.com/9triver/iarnet-global/internal/util"
+	"github.com/sirupsen/logrus"
+)
+
+// joinTokenPrefix 加入令牌前缀，便于与项目访问令牌区分
+const joinTokenPrefix = "ijt_"
+
+// JoinToken 节点加入域的令牌，令牌明文只在创建时返回一次
+type JoinToken struct {
+	ID          string    `json:"id" yaml:"id"`
+	DomainID    DomainID  `json:"domain_id" yaml:"domain_id"`
+	Description string    `json:"description,omitempty" yaml:"description,omitempty"`
+	MaxUses     int       `json:"max_uses" yaml:"max_uses"`                         // 最大使用次数，0 表示不限
+	Uses        int       `json:"uses" yaml:"uses"`                                 // 已使用次数
+	ExpiresAt   time.Time `json:"expires_at,omitempty" yaml:"expires_at,omitempty"` // 过期时间，零值表示永不过期
+	CreatedAt   time.Time `json:"created_at" yaml:"created_at"`
+	tokenHash   string
+}
+
+// Usable 令牌在 now 时刻是否仍可使用
+func (t *JoinToken) Usable(now time.Time) bool {
+	if !t.ExpiresAt.IsZero() && !now.Before(t.ExpiresAt) {
+		return false
+	}
+	return t.MaxUses == 0 || t.Uses < t.MaxUses
+}
+
+// CreateJoinToken 为域创建加入令牌，返回令牌记录与明文
+// ttl 为 0 表示永不过期，maxUses 为 0 表示不限次数
+func (s *service) CreateJoinToken(ctx context.Context, domainID DomainID, description string, ttl time.Duration, maxUses int) (*JoinToken, string, error) {
+	if ttl < 0 || maxUses < 0 {
+		return nil, "", fmt.Errorf("%w: ttl and max uses must not be negative", ErrInvalidJoinToken)
+	}
+	if _, err := s.manager.GetDomain(domainID); err != nil {
+		return nil, "", err
+	}
+
+	secret, err := generateJoinToken()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate join token: %w", err)
+	}
+
+	token := &JoinToken{
+		ID:          util.GenIDWith("jointoken."),
+		DomainID:    domainID,
+		Description: description,
+		MaxUses:     maxUses,
+		CreatedAt:   time.Now(),
+		tokenHash:   hashJoinToken(secret),
+	}
+	if ttl > 0 {
+		token.ExpiresAt = token.CreatedAt.Add(ttl)
+	}
+
+	if err := s.domainRepo.CreateJoinToken(ctx, &repository.JoinTokenDAO{
+		ID:          token.ID,
+		DomainID:    token.DomainID,
+		TokenHash:   token.tokenHash,
+		Description: token.Description,
+		MaxUses:     token.MaxUses,
+		ExpiresAt:   token.ExpiresAt,
+		CreatedAt:   token.CreatedAt,
+	}); err != nil {
+		return nil, "", fmt.Errorf("failed to persist join token to repository: %w", err)
+	}
+
+	logrus.Infof("Join token created: id=%s, domain=%s, max_uses=%d, expires_at=%v", token.ID, domainID, maxUses, token.ExpiresAt)
+	return token, secret, nil
+}
+
+// ListJoinTokens 列出加入令牌，domainID 为空时返回所有域的令牌
+func (s *service) ListJoinTokens(ctx context.Context, domainID DomainID) ([]*JoinToken, error) {
+	daos, err := s.domainRepo.GetAllJoinTokens(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load join tokens from repository: %w", err)
+	}
+
+	tokens := make([]*JoinToken, 0, len(daos))
+	for _, dao := range daos {
+		if domainID != "" && dao.DomainID != domainID {
+			continue
+		}
+		tokens = append(tokens, joinTokenFromDAO(dao))
+	}
+	return tokens, nil
+}
+
+// RevokeJoinToken 删除加入令牌
+func (s *service) RevokeJoinToken(ctx context.Context, id string) error {
+	tokens, err := s.ListJoinTokens(ctx, "")
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, token := range tokens {
+		if token.ID == id {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return ErrJoinTokenNotFound
+	}
+
+	if err := s.domainRepo.DeleteJoinToken(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete join token from repository: %w", err)
+	}
+
+	logrus.Infof("Join token revoked: id=%s", id)
+	return nil
+}
+
+// ConsumeJoinToken 校验节点加入域时携带的令牌并记录一次使用
+func (s *service) ConsumeJoinToken(ctx context.Context, domainID DomainID, secret string) error {
+	if secret == "" {
+		return fmt.Errorf("%w: join token is required", ErrInvalidJoinToken)
+	}
+	hash := hashJoinToken(secret)
+
+	s.joinMu.Lock()
+	defer s.joinMu.Unlock()
+
+	tokens, err := s.ListJoinTokens(ctx, domainID)
+	if err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		if token.tokenHash != hash {
+			continue
+		}
+		if !token.Usable(time.Now()) {
+			return fmt.Errorf("%w: token %s has expired or reached its usage limit", ErrInvalidJoinToken, token.ID)
+		}
+		if err := s.domainRepo.IncrementJoinTokenUses(ctx, token.ID); err != nil {
+			return err
+		}
+		return nil
+	}
+	return ErrInvalidJoinToken
+}
+
+func joinTokenFromDAO(dao *repository.JoinTokenDAO) *JoinToken {
+	return &JoinToken{
+		ID:          dao.ID,
+		DomainID:    dao.DomainID,
+		Description: dao.Description,
+		MaxUses:     dao.MaxUses,
+		Uses:        dao.Uses,
+		ExpiresAt:   dao.ExpiresAt,
+		CreatedAt:   dao.CreatedAt,
+		tokenHash:   dao.TokenHash,
+	}
+}
+
+// generateJoinToken 生成加入令牌明文
+func generateJoinToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return joinTokenPrefix + hex.EncodeToString(buf), nil
+}
+
+// hashJoinToken 计算加入令牌摘要，仓库中只保存摘要
+func hashJoinToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
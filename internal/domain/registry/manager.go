@@ -2,6 +2,7 @@ package registry
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
@@ -15,19 +16,64 @@ type Manager struct {
 	domains         map[DomainID]*Domain
 	nodes           map[NodeID]*Node
 	healthCheckStop chan struct{} // 用于停止健康检查超时监控
-	timeoutDuration time.Duration // 节点超时时间（默认 90 秒）
-	cleanupDuration time.Duration // 节点清理时间（默认 180 秒，即超时时间的2倍）
+	policyChanged   chan struct{} // 检测周期变化时通知监控 goroutine 重置 ticker
+	defaultPolicy   HealthPolicy  // 全局默认健康检查策略（域可单独覆盖）
+	checkInterval   time.Duration // 超时检测周期
+	detectorOpts    FailureDetectorOptions
+	detectors       map[NodeID]*phiAccrualDetector // 每个节点的心跳间隔历史
+	nodeAdminLabels map[NodeID]Labels              // 管理员为节点设置的标签（节点重新注册后仍保留）
+	cordonedNodes   map[NodeID]struct{}            // 管理员标记为不可调度的节点（节点重新注册后仍保留）
+	capacityChanged chan struct{}                  // 节点加入、可用资源/状态/标签变化时通知（合并通知，不阻塞）
+	isLeader        func() bool                    // HA 模式下判断本副本是否为 leader（为空表示单副本）
+	listeners       []func(*Event)                 // 事件回调，在持有锁时同步调用
+}
+
+// ManagerOptions 管理器选项
+type ManagerOptions struct {
+	// HealthPolicy 全局默认健康检查策略，零值字段使用内置默认值
+	HealthPolicy HealthPolicy
+	// CheckInterval 超时检测周期，为 0 时使用 DefaultCheckInterval
+	CheckInterval time.Duration
+	// FailureDetector phi-accrual 故障检测器参数，零值字段使用默认值
+	FailureDetector FailureDetectorOptions
+	// IsLeader HA 模式下判断本副本是否为 leader，只有 leader 执行节点超时检测与清理
+	// 为空时视为单副本部署
+	IsLeader func() bool
 }
 
 // NewManager 创建新的管理器
-func NewManager() *Manager {
-	timeoutDuration := 30 * time.Second // 默认 30 秒超时（便于调试，生产环境建议 90 秒）
+func NewManager(opts ManagerOptions) *Manager {
+	checkInterval := opts.CheckInterval
+	if checkInterval <= 0 {
+		checkInterval = DefaultCheckInterval
+	}
 	return &Manager{
 		domains:         make(map[DomainID]*Domain),
 		nodes:           make(map[NodeID]*Node),
 		healthCheckStop: make(chan struct{}),
-		timeoutDuration: timeoutDuration,
-		cleanupDuration: timeoutDuration * 2, // 清理时间 = 超时时间的2倍（节点离线后60秒才删除）
+		policyChanged:   make(chan struct{}, 1),
+		defaultPolicy:   DefaultHealthPolicy().Merge(&opts.HealthPolicy),
+		checkInterval:   checkInterval,
+		detectorOpts:    opts.FailureDetector.withDefaults(),
+		detectors:       make(map[NodeID]*phiAccrualDetector),
+		nodeAdminLabels: make(map[NodeID]Labels),
+		cordonedNodes:   make(map[NodeID]struct{}),
+		capacityChanged: make(chan struct{}, 1),
+		isLeader:        opts.IsLeader,
+	}
+}
+
+// CapacityChanged 返回可调度容量变化的通知通道
+// 连续的多次变化会被合并为一次通知，消费者收到通知后应重新读取最新状态
+func (m *Manager) CapacityChanged() <-chan struct{} {
+	return m.capacityChanged
+}
+
+// notifyCapacityChanged 发送容量变化通知（不阻塞）
+func (m *Manager) notifyCapacityChanged() {
+	select {
+	case m.capacityChanged <- struct{}{}:
+	default:
 	}
 }
 
@@ -65,6 +111,11 @@ func (m *Manager) AddDomain(domain *Domain) error {
 	}
 
 	m.domains[domain.ID] = domain
+	m.emitUnsafe(&Event{
+		Type:     EventDomainCreated,
+		DomainID: domain.ID,
+		Message:  fmt.Sprintf("domain %s was created", domain.Name),
+	})
 	logrus.Infof("Domain added: id=%s, name=%s", domain.ID, domain.Name)
 	return nil
 }
@@ -82,9 +133,15 @@ func (m *Manager) RemoveDomain(domainID DomainID) error {
 	// 移除域下的所有节点
 	for _, nodeID := range domain.NodeIDs {
 		delete(m.nodes, nodeID)
+		delete(m.detectors, nodeID)
 	}
 
 	delete(m.domains, domainID)
+	m.emitUnsafe(&Event{
+		Type:     EventDomainDeleted,
+		DomainID: domainID,
+		Message:  fmt.Sprintf("domain %s was deleted", domain.Name),
+	})
 	logrus.Infof("Domain removed: id=%s, name=%s", domainID, domain.Name)
 	return nil
 }
@@ -149,6 +206,12 @@ func (m *Manager) AddNode(node *Node) error {
 		return ErrDomainNotFound
 	}
 
+	// 应用管理员为该节点设置的标签
+	if labels, ok := m.nodeAdminLabels[node.ID]; ok {
+		node.AdminLabels = labels.Clone()
+	}
+	_, node.Cordoned = m.cordonedNodes[node.ID]
+
 	// 添加节点到管理器
 	m.nodes[node.ID] = node
 
@@ -165,8 +228,18 @@ func (m *Manager) AddNode(node *Node) error {
 		}
 	}
 
-	// 更新域的资源标签
+	// 更新域的资源标签与汇总容量
 	m.updateDomainResourceTags(domain)
+	applyDomainCapacityUnsafe(domain, nil, nodeCapacity(node))
+
+	m.notifyCapacityChanged()
+	m.emitUnsafe(&Event{
+		Type:     EventNodeRegistered,
+		DomainID: node.DomainID,
+		NodeID:   node.ID,
+		Message:  fmt.Sprintf("node %s joined domain %s", node.Name, domain.Name),
+		Details:  map[string]string{"node_name": node.Name, "status": string(node.Status)},
+	})
 
 	logrus.Infof("Node added: id=%s, name=%s, domain=%s, isHead=%v", node.ID, node.Name, node.DomainID, node.IsHead)
 	return nil
@@ -182,14 +255,31 @@ func (m *Manager) UpdateNode(nodeID NodeID, updateFn func(*Node)) error {
 		return ErrNodeNotFound
 	}
 
+	prevStatus := node.Status
+	prevAvailable := availableResources(node)
+	prevReachable := node.IsReachable()
+	prevDomainID := node.DomainID
+	// 更新函数可能原地修改容量，先保留一份用于扣除
+	prevCapacity := nodeCapacity(node).Clone()
 	updateFn(node)
 	node.UpdatedAt = time.Now()
 
-	// 更新域的资源标签
+	if prevDomain, ok := m.domains[prevDomainID]; ok {
+		applyDomainCapacityUnsafe(prevDomain, prevCapacity, nil)
+	}
+
+	// 更新域的资源标签与汇总容量
 	domain, ok := m.domains[node.DomainID]
 	if ok {
 		m.updateDomainResourceTags(domain)
+		applyDomainCapacityUnsafe(domain, nil, nodeCapacity(node))
+	}
+
+	// 地址可达性变化同样改变可调度的容量（例如探测恢复后排队的部署可以重试）
+	if node.Status != prevStatus || node.IsReachable() != prevReachable || !availableResources(node).Equal(prevAvailable) {
+		m.notifyCapacityChanged()
 	}
+	m.nodeStatusChangedUnsafe(node, prevStatus)
 
 	logrus.Debugf("Node updated: id=%s", nodeID)
 	return nil
@@ -205,13 +295,16 @@ func (m *Manager) RemoveNode(nodeID NodeID) error {
 		return ErrNodeNotFound
 	}
 
+	m.nodeRemovedUnsafe(node, "removed")
 	domain, ok := m.domains[node.DomainID]
 	if ok {
 		domain.RemoveNode(nodeID)
 		m.updateDomainResourceTags(domain)
+		applyDomainCapacityUnsafe(domain, nodeCapacity(node), nil)
 	}
 
 	delete(m.nodes, nodeID)
+	delete(m.detectors, nodeID)
 	logrus.Infof("Node removed: id=%s, name=%s", nodeID, node.Name)
 	return nil
 }
@@ -224,6 +317,93 @@ func (m *Manager) UpdateNodeStatus(nodeID NodeID, status NodeStatus) error {
 	})
 }
 
+// RecordHeartbeat 记录节点心跳到达，用于更新 phi-accrual 故障检测器的间隔历史
+func (m *Manager) RecordHeartbeat(nodeID NodeID) error {
+	return m.recordHeartbeatAt(nodeID, time.Now())
+}
+
+func (m *Manager) recordHeartbeatAt(nodeID NodeID, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	node, ok := m.nodes[nodeID]
+	if !ok {
+		return ErrNodeNotFound
+	}
+
+	detector, ok := m.detectors[nodeID]
+	if !ok {
+		// 以域的建议心跳间隔作为初始估计
+		estimate := m.healthPolicyUnsafe(node.DomainID).RecommendedInterval()
+		detector = newPhiAccrualDetector(m.detectorOpts, estimate)
+		m.detectors[nodeID] = detector
+	}
+	detector.heartbeat(at)
+	node.Suspicion = 0
+	return nil
+}
+
+// SetDomainLabels 替换域的键值标签
+func (m *Manager) SetDomainLabels(domainID DomainID, labels Labels) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	domain, ok := m.domains[domainID]
+	if !ok {
+		return ErrDomainNotFound
+	}
+	domain.Labels = labels.Clone()
+	domain.UpdatedAt = time.Now()
+	m.notifyCapacityChanged()
+	return nil
+}
+
+// SetNodeAdminLabels 替换管理员为节点设置的标签
+// 节点当前不在线时也会保存，待节点注册后生效
+func (m *Manager) SetNodeAdminLabels(nodeID NodeID, labels Labels) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(labels) == 0 {
+		delete(m.nodeAdminLabels, nodeID)
+	} else {
+		m.nodeAdminLabels[nodeID] = labels.Clone()
+	}
+
+	if node, ok := m.nodes[nodeID]; ok {
+		node.AdminLabels = labels.Clone()
+		node.UpdatedAt = time.Now()
+		m.notifyCapacityChanged()
+	}
+}
+
+// SetNodeCordoned 标记或取消标记节点为不可调度
+// 节点当前不在线时也会保存，待节点注册后生效
+func (m *Manager) SetNodeCordoned(nodeID NodeID, cordoned bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if cordoned {
+		m.cordonedNodes[nodeID] = struct{}{}
+	} else {
+		delete(m.cordonedNodes, nodeID)
+	}
+
+	if node, ok := m.nodes[nodeID]; ok && node.Cordoned != cordoned {
+		node.Cordoned = cordoned
+		node.UpdatedAt = time.Now()
+		m.notifyCapacityChanged()
+	}
+}
+
+// availableResources 返回节点当前可用资源的副本
+func availableResources(node *Node) *ResourceInfo {
+	if node.ResourceCapacity == nil {
+		return nil
+	}
+	return node.ResourceCapacity.Available.Clone()
+}
+
 // GetNodeStatus 获取节点状态（用于 Domain.GetOnlineNodeCount）
 func (m *Manager) GetNodeStatus(nodeID NodeID) NodeStatus {
 	m.mu.RLock()
@@ -287,6 +467,74 @@ func (m *Manager) updateDomainResourceTags(domain *Domain) {
 	domain.UpdatedAt = time.Now()
 }
 
+// GetDefaultHealthPolicy 获取全局默认健康检查策略
+func (m *Manager) GetDefaultHealthPolicy() HealthPolicy {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.defaultPolicy
+}
+
+// SetDefaultHealthPolicy 更新全局默认健康检查策略，下一次超时检测即生效
+func (m *Manager) SetDefaultHealthPolicy(policy HealthPolicy) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.defaultPolicy = m.defaultPolicy.Merge(&policy)
+	logrus.Infof("Default health policy updated: timeout=%v, cleanup_factor=%.2f",
+		m.defaultPolicy.Timeout, m.defaultPolicy.CleanupFactor)
+}
+
+// GetCheckInterval 获取超时检测周期
+func (m *Manager) GetCheckInterval() time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.checkInterval
+}
+
+// SetCheckInterval 更新超时检测周期，并通知监控 goroutine 重置 ticker
+func (m *Manager) SetCheckInterval(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	m.mu.Lock()
+	m.checkInterval = interval
+	m.mu.Unlock()
+
+	select {
+	case m.policyChanged <- struct{}{}:
+	default:
+	}
+	logrus.Infof("Health check interval updated: %v", interval)
+}
+
+// SetDomainHealthPolicy 设置域级健康检查策略覆盖，传入 nil 表示恢复为全局默认策略
+func (m *Manager) SetDomainHealthPolicy(domainID DomainID, policy *HealthPolicy) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	domain, ok := m.domains[domainID]
+	if !ok {
+		return ErrDomainNotFound
+	}
+	domain.HealthPolicy = policy.Clone()
+	domain.UpdatedAt = time.Now()
+	return nil
+}
+
+// GetHealthPolicy 获取域的生效健康检查策略（域覆盖合并全局默认）
+func (m *Manager) GetHealthPolicy(domainID DomainID) HealthPolicy {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.healthPolicyUnsafe(domainID)
+}
+
+// healthPolicyUnsafe 获取域的生效健康检查策略（不加锁版本，调用者需确保已持有锁）
+func (m *Manager) healthPolicyUnsafe(domainID DomainID) HealthPolicy {
+	if domain, ok := m.domains[domainID]; ok {
+		return m.defaultPolicy.Merge(domain.HealthPolicy)
+	}
+	return m.defaultPolicy
+}
+
 // Start 启动管理器（启动节点超时检测）
 func (m *Manager) Start(ctx context.Context) error {
 	logrus.Info("Registry manager started")
@@ -300,13 +548,15 @@ func (m *Manager) Start(ctx context.Context) error {
 // startHealthCheckTimeoutMonitor 启动健康检查超时监控
 // 定期检查所有节点的 LastSeen 时间，如果超过超时时间，标记为离线
 func (m *Manager) startHealthCheckTimeoutMonitor(ctx context.Context) {
-	ticker := time.NewTicker(10 * time.Second) // 每 10 秒检查一次
+	ticker := time.NewTicker(m.GetCheckInterval())
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ticker.C:
-			m.checkNodeTimeouts()
+			m.checkNodeTimeouts(time.Now())
+		case <-m.policyChanged:
+			ticker.Reset(m.GetCheckInterval())
 		case <-m.healthCheckStop:
 			logrus.Info("Health check timeout monitor stopped")
 			return
@@ -318,21 +568,28 @@ func (m *Manager) startHealthCheckTimeoutMonitor(ctx context.Context) {
 }
 
 // checkNodeTimeouts 检查所有节点的超时状态，并清理长时间离线的节点
-func (m *Manager) checkNodeTimeouts() {
+func (m *Manager) checkNodeTimeouts(now time.Time) {
+	// follower 的节点状态由 leader 复制，不在本地判定超时
+	if m.isLeader != nil && !m.isLeader() {
+		return
+	}
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	now := time.Now()
 	timeoutCount := 0
+	suspectCount := 0
 	cleanupCount := 0
 	nodesToRemove := make([]NodeID, 0)
 
 	for nodeID, node := range m.nodes {
+		policy := m.healthPolicyUnsafe(node.DomainID)
+
 		// 检查是否应该清理（节点离线超过清理时间）
 		if node.Status == NodeStatusOffline || node.Status == NodeStatusError {
 			// 计算节点离线时长（从 LastSeen 开始计算）
 			offlineDuration := now.Sub(node.LastSeen)
-			if offlineDuration > m.cleanupDuration {
+			if offlineDuration > policy.CleanupDuration() {
 				// 标记为待删除
 				nodesToRemove = append(nodesToRemove, nodeID)
 				cleanupCount++
@@ -342,21 +599,44 @@ func (m *Manager) checkNodeTimeouts() {
 			}
 		}
 
-		// 检查在线节点是否超时
-		if node.Status == NodeStatusOnline {
-			// 检查是否超时
-			if now.Sub(node.LastSeen) > m.timeoutDuration {
-				// 标记为离线
+		// 检查在线/疑似失效节点的怀疑度与超时
+		if node.IsAlive() {
+			if detector, ok := m.detectors[nodeID]; ok {
+				node.Suspicion = detector.phi(now)
+			}
+			timedOut := now.Sub(node.LastSeen) > policy.Timeout
+
+			// 在线节点 phi 超过怀疑阈值或超过硬超时时间，先进入 suspect 状态
+			// 至少经过一次 suspect 观察后才标记离线，避免检测周期较长时节点从在线直接变为离线
+			if node.Status == NodeStatusOnline {
+				if timedOut || node.Suspicion >= m.detectorOpts.SuspectThreshold {
+					node.Status = NodeStatusSuspect
+					node.UpdatedAt = now
+					suspectCount++
+					m.nodeStatusChangedUnsafe(node, NodeStatusOnline)
+
+					logrus.Warnf("Node %s (domain: %s) marked as suspect (last seen: %v, phi: %.2f)",
+						nodeID, node.DomainID, node.LastSeen, node.Suspicion)
+				}
+				continue
+			}
+
+			// suspect 节点超过硬超时时间或 phi 超过离线阈值，标记为离线
+			if timedOut || node.Suspicion >= m.detectorOpts.OfflineThreshold {
+				prevCapacity := nodeCapacity(node)
+				prevStatus := node.Status
 				node.Status = NodeStatusOffline
 				node.UpdatedAt = now
 				timeoutCount++
+				m.nodeStatusChangedUnsafe(node, prevStatus)
 
-				logrus.Warnf("Node %s (domain: %s) marked as offline due to timeout (last seen: %v)",
-					nodeID, node.DomainID, node.LastSeen)
+				logrus.Warnf("Node %s (domain: %s) marked as offline due to timeout (last seen: %v, phi: %.2f)",
+					nodeID, node.DomainID, node.LastSeen, node.Suspicion)
 
-				// 更新域的资源标签
+				// 更新域的资源标签与汇总容量
 				if domain, ok := m.domains[node.DomainID]; ok {
 					m.updateDomainResourceTagsUnsafe(domain)
+					applyDomainCapacityUnsafe(domain, prevCapacity, nil)
 				}
 			}
 		}
@@ -369,6 +649,9 @@ func (m *Manager) checkNodeTimeouts() {
 		}
 	}
 
+	if suspectCount > 0 {
+		logrus.Debugf("Marked %d node(s) as suspect", suspectCount)
+	}
 	if timeoutCount > 0 {
 		logrus.Debugf("Marked %d node(s) as offline due to timeout", timeoutCount)
 	}
@@ -384,13 +667,16 @@ func (m *Manager) removeNodeUnsafe(nodeID NodeID) error {
 		return ErrNodeNotFound
 	}
 
+	m.nodeRemovedUnsafe(node, "offline for too long")
 	domain, ok := m.domains[node.DomainID]
 	if ok {
 		domain.RemoveNode(nodeID)
 		m.updateDomainResourceTagsUnsafe(domain)
+		applyDomainCapacityUnsafe(domain, nodeCapacity(node), nil)
 	}
 
 	delete(m.nodes, nodeID)
+	delete(m.detectors, nodeID)
 	logrus.Infof("Node removed: id=%s, name=%s, domain=%s", nodeID, node.Name, node.DomainID)
 	return nil
 }
@@ -0,0 +1,156 @@
+package registry
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// DefaultSuspectThreshold 默认怀疑阈值，phi 超过该值时节点进入 suspect 状态
+	DefaultSuspectThreshold = 3.0
+	// DefaultOfflineThreshold 默认离线阈值，phi 超过该值时节点标记为离线
+	DefaultOfflineThreshold = 8.0
+	// DefaultPhiWindowSize 默认保留的心跳间隔样本数
+	DefaultPhiWindowSize = 100
+	// DefaultMinStdDeviationRatio 未配置最小标准差时取心跳间隔的该比例，避免心跳过于规律时 phi 对轻微抖动过度敏感
+	DefaultMinStdDeviationRatio = 0.25
+	// DefaultAcceptablePauseRatio 未配置可容忍停顿时取心跳间隔的该比例（跨域 WAN 链路抖动）
+	DefaultAcceptablePauseRatio = 0.5
+
+	// maxPhi phi 的上限，超过该值已可视为确定失效（同时避免出现 +Inf）
+	maxPhi = 100.0
+)
+
+// FailureDetectorOptions phi-accrual 故障检测器参数
+type FailureDetectorOptions struct {
+	// SuspectThreshold phi 超过该值时节点进入 suspect 状态
+	SuspectThreshold float64
+	// OfflineThreshold phi 超过该值时节点标记为离线
+	OfflineThreshold float64
+	// WindowSize 保留的心跳间隔样本数
+	WindowSize int
+	// MinStdDeviation 计算时使用的最小标准差，为 0 时按心跳间隔缩放
+	MinStdDeviation time.Duration
+	// AcceptablePause 可容忍的额外停顿，计算时叠加到平均间隔上，为 0 时按心跳间隔缩放，小于 0 表示不容忍
+	AcceptablePause time.Duration
+}
+
+// withDefaults 为零值字段填充默认值
+func (o FailureDetectorOptions) withDefaults() FailureDetectorOptions {
+	if o.SuspectThreshold <= 0 {
+		o.SuspectThreshold = DefaultSuspectThreshold
+	}
+	if o.OfflineThreshold <= 0 {
+		o.OfflineThreshold = DefaultOfflineThreshold
+	}
+	if o.OfflineThreshold < o.SuspectThreshold {
+		o.OfflineThreshold = o.SuspectThreshold
+	}
+	if o.WindowSize <= 0 {
+		o.WindowSize = DefaultPhiWindowSize
+	}
+	return o
+}
+
+// scaledTo 按心跳间隔填充未配置的最小标准差与可容忍停顿
+// 固定值在心跳间隔较长时过小，会使 phi 在一个检测周期内从怀疑阈值以下直接越过离线阈值
+func (o FailureDetectorOptions) scaledTo(interval time.Duration) FailureDetectorOptions {
+	if o.MinStdDeviation <= 0 {
+		o.MinStdDeviation = time.Duration(float64(interval) * DefaultMinStdDeviationRatio)
+	}
+	if o.AcceptablePause < 0 {
+		o.AcceptablePause = 0
+	} else if o.AcceptablePause == 0 {
+		o.AcceptablePause = time.Duration(float64(interval) * DefaultAcceptablePauseRatio)
+	}
+	return o
+}
+
+// phiAccrualDetector 单个节点的 phi-accrual 故障检测器
+// 参考 Hayashibara 等人的 φ Accrual Failure Detector，使用正态分布近似心跳间隔
+// 非线程安全，由 Manager 在持锁状态下访问
+type phiAccrualDetector struct {
+	opts          FailureDetectorOptions
+	intervals     []float64 // 心跳间隔样本（毫秒），环形缓冲区
+	next          int       // 下一个写入位置
+	sum           float64
+	squaredSum    float64
+	lastHeartbeat time.Time
+}
+
+// newPhiAccrualDetector 创建故障检测器
+// firstHeartbeatEstimate 用于在样本不足时给出初始的心跳间隔估计
+func newPhiAccrualDetector(opts FailureDetectorOptions, firstHeartbeatEstimate time.Duration) *phiAccrualDetector {
+	d := &phiAccrualDetector{
+		opts:      opts.scaledTo(firstHeartbeatEstimate),
+		intervals: make([]float64, 0, opts.WindowSize),
+	}
+
+	// 使用估计值预置两个样本（均值 = 估计值，标准差 = 估计值 / 4）
+	mean := float64(firstHeartbeatEstimate.Milliseconds())
+	stdDeviation := mean / 4
+	d.addInterval(mean - stdDeviation)
+	d.addInterval(mean + stdDeviation)
+	return d
+}
+
+// heartbeat 记录一次心跳
+func (d *phiAccrualDetector) heartbeat(at time.Time) {
+	if !d.lastHeartbeat.IsZero() {
+		interval := at.Sub(d.lastHeartbeat)
+		if interval > 0 {
+			d.addInterval(float64(interval.Milliseconds()))
+		}
+	}
+	d.lastHeartbeat = at
+}
+
+func (d *phiAccrualDetector) addInterval(interval float64) {
+	if len(d.intervals) < d.opts.WindowSize {
+		d.intervals = append(d.intervals, interval)
+	} else {
+		evicted := d.intervals[d.next]
+		d.sum -= evicted
+		d.squaredSum -= evicted * evicted
+		d.intervals[d.next] = interval
+		d.next = (d.next + 1) % d.opts.WindowSize
+	}
+	d.sum += interval
+	d.squaredSum += interval * interval
+}
+
+// phi 计算当前怀疑度，值越大表示节点越可能已经失效
+func (d *phiAccrualDetector) phi(now time.Time) float64 {
+	if d.lastHeartbeat.IsZero() || len(d.intervals) == 0 {
+		return 0
+	}
+
+	n := float64(len(d.intervals))
+	mean := d.sum / n
+	variance := d.squaredSum/n - mean*mean
+	stdDeviation := math.Sqrt(math.Max(variance, 0))
+	minStdDeviation := float64(d.opts.MinStdDeviation.Milliseconds())
+	if stdDeviation < minStdDeviation {
+		stdDeviation = minStdDeviation
+	}
+	mean += float64(d.opts.AcceptablePause.Milliseconds())
+
+	elapsed := float64(now.Sub(d.lastHeartbeat).Milliseconds())
+	return phiOf(elapsed, mean, stdDeviation)
+}
+
+// phiOf 使用 logistic 近似正态分布累积函数计算 phi
+func phiOf(elapsed, mean, stdDeviation float64) float64 {
+	y := (elapsed - mean) / stdDeviation
+	e := math.Exp(-y * (1.5976 + 0.070566*y*y))
+	var phi float64
+	if elapsed > mean {
+		phi = -math.Log10(e / (1.0 + e))
+	} else {
+		phi = -math.Log10(1.0 - 1.0/(1.0+e))
+	}
+	if math.IsNaN(phi) || phi > maxPhi {
+		return maxPhi
+	}
+	return math.Max(phi, 0)
+}
@@ -0,0 +1,82 @@
+package registry
+
+import "time"
+
+const (
+	// DefaultNodeTimeout 默认节点超时时间
+	DefaultNodeTimeout = 90 * time.Second
+	// DefaultCleanupFactor 默认清理倍数（清理时间 = 超时时间 × 倍数）
+	DefaultCleanupFactor = 2.0
+	// DefaultCheckInterval 默认超时检测周期
+	DefaultCheckInterval = 10 * time.Second
+
+	// heartbeatsPerTimeout 一个超时窗口内期望收到的心跳次数，用于推导建议心跳间隔
+	heartbeatsPerTimeout = 3
+)
+
+// HealthPolicy 节点健康检查策略
+// 零值字段表示继承上一级（全局默认）策略
+type HealthPolicy struct {
+	// Timeout 节点超过该时间未上报心跳即标记为离线
+	Timeout time.Duration `json:"timeout" yaml:"timeout"`
+	// CleanupFactor 离线节点在 Timeout × CleanupFactor 后被清理
+	CleanupFactor float64 `json:"cleanup_factor" yaml:"cleanup_factor"`
+}
+
+// DefaultHealthPolicy 返回内置的默认健康检查策略
+func DefaultHealthPolicy() HealthPolicy {
+	return HealthPolicy{
+		Timeout:       DefaultNodeTimeout,
+		CleanupFactor: DefaultCleanupFactor,
+	}
+}
+
+// Merge 用 override 中的非零字段覆盖当前策略，返回新的策略
+func (p HealthPolicy) Merge(override *HealthPolicy) HealthPolicy {
+	if override == nil {
+		return p
+	}
+	merged := p
+	if override.Timeout > 0 {
+		merged.Timeout = override.Timeout
+	}
+	if override.CleanupFactor > 0 {
+		merged.CleanupFactor = override.CleanupFactor
+	}
+	return merged
+}
+
+// CleanupDuration 节点离线后被清理的时间
+func (p HealthPolicy) CleanupDuration() time.Duration {
+	return time.Duration(float64(p.Timeout) * p.CleanupFactor)
+}
+
+// RecommendedInterval 建议节点使用的心跳间隔
+// 保证一个超时窗口内至少能收到 heartbeatsPerTimeout 次心跳
+func (p HealthPolicy) RecommendedInterval() time.Duration {
+	interval := (p.Timeout / heartbeatsPerTimeout).Truncate(time.Second)
+	if interval < time.Second {
+		interval = time.Second
+	}
+	return interval
+}
+
+// Validate 校验策略取值（零值表示继承，允许出现）
+func (p HealthPolicy) Validate() error {
+	if p.Timeout < 0 || (p.Timeout > 0 && p.Timeout < time.Second) {
+		return ErrInvalidHealthPolicy
+	}
+	if p.CleanupFactor < 0 || (p.CleanupFactor > 0 && p.CleanupFactor < 1) {
+		return ErrInvalidHealthPolicy
+	}
+	return nil
+}
+
+// Clone 深拷贝 HealthPolicy
+func (p *HealthPolicy) Clone() *HealthPolicy {
+	if p == nil {
+		return nil
+	}
+	copy := *p
+	return &copy
+}
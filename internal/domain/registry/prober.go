@@ -0,0 +1,244 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// ProbeMode 主动探测方式
+type ProbeMode string
+
+const (
+	// ProbeModeGRPC 使用 gRPC 健康检查协议探测，节点未实现时回退到 TCP 连接
+	ProbeModeGRPC ProbeMode = "grpc"
+	// ProbeModeTCP 仅探测 TCP 端口是否可连接
+	ProbeModeTCP ProbeMode = "tcp"
+)
+
+// ProberOptions 主动探测器选项
+type ProberOptions struct {
+	// Mode 探测方式，默认 ProbeModeGRPC
+	Mode ProbeMode
+	// Interval 探测周期
+	Interval time.Duration
+	// Timeout 单次探测超时时间
+	Timeout time.Duration
+	// FailureThreshold 连续失败多少次后标记为不可达
+	FailureThreshold int
+	// MaxConcurrency 同时进行的最大探测数
+	MaxConcurrency int
+	// IsLeader HA 模式下判断本副本是否为 leader，只有 leader 执行探测（为空时视为单副本）
+	IsLeader func() bool
+}
+
+// ProbeStatus 节点地址的可达性探测结果
+type ProbeStatus struct {
+	// Reachable 全局调度器能否访问节点地址
+	Reachable bool `json:"reachable" yaml:"reachable"`
+	// RTT 最近一次成功探测的往返时延
+	RTT time.Duration `json:"rtt" yaml:"rtt"`
+	// ConsecutiveFailures 连续探测失败次数
+	ConsecutiveFailures int `json:"consecutive_failures" yaml:"consecutive_failures"`
+	// LastError 最近一次探测失败的原因
+	LastError string `json:"last_error,omitempty" yaml:"last_error,omitempty"`
+	// LastProbe 最近一次探测时间
+	LastProbe time.Time `json:"last_probe" yaml:"last_probe"`
+}
+
+// Clone 深拷贝 ProbeStatus
+func (ps *ProbeStatus) Clone() *ProbeStatus {
+	if ps == nil {
+		return nil
+	}
+	copy := *ps
+	return &copy
+}
+
+// Prober 节点地址主动探测器
+// 定期拨测每个存活节点上报的地址，记录可达性与 RTT，供调度时排除不可达节点
+type Prober struct {
+	manager  *Manager
+	opts     ProberOptions
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+// NewProber 创建主动探测器
+func NewProber(manager *Manager, opts ProberOptions) *Prober {
+	if opts.Mode == "" {
+		opts.Mode = ProbeModeGRPC
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = 15 * time.Second
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 3 * time.Second
+	}
+	if opts.FailureThreshold <= 0 {
+		opts.FailureThreshold = 2
+	}
+	if opts.MaxConcurrency <= 0 {
+		opts.MaxConcurrency = 16
+	}
+	return &Prober{
+		manager: manager,
+		opts:    opts,
+		stopCh:  make(chan struct{}),
+	}
+}
+
+// Start 启动探测循环
+func (p *Prober) Start(ctx context.Context) {
+	logrus.Infof("Node prober started: mode=%s, interval=%v, timeout=%v", p.opts.Mode, p.opts.Interval, p.opts.Timeout)
+	go p.run(ctx)
+}
+
+// Stop 停止探测循环
+func (p *Prober) Stop() {
+	p.stopOnce.Do(func() {
+		close(p.stopCh)
+		logrus.Info("Node prober stopped")
+	})
+}
+
+func (p *Prober) run(ctx context.Context) {
+	ticker := time.NewTicker(p.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.probeAll(ctx)
+		case <-p.stopCh:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// probeAll 并发探测所有存活且已上报地址的节点
+func (p *Prober) probeAll(ctx context.Context) {
+	if p.opts.IsLeader != nil && !p.opts.IsLeader() {
+		return
+	}
+	targets := make([]*Node, 0)
+	for _, domain := range p.manager.GetAllDomains() {
+		nodes, err := p.manager.GetNodesByDomain(domain.ID)
+		if err != nil {
+			continue
+		}
+		for _, node := range nodes {
+			if node.IsAlive() && node.Address != "" {
+				targets = append(targets, node.Clone())
+			}
+		}
+	}
+
+	sem := make(chan struct{}, p.opts.MaxConcurrency)
+	var wg sync.WaitGroup
+	for _, node := range targets {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(node *Node) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			rtt, err := p.probe(ctx, node.Address)
+			p.record(node, rtt, err)
+		}(node)
+	}
+	wg.Wait()
+}
+
+// probe 探测单个地址，返回往返时延
+func (p *Prober) probe(ctx context.Context, address string) (time.Duration, error) {
+	if p.opts.Mode == ProbeModeTCP {
+		return p.probeTCP(ctx, address)
+	}
+
+	rtt, err := p.probeGRPC(ctx, address)
+	if status.Code(err) == codes.Unimplemented {
+		// 节点未注册 gRPC 健康检查服务，回退到 TCP 连接探测
+		return p.probeTCP(ctx, address)
+	}
+	return rtt, err
+}
+
+func (p *Prober) probeGRPC(ctx context.Context, address string) (time.Duration, error) {
+	probeCtx, cancel := context.WithTimeout(ctx, p.opts.Timeout)
+	defer cancel()
+
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create client: %w", err)
+	}
+	defer conn.Close()
+
+	start := time.Now()
+	resp, err := healthpb.NewHealthClient(conn).Check(probeCtx, &healthpb.HealthCheckRequest{}, grpc.WaitForReady(true))
+	rtt := time.Since(start)
+	if err != nil {
+		return 0, err
+	}
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		return 0, fmt.Errorf("health status %s", resp.Status)
+	}
+	return rtt, nil
+}
+
+func (p *Prober) probeTCP(ctx context.Context, address string) (time.Duration, error) {
+	dialer := net.Dialer{Timeout: p.opts.Timeout}
+	start := time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return 0, err
+	}
+	rtt := time.Since(start)
+	_ = conn.Close()
+	return rtt, nil
+}
+
+// record 将探测结果写回节点
+func (p *Prober) record(node *Node, rtt time.Duration, probeErr error) {
+	err := p.manager.UpdateNode(node.ID, func(n *Node) {
+		ps := n.Probe
+		if ps == nil {
+			ps = &ProbeStatus{Reachable: true}
+			n.Probe = ps
+		}
+		ps.LastProbe = time.Now()
+
+		if probeErr == nil {
+			if !ps.Reachable {
+				logrus.Infof("Node %s (%s, domain=%s) is reachable again, rtt=%v", n.ID, n.Address, n.DomainID, rtt)
+			}
+			ps.Reachable = true
+			ps.RTT = rtt
+			ps.ConsecutiveFailures = 0
+			ps.LastError = ""
+			return
+		}
+
+		ps.ConsecutiveFailures++
+		ps.LastError = probeErr.Error()
+		if ps.Reachable && ps.ConsecutiveFailures >= p.opts.FailureThreshold {
+			ps.Reachable = false
+			logrus.Warnf("Node %s (%s, domain=%s) marked as unreachable after %d failed probe(s): %v",
+				n.ID, n.Address, n.DomainID, ps.ConsecutiveFailures, probeErr)
+		}
+	})
+	if err != nil && err != ErrNodeNotFound {
+		logrus.Debugf("Failed to record probe result for node %s: %v", node.ID, err)
+	}
+}
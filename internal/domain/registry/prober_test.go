@@ -0,0 +1,47 @@
+package registry
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestProberRecordNotifiesReachabilityChange 节点变为不可达或恢复可达时发出容量变化通知
+func TestProberRecordNotifiesReachabilityChange(t *testing.T) {
+	m := NewManager(ManagerOptions{})
+	if err := m.AddDomain(&Domain{ID: "domain.test", Name: "test"}); err != nil {
+		t.Fatal(err)
+	}
+	node := &Node{ID: "node.test", DomainID: "domain.test", Name: "n1", Status: NodeStatusOnline, LastSeen: time.Now()}
+	if err := m.AddNode(node); err != nil {
+		t.Fatal(err)
+	}
+	p := NewProber(m, ProberOptions{FailureThreshold: 2})
+	drain := func() bool {
+		select {
+		case <-m.CapacityChanged():
+			return true
+		default:
+			return false
+		}
+	}
+	drain()
+
+	probeErr := errors.New("connection refused")
+	p.record(node, 0, probeErr)
+	if drain() {
+		t.Fatal("notified before the node was marked unreachable")
+	}
+	p.record(node, 0, probeErr)
+	if !drain() {
+		t.Fatal("no notification when the node became unreachable")
+	}
+	p.record(node, time.Millisecond, nil)
+	if !drain() {
+		t.Fatal("no notification when the node became reachable again")
+	}
+	p.record(node, time.Millisecond, nil)
+	if drain() {
+		t.Fatal("notified although reachability did not change")
+	}
+}
@@ -0,0 +1,352 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/intra/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// HA 模式下复制状态的条目键前缀，应用顺序与声明顺序一致（域先于节点）
+const (
+	replicaDomainPrefix     = "domain/"
+	replicaNodeLabelsPrefix = "node_labels/"
+	replicaCordonPrefix     = "cordon/"
+	replicaNodePrefix       = "node/"
+	replicaJoinTokenPrefix  = "join_token/"
+)
+
+var replicaPrefixes = []string{
+	replicaDomainPrefix,
+	replicaNodeLabelsPrefix,
+	replicaCordonPrefix,
+	replicaNodePrefix,
+	replicaJoinTokenPrefix,
+}
+
+// replicatedDomain 复制的域信息
+// 资源标签、节点列表与 head 节点由节点条目推导，不单独复制
+type replicatedDomain struct {
+	ID           DomainID      `json:"id"`
+	Name         string        `json:"name"`
+	Description  string        `json:"description,omitempty"`
+	Labels       Labels        `json:"labels,omitempty"`
+	ParentID     DomainID      `json:"parent_id,omitempty"`
+	Kind         string        `json:"kind,omitempty"`
+	HealthPolicy *HealthPolicy `json:"health_policy,omitempty"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+// ExportState 导出需要在 HA 副本间复制的注册中心状态（条目键 -> JSON）
+// 怀疑度与主动探测结果只在 leader 本地计算，不参与复制
+func (s *service) ExportState(ctx context.Context) (map[string][]byte, error) {
+	state := make(map[string][]byte)
+	put := func(key string, value any) error {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", key, err)
+		}
+		state[key] = data
+		return nil
+	}
+
+	m := s.manager
+	m.mu.RLock()
+	for id, domain := range m.domains {
+		err := put(replicaDomainPrefix+id, replicatedDomain{
+			ID:           domain.ID,
+			Name:         domain.Name,
+			Description:  domain.Description,
+			Labels:       domain.Labels,
+			ParentID:     domain.ParentID,
+			Kind:         domain.Kind,
+			HealthPolicy: domain.HealthPolicy,
+			CreatedAt:    domain.CreatedAt,
+		})
+		if err != nil {
+			m.mu.RUnlock()
+			return nil, err
+		}
+	}
+	for id, node := range m.nodes {
+		exported := node.Clone()
+		exported.Suspicion = 0
+		exported.Probe = nil
+		if err := put(replicaNodePrefix+id, exported); err != nil {
+			m.mu.RUnlock()
+			return nil, err
+		}
+	}
+	for id, labels := range m.nodeAdminLabels {
+		if err := put(replicaNodeLabelsPrefix+id, labels); err != nil {
+			m.mu.RUnlock()
+			return nil, err
+		}
+	}
+	for id := range m.cordonedNodes {
+		state[replicaCordonPrefix+id] = []byte("true")
+	}
+	m.mu.RUnlock()
+
+	tokens, err := s.domainRepo.GetAllJoinTokens(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load join tokens from repository: %w", err)
+	}
+	for _, token := range tokens {
+		if err := put(replicaJoinTokenPrefix+token.ID, token); err != nil {
+			return nil, err
+		}
+	}
+	return state, nil
+}
+
+// ApplyState 应用 leader 复制的状态变化，值为空的条目表示删除
+// 域、标签、不可调度标记与加入令牌同时写入本地 repository，成为 leader 后可直接使用
+func (s *service) ApplyState(ctx context.Context, changes map[string][]byte) error {
+	for _, prefix := range replicaPrefixes {
+		keys := make([]string, 0)
+		for key := range changes {
+			if strings.HasPrefix(key, prefix) {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			id := strings.TrimPrefix(key, prefix)
+			if err := s.applyEntry(ctx, prefix, id, changes[key]); err != nil {
+				return fmt.Errorf("failed to apply %s: %w", key, err)
+			}
+		}
+	}
+	return nil
+}
+
+// RestoreState 以 leader 的完整状态替换本地状态
+func (s *service) RestoreState(ctx context.Context, state map[string][]byte) error {
+	local, err := s.ExportState(ctx)
+	if err != nil {
+		return err
+	}
+
+	changes := make(map[string][]byte, len(state))
+	for key := range local {
+		if _, ok := state[key]; !ok {
+			changes[key] = nil
+		}
+	}
+	for key, value := range state {
+		if existing, ok := local[key]; ok && bytes.Equal(existing, value) {
+			continue
+		}
+		changes[key] = value
+	}
+	return s.ApplyState(ctx, changes)
+}
+
+func (s *service) applyEntry(ctx context.Context, prefix, id string, data []byte) error {
+	switch prefix {
+	case replicaDomainPrefix:
+		if data == nil {
+			return s.removeReplicatedDomain(ctx, id)
+		}
+		domain := replicatedDomain{}
+		if err := json.Unmarshal(data, &domain); err != nil {
+			return err
+		}
+		return s.putReplicatedDomain(ctx, &domain)
+
+	case replicaNodePrefix:
+		if data == nil {
+			if err := s.manager.RemoveNode(id); err != nil && err != ErrNodeNotFound {
+				return err
+			}
+			return nil
+		}
+		node := &Node{}
+		if err := json.Unmarshal(data, node); err != nil {
+			return err
+		}
+		return s.manager.putReplicatedNode(node)
+
+	case replicaNodeLabelsPrefix:
+		var labels Labels
+		if data != nil {
+			if err := json.Unmarshal(data, &labels); err != nil {
+				return err
+			}
+		}
+		if err := s.domainRepo.SetNodeLabels(ctx, id, labels); err != nil {
+			return err
+		}
+		s.manager.SetNodeAdminLabels(id, labels)
+		return nil
+
+	case replicaCordonPrefix:
+		cordoned := data != nil
+		if err := s.domainRepo.SetNodeCordoned(ctx, id, cordoned); err != nil {
+			return err
+		}
+		s.manager.SetNodeCordoned(id, cordoned)
+		return nil
+
+	case replicaJoinTokenPrefix:
+		if data == nil {
+			if err := s.domainRepo.DeleteJoinToken(ctx, id); err != nil && !isNotFound(err) {
+				return err
+			}
+			return nil
+		}
+		token := &repository.JoinTokenDAO{}
+		if err := json.Unmarshal(data, token); err != nil {
+			return err
+		}
+		return s.domainRepo.SaveJoinToken(ctx, token)
+	}
+	return nil
+}
+
+// putReplicatedDomain 新增或更新复制的域并持久化
+func (s *service) putReplicatedDomain(ctx context.Context, replicated *replicatedDomain) error {
+	now := time.Now()
+	err := s.domainRepo.SaveDomain(ctx, &repository.DomainDAO{
+		ID:          replicated.ID,
+		Name:        replicated.Name,
+		Description: replicated.Description,
+		CreatedAt:   replicated.CreatedAt,
+		UpdatedAt:   now,
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.domainRepo.SetDomainLabels(ctx, replicated.ID, replicated.Labels); err != nil {
+		return err
+	}
+	err = s.domainRepo.SetDomainHierarchy(ctx, &repository.DomainHierarchyDAO{
+		DomainID: replicated.ID,
+		ParentID: replicated.ParentID,
+		Kind:     replicated.Kind,
+	})
+	if err != nil {
+		return err
+	}
+	if policy := replicated.HealthPolicy; policy != nil {
+		err = s.domainRepo.UpsertDomainPolicy(ctx, &repository.DomainPolicyDAO{
+			DomainID:       replicated.ID,
+			TimeoutSeconds: int(policy.Timeout / time.Second),
+			CleanupFactor:  policy.CleanupFactor,
+			UpdatedAt:      now,
+		})
+	} else {
+		err = s.domainRepo.DeleteDomainPolicy(ctx, replicated.ID)
+	}
+	if err != nil {
+		return err
+	}
+
+	m := s.manager
+	m.mu.Lock()
+	domain, ok := m.domains[replicated.ID]
+	if !ok {
+		domain = &Domain{
+			ID:           replicated.ID,
+			ResourceTags: NewEmptyResourceTags(),
+			NodeIDs:      make([]NodeID, 0),
+			CreatedAt:    replicated.CreatedAt,
+		}
+		m.domains[replicated.ID] = domain
+		logrus.Infof("Replicated domain added: id=%s, name=%s", replicated.ID, replicated.Name)
+	}
+	domain.Name = replicated.Name
+	domain.Description = replicated.Description
+	domain.Labels = replicated.Labels.Clone()
+	// 上级域可能稍后才被复制，不在此校验
+	domain.ParentID = replicated.ParentID
+	domain.Kind = replicated.Kind
+	domain.HealthPolicy = replicated.HealthPolicy.Clone()
+	domain.UpdatedAt = now
+	m.mu.Unlock()
+
+	m.notifyCapacityChanged()
+	return nil
+}
+
+// removeReplicatedDomain 删除复制的域及其持久化数据
+func (s *service) removeReplicatedDomain(ctx context.Context, domainID DomainID) error {
+	if err := s.manager.RemoveDomain(domainID); err != nil && err != ErrDomainNotFound {
+		return err
+	}
+	if err := s.domainRepo.DeleteDomainPolicy(ctx, domainID); err != nil {
+		return err
+	}
+	if err := s.domainRepo.SetDomainLabels(ctx, domainID, nil); err != nil {
+		return err
+	}
+	if err := s.domainRepo.DeleteDomain(ctx, domainID); err != nil && !isNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// putReplicatedNode 新增或替换复制的节点，只在修改前校验，修改开始后不再失败
+func (m *Manager) putReplicatedNode(node *Node) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	domain, ok := m.domains[node.DomainID]
+	if !ok {
+		return ErrDomainNotFound
+	}
+	if prev, ok := m.nodes[node.ID]; ok {
+		if prevDomain, ok := m.domains[prev.DomainID]; ok {
+			if prev.DomainID != node.DomainID {
+				prevDomain.RemoveNode(node.ID)
+				m.updateDomainResourceTags(prevDomain)
+			}
+			applyDomainCapacityUnsafe(prevDomain, nodeCapacity(prev), nil)
+		}
+	}
+
+	// 管理员标签与不可调度标记以单独复制的条目为准
+	node.AdminLabels = m.nodeAdminLabels[node.ID].Clone()
+	_, node.Cordoned = m.cordonedNodes[node.ID]
+
+	m.nodes[node.ID] = node
+	domain.AddNode(node.ID)
+	if node.IsHead {
+		// 节点刚加入域，直接设置 head 节点，不再经过可能失败的校验，避免副本状态只更新一半
+		headID := node.ID
+		domain.HeadNodeID = &headID
+	}
+	m.updateDomainResourceTags(domain)
+	applyDomainCapacityUnsafe(domain, nil, nodeCapacity(node))
+	m.notifyCapacityChanged()
+	return nil
+}
+
+// ResetLiveness 将所有存活节点的最后活跃时间重置为当前时间并清空心跳历史
+// 副本成为 leader 时调用，给节点留出发现新 leader 并恢复心跳的时间，避免被立即判定为离线
+func (m *Manager) ResetLiveness() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	for id, node := range m.nodes {
+		delete(m.detectors, id)
+		if node.IsAlive() {
+			node.LastSeen = now
+			node.Suspicion = 0
+		}
+	}
+}
+
+// isNotFound repository 在记录不存在时返回的错误
+func isNotFound(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "not found")
+}
@@ -0,0 +1,307 @@
+package registry
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Labels 任意键值标签，例如 arch=arm64、region=east、camera=thermal
+type Labels map[string]string
+
+// Clone 深拷贝 Labels
+func (l Labels) Clone() Labels {
+	if l == nil {
+		return nil
+	}
+	copy := make(Labels, len(l))
+	for k, v := range l {
+		copy[k] = v
+	}
+	return copy
+}
+
+// Validate 校验标签键值，键值中不允许出现空白与选择器保留字符
+func (l Labels) Validate() error {
+	for k, v := range l {
+		if !isValidLabelToken(k) {
+			return fmt.Errorf("%w: invalid key %q", ErrInvalidLabels, k)
+		}
+		if v != "" && !isValidLabelToken(v) {
+			return fmt.Errorf("%w: invalid value %q for key %q", ErrInvalidLabels, v, k)
+		}
+	}
+	return nil
+}
+
+func isValidLabelToken(s string) bool {
+	if s == "" || len(s) > 253 {
+		return false
+	}
+	return !strings.ContainsAny(s, " \t\r\n=!(),")
+}
+
+// LabelSet 选择器求值时使用的标签视图
+type LabelSet interface {
+	// Get 获取标签值，第二个返回值表示标签是否存在
+	Get(key string) (string, bool)
+}
+
+// nodeLabelSet 节点的生效标签视图：节点管理员标签 > 节点上报标签 > 域标签 > 旧版资源标签
+type nodeLabelSet struct {
+	node   *Node
+	domain *Domain
+}
+
+// NodeLabelSet 构造节点的生效标签视图
+// 旧版布尔资源标签（cpu/gpu/memory/camera）作为值为 "true" 的标签参与求值，
+// 以兼容仅携带资源类型的 tags 请求
+func NodeLabelSet(node *Node, domain *Domain) LabelSet {
+	return nodeLabelSet{node: node, domain: domain}
+}
+
+func (s nodeLabelSet) Get(key string) (string, bool) {
+	if s.node != nil {
+		if v, ok := s.node.AdminLabels[key]; ok {
+			return v, true
+		}
+		if v, ok := s.node.Labels[key]; ok {
+			return v, true
+		}
+	}
+	if s.domain != nil {
+		if v, ok := s.domain.Labels[key]; ok {
+			return v, true
+		}
+	}
+	if s.node != nil && s.node.ResourceTags != nil && s.node.ResourceTags.HasResource(strings.ToLower(key)) {
+		return "true", true
+	}
+	return "", false
+}
+
+// Get 使 Labels 本身满足 LabelSet
+func (l Labels) Get(key string) (string, bool) {
+	v, ok := l[key]
+	return v, ok
+}
+
+// SelectorOperator 选择器操作符
+type SelectorOperator string
+
+const (
+	// SelectorOpEquals key=value / key==value
+	SelectorOpEquals SelectorOperator = "="
+	// SelectorOpNotEquals key!=value（标签不存在也视为满足）
+	SelectorOpNotEquals SelectorOperator = "!="
+	// SelectorOpIn key in (v1,v2)
+	SelectorOpIn SelectorOperator = "in"
+	// SelectorOpNotIn key notin (v1,v2)（标签不存在也视为满足）
+	SelectorOpNotIn SelectorOperator = "notin"
+	// SelectorOpExists key
+	SelectorOpExists SelectorOperator = "exists"
+	// SelectorOpDoesNotExist !key
+	SelectorOpDoesNotExist SelectorOperator = "!"
+)
+
+// Requirement 单个选择器条件
+type Requirement struct {
+	Key      string
+	Operator SelectorOperator
+	Values   []string
+}
+
+// Matches 判断标签集合是否满足条件
+func (r Requirement) Matches(labels LabelSet) bool {
+	value, exists := labels.Get(r.Key)
+	switch r.Operator {
+	case SelectorOpExists:
+		return exists
+	case SelectorOpDoesNotExist:
+		return !exists
+	case SelectorOpEquals:
+		return exists && value == r.Values[0]
+	case SelectorOpNotEquals:
+		return !exists || value != r.Values[0]
+	case SelectorOpIn:
+		return exists && containsString(r.Values, value)
+	case SelectorOpNotIn:
+		return !exists || !containsString(r.Values, value)
+	default:
+		return false
+	}
+}
+
+// String 返回条件的文本形式
+func (r Requirement) String() string {
+	switch r.Operator {
+	case SelectorOpExists:
+		return r.Key
+	case SelectorOpDoesNotExist:
+		return "!" + r.Key
+	case SelectorOpIn, SelectorOpNotIn:
+		return fmt.Sprintf("%s %s (%s)", r.Key, r.Operator, strings.Join(r.Values, ","))
+	default:
+		return r.Key + string(r.Operator) + r.Values[0]
+	}
+}
+
+// Selector 标签选择器，所有条件需同时满足
+type Selector []Requirement
+
+// Matches 判断标签集合是否满足选择器
+func (s Selector) Matches(labels LabelSet) bool {
+	for _, r := range s {
+		if !r.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// String 返回选择器的文本形式
+func (s Selector) String() string {
+	parts := make([]string, 0, len(s))
+	for _, r := range s {
+		parts = append(parts, r.String())
+	}
+	return strings.Join(parts, ",")
+}
+
+// ParseSelectors 解析多个选择器表达式（例如 resource.Info.tags），结果为所有条件的合取
+func ParseSelectors(exprs []string) (Selector, error) {
+	selector := make(Selector, 0, len(exprs))
+	for _, expr := range exprs {
+		s, err := ParseSelector(expr)
+		if err != nil {
+			return nil, err
+		}
+		selector = append(selector, s...)
+	}
+	return selector, nil
+}
+
+// ParseSelector 解析选择器表达式，多个条件以逗号分隔，支持：
+//
+//	key            标签存在
+//	!key           标签不存在
+//	key=value      等于（也可写作 key==value）
+//	key!=value     不等于
+//	key in (a,b)   属于集合
+//	key notin (a,b) 不属于集合
+func ParseSelector(expr string) (Selector, error) {
+	selector := make(Selector, 0)
+	for _, part := range splitSelector(expr) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		r, err := parseRequirement(part)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", ErrInvalidSelector, part, err)
+		}
+		selector = append(selector, r)
+	}
+	return selector, nil
+}
+
+// splitSelector 按逗号切分条件，忽略括号内的逗号
+func splitSelector(expr string) []string {
+	parts := make([]string, 0)
+	depth := 0
+	start := 0
+	for i, c := range expr {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, expr[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, expr[start:])
+}
+
+func parseRequirement(part string) (Requirement, error) {
+	if strings.HasPrefix(part, "!") && !strings.Contains(part, "=") {
+		key := strings.TrimSpace(part[1:])
+		if !isValidLabelToken(key) {
+			return Requirement{}, fmt.Errorf("invalid key")
+		}
+		return Requirement{Key: key, Operator: SelectorOpDoesNotExist}, nil
+	}
+
+	if idx := strings.Index(part, "!="); idx >= 0 {
+		return newBinaryRequirement(part[:idx], SelectorOpNotEquals, part[idx+2:])
+	}
+	if idx := strings.Index(part, "=="); idx >= 0 {
+		return newBinaryRequirement(part[:idx], SelectorOpEquals, part[idx+2:])
+	}
+	if idx := strings.Index(part, "="); idx >= 0 {
+		return newBinaryRequirement(part[:idx], SelectorOpEquals, part[idx+1:])
+	}
+
+	fields := strings.Fields(part)
+	if len(fields) == 1 {
+		if !isValidLabelToken(fields[0]) {
+			return Requirement{}, fmt.Errorf("invalid key")
+		}
+		return Requirement{Key: fields[0], Operator: SelectorOpExists}, nil
+	}
+	if len(fields) < 3 {
+		return Requirement{}, fmt.Errorf("unexpected expression")
+	}
+
+	key := fields[0]
+	op := SelectorOperator(strings.ToLower(fields[1]))
+	if op != SelectorOpIn && op != SelectorOpNotIn {
+		return Requirement{}, fmt.Errorf("unknown operator %q", fields[1])
+	}
+	if !isValidLabelToken(key) {
+		return Requirement{}, fmt.Errorf("invalid key")
+	}
+
+	set := strings.TrimSpace(strings.Join(fields[2:], " "))
+	if !strings.HasPrefix(set, "(") || !strings.HasSuffix(set, ")") {
+		return Requirement{}, fmt.Errorf("set must be enclosed in parentheses")
+	}
+	values := make([]string, 0)
+	for _, v := range strings.Split(set[1:len(set)-1], ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if !isValidLabelToken(v) {
+			return Requirement{}, fmt.Errorf("invalid value %q", v)
+		}
+		values = append(values, v)
+	}
+	if len(values) == 0 {
+		return Requirement{}, fmt.Errorf("empty set")
+	}
+	return Requirement{Key: key, Operator: op, Values: values}, nil
+}
+
+func newBinaryRequirement(key string, op SelectorOperator, value string) (Requirement, error) {
+	key = strings.TrimSpace(key)
+	value = strings.TrimSpace(value)
+	if !isValidLabelToken(key) {
+		return Requirement{}, fmt.Errorf("invalid key")
+	}
+	if value != "" && !isValidLabelToken(value) {
+		return Requirement{}, fmt.Errorf("invalid value")
+	}
+	return Requirement{Key: key, Operator: op, Values: []string{value}}, nil
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
@@ -3,6 +3,7 @@ package registry
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/9triver/iarnet-global/internal/intra/repository"
@@ -25,9 +26,18 @@ type Service interface {
 	// UpdateDomain 更新域信息
 	UpdateDomain(ctx context.Context, domainID DomainID, name, description string) error
 
-	// DeleteDomain 删除域
+	// DeleteDomain 删除域，仍有下级域时拒绝
 	DeleteDomain(ctx context.Context, domainID DomainID) error
 
+	// SetDomainParent 设置域的上级域与层级类型并持久化，parentID 为空表示顶层域
+	SetDomainParent(ctx context.Context, domainID, parentID DomainID, kind string) error
+
+	// GetChildDomains 获取直接下级域的 ID
+	GetChildDomains(ctx context.Context, domainID DomainID) []DomainID
+
+	// GetDomainTree 获取域层级树，visible 非空时只包含返回 true 的域
+	GetDomainTree(ctx context.Context, visible func(DomainID) bool) []*DomainTreeNode
+
 	// GetDomainNodes 获取域下的所有节点
 	GetDomainNodes(ctx context.Context, domainID DomainID) ([]*Node, error)
 
@@ -36,12 +46,64 @@ type Service interface {
 
 	// LoadDomains 从 repository 加载所有域数据到 manager
 	LoadDomains(ctx context.Context) error
+
+	// GetDefaultHealthPolicy 获取全局默认健康检查策略及超时检测周期
+	GetDefaultHealthPolicy(ctx context.Context) (HealthPolicy, time.Duration)
+
+	// UpdateDefaultHealthPolicy 运行时更新全局默认健康检查策略，零值字段保持不变
+	UpdateDefaultHealthPolicy(ctx context.Context, policy HealthPolicy, checkInterval time.Duration) error
+
+	// GetDomainHealthPolicy 获取域的健康检查策略（覆盖值与生效值）
+	GetDomainHealthPolicy(ctx context.Context, domainID DomainID) (*DomainHealthPolicy, error)
+
+	// SetDomainHealthPolicy 设置域级健康检查策略覆盖并持久化
+	SetDomainHealthPolicy(ctx context.Context, domainID DomainID, policy HealthPolicy) error
+
+	// ResetDomainHealthPolicy 删除域级健康检查策略覆盖，恢复为全局默认策略
+	ResetDomainHealthPolicy(ctx context.Context, domainID DomainID) error
+
+	// SetDomainLabels 替换域的键值标签并持久化
+	SetDomainLabels(ctx context.Context, domainID DomainID, labels Labels) error
+
+	// SetNodeLabels 替换管理员为节点设置的键值标签并持久化
+	SetNodeLabels(ctx context.Context, domainID DomainID, nodeID NodeID, labels Labels) error
+
+	// SetNodeCordoned 标记或取消标记节点为不可调度并持久化
+	SetNodeCordoned(ctx context.Context, domainID DomainID, nodeID NodeID, cordoned bool) error
+
+	// CreateJoinToken 为域创建加入令牌，返回令牌记录与明文（明文只返回一次）
+	CreateJoinToken(ctx context.Context, domainID DomainID, description string, ttl time.Duration, maxUses int) (*JoinToken, string, error)
+
+	// ListJoinTokens 列出加入令牌，domainID 为空时返回所有域的令牌
+	ListJoinTokens(ctx context.Context, domainID DomainID) ([]*JoinToken, error)
+
+	// RevokeJoinToken 删除加入令牌
+	RevokeJoinToken(ctx context.Context, id string) error
+
+	// ConsumeJoinToken 校验节点加入域时携带的令牌并记录一次使用
+	ConsumeJoinToken(ctx context.Context, domainID DomainID, token string) error
+
+	// ExportState 导出需要在 HA 副本间复制的状态（条目键 -> JSON）
+	ExportState(ctx context.Context) (map[string][]byte, error)
+
+	// ApplyState 应用 leader 复制的状态变化，值为空的条目表示删除
+	ApplyState(ctx context.Context, changes map[string][]byte) error
+
+	// RestoreState 以 leader 的完整状态替换本地状态
+	RestoreState(ctx context.Context, state map[string][]byte) error
+}
+
+// DomainHealthPolicy 域健康检查策略
+type DomainHealthPolicy struct {
+	Override  *HealthPolicy // 域级覆盖（为空表示未覆盖）
+	Effective HealthPolicy  // 合并全局默认后的生效策略
 }
 
 // DomainStats 域统计信息
 type DomainStats struct {
 	TotalNodes   int // 节点总数
 	OnlineNodes  int // 在线节点数
+	SuspectNodes int // 疑似失效节点数
 	OfflineNodes int // 离线节点数
 	ErrorNodes   int // 错误节点数
 }
@@ -49,6 +111,8 @@ type DomainStats struct {
 type service struct {
 	manager    *Manager
 	domainRepo repository.DomainRepo
+	// joinMu 保证加入令牌使用次数的检查与递增是原子的
+	joinMu sync.Mutex
 }
 
 // NewService 创建域注册服务
@@ -135,9 +199,54 @@ func (s *service) UpdateDomain(ctx context.Context, domainID DomainID, name, des
 
 // DeleteDomain 删除域
 func (s *service) DeleteDomain(ctx context.Context, domainID DomainID) error {
+	if children := s.manager.ChildDomains(domainID); len(children) > 0 {
+		return fmt.Errorf("%w: %d child domain(s) must be moved or deleted first", ErrDomainHasChildren, len(children))
+	}
 	return s.manager.RemoveDomain(domainID)
 }
 
+// SetDomainParent 设置域的上级域与层级类型
+func (s *service) SetDomainParent(ctx context.Context, domainID, parentID DomainID, kind string) error {
+	if err := ValidateDomainKind(kind); err != nil {
+		return err
+	}
+	if parentID == domainID {
+		return fmt.Errorf("%w: domain cannot be its own parent", ErrInvalidHierarchy)
+	}
+	domain, err := s.manager.GetDomain(domainID)
+	if err != nil {
+		return err
+	}
+	previousParent, previousKind := domain.ParentID, domain.Kind
+
+	// 先在内存中校验并更新（检查环），再持久化，持久化失败时回滚
+	if err := s.manager.SetDomainParent(domainID, parentID, kind); err != nil {
+		return err
+	}
+	err = s.domainRepo.SetDomainHierarchy(ctx, &repository.DomainHierarchyDAO{
+		DomainID: domainID,
+		ParentID: parentID,
+		Kind:     kind,
+	})
+	if err != nil {
+		_ = s.manager.SetDomainParent(domainID, previousParent, previousKind)
+		return fmt.Errorf("failed to persist domain hierarchy to repository: %w", err)
+	}
+
+	logrus.Infof("Domain hierarchy updated: id=%s, parent=%s, kind=%s", domainID, parentID, kind)
+	return nil
+}
+
+// GetChildDomains 获取直接下级域的 ID
+func (s *service) GetChildDomains(ctx context.Context, domainID DomainID) []DomainID {
+	return s.manager.ChildDomains(domainID)
+}
+
+// GetDomainTree 获取域层级树
+func (s *service) GetDomainTree(ctx context.Context, visible func(DomainID) bool) []*DomainTreeNode {
+	return s.manager.DomainTree(visible)
+}
+
 // GetDomainNodes 获取域下的所有节点
 func (s *service) GetDomainNodes(ctx context.Context, domainID DomainID) ([]*Node, error) {
 	return s.manager.GetNodesByDomain(domainID)
@@ -154,6 +263,7 @@ func (s *service) GetDomainStats(ctx context.Context, domainID DomainID) (*Domai
 	stats := &DomainStats{
 		TotalNodes:   0, // 先初始化为0，只统计实际存在的节点
 		OnlineNodes:  0,
+		SuspectNodes: 0,
 		OfflineNodes: 0,
 		ErrorNodes:   0,
 	}
@@ -180,6 +290,8 @@ func (s *service) GetDomainStats(ctx context.Context, domainID DomainID) (*Domai
 		switch status {
 		case NodeStatusOnline:
 			stats.OnlineNodes++
+		case NodeStatusSuspect:
+			stats.SuspectNodes++
 		case NodeStatusOffline:
 			stats.OfflineNodes++
 		case NodeStatusError:
@@ -191,8 +303,8 @@ func (s *service) GetDomainStats(ctx context.Context, domainID DomainID) (*Domai
 		}
 	}
 
-	logrus.Infof("Domain stats: id=%s, total=%d, online=%d, offline=%d, error=%d, node_ids=%v",
-		domainID, stats.TotalNodes, stats.OnlineNodes, stats.OfflineNodes, stats.ErrorNodes, domain.NodeIDs)
+	logrus.Infof("Domain stats: id=%s, total=%d, online=%d, suspect=%d, offline=%d, error=%d, node_ids=%v",
+		domainID, stats.TotalNodes, stats.OnlineNodes, stats.SuspectNodes, stats.OfflineNodes, stats.ErrorNodes, domain.NodeIDs)
 
 	return stats, nil
 }
@@ -245,5 +357,204 @@ func (s *service) LoadDomains(ctx context.Context) error {
 	}
 
 	logrus.Infof("Successfully loaded %d domain(s) from database", loadedCount)
+
+	// 加载域级健康检查策略覆盖
+	policyDAOs, err := s.domainRepo.GetAllDomainPolicies(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load domain policies from repository: %w", err)
+	}
+	for _, dao := range policyDAOs {
+		policy := &HealthPolicy{
+			Timeout:       time.Duration(dao.TimeoutSeconds) * time.Second,
+			CleanupFactor: dao.CleanupFactor,
+		}
+		if err := s.manager.SetDomainHealthPolicy(DomainID(dao.DomainID), policy); err != nil {
+			logrus.Warnf("Failed to apply health policy for domain %s: %v", dao.DomainID, err)
+		}
+	}
+	if len(policyDAOs) > 0 {
+		logrus.Infof("Loaded %d domain health policy override(s) from database", len(policyDAOs))
+	}
+
+	// 加载域标签与管理员设置的节点标签
+	domainLabels, err := s.domainRepo.GetAllDomainLabels(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load domain labels from repository: %w", err)
+	}
+	for domainID, labels := range domainLabels {
+		if err := s.manager.SetDomainLabels(DomainID(domainID), Labels(labels)); err != nil {
+			logrus.Warnf("Failed to apply labels for domain %s: %v", domainID, err)
+		}
+	}
+
+	// 加载域层级（所有域加载完成后再设置，上级域必须已存在）
+	hierarchy, err := s.domainRepo.GetAllDomainHierarchy(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load domain hierarchy from repository: %w", err)
+	}
+	for _, dao := range hierarchy {
+		if err := s.manager.SetDomainParent(DomainID(dao.DomainID), DomainID(dao.ParentID), dao.Kind); err != nil {
+			logrus.Warnf("Failed to apply hierarchy for domain %s: %v", dao.DomainID, err)
+		}
+	}
+
+	nodeLabels, err := s.domainRepo.GetAllNodeLabels(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load node labels from repository: %w", err)
+	}
+	for nodeID, labels := range nodeLabels {
+		s.manager.SetNodeAdminLabels(NodeID(nodeID), Labels(labels))
+	}
+
+	// 加载不可调度的节点标记
+	cordoned, err := s.domainRepo.GetCordonedNodes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load node cordons from repository: %w", err)
+	}
+	for _, nodeID := range cordoned {
+		s.manager.SetNodeCordoned(NodeID(nodeID), true)
+	}
+
+	return nil
+}
+
+// SetDomainLabels 替换域的键值标签
+func (s *service) SetDomainLabels(ctx context.Context, domainID DomainID, labels Labels) error {
+	if err := labels.Validate(); err != nil {
+		return err
+	}
+	if _, err := s.manager.GetDomain(domainID); err != nil {
+		return err
+	}
+
+	if err := s.domainRepo.SetDomainLabels(ctx, domainID, labels); err != nil {
+		return fmt.Errorf("failed to persist domain labels to repository: %w", err)
+	}
+	if err := s.manager.SetDomainLabels(domainID, labels); err != nil {
+		return err
+	}
+
+	logrus.Infof("Domain labels updated: id=%s, labels=%v", domainID, labels)
+	return nil
+}
+
+// SetNodeLabels 替换管理员为节点设置的键值标签
+func (s *service) SetNodeLabels(ctx context.Context, domainID DomainID, nodeID NodeID, labels Labels) error {
+	if err := labels.Validate(); err != nil {
+		return err
+	}
+	node, err := s.manager.GetNode(nodeID)
+	if err != nil {
+		return err
+	}
+	if node.DomainID != domainID {
+		return ErrNodeNotInDomain
+	}
+
+	if err := s.domainRepo.SetNodeLabels(ctx, nodeID, labels); err != nil {
+		return fmt.Errorf("failed to persist node labels to repository: %w", err)
+	}
+	s.manager.SetNodeAdminLabels(nodeID, labels)
+
+	logrus.Infof("Node labels updated: id=%s, domain=%s, labels=%v", nodeID, domainID, labels)
+	return nil
+}
+
+// SetNodeCordoned 标记或取消标记节点为不可调度
+func (s *service) SetNodeCordoned(ctx context.Context, domainID DomainID, nodeID NodeID, cordoned bool) error {
+	node, err := s.manager.GetNode(nodeID)
+	if err != nil {
+		return err
+	}
+	if node.DomainID != domainID {
+		return ErrNodeNotInDomain
+	}
+
+	if err := s.domainRepo.SetNodeCordoned(ctx, nodeID, cordoned); err != nil {
+		return fmt.Errorf("failed to persist node cordon to repository: %w", err)
+	}
+	s.manager.SetNodeCordoned(nodeID, cordoned)
+
+	logrus.Infof("Node cordon updated: id=%s, domain=%s, cordoned=%v", nodeID, domainID, cordoned)
+	return nil
+}
+
+// GetDefaultHealthPolicy 获取全局默认健康检查策略及超时检测周期
+func (s *service) GetDefaultHealthPolicy(ctx context.Context) (HealthPolicy, time.Duration) {
+	return s.manager.GetDefaultHealthPolicy(), s.manager.GetCheckInterval()
+}
+
+// UpdateDefaultHealthPolicy 运行时更新全局默认健康检查策略
+// 全局默认值来源于配置文件，此处的修改仅在进程生命周期内有效
+func (s *service) UpdateDefaultHealthPolicy(ctx context.Context, policy HealthPolicy, checkInterval time.Duration) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	if checkInterval < 0 || (checkInterval > 0 && checkInterval < time.Second) {
+		return ErrInvalidHealthPolicy
+	}
+
+	s.manager.SetDefaultHealthPolicy(policy)
+	if checkInterval > 0 {
+		s.manager.SetCheckInterval(checkInterval)
+	}
+	return nil
+}
+
+// GetDomainHealthPolicy 获取域的健康检查策略
+func (s *service) GetDomainHealthPolicy(ctx context.Context, domainID DomainID) (*DomainHealthPolicy, error) {
+	domain, err := s.manager.GetDomain(domainID)
+	if err != nil {
+		return nil, err
+	}
+	return &DomainHealthPolicy{
+		Override:  domain.HealthPolicy.Clone(),
+		Effective: s.manager.GetHealthPolicy(domainID),
+	}, nil
+}
+
+// SetDomainHealthPolicy 设置域级健康检查策略覆盖
+func (s *service) SetDomainHealthPolicy(ctx context.Context, domainID DomainID, policy HealthPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	if _, err := s.manager.GetDomain(domainID); err != nil {
+		return err
+	}
+
+	err := s.domainRepo.UpsertDomainPolicy(ctx, &repository.DomainPolicyDAO{
+		DomainID:       domainID,
+		TimeoutSeconds: int(policy.Timeout / time.Second),
+		CleanupFactor:  policy.CleanupFactor,
+		UpdatedAt:      time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to persist domain policy to repository: %w", err)
+	}
+
+	if err := s.manager.SetDomainHealthPolicy(domainID, &policy); err != nil {
+		return err
+	}
+
+	logrus.Infof("Domain health policy updated: id=%s, timeout=%v, cleanup_factor=%.2f",
+		domainID, policy.Timeout, policy.CleanupFactor)
+	return nil
+}
+
+// ResetDomainHealthPolicy 删除域级健康检查策略覆盖
+func (s *service) ResetDomainHealthPolicy(ctx context.Context, domainID DomainID) error {
+	if _, err := s.manager.GetDomain(domainID); err != nil {
+		return err
+	}
+
+	if err := s.domainRepo.DeleteDomainPolicy(ctx, domainID); err != nil {
+		return fmt.Errorf("failed to delete domain policy from repository: %w", err)
+	}
+
+	if err := s.manager.SetDomainHealthPolicy(domainID, nil); err != nil {
+		return err
+	}
+
+	logrus.Infof("Domain health policy reset to default: id=%s", domainID)
 	return nil
 }
@@ -51,6 +51,8 @@ type NodeStatus string
 const (
 	// NodeStatusOnline 节点在线
 	NodeStatusOnline NodeStatus = "online"
+	// NodeStatusSuspect 节点心跳异常，疑似失效（调度时降低优先级）
+	NodeStatusSuspect NodeStatus = "suspect"
 	// NodeStatusOffline 节点离线
 	NodeStatusOffline NodeStatus = "offline"
 	// NodeStatusError 节点错误
@@ -64,11 +66,124 @@ type ResourceCapacity struct {
 	Available *ResourceInfo `json:"available,omitempty" yaml:"available,omitempty"` // 可用资源
 }
 
+// 内置资源名称，其余名称（如 gpu.memory、npu、disk、network.bandwidth、camera）均为扩展资源
+const (
+	ResourceCPU    = "cpu"    // CPU millicores (毫核)
+	ResourceMemory = "memory" // Memory bytes (字节)
+	ResourceGPU    = "gpu"    // GPU count (数量)
+)
+
 // ResourceInfo 资源信息
 type ResourceInfo struct {
 	CPU    int64 `json:"cpu" yaml:"cpu"`       // CPU millicores (毫核)
 	Memory int64 `json:"memory" yaml:"memory"` // Memory bytes (字节)
 	GPU    int64 `json:"gpu" yaml:"gpu"`       // GPU count (数量)
+	// Extended 扩展资源（名称 -> 数量），单位由资源名称约定，例如 gpu.memory 为字节、camera 为个数
+	Extended map[string]int64 `json:"extended,omitempty" yaml:"extended,omitempty"`
+}
+
+// Get 获取指定名称的资源数量，内置资源与扩展资源使用统一的名称空间
+func (ri *ResourceInfo) Get(name string) int64 {
+	if ri == nil {
+		return 0
+	}
+	switch name {
+	case ResourceCPU:
+		return ri.CPU
+	case ResourceMemory:
+		return ri.Memory
+	case ResourceGPU:
+		return ri.GPU
+	default:
+		return ri.Extended[name]
+	}
+}
+
+// Names 返回数量大于 0 的资源名称
+func (ri *ResourceInfo) Names() []string {
+	if ri == nil {
+		return nil
+	}
+	names := make([]string, 0, 3+len(ri.Extended))
+	if ri.CPU > 0 {
+		names = append(names, ResourceCPU)
+	}
+	if ri.Memory > 0 {
+		names = append(names, ResourceMemory)
+	}
+	if ri.GPU > 0 {
+		names = append(names, ResourceGPU)
+	}
+	for name, value := range ri.Extended {
+		if value > 0 {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Equal 判断两份资源信息是否相同（数量为 0 的扩展资源视为不存在）
+func (ri *ResourceInfo) Equal(other *ResourceInfo) bool {
+	if ri == nil || other == nil {
+		return ri == other
+	}
+	if ri.CPU != other.CPU || ri.Memory != other.Memory || ri.GPU != other.GPU {
+		return false
+	}
+	for name, value := range ri.Extended {
+		if other.Extended[name] != value {
+			return false
+		}
+	}
+	for name, value := range other.Extended {
+		if ri.Extended[name] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// Fits 判断 request 中的每一项资源是否都能被当前资源满足
+// 不满足时返回第一个不足的资源名称
+func (ri *ResourceInfo) Fits(request *ResourceInfo) (bool, string) {
+	for _, name := range request.Names() {
+		if ri.Get(name) < request.Get(name) {
+			return false, name
+		}
+	}
+	return true, ""
+}
+
+// Add 将 other 中的各项资源累加到当前资源
+func (ri *ResourceInfo) Add(other *ResourceInfo) {
+	if other == nil {
+		return
+	}
+	ri.CPU += other.CPU
+	ri.Memory += other.Memory
+	ri.GPU += other.GPU
+	for name, value := range other.Extended {
+		if ri.Extended == nil {
+			ri.Extended = make(map[string]int64)
+		}
+		ri.Extended[name] += value
+	}
+}
+
+// Sub 从当前资源中扣除 other 中的各项资源
+func (ri *ResourceInfo) Sub(other *ResourceInfo) {
+	if other == nil {
+		return
+	}
+	ri.CPU -= other.CPU
+	ri.Memory -= other.Memory
+	ri.GPU -= other.GPU
+	for name, value := range other.Extended {
+		if ri.Extended == nil {
+			ri.Extended = make(map[string]int64)
+		}
+		ri.Extended[name] -= value
+	}
 }
 
 // Node iarnet 节点信息
@@ -87,8 +202,18 @@ type Node struct {
 	Status NodeStatus `json:"status" yaml:"status"`
 	// ResourceTags 节点支持的资源标签
 	ResourceTags *ResourceTags `json:"resource_tags,omitempty" yaml:"resource_tags,omitempty"`
+	// Labels 节点上报的键值标签
+	Labels Labels `json:"labels,omitempty" yaml:"labels,omitempty"`
+	// AdminLabels 管理员设置的键值标签（持久化，优先级高于节点上报的标签）
+	AdminLabels Labels `json:"admin_labels,omitempty" yaml:"admin_labels,omitempty"`
+	// Cordoned 管理员标记为不可调度（持久化），已运行的 component 不受影响
+	Cordoned bool `json:"cordoned,omitempty" yaml:"cordoned,omitempty"`
 	// ResourceCapacity 节点资源容量信息
 	ResourceCapacity *ResourceCapacity `json:"resource_capacity,omitempty" yaml:"resource_capacity,omitempty"`
+	// Suspicion phi-accrual 故障检测器给出的怀疑度（越大越可能已失效）
+	Suspicion float64 `json:"suspicion" yaml:"suspicion"`
+	// Probe 全局注册中心主动探测节点地址的结果（未启用探测时为空）
+	Probe *ProbeStatus `json:"probe,omitempty" yaml:"probe,omitempty"`
 	// LastSeen 最后活跃时间
 	LastSeen time.Time `json:"last_seen" yaml:"last_seen"`
 	// CreatedAt 创建时间
@@ -97,6 +222,16 @@ type Node struct {
 	UpdatedAt time.Time `json:"updated_at" yaml:"updated_at"`
 }
 
+// IsAlive 节点是否仍可参与调度（在线或疑似失效）
+func (n *Node) IsAlive() bool {
+	return n.Status == NodeStatusOnline || n.Status == NodeStatusSuspect
+}
+
+// IsReachable 节点地址是否可达（未探测过的节点视为可达）
+func (n *Node) IsReachable() bool {
+	return n.Probe == nil || n.Probe.Reachable
+}
+
 // Clone 深拷贝节点信息，避免并发读写冲突
 func (n *Node) Clone() *Node {
 	if n == nil {
@@ -110,9 +245,24 @@ func (n *Node) Clone() *Node {
 	if n.ResourceCapacity != nil {
 		copy.ResourceCapacity = n.ResourceCapacity.Clone()
 	}
+	copy.Probe = n.Probe.Clone()
+	copy.Labels = n.Labels.Clone()
+	copy.AdminLabels = n.AdminLabels.Clone()
 	return &copy
 }
 
+// EffectiveLabels 节点生效的键值标签（管理员标签覆盖节点上报标签）
+func (n *Node) EffectiveLabels() Labels {
+	labels := make(Labels, len(n.Labels)+len(n.AdminLabels))
+	for k, v := range n.Labels {
+		labels[k] = v
+	}
+	for k, v := range n.AdminLabels {
+		labels[k] = v
+	}
+	return labels
+}
+
 // Clone 深拷贝 ResourceCapacity
 func (rc *ResourceCapacity) Clone() *ResourceCapacity {
 	if rc == nil {
@@ -137,6 +287,12 @@ func (ri *ResourceInfo) Clone() *ResourceInfo {
 		return nil
 	}
 	copy := *ri
+	if ri.Extended != nil {
+		copy.Extended = make(map[string]int64, len(ri.Extended))
+		for name, value := range ri.Extended {
+			copy.Extended[name] = value
+		}
+	}
 	return &copy
 }
 
@@ -150,10 +306,20 @@ type Domain struct {
 	Description string `json:"description,omitempty" yaml:"description,omitempty"`
 	// ResourceTags 域的资源标签（汇总所有节点的资源标签）
 	ResourceTags *ResourceTags `json:"resource_tags,omitempty" yaml:"resource_tags,omitempty"`
+	// Capacity 域内存活节点的资源汇总，随节点心跳增量更新
+	Capacity *ResourceCapacity `json:"capacity,omitempty" yaml:"capacity,omitempty"`
+	// Labels 域的键值标签（域内所有节点继承）
+	Labels Labels `json:"labels,omitempty" yaml:"labels,omitempty"`
+	// ParentID 上级域的 ID（为空表示顶层域），用于按 region → site → domain 组织域
+	ParentID DomainID `json:"parent_id,omitempty" yaml:"parent_id,omitempty"`
+	// Kind 域的层级类型（如 region、site），放置约束可以按层级限定范围或分散部署
+	Kind string `json:"kind,omitempty" yaml:"kind,omitempty"`
 	// HeadNodeID head 节点的 ID（全局调度器跨域调度的入口）
 	HeadNodeID *NodeID `json:"head_node_id,omitempty" yaml:"head_node_id,omitempty"`
 	// NodeIDs 域下所有节点的 ID 列表
 	NodeIDs []NodeID `json:"node_ids" yaml:"node_ids"`
+	// HealthPolicy 域级健康检查策略覆盖（为空则使用全局默认策略）
+	HealthPolicy *HealthPolicy `json:"health_policy,omitempty" yaml:"health_policy,omitempty"`
 	// CreatedAt 创建时间
 	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
 	// UpdatedAt 更新时间
@@ -177,55 +343,6 @@ func (d *Domain) GetTotalNodeCount() int {
 	return len(d.NodeIDs)
 }
 
-// // UpdateResourceTags 更新域的资源标签（汇总所有节点的资源标签）
-// func (d *Domain) UpdateResourceTags(getNodeResourceTags func(NodeID) *ResourceTags) {
-// 	// 汇总所有节点的资源标签
-// 	aggregatedTags := &ResourceTags{}
-
-// 	for _, nodeID := range d.NodeIDs {
-// 		nodeTags := getNodeResourceTags(nodeID)
-// 		if nodeTags == nil {
-// 			continue
-// 		}
-
-// 		// 汇总 CPU
-// 		if nodeTags.CPU != nil {
-// 			if aggregatedTags.CPU == nil {
-// 				aggregatedTags.CPU = new(int64)
-// 			}
-// 			*aggregatedTags.CPU += *nodeTags.CPU
-// 		}
-
-// 		// 汇总 GPU
-// 		if nodeTags.GPU != nil {
-// 			if aggregatedTags.GPU == nil {
-// 				aggregatedTags.GPU = new(int64)
-// 			}
-// 			*aggregatedTags.GPU += *nodeTags.GPU
-// 		}
-
-// 		// 汇总 Memory（取最大值，因为内存是容量概念）
-// 		if nodeTags.Memory != nil {
-// 			if aggregatedTags.Memory == nil {
-// 				aggregatedTags.Memory = new(int64)
-// 			}
-// 			if *nodeTags.Memory > *aggregatedTags.Memory {
-// 				*aggregatedTags.Memory = *nodeTags.Memory
-// 			}
-// 		}
-
-// 		// 汇总 Camera（任意节点支持即支持）
-// 		if nodeTags.Camera != nil && *nodeTags.Camera {
-// 			if aggregatedTags.Camera == nil {
-// 				aggregatedTags.Camera = new(bool)
-// 			}
-// 			*aggregatedTags.Camera = true
-// 		}
-// 	}
-
-// 	d.ResourceTags = aggregatedTags
-// }
-
 // AddNode 添加节点到域
 func (d *Domain) AddNode(nodeID NodeID) {
 	// 检查节点是否已存在
@@ -0,0 +1,396 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/9triver/iarnet-global/internal/domain/deployment"
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	schedulerpb "github.com/9triver/iarnet-global/internal/proto/scheduler"
+)
+
+const (
+	// maxAffinityWeight 软约束权重上限
+	maxAffinityWeight = 100
+)
+
+// affinity 解析后的放置约束
+type affinity struct {
+	labelTerms      []labelTerm
+	deploymentTerms []deploymentTerm
+	spreadTerms     []spreadTerm
+
+	// scopeRefs 放置范围（域 ID 或名称），scope 为 resolve 后的域 ID
+	scopeRefs []string
+	scope     []registry.DomainID
+	manager   *registry.Manager
+}
+
+// labelTerm 节点/域标签亲和规则
+type labelTerm struct {
+	selector registry.Selector
+	anti     bool
+	required bool
+	weight   int64
+}
+
+// deploymentTerm 与已部署 component 的亲和规则
+type deploymentTerm struct {
+	componentIDs map[string]struct{}
+	selector     registry.Selector
+	topology     schedulerpb.AffinityTopology
+	anti         bool
+	required     bool
+	weight       int64
+
+	// nodes / domains 匹配的部署所在的节点与域（resolve 后填充）
+	nodes   map[registry.NodeID]struct{}
+	domains map[registry.DomainID]struct{}
+}
+
+// spreadTerm 按层级分散部署的规则
+type spreadTerm struct {
+	selector registry.Selector
+	level    string
+	maxSkew  int
+	required bool
+	weight   int64
+
+	// counts 各层级单元中匹配的部署数量，min 为其中的最小值（resolve 后填充）
+	counts map[string]int
+	min    int
+}
+
+// parseAffinity 解析并校验放置约束，未设置时返回 nil
+// labels 为本次请求的 component 标签，作为未指定选择器的分散规则的默认匹配条件
+func parseAffinity(policy *schedulerpb.PlacementPolicy, labels map[string]string) (*affinity, error) {
+	if policy == nil || (len(policy.LabelAffinity) == 0 && len(policy.DeploymentAffinity) == 0 &&
+		len(policy.Domains) == 0 && len(policy.Spread) == 0) {
+		return nil, nil
+	}
+
+	a := &affinity{}
+	for i, ref := range policy.Domains {
+		if ref == "" {
+			return nil, fmt.Errorf("invalid placement: domains[%d] is empty", i)
+		}
+		a.scopeRefs = append(a.scopeRefs, ref)
+	}
+	for i, term := range policy.LabelAffinity {
+		if len(term.Selector) == 0 {
+			return nil, fmt.Errorf("invalid placement: label_affinity[%d] requires a selector", i)
+		}
+		selector, err := registry.ParseSelectors(term.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid placement: label_affinity[%d]: %w", i, err)
+		}
+		weight, err := affinityWeight(term.Weight)
+		if err != nil {
+			return nil, fmt.Errorf("invalid placement: label_affinity[%d]: %w", i, err)
+		}
+		a.labelTerms = append(a.labelTerms, labelTerm{
+			selector: selector,
+			anti:     term.Anti,
+			required: term.Required,
+			weight:   weight,
+		})
+	}
+
+	for i, term := range policy.DeploymentAffinity {
+		if len(term.ComponentIds) == 0 && len(term.Selector) == 0 {
+			return nil, fmt.Errorf("invalid placement: deployment_affinity[%d] requires component_ids or a selector", i)
+		}
+		selector, err := registry.ParseSelectors(term.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid placement: deployment_affinity[%d]: %w", i, err)
+		}
+		weight, err := affinityWeight(term.Weight)
+		if err != nil {
+			return nil, fmt.Errorf("invalid placement: deployment_affinity[%d]: %w", i, err)
+		}
+		componentIDs := make(map[string]struct{}, len(term.ComponentIds))
+		for _, id := range term.ComponentIds {
+			componentIDs[id] = struct{}{}
+		}
+		a.deploymentTerms = append(a.deploymentTerms, deploymentTerm{
+			componentIDs: componentIDs,
+			selector:     selector,
+			topology:     term.Topology,
+			anti:         term.Anti,
+			required:     term.Required,
+			weight:       weight,
+		})
+	}
+
+	for i, term := range policy.Spread {
+		if term.Level == "" {
+			return nil, fmt.Errorf("invalid placement: spread[%d] requires a level", i)
+		}
+		if term.MaxSkew < 0 {
+			return nil, fmt.Errorf("invalid placement: spread[%d]: max_skew must not be negative", i)
+		}
+		var selector registry.Selector
+		if len(term.Selector) > 0 {
+			var err error
+			if selector, err = registry.ParseSelectors(term.Selector); err != nil {
+				return nil, fmt.Errorf("invalid placement: spread[%d]: %w", i, err)
+			}
+		} else if len(labels) > 0 {
+			selector = labelsSelector(labels)
+		} else {
+			return nil, fmt.Errorf("invalid placement: spread[%d] requires a selector or request labels", i)
+		}
+		weight, err := affinityWeight(term.Weight)
+		if err != nil {
+			return nil, fmt.Errorf("invalid placement: spread[%d]: %w", i, err)
+		}
+		maxSkew := int(term.MaxSkew)
+		if maxSkew == 0 {
+			maxSkew = 1
+		}
+		a.spreadTerms = append(a.spreadTerms, spreadTerm{
+			selector: selector,
+			level:    term.Level,
+			maxSkew:  maxSkew,
+			required: term.Required,
+			weight:   weight,
+		})
+	}
+
+	return a, nil
+}
+
+// labelsSelector 将标签转换为逐一相等匹配的选择器
+func labelsSelector(labels map[string]string) registry.Selector {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	selector := make(registry.Selector, 0, len(keys))
+	for _, key := range keys {
+		selector = append(selector, registry.Requirement{
+			Key:      key,
+			Operator: registry.SelectorOpEquals,
+			Values:   []string{labels[key]},
+		})
+	}
+	return selector
+}
+
+func affinityWeight(weight int32) (int64, error) {
+	if weight < 0 || weight > maxAffinityWeight {
+		return 0, fmt.Errorf("weight must be between 0 and %d", maxAffinityWeight)
+	}
+	if weight == 0 {
+		return 1, nil
+	}
+	return int64(weight), nil
+}
+
+// resolve 解析放置范围，并根据租户的活跃部署计算部署亲和规则命中的节点与域、分散规则各单元的部署数量
+// usable 非空时只有返回 true 的域参与分散统计
+func (a *affinity) resolve(deployments []*deployment.Deployment, manager *registry.Manager, usable func(registry.DomainID) bool) error {
+	a.manager = manager
+	a.scope = a.scope[:0]
+	for _, ref := range a.scopeRefs {
+		domainID, err := manager.ResolveDomain(ref)
+		if err != nil {
+			return fmt.Errorf("invalid placement: %w", err)
+		}
+		a.scope = append(a.scope, domainID)
+	}
+
+	for i := range a.deploymentTerms {
+		term := &a.deploymentTerms[i]
+		term.nodes = make(map[registry.NodeID]struct{})
+		term.domains = make(map[registry.DomainID]struct{})
+		for _, d := range deployments {
+			if !term.matches(d) {
+				continue
+			}
+			term.nodes[d.NodeID] = struct{}{}
+			term.domains[d.DomainID] = struct{}{}
+		}
+	}
+
+	if len(a.spreadTerms) > 0 {
+		a.resolveSpread(deployments, usable)
+	}
+	return nil
+}
+
+// resolveSpread 统计分散规则各层级单元的部署数量
+// 存在可调度节点的单元即使没有部署也计为 0，使最小值反映真正空闲的单元
+func (a *affinity) resolveSpread(deployments []*deployment.Deployment, usable func(registry.DomainID) bool) {
+	for i := range a.spreadTerms {
+		a.spreadTerms[i].counts = make(map[string]int)
+	}
+
+	for _, domain := range a.manager.GetAllDomains() {
+		if (usable != nil && !usable(domain.ID)) || !a.inScope(domain.ID) {
+			continue
+		}
+		nodes, err := a.manager.GetNodesByDomain(domain.ID)
+		if err != nil {
+			continue
+		}
+		for _, node := range nodes {
+			if !node.IsAlive() || node.Cordoned {
+				continue
+			}
+			for i := range a.spreadTerms {
+				term := &a.spreadTerms[i]
+				if group, ok := a.groupOf(term.level, node.ID, node.DomainID); ok {
+					if _, seen := term.counts[group]; !seen {
+						term.counts[group] = 0
+					}
+				}
+			}
+		}
+	}
+
+	for _, d := range deployments {
+		if d.NodeID == "" {
+			continue
+		}
+		for i := range a.spreadTerms {
+			term := &a.spreadTerms[i]
+			if !term.selector.Matches(d.Labels()) {
+				continue
+			}
+			if group, ok := a.groupOf(term.level, d.NodeID, d.DomainID); ok {
+				term.counts[group]++
+			}
+		}
+	}
+
+	for i := range a.spreadTerms {
+		term := &a.spreadTerms[i]
+		term.min = 0
+		first := true
+		for _, count := range term.counts {
+			if first || count < term.min {
+				term.min = count
+				first = false
+			}
+		}
+	}
+}
+
+// groupOf 返回节点在指定层级上所属的单元
+func (a *affinity) groupOf(level string, nodeID registry.NodeID, domainID registry.DomainID) (string, bool) {
+	if level == registry.LevelNode {
+		return nodeID, nodeID != ""
+	}
+	if domainID == "" {
+		return "", false
+	}
+	return a.manager.DomainAtLevel(domainID, level)
+}
+
+// inScope 域是否位于放置范围内
+func (a *affinity) inScope(domainID registry.DomainID) bool {
+	if a == nil || len(a.scope) == 0 {
+		return true
+	}
+	for _, ancestor := range a.scope {
+		if a.manager.IsWithin(domainID, ancestor) {
+			return true
+		}
+	}
+	return false
+}
+
+// feasible 节点是否满足所有硬约束
+func (a *affinity) feasible(node *registry.Node, domain *registry.Domain) bool {
+	if a == nil {
+		return true
+	}
+	if !a.inScope(node.DomainID) {
+		return false
+	}
+	labels := registry.NodeLabelSet(node, domain)
+	for _, term := range a.labelTerms {
+		if term.required && !term.satisfied(labels) {
+			return false
+		}
+	}
+	for _, term := range a.deploymentTerms {
+		if term.required && !term.satisfied(node) {
+			return false
+		}
+	}
+	for _, term := range a.spreadTerms {
+		if term.required && !a.spreadSatisfied(term, node) {
+			return false
+		}
+	}
+	return true
+}
+
+// score 节点满足的软约束权重之和
+func (a *affinity) score(node *registry.Node, domain *registry.Domain) int64 {
+	if a == nil {
+		return 0
+	}
+	var score int64
+	labels := registry.NodeLabelSet(node, domain)
+	for _, term := range a.labelTerms {
+		if !term.required && term.satisfied(labels) {
+			score += term.weight
+		}
+	}
+	for _, term := range a.deploymentTerms {
+		if !term.required && term.satisfied(node) {
+			score += term.weight
+		}
+	}
+	for _, term := range a.spreadTerms {
+		if term.required {
+			continue
+		}
+		if group, ok := a.groupOf(term.level, node.ID, node.DomainID); ok && term.counts[group] == term.min {
+			score += term.weight
+		}
+	}
+	return score
+}
+
+// spreadSatisfied 在节点上再部署一份后，该节点所在单元与最少部署单元的差值不超过 max_skew
+func (a *affinity) spreadSatisfied(term spreadTerm, node *registry.Node) bool {
+	group, ok := a.groupOf(term.level, node.ID, node.DomainID)
+	if !ok {
+		return false
+	}
+	return term.counts[group]+1-term.min <= term.maxSkew
+}
+
+func (t labelTerm) satisfied(labels registry.LabelSet) bool {
+	return t.selector.Matches(labels) != t.anti
+}
+
+// matches 部署是否被该规则选中
+func (t deploymentTerm) matches(d *deployment.Deployment) bool {
+	if len(t.componentIDs) > 0 {
+		if _, ok := t.componentIDs[d.ComponentID]; !ok {
+			return false
+		}
+	}
+	if len(t.selector) > 0 && !t.selector.Matches(d.Labels()) {
+		return false
+	}
+	return true
+}
+
+// satisfied 节点是否满足规则：亲和要求拓扑范围内存在匹配部署，反亲和要求不存在
+func (t deploymentTerm) satisfied(node *registry.Node) bool {
+	var colocated bool
+	switch t.topology {
+	case schedulerpb.AffinityTopology_AFFINITY_TOPOLOGY_DOMAIN:
+		_, colocated = t.domains[node.DomainID]
+	default:
+		_, colocated = t.nodes[node.ID]
+	}
+	return colocated != t.anti
+}
@@ -0,0 +1,67 @@
+package scheduler
+
+import (
+	"context"
+
+	"github.com/9triver/iarnet-global/internal/domain/deployment"
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	"github.com/9triver/iarnet-global/internal/domain/tenant"
+	schedulerpb "github.com/9triver/iarnet-global/internal/proto/scheduler"
+	"github.com/sirupsen/logrus"
+)
+
+// EvictResult 节点驱逐结果
+type EvictResult struct {
+	NodeID   registry.NodeID     `json:"node_id"`
+	Migrated []EvictedDeployment `json:"migrated"` // 已迁移到其他节点的部署
+	Failed   []EvictedDeployment `json:"failed"`   // 迁移失败、仍留在原节点的部署
+}
+
+// EvictedDeployment 单个部署的驱逐结果
+type EvictedDeployment struct {
+	DeploymentID    deployment.DeploymentID `json:"deployment_id"`
+	Tenant          string                  `json:"tenant"`
+	NewDeploymentID deployment.DeploymentID `json:"new_deployment_id,omitempty"`
+	NodeID          registry.NodeID         `json:"node_id,omitempty"` // 迁移后的节点
+	Error           string                  `json:"error,omitempty"`
+}
+
+// EvictNode 将节点上运行中的 component 逐个迁移到其他节点
+// 每个迁移以部署所属租户的身份执行，与 MigrateComponent 一样需要通过配额准入；
+// 通常先将节点标记为不可调度，避免驱逐期间有新的 component 被放置到该节点
+func (s *service) EvictNode(ctx context.Context, nodeID registry.NodeID) (*EvictResult, error) {
+	if _, err := s.manager.GetNode(nodeID); err != nil {
+		return nil, err
+	}
+
+	result := &EvictResult{
+		NodeID:   nodeID,
+		Migrated: make([]EvictedDeployment, 0),
+		Failed:   make([]EvictedDeployment, 0),
+	}
+	for _, d := range s.tracker.List(deployment.Filter{NodeID: nodeID, Status: deployment.StatusRunning}) {
+		item := EvictedDeployment{DeploymentID: d.ID, Tenant: d.Tenant}
+
+		tenantCtx := tenant.WithIdentity(ctx, &tenant.Identity{Tenant: d.Tenant})
+		resp, err := s.MigrateComponent(tenantCtx, &schedulerpb.MigrateComponentRequest{DeploymentId: d.ID})
+		switch {
+		case err != nil:
+			item.Error = err.Error()
+		case !resp.Success:
+			item.Error = resp.Error
+		default:
+			item.NewDeploymentID = resp.DeploymentId
+			item.NodeID = resp.NodeId
+			item.Error = resp.Error
+		}
+
+		if item.NewDeploymentID == "" {
+			result.Failed = append(result.Failed, item)
+			continue
+		}
+		result.Migrated = append(result.Migrated, item)
+	}
+
+	logrus.Infof("Evicted node %s: %d deployment(s) migrated, %d failed", nodeID, len(result.Migrated), len(result.Failed))
+	return result, nil
+}
@@ -0,0 +1,169 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/9triver/iarnet-global/internal/domain/audit"
+	"github.com/9triver/iarnet-global/internal/domain/deployment"
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	"github.com/9triver/iarnet-global/internal/domain/tenant"
+	schedulerpb "github.com/9triver/iarnet-global/internal/proto/scheduler"
+	"github.com/9triver/iarnet-global/internal/util"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+)
+
+// Federation 跨实例联邦：本地域无法容纳请求时转发到对等的 iarnet-global 实例
+// 对等实例是一个虚拟域，转发的部署以对等实例 ID 作为域 ID 登记；对等实例转发来的请求以对等实例 ID 作为租户
+type Federation interface {
+	// Authenticate 解析对等实例转发请求携带的联邦令牌，请求未携带联邦令牌时返回 nil
+	Authenticate(ctx context.Context) (*tenant.Identity, error)
+	// IsPeer 租户是否为对等实例
+	IsPeer(tenantID string) bool
+	// AdmitInbound 检查对等实例转发的部署是否超出为其开放的上限，tenantID 不是对等实例时不做限制
+	AdmitInbound(tenantID string, requested *registry.ResourceInfo) error
+	// Candidates 按优先级返回可以转发的对等实例（已排除转发路径中的实例）
+	Candidates(ctx context.Context, requested *registry.ResourceInfo) []string
+	// AdmitOutbound 检查转发到对等实例的部署是否超出出站上限
+	AdmitOutbound(peerID string, requested *registry.ResourceInfo) error
+	// Deploy 将部署请求转发到对等实例
+	Deploy(ctx context.Context, peerID string, req *schedulerpb.DeployComponentRequest) (*schedulerpb.DeployComponentResponse, error)
+	// StopRemote 停止转发到对等实例的部署
+	StopRemote(ctx context.Context, d *deployment.Deployment, reason string) error
+	// RemoveRemote 移除转发到对等实例的部署
+	RemoveRemote(ctx context.Context, d *deployment.Deployment) error
+}
+
+// inboundRequest 对等实例转发的请求不参与抢占与排队：抢占本实例的 component 需要本地授权，
+// 排队则会让对端无法及时尝试其他实例
+func inboundRequest(req *schedulerpb.DeployComponentRequest) *schedulerpb.DeployComponentRequest {
+	if req.Priority == 0 && req.MaxWaitSeconds == 0 {
+		return req
+	}
+	inbound := proto.Clone(req).(*schedulerpb.DeployComponentRequest)
+	inbound.Priority = 0
+	inbound.MaxWaitSeconds = 0
+	return inbound
+}
+
+// canFederate 本地放置失败的原因是否可以通过转发到对等实例解决
+// 配额错误与本地节点相关的亲和约束无法由对等实例满足
+func (s *service) canFederate(req *schedulerpb.DeployComponentRequest, err error) bool {
+	if s.federation == nil || req.Placement != nil {
+		return false
+	}
+	return errors.Is(err, errNoCapacity) || errors.Is(err, errConstraintsUnsatisfied)
+}
+
+// forwardToPeer 依次尝试摘要显示可以容纳请求的对等实例，成功时返回以本地部署 ID 改写的响应
+// 全部失败时删除部署记录并返回 nil，由调用方回退到排队或拒绝
+func (s *service) forwardToPeer(ctx context.Context, tenantID string, req *schedulerpb.DeployComponentRequest, requested *registry.ResourceInfo) *schedulerpb.DeployComponentResponse {
+	peers := s.federation.Candidates(ctx, requested)
+	if len(peers) == 0 {
+		return nil
+	}
+
+	var record *deployment.Deployment
+	for _, peerID := range peers {
+		var err error
+		record, err = s.admitPeer(ctx, record, tenantID, req, requested, peerID)
+		if err != nil {
+			logrus.Warnf("Skipped federation peer %s: %v", peerID, err)
+			continue
+		}
+
+		resp, err := s.federation.Deploy(ctx, peerID, req)
+		if err != nil {
+			logrus.Warnf("Failed to forward scheduling request to peer %s: %v", peerID, err)
+			continue
+		}
+		if !resp.Success {
+			logrus.Warnf("Peer %s rejected forwarded scheduling request: %s", peerID, resp.Error)
+			continue
+		}
+
+		s.markForwarded(ctx, record.ID, resp)
+		s.recordAudit(ctx, &audit.Event{
+			Type:         audit.EventDeploymentForwarded,
+			Tenant:       tenantID,
+			DeploymentID: record.ID,
+			DomainID:     peerID,
+			Message:      fmt.Sprintf("no local domain could host the component, forwarded to peer %s", peerID),
+			Details: map[string]string{
+				"peer_id":   peerID,
+				"remote_id": resp.DeploymentId,
+			},
+		})
+		logrus.Infof("Forwarded scheduling request to peer %s (deployment=%s, remote=%s)", peerID, record.ID, resp.DeploymentId)
+
+		resp.DeploymentId = record.ID
+		resp.Status = schedulerpb.ComponentStatus_COMPONENT_STATUS_RUNNING
+		return resp
+	}
+
+	if record != nil {
+		if err := s.tracker.Delete(ctx, record.ID); err != nil {
+			logrus.Errorf("Failed to delete deployment %s: %v", record.ID, err)
+		}
+	}
+	return nil
+}
+
+// admitPeer 在准入锁内检查出站上限并登记（或改写）转发到对等实例的部署记录
+func (s *service) admitPeer(ctx context.Context, record *deployment.Deployment, tenantID string, req *schedulerpb.DeployComponentRequest, requested *registry.ResourceInfo, peerID string) (*deployment.Deployment, error) {
+	s.admitMu.Lock()
+	defer s.admitMu.Unlock()
+
+	// 上一次尝试的记录登记在其他对等实例的虚拟域下，不计入本次检查的用量
+	if err := s.federation.AdmitOutbound(peerID, requested); err != nil {
+		return record, err
+	}
+	if s.quotas != nil {
+		if err := s.quotas.Check(tenantID, peerID, requested); err != nil {
+			return record, err
+		}
+	}
+
+	if record != nil {
+		return s.tracker.Update(ctx, record.ID, func(d *deployment.Deployment) {
+			d.DomainID = peerID
+			d.PeerID = peerID
+		})
+	}
+
+	record = &deployment.Deployment{
+		ID:        util.GenIDWith("deploy."),
+		Tenant:    tenantID,
+		DomainID:  peerID,
+		PeerID:    peerID,
+		Status:    deployment.StatusDeploying,
+		Resources: requested,
+		Request:   req,
+	}
+	if err := s.tracker.Create(ctx, record); err != nil {
+		return nil, fmt.Errorf("failed to record deployment: %w", err)
+	}
+	return record, nil
+}
+
+// markForwarded 记录对等实例返回的部署信息
+func (s *service) markForwarded(ctx context.Context, id deployment.DeploymentID, resp *schedulerpb.DeployComponentResponse) {
+	_, err := s.tracker.Update(ctx, id, func(d *deployment.Deployment) {
+		d.Status = deployment.StatusRunning
+		d.RemoteID = resp.DeploymentId
+		d.ProviderID = resp.ProviderId
+		d.NodeID = resp.NodeId
+		d.NodeName = resp.NodeName
+		if resp.Component != nil {
+			d.ComponentID = resp.Component.ComponentId
+			if d.ProviderID == "" {
+				d.ProviderID = resp.Component.ProviderId
+			}
+		}
+	})
+	if err != nil {
+		logrus.Errorf("Failed to update deployment %s: %v", id, err)
+	}
+}
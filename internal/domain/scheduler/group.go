@@ -0,0 +1,231 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/9triver/iarnet-global/internal/domain/audit"
+	"github.com/9triver/iarnet-global/internal/domain/deployment"
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	schedulerpb "github.com/9triver/iarnet-global/internal/proto/scheduler"
+	"github.com/9triver/iarnet-global/internal/util"
+	"github.com/sirupsen/logrus"
+)
+
+// groupMember 已预留节点的组成员
+type groupMember struct {
+	index  int
+	node   *registry.Node
+	record *deployment.Deployment
+}
+
+// DeployComponentGroup 成组部署：先为所有成员预留节点，全部可放置后并发转发；
+// 任一成员部署失败时移除已部署成功的成员并删除整组的部署记录，整组失败
+func (s *service) DeployComponentGroup(ctx context.Context, req *schedulerpb.DeployComponentGroupRequest) (*schedulerpb.DeployComponentGroupResponse, error) {
+	if req == nil || len(req.Members) == 0 {
+		return groupFailure("", "at least one member is required"), nil
+	}
+
+	groupID := req.GroupId
+	if groupID == "" {
+		groupID = util.GenIDWith("group.")
+	}
+
+	for i, member := range req.Members {
+		if member == nil || member.ResourceRequest == nil {
+			return groupFailure(groupID, fmt.Sprintf("member %d: resource_request is required", i)), nil
+		}
+		if _, err := buildPlacement(member); err != nil {
+			return groupFailure(groupID, fmt.Sprintf("member %d: %v", i, err)), nil
+		}
+	}
+
+	identity, err := s.identityFromContext(ctx)
+	if err != nil {
+		logrus.Warnf("Rejected unauthenticated group scheduling request: %v", err)
+		return groupFailure(groupID, err.Error()), nil
+	}
+
+	members, err := s.reserveGroup(ctx, identity.Tenant, req.Members)
+	if err != nil {
+		logrus.Warnf("Failed to place component group %s from tenant %s: %v", groupID, identity.Tenant, err)
+		return groupFailure(groupID, err.Error()), nil
+	}
+
+	// 全部成员已预留节点，并发转发
+	responses := make([]*schedulerpb.DeployComponentResponse, len(members))
+	var wg sync.WaitGroup
+	for _, m := range members {
+		wg.Add(1)
+		go func(m *groupMember) {
+			defer wg.Done()
+			responses[m.index] = s.dispatch(ctx, m.record.ID, m.node, req.Members[m.index])
+		}(m)
+	}
+	wg.Wait()
+
+	failed := make([]string, 0)
+	for i, resp := range responses {
+		if !resp.Success {
+			failed = append(failed, fmt.Sprintf("member %d: %s", i, resp.Error))
+		}
+	}
+	if len(failed) == 0 {
+		logrus.Infof("Deployed component group %s with %d member(s) for tenant %s", groupID, len(members), identity.Tenant)
+		return &schedulerpb.DeployComponentGroupResponse{
+			Success: true,
+			GroupId: groupID,
+			Members: responses,
+		}, nil
+	}
+
+	reason := fmt.Sprintf("component group %s failed: %s", groupID, strings.Join(failed, "; "))
+	s.rollbackGroup(ctx, groupID, identity.Tenant, members, responses, reason)
+	return &schedulerpb.DeployComponentGroupResponse{
+		Success: false,
+		Error:   reason,
+		GroupId: groupID,
+		Members: responses,
+	}, nil
+}
+
+// reserveGroup 在准入锁内依次为所有成员选择节点并登记部署记录
+// 已登记的成员计入后续成员的配额、亲和规则与节点预留资源；按请求顺序无法放置时，再按资源从大到小的顺序尝试一次
+func (s *service) reserveGroup(ctx context.Context, tenantID string, requests []*schedulerpb.DeployComponentRequest) ([]*groupMember, error) {
+	s.admitMu.Lock()
+	defer s.admitMu.Unlock()
+
+	orders := [][]int{make([]int, len(requests))}
+	for i := range requests {
+		orders[0][i] = i
+	}
+	bySize := append([]int(nil), orders[0]...)
+	sort.SliceStable(bySize, func(a, b int) bool {
+		return largerResources(requestedResources(requests[bySize[a]].ResourceRequest), requestedResources(requests[bySize[b]].ResourceRequest))
+	})
+	for i := range bySize {
+		if bySize[i] != i {
+			orders = append(orders, bySize)
+			break
+		}
+	}
+
+	var lastErr error
+	for _, order := range orders {
+		members, err := s.tryReserveGroup(ctx, tenantID, requests, order)
+		if err == nil {
+			return members, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// tryReserveGroup 按指定顺序为成员预留节点，任一成员无法放置时删除本次登记的全部记录
+func (s *service) tryReserveGroup(ctx context.Context, tenantID string, requests []*schedulerpb.DeployComponentRequest, order []int) ([]*groupMember, error) {
+	members := make([]*groupMember, 0, len(requests))
+	release := func() {
+		for _, m := range members {
+			if err := s.tracker.Delete(ctx, m.record.ID); err != nil {
+				logrus.Errorf("Failed to release reservation %s: %v", m.record.ID, err)
+			}
+		}
+	}
+
+	for _, i := range order {
+		req := requests[i]
+		p, err := buildPlacement(req)
+		if err != nil {
+			release()
+			return nil, fmt.Errorf("member %d: %w", i, err)
+		}
+		// 已预留的成员尚未转发，同样需要从节点可用资源中扣除
+		p.reserved = s.inflightReservations()
+
+		requested := requestedResources(req.ResourceRequest)
+		targetNode, err := s.place(tenantID, req, requested, p)
+		if err != nil {
+			release()
+			return nil, fmt.Errorf("member %d: %w", i, err)
+		}
+
+		record := &deployment.Deployment{
+			ID:        util.GenIDWith("deploy."),
+			Tenant:    tenantID,
+			DomainID:  targetNode.DomainID,
+			NodeID:    targetNode.ID,
+			NodeName:  targetNode.Name,
+			Status:    deployment.StatusDeploying,
+			Resources: requested,
+			Request:   req,
+		}
+		if err := s.tracker.Create(ctx, record); err != nil {
+			release()
+			return nil, fmt.Errorf("failed to record deployment: %w", err)
+		}
+		members = append(members, &groupMember{index: i, node: targetNode, record: record})
+	}
+	return members, nil
+}
+
+// rollbackGroup 移除已部署成功的成员并删除整组的部署记录，失败的成组部署不在节点和部署列表中留下残留
+// 移除失败的成员保留运行中的记录，以便之后通过 RemoveComponent 清理
+func (s *service) rollbackGroup(ctx context.Context, groupID, tenantID string, members []*groupMember, responses []*schedulerpb.DeployComponentResponse, reason string) {
+	rolledBack := make([]string, 0)
+	leftover := make([]string, 0)
+	for _, m := range members {
+		resp := responses[m.index]
+		d, err := s.tracker.Get(m.record.ID)
+		if err != nil {
+			continue
+		}
+
+		if resp.Success {
+			err := s.removeComponent(ctx, d, m.node)
+			if err != nil && !errors.Is(err, registry.ErrNodeNotFound) {
+				logrus.Errorf("Failed to roll back member %s of component group %s: %v", d.ID, groupID, err)
+				_, _ = s.tracker.Update(ctx, d.ID, func(d *deployment.Deployment) {
+					d.Error = fmt.Sprintf("%s; rollback failed: %v", reason, err)
+				})
+				resp.Success = false
+				resp.Error = fmt.Sprintf("rollback failed: %v", err)
+				leftover = append(leftover, d.ID)
+				continue
+			}
+			resp.Success = false
+			resp.Status = schedulerpb.ComponentStatus_COMPONENT_STATUS_STOPPED
+			resp.Error = "rolled back: " + reason
+			rolledBack = append(rolledBack, d.ID)
+		}
+
+		if err := s.tracker.Delete(ctx, d.ID); err != nil {
+			logrus.Errorf("Failed to delete deployment %s of component group %s: %v", d.ID, groupID, err)
+		}
+	}
+
+	details := map[string]string{
+		"group":       groupID,
+		"rolled_back": strings.Join(rolledBack, ","),
+	}
+	if len(leftover) > 0 {
+		details["leftover"] = strings.Join(leftover, ",")
+	}
+	s.recordAudit(ctx, &audit.Event{
+		Type:    audit.EventGroupRolledBack,
+		Tenant:  tenantID,
+		Message: reason,
+		Details: details,
+	})
+}
+
+func groupFailure(groupID, msg string) *schedulerpb.DeployComponentGroupResponse {
+	return &schedulerpb.DeployComponentGroupResponse{
+		Success: false,
+		Error:   msg,
+		GroupId: groupID,
+	}
+}
@@ -0,0 +1,333 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/9triver/iarnet-global/internal/domain/audit"
+	"github.com/9triver/iarnet-global/internal/domain/deployment"
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	schedulerpb "github.com/9triver/iarnet-global/internal/proto/scheduler"
+	"github.com/9triver/iarnet-global/internal/util"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// StopComponent 停止通过全局调度器部署的 component
+// 排队中的部署直接取消；运行中的部署转发到所在节点停止
+func (s *service) StopComponent(ctx context.Context, req *schedulerpb.StopComponentRequest) (*schedulerpb.StopComponentResponse, error) {
+	if req == nil {
+		return stopFailure("request is required"), nil
+	}
+
+	d, err := s.lookupDeployment(ctx, req.DeploymentId, req.ComponentId)
+	if err != nil {
+		return stopFailure(err.Error()), nil
+	}
+
+	switch d.Status {
+	case deployment.StatusStopped:
+		return &schedulerpb.StopComponentResponse{Success: true}, nil
+	case deployment.StatusPending:
+		// 取消排队
+	case deployment.StatusRunning:
+		if err := s.stopComponent(ctx, d, nil, req.Reason); err != nil {
+			logrus.Errorf("Failed to stop component %s of deployment %s on node %s: %v", d.ComponentID, d.ID, d.NodeID, err)
+			return stopFailure(fmt.Sprintf("failed to stop component: %v", err)), nil
+		}
+	default:
+		return stopFailure(fmt.Sprintf("deployment %s is %s and cannot be stopped", d.ID, d.Status)), nil
+	}
+
+	_, err = s.tracker.Update(ctx, d.ID, func(d *deployment.Deployment) {
+		d.Status = deployment.StatusStopped
+		d.Error = req.Reason
+	})
+	if err != nil {
+		return stopFailure(err.Error()), nil
+	}
+
+	logrus.Infof("Stopped deployment %s (component=%s, node=%s)", d.ID, d.ComponentID, d.NodeID)
+	return &schedulerpb.StopComponentResponse{Success: true}, nil
+}
+
+// RemoveComponent 移除 component 并删除部署记录
+// 所在节点已不在注册中心时只删除部署记录
+func (s *service) RemoveComponent(ctx context.Context, req *schedulerpb.RemoveComponentRequest) (*schedulerpb.RemoveComponentResponse, error) {
+	if req == nil {
+		return removeFailure("request is required"), nil
+	}
+
+	d, err := s.lookupDeployment(ctx, req.DeploymentId, req.ComponentId)
+	if err != nil {
+		return removeFailure(err.Error()), nil
+	}
+	if d.Status == deployment.StatusDeploying {
+		return removeFailure(fmt.Sprintf("deployment %s is still being dispatched", d.ID)), nil
+	}
+
+	if d.ComponentID != "" && (d.Status == deployment.StatusRunning || d.Status == deployment.StatusStopped) {
+		err := s.removeComponent(ctx, d, nil)
+		switch {
+		case errors.Is(err, registry.ErrNodeNotFound):
+			logrus.Warnf("Node %s of deployment %s is no longer registered, removing record only", d.NodeID, d.ID)
+		case err != nil:
+			logrus.Errorf("Failed to remove component %s of deployment %s on node %s: %v", d.ComponentID, d.ID, d.NodeID, err)
+			return removeFailure(fmt.Sprintf("failed to remove component: %v", err)), nil
+		}
+	}
+
+	// 记录删除后无法再追踪失联节点上的旧实例，尽量先移除
+	if len(d.StaleInstances) > 0 {
+		s.removeStaleInstances(ctx, d)
+	}
+
+	if err := s.tracker.Delete(ctx, d.ID); err != nil {
+		return removeFailure(err.Error()), nil
+	}
+
+	logrus.Infof("Removed deployment %s (component=%s, node=%s)", d.ID, d.ComponentID, d.NodeID)
+	return &schedulerpb.RemoveComponentResponse{Success: true}, nil
+}
+
+// MigrateComponent 将运行中的 component 迁移到其他节点或域
+// 先按原始请求在目标范围内放置并部署新实例，成功后移除旧实例；迁移期间新旧实例同时存在，新实例同样需要通过配额准入
+func (s *service) MigrateComponent(ctx context.Context, req *schedulerpb.MigrateComponentRequest) (*schedulerpb.MigrateComponentResponse, error) {
+	if req == nil {
+		return migrateFailure("request is required"), nil
+	}
+
+	d, err := s.lookupDeployment(ctx, req.DeploymentId, req.ComponentId)
+	if err != nil {
+		return migrateFailure(err.Error()), nil
+	}
+	if d.Status != deployment.StatusRunning || d.ComponentID == "" {
+		return migrateFailure(fmt.Sprintf("deployment %s is %s, only running deployments can be migrated", d.ID, d.Status)), nil
+	}
+	if d.Federated() {
+		return migrateFailure(fmt.Sprintf("deployment %s runs on federation peer %s and cannot be migrated", d.ID, d.PeerID)), nil
+	}
+	if d.Request == nil {
+		return migrateFailure("original deployment request is missing"), nil
+	}
+	if req.TargetNodeId != "" {
+		target, err := s.manager.GetNode(req.TargetNodeId)
+		if err != nil {
+			return migrateFailure(fmt.Sprintf("target node %s not found", req.TargetNodeId)), nil
+		}
+		if req.TargetDomainId != "" && target.DomainID != req.TargetDomainId {
+			return migrateFailure(fmt.Sprintf("target node %s is not in domain %s", req.TargetNodeId, req.TargetDomainId)), nil
+		}
+		if target.ID == d.NodeID {
+			return migrateFailure(fmt.Sprintf("component already runs on node %s", d.NodeID)), nil
+		}
+	}
+
+	targetNode, record, err := s.admitMigration(ctx, d, req.TargetNodeId, req.TargetDomainId)
+	if err != nil {
+		logrus.Warnf("Failed to place migration of deployment %s: %v", d.ID, err)
+		return migrateFailure(err.Error()), nil
+	}
+
+	deployResp := s.dispatch(ctx, record.ID, targetNode, d.Request)
+	if !deployResp.Success {
+		return &schedulerpb.MigrateComponentResponse{
+			Success:              false,
+			Error:                fmt.Sprintf("failed to deploy new instance: %s", deployResp.Error),
+			DeploymentId:         record.ID,
+			PreviousDeploymentId: d.ID,
+		}, nil
+	}
+
+	resp := &schedulerpb.MigrateComponentResponse{
+		Success:              true,
+		DeploymentId:         record.ID,
+		Component:            deployResp.Component,
+		NodeId:               deployResp.NodeId,
+		NodeName:             deployResp.NodeName,
+		PreviousDeploymentId: d.ID,
+	}
+	if resp.NodeId == "" {
+		resp.NodeId = targetNode.ID
+		resp.NodeName = targetNode.Name
+	}
+
+	// 退役旧实例
+	if err := s.removeComponent(ctx, d, nil); err != nil && !errors.Is(err, registry.ErrNodeNotFound) {
+		logrus.Errorf("Migrated deployment %s to %s but failed to retire the previous instance: %v", d.ID, record.ID, err)
+		resp.Error = fmt.Sprintf("new instance is running but the previous instance could not be retired: %v", err)
+	} else {
+		_, _ = s.tracker.Update(ctx, d.ID, func(old *deployment.Deployment) {
+			old.Status = deployment.StatusStopped
+			old.Error = fmt.Sprintf("migrated to deployment %s", record.ID)
+		})
+	}
+
+	details := map[string]string{
+		"from_node":     d.NodeID,
+		"to_deployment": record.ID,
+		"to_component":  deployResp.GetComponent().GetComponentId(),
+	}
+	if resp.Error != "" {
+		details["error"] = resp.Error
+	}
+	s.recordAudit(ctx, &audit.Event{
+		Type:         audit.EventComponentMigrated,
+		Tenant:       d.Tenant,
+		DeploymentID: d.ID,
+		DomainID:     targetNode.DomainID,
+		NodeID:       targetNode.ID,
+		Message:      fmt.Sprintf("component migrated from node %s to node %s", d.NodeName, targetNode.Name),
+		Details:      details,
+	})
+
+	logrus.Infof("Migrated deployment %s from node %s to node %s as %s", d.ID, d.NodeID, targetNode.ID, record.ID)
+	return resp, nil
+}
+
+// admitMigration 为迁移的新实例选择节点（排除当前节点）并登记部署记录
+func (s *service) admitMigration(ctx context.Context, d *deployment.Deployment, targetNodeID registry.NodeID, targetDomainID registry.DomainID) (*registry.Node, *deployment.Deployment, error) {
+	p, err := buildPlacement(d.Request)
+	if err != nil {
+		return nil, nil, err
+	}
+	p.allowNode = func(node *registry.Node) bool {
+		if node.ID == d.NodeID {
+			return false
+		}
+		if targetNodeID != "" && node.ID != targetNodeID {
+			return false
+		}
+		return targetDomainID == "" || node.DomainID == targetDomainID
+	}
+
+	s.admitMu.Lock()
+	defer s.admitMu.Unlock()
+
+	p.reserved = s.inflightReservations()
+	targetNode, err := s.place(d.Tenant, d.Request, d.Resources, p)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	record := &deployment.Deployment{
+		ID:        util.GenIDWith("deploy."),
+		Tenant:    d.Tenant,
+		DomainID:  targetNode.DomainID,
+		NodeID:    targetNode.ID,
+		NodeName:  targetNode.Name,
+		Status:    deployment.StatusDeploying,
+		Resources: d.Resources.Clone(),
+		Request:   d.Request,
+	}
+	if err := s.tracker.Create(ctx, record); err != nil {
+		return nil, nil, fmt.Errorf("failed to record deployment: %w", err)
+	}
+	return targetNode, record, nil
+}
+
+// nodeClient 连接部署所在的节点；节点上报的实际部署节点可能与准入时选中的节点不同，找不到时使用 fallback
+func (s *service) nodeClient(d *deployment.Deployment, fallback *registry.Node) (schedulerpb.SchedulerServiceClient, func(), error) {
+	address := ""
+	if node, err := s.manager.GetNode(d.NodeID); err == nil {
+		address = node.Address
+	}
+	if address == "" && fallback != nil {
+		address = fallback.Address
+	}
+	if address == "" {
+		return nil, nil, fmt.Errorf("%w: %s", registry.ErrNodeNotFound, d.NodeID)
+	}
+
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to dial node %s: %w", address, err)
+	}
+	return schedulerpb.NewSchedulerServiceClient(conn), func() { conn.Close() }, nil
+}
+
+// stopComponent 通知部署所在节点（或对等实例）停止 component
+func (s *service) stopComponent(ctx context.Context, d *deployment.Deployment, fallback *registry.Node, reason string) error {
+	if d.Federated() {
+		if s.federation == nil {
+			return fmt.Errorf("deployment %s runs on federation peer %s but federation is disabled", d.ID, d.PeerID)
+		}
+		return s.federation.StopRemote(ctx, d, reason)
+	}
+
+	client, closeFn, err := s.nodeClient(d, fallback)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	callCtx, cancel := context.WithTimeout(ctx, s.dialTimeout)
+	defer cancel()
+
+	resp, err := client.StopComponent(callCtx, &schedulerpb.StopComponentRequest{
+		ComponentId:  d.ComponentID,
+		DeploymentId: d.ID,
+		Reason:       reason,
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return errors.New(resp.Error)
+	}
+	return nil
+}
+
+// removeComponent 通知部署所在节点（或对等实例）移除 component
+func (s *service) removeComponent(ctx context.Context, d *deployment.Deployment, fallback *registry.Node) error {
+	if d.Federated() {
+		if s.federation == nil {
+			return fmt.Errorf("deployment %s runs on federation peer %s but federation is disabled", d.ID, d.PeerID)
+		}
+		return s.federation.RemoveRemote(ctx, d)
+	}
+
+	client, closeFn, err := s.nodeClient(d, fallback)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	callCtx, cancel := context.WithTimeout(ctx, s.dialTimeout)
+	defer cancel()
+
+	resp, err := client.RemoveComponent(callCtx, &schedulerpb.RemoveComponentRequest{
+		ComponentId:  d.ComponentID,
+		DeploymentId: d.ID,
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return errors.New(resp.Error)
+	}
+	return nil
+}
+
+func stopFailure(msg string) *schedulerpb.StopComponentResponse {
+	return &schedulerpb.StopComponentResponse{
+		Success: false,
+		Error:   msg,
+	}
+}
+
+func removeFailure(msg string) *schedulerpb.RemoveComponentResponse {
+	return &schedulerpb.RemoveComponentResponse{
+		Success: false,
+		Error:   msg,
+	}
+}
+
+func migrateFailure(msg string) *schedulerpb.MigrateComponentResponse {
+	return &schedulerpb.MigrateComponentResponse{
+		Success: false,
+		Error:   msg,
+	}
+}
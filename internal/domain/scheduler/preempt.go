@@ -0,0 +1,485 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/audit"
+	"github.com/9triver/iarnet-global/internal/domain/deployment"
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	schedulerpb "github.com/9triver/iarnet-global/internal/proto/scheduler"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultPreemptionStopTimeout 通知节点停止被抢占 component 的默认超时时间
+const DefaultPreemptionStopTimeout = 10 * time.Second
+
+// PreemptionOptions 抢占配置
+// 启用后，priority 大于 0 的请求在没有可用容量时，可以停止同一节点上优先级更低的 component 为其腾出资源
+type PreemptionOptions struct {
+	Enabled bool
+	// StopTimeout 通知节点停止被抢占 component 的超时时间
+	StopTimeout time.Duration
+}
+
+func (o PreemptionOptions) withDefaults() PreemptionOptions {
+	if o.StopTimeout <= 0 {
+		o.StopTimeout = DefaultPreemptionStopTimeout
+	}
+	return o
+}
+
+// preemptionPlan 抢占方案：目标节点及需要停止的部署
+type preemptionPlan struct {
+	node    *registry.Node
+	victims []*deployment.Deployment
+	score   int64
+}
+
+// betterThan 方案比较：抢占数量更少 > 被抢占优先级更低 > 放置得分更高
+func (p *preemptionPlan) betterThan(other *preemptionPlan) bool {
+	if len(p.victims) != len(other.victims) {
+		return len(p.victims) < len(other.victims)
+	}
+	if maxPriority(p.victims) != maxPriority(other.victims) {
+		return maxPriority(p.victims) < maxPriority(other.victims)
+	}
+	return p.score > other.score
+}
+
+// canPreempt 请求是否可以通过抢占获得资源
+// 只要存在仅因容量不足被拒绝的节点，selectRandomNode 就返回 errNoCapacity
+func (s *service) canPreempt(req *schedulerpb.DeployComponentRequest, err error) bool {
+	return s.preemption.Enabled && req.Priority > 0 && errors.Is(err, errNoCapacity)
+}
+
+// planPreemption 在满足放置条件的节点中寻找需要停止的低优先级部署最少的方案（调用者需持有 admitMu）
+// p 需已由 place 填充租户可用域、配额准入与亲和信息，并设置在途预留
+func (s *service) planPreemption(req *schedulerpb.DeployComponentRequest, requested *registry.ResourceInfo, p *placement) *preemptionPlan {
+	var best *preemptionPlan
+
+	for _, domain := range s.manager.GetAllDomains() {
+		if p.canUseDomain != nil && !p.canUseDomain(domain.ID) {
+			continue
+		}
+		if p.admitDomain != nil && p.admitDomain(domain.ID) != nil {
+			continue
+		}
+
+		nodes, err := s.manager.GetNodesByDomain(domain.ID)
+		if err != nil {
+			continue
+		}
+
+		for _, node := range nodes {
+			// 疑似失效或不可达的节点无法可靠地停止 component
+			if node.Status != registry.NodeStatusOnline || node.Address == "" || !node.IsReachable() {
+				continue
+			}
+			if node.Cordoned {
+				continue
+			}
+			if p.allowNode != nil && !p.allowNode(node) {
+				continue
+			}
+			if node.ResourceCapacity == nil || node.ResourceCapacity.Available == nil {
+				continue
+			}
+			if len(p.selector) > 0 && !p.selector.Matches(registry.NodeLabelSet(node, domain)) {
+				continue
+			}
+			if !p.affinity.feasible(node, domain) {
+				continue
+			}
+
+			candidates := make([]*deployment.Deployment, 0)
+			for _, d := range s.tracker.List(deployment.Filter{NodeID: node.ID, Status: deployment.StatusRunning}) {
+				if d.ComponentID == "" || d.Resources == nil || d.Priority() >= req.Priority {
+					continue
+				}
+				candidates = append(candidates, d)
+			}
+
+			// 扣除仍在下发中的部署预留的资源
+			available := node.ResourceCapacity.Available.Clone()
+			available.Sub(p.reserved[node.ID])
+			victims := chooseVictims(available, requested, candidates)
+			if len(victims) == 0 {
+				continue
+			}
+
+			plan := &preemptionPlan{
+				node:    node.Clone(),
+				victims: victims,
+				score:   p.score(node, domain),
+			}
+			if best == nil || plan.betterThan(best) {
+				best = plan
+			}
+		}
+	}
+
+	return best
+}
+
+// maxVictimSearchSteps 搜索最小被抢占集合的步数上限，候选过多时使用贪心结果
+const maxVictimSearchSteps = 10000
+
+// chooseVictims 选出释放后能满足请求的最小部署集合
+// 优先抢占数量最少，其次被抢占的最高优先级最低、优先级之和最小
+func chooseVictims(available, requested *registry.ResourceInfo, candidates []*deployment.Deployment) []*deployment.Deployment {
+	if len(candidates) == 0 || !fitsAfterEviction(available, requested, candidates, -1) {
+		return nil
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Priority() != candidates[j].Priority() {
+			return candidates[i].Priority() < candidates[j].Priority()
+		}
+		return largerResources(candidates[i].Resources, candidates[j].Resources)
+	})
+
+	search := &victimSearch{
+		available:  available,
+		requested:  requested,
+		candidates: candidates,
+		remaining:  make([]*registry.ResourceInfo, len(candidates)+1),
+		best:       greedyVictims(available, requested, candidates),
+		steps:      maxVictimSearchSteps,
+	}
+	search.remaining[len(candidates)] = &registry.ResourceInfo{}
+	for i := len(candidates) - 1; i >= 0; i-- {
+		search.remaining[i] = search.remaining[i+1].Clone()
+		search.remaining[i].Add(candidates[i].Resources)
+	}
+	search.visit(0, available.Clone(), nil)
+	return search.best
+}
+
+// greedyVictims 按排序依次加入部署直到满足请求，再剔除不影响结果的多余部署，作为搜索的初始方案
+func greedyVictims(available, requested *registry.ResourceInfo, candidates []*deployment.Deployment) []*deployment.Deployment {
+	chosen := make([]*deployment.Deployment, 0)
+	for _, c := range candidates {
+		if fitsAfterEviction(available, requested, chosen, -1) {
+			break
+		}
+		chosen = append(chosen, c)
+	}
+
+	// 优先保留优先级更高的部署
+	for i := len(chosen) - 1; i >= 0; i-- {
+		if fitsAfterEviction(available, requested, chosen, i) {
+			chosen = append(chosen[:i], chosen[i+1:]...)
+		}
+	}
+	return chosen
+}
+
+// victimSearch 以分支限界搜索最小被抢占集合
+type victimSearch struct {
+	available  *registry.ResourceInfo
+	requested  *registry.ResourceInfo
+	candidates []*deployment.Deployment
+	// remaining[i] 为 candidates[i:] 占用资源之和
+	remaining []*registry.ResourceInfo
+	best      []*deployment.Deployment
+	steps     int
+}
+
+func (vs *victimSearch) visit(start int, freed *registry.ResourceInfo, chosen []*deployment.Deployment) {
+	if vs.steps <= 0 {
+		return
+	}
+	vs.steps--
+
+	if fits, _ := freed.Fits(vs.requested); fits {
+		if betterVictims(chosen, vs.best) {
+			vs.best = append([]*deployment.Deployment(nil), chosen...)
+		}
+		return
+	}
+	// 再加入一个部署也不会比当前方案更优
+	if len(chosen)+1 > len(vs.best) {
+		return
+	}
+	// 剩余部署全部停止仍无法满足请求
+	upper := freed.Clone()
+	upper.Add(vs.remaining[start])
+	if fits, _ := upper.Fits(vs.requested); !fits {
+		return
+	}
+
+	for i := start; i < len(vs.candidates); i++ {
+		next := freed.Clone()
+		next.Add(vs.candidates[i].Resources)
+		vs.visit(i+1, next, append(chosen, vs.candidates[i]))
+	}
+}
+
+// betterVictims 被抢占集合比较：数量更少 > 最高优先级更低 > 优先级之和更小
+func betterVictims(a, b []*deployment.Deployment) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	if maxPriority(a) != maxPriority(b) {
+		return maxPriority(a) < maxPriority(b)
+	}
+	return totalPriority(a) < totalPriority(b)
+}
+
+// maxPriority 部署中的最高优先级
+func maxPriority(victims []*deployment.Deployment) int32 {
+	var max int32
+	for i, v := range victims {
+		if i == 0 || v.Priority() > max {
+			max = v.Priority()
+		}
+	}
+	return max
+}
+
+func totalPriority(victims []*deployment.Deployment) int64 {
+	var total int64
+	for _, v := range victims {
+		total += int64(v.Priority())
+	}
+	return total
+}
+
+// fitsAfterEviction 停止 victims（跳过下标 skip）后节点可用资源是否满足请求
+func fitsAfterEviction(available, requested *registry.ResourceInfo, victims []*deployment.Deployment, skip int) bool {
+	freed := available.Clone()
+	for i, v := range victims {
+		if i != skip {
+			freed.Add(v.Resources)
+		}
+	}
+	fits, _ := freed.Fits(requested)
+	return fits
+}
+
+func largerResources(a, b *registry.ResourceInfo) bool {
+	if a.CPU != b.CPU {
+		return a.CPU > b.CPU
+	}
+	if a.Memory != b.Memory {
+		return a.Memory > b.Memory
+	}
+	return a.GPU > b.GPU
+}
+
+// claimVictims 将被抢占的部署标记为停止，释放其占用的配额并避免被重复抢占（调用者需持有 admitMu）
+func (s *service) claimVictims(ctx context.Context, preemptor *deployment.Deployment, plan *preemptionPlan) []*deployment.Deployment {
+	reason := fmt.Sprintf("preempted by deployment %s (priority %d)", preemptor.ID, preemptor.Priority())
+	claimed := make([]*deployment.Deployment, 0, len(plan.victims))
+	for _, v := range plan.victims {
+		updated, err := s.tracker.Update(ctx, v.ID, func(d *deployment.Deployment) {
+			d.Status = deployment.StatusStopped
+			d.Error = reason
+		})
+		if err != nil {
+			logrus.Errorf("Failed to mark deployment %s as preempted: %v", v.ID, err)
+			continue
+		}
+		claimed = append(claimed, updated)
+	}
+	return claimed
+}
+
+// evictVictims 通知节点停止被抢占的 component，随后异步为已停止的部署重新调度或重新排队
+// 任一 component 停止失败时返回错误，此时节点上没有足够的资源，不能再下发抢占者
+func (s *service) evictVictims(ctx context.Context, preemptor *deployment.Deployment, node *registry.Node, victims []*deployment.Deployment) error {
+	ids := make([]string, 0, len(victims))
+	for _, v := range victims {
+		ids = append(ids, v.ID)
+	}
+	s.recordAudit(ctx, &audit.Event{
+		Type:         audit.EventPreemptionPlanned,
+		Tenant:       preemptor.Tenant,
+		DeploymentID: preemptor.ID,
+		DomainID:     node.DomainID,
+		NodeID:       node.ID,
+		Message:      fmt.Sprintf("preempting %d component(s) on node %s for deployment %s", len(victims), node.Name, preemptor.ID),
+		Details: map[string]string{
+			"priority": strconv.Itoa(int(preemptor.Priority())),
+			"victims":  strings.Join(ids, ","),
+		},
+	})
+
+	stopped := make([]*deployment.Deployment, 0, len(victims))
+	var stopErr error
+	for _, v := range victims {
+		details := map[string]string{
+			"preemptor":          preemptor.ID,
+			"preemptor_priority": strconv.Itoa(int(preemptor.Priority())),
+			"priority":           strconv.Itoa(int(v.Priority())),
+			"component_id":       v.ComponentID,
+		}
+		stopCtx, cancel := context.WithTimeout(ctx, s.preemption.StopTimeout)
+		err := s.stopComponent(stopCtx, v, node, v.Error)
+		cancel()
+		if err != nil {
+			// 停止失败时 component 仍在运行，恢复记录以免重复部署
+			_, _ = s.tracker.Update(ctx, v.ID, func(d *deployment.Deployment) {
+				d.Status = deployment.StatusRunning
+				d.Error = ""
+			})
+			details["error"] = err.Error()
+			s.recordAudit(ctx, &audit.Event{
+				Type:         audit.EventPreemptionStopFailed,
+				Tenant:       v.Tenant,
+				DeploymentID: v.ID,
+				DomainID:     v.DomainID,
+				NodeID:       v.NodeID,
+				Message:      fmt.Sprintf("failed to stop component %s for preemption: %v", v.ComponentID, err),
+				Details:      details,
+			})
+			if stopErr == nil {
+				stopErr = fmt.Errorf("failed to stop preempted deployment %s: %w", v.ID, err)
+			}
+			continue
+		}
+
+		s.recordAudit(ctx, &audit.Event{
+			Type:         audit.EventComponentPreempted,
+			Tenant:       v.Tenant,
+			DeploymentID: v.ID,
+			DomainID:     v.DomainID,
+			NodeID:       v.NodeID,
+			Message:      fmt.Sprintf("component %s stopped: %s", v.ComponentID, v.Error),
+			Details:      details,
+		})
+		stopped = append(stopped, v)
+	}
+
+	if len(stopped) > 0 {
+		s.dispatching.Add(1)
+		go func() {
+			defer s.dispatching.Done()
+			for _, v := range stopped {
+				s.relocateVictim(context.Background(), preemptor, v)
+			}
+		}()
+	}
+	return stopErr
+}
+
+// abortPreemption 抢占未能腾出资源时不下发抢占者：允许排队时重新进入等待队列，否则标记为失败
+func (s *service) abortPreemption(ctx context.Context, record *deployment.Deployment, req *schedulerpb.DeployComponentRequest, cause error) *schedulerpb.DeployComponentResponse {
+	reason := fmt.Sprintf("preemption on node %s failed: %v", record.NodeName, cause)
+	if !s.canQueue(req, errNoCapacity) {
+		s.markFailed(ctx, record.ID, reason)
+		return failureResponse(reason)
+	}
+
+	_, err := s.tracker.Update(ctx, record.ID, func(d *deployment.Deployment) {
+		d.Status = deployment.StatusPending
+		d.Error = reason
+		d.DomainID = ""
+		d.NodeID = ""
+		d.NodeName = ""
+		d.Request = s.queue.clampWait(d.Request)
+	})
+	if err != nil {
+		logrus.Errorf("Failed to requeue deployment %s: %v", record.ID, err)
+		s.markFailed(ctx, record.ID, reason)
+		return failureResponse(reason)
+	}
+	position := s.tracker.QueuePosition(record.ID)
+	logrus.Warnf("Requeued deployment %s (position=%d): %s", record.ID, position, reason)
+	return &schedulerpb.DeployComponentResponse{
+		Success:       true,
+		DeploymentId:  record.ID,
+		Status:        schedulerpb.ComponentStatus_COMPONENT_STATUS_PENDING,
+		QueuePosition: int32(position),
+	}
+}
+
+// relocateVictim 为被抢占的部署重新选择节点；暂无容量且允许排队时重新进入等待队列，否则标记为失败
+// 重新调度不会再次触发抢占，避免级联
+func (s *service) relocateVictim(ctx context.Context, preemptor *deployment.Deployment, victim *deployment.Deployment) {
+	reason := fmt.Sprintf("preempted by deployment %s", preemptor.ID)
+	event := &audit.Event{
+		Tenant:       victim.Tenant,
+		DeploymentID: victim.ID,
+		Details:      map[string]string{"preemptor": preemptor.ID},
+	}
+
+	s.admitMu.Lock()
+	var targetNode *registry.Node
+	var err error
+	if victim.Request == nil {
+		err = fmt.Errorf("original deployment request is missing")
+	} else {
+		var p *placement
+		if p, err = buildPlacement(victim.Request); err == nil {
+			p.reserved = s.inflightReservations()
+			targetNode, err = s.place(victim.Tenant, victim.Request, victim.Resources, p)
+		}
+	}
+
+	placeErr := err
+	switch {
+	case placeErr == nil:
+		_, err = s.tracker.Update(ctx, victim.ID, func(d *deployment.Deployment) {
+			d.Status = deployment.StatusDeploying
+			d.Error = ""
+			d.ComponentID = ""
+			d.ProviderID = ""
+			d.DomainID = targetNode.DomainID
+			d.NodeID = targetNode.ID
+			d.NodeName = targetNode.Name
+		})
+		event.Type = audit.EventVictimRescheduled
+		event.DomainID = targetNode.DomainID
+		event.NodeID = targetNode.ID
+		event.Message = fmt.Sprintf("preempted deployment rescheduled to node %s", targetNode.Name)
+	case s.canQueue(victim.Request, placeErr):
+		// 重新排队，等待时间从被抢占时开始计算
+		_, err = s.tracker.Update(ctx, victim.ID, func(d *deployment.Deployment) {
+			d.Status = deployment.StatusPending
+			d.Error = fmt.Sprintf("%s: %v", reason, placeErr)
+			d.ComponentID = ""
+			d.ProviderID = ""
+			d.DomainID = ""
+			d.NodeID = ""
+			d.NodeName = ""
+			d.Request = s.queue.clampWait(d.Request)
+			d.CreatedAt = time.Now()
+		})
+		targetNode = nil
+		event.Type = audit.EventVictimRequeued
+		event.Message = "preempted deployment requeued until capacity is available"
+	default:
+		_, err = s.tracker.Update(ctx, victim.ID, func(d *deployment.Deployment) {
+			d.Status = deployment.StatusFailed
+			d.Error = fmt.Sprintf("%s: %v", reason, placeErr)
+		})
+		event.Type = audit.EventVictimFailed
+		event.Message = fmt.Sprintf("preempted deployment could not be rescheduled: %v", placeErr)
+	}
+	s.admitMu.Unlock()
+
+	if err != nil {
+		logrus.Errorf("Failed to update preempted deployment %s: %v", victim.ID, err)
+		return
+	}
+	s.recordAudit(ctx, event)
+
+	if event.Type == audit.EventVictimRescheduled {
+		s.dispatch(ctx, victim.ID, targetNode, victim.Request)
+	}
+}
+
+// recordAudit 记录审计事件（未配置审计服务时忽略）
+func (s *service) recordAudit(ctx context.Context, event *audit.Event) {
+	if s.audit == nil {
+		return
+	}
+	s.audit.Record(ctx, event)
+}
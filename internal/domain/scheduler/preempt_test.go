@@ -0,0 +1,68 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/9triver/iarnet-global/internal/domain/deployment"
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	schedulerpb "github.com/9triver/iarnet-global/internal/proto/scheduler"
+)
+
+func victim(id string, priority int32, cpu, memoryGiB int64) *deployment.Deployment {
+	return &deployment.Deployment{
+		ID:        id,
+		Resources: &registry.ResourceInfo{CPU: cpu, Memory: memoryGiB << 30},
+		Request:   &schedulerpb.DeployComponentRequest{Priority: priority},
+	}
+}
+
+func victimIDs(victims []*deployment.Deployment) []string {
+	ids := make([]string, 0, len(victims))
+	for _, v := range victims {
+		ids = append(ids, v.ID)
+	}
+	return ids
+}
+
+// TestChooseVictimsMinimalSet 按资源从大到小贪心会停止 3 个部署，最小集合只需 2 个
+func TestChooseVictimsMinimalSet(t *testing.T) {
+	available := &registry.ResourceInfo{}
+	requested := &registry.ResourceInfo{CPU: 4000, Memory: 4 << 30}
+	candidates := func() []*deployment.Deployment {
+		return []*deployment.Deployment{
+			victim("a", 1, 4000, 1),
+			victim("b", 1, 4000, 1),
+			victim("c", 1, 3000, 2),
+			victim("d", 1, 1000, 4),
+		}
+	}
+
+	if greedy := greedyVictims(available, requested, candidates()); len(greedy) != 3 {
+		t.Fatalf("greedy chose %v, want the three-victim over-eviction this test guards against", victimIDs(greedy))
+	}
+	victims := chooseVictims(available, requested, candidates())
+	if len(victims) != 2 {
+		t.Fatalf("chose %v, want two victims", victimIDs(victims))
+	}
+	if !fitsAfterEviction(available, requested, victims, -1) {
+		t.Fatalf("evicting %v does not free enough resources", victimIDs(victims))
+	}
+}
+
+// TestChooseVictimsPrefersLowerPriority 数量相同时停止优先级更低的部署
+func TestChooseVictimsPrefersLowerPriority(t *testing.T) {
+	available := &registry.ResourceInfo{CPU: 1000}
+	requested := &registry.ResourceInfo{CPU: 3000}
+	victims := chooseVictims(available, requested, []*deployment.Deployment{
+		victim("high", 5, 2000, 0),
+		victim("low", 1, 2000, 0),
+		victim("mid", 3, 4000, 0),
+	})
+	if ids := victimIDs(victims); len(ids) != 1 || ids[0] != "low" {
+		t.Fatalf("chose %v, want [low]", ids)
+	}
+
+	if victims := chooseVictims(available, &registry.ResourceInfo{CPU: 10000}, []*deployment.Deployment{victim("low", 1, 2000, 0)}); victims != nil {
+		t.Fatalf("chose %v although evicting every candidate is not enough", victimIDs(victims))
+	}
+}
@@ -0,0 +1,174 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/deployment"
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	schedulerpb "github.com/9triver/iarnet-global/internal/proto/scheduler"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// DefaultQueueRetryInterval 等待队列的默认周期性重试间隔
+	DefaultQueueRetryInterval = 30 * time.Second
+	// DefaultQueueMaxWait 排队等待时间的默认上限
+	DefaultQueueMaxWait = time.Hour
+)
+
+// QueueOptions 等待队列配置
+// 启用后，携带 max_wait_seconds 的请求在暂无可用容量时进入持久化的等待队列，
+// 节点加入或心跳上报的容量变化时按优先级重试
+type QueueOptions struct {
+	Enabled bool
+	// RetryInterval 周期性重试间隔（容量变化事件之外的兜底）
+	RetryInterval time.Duration
+	// MaxWait 单个请求允许的最长等待时间，超过时截断
+	MaxWait time.Duration
+}
+
+func (o QueueOptions) withDefaults() QueueOptions {
+	if o.RetryInterval <= 0 {
+		o.RetryInterval = DefaultQueueRetryInterval
+	}
+	if o.MaxWait <= 0 {
+		o.MaxWait = DefaultQueueMaxWait
+	}
+	return o
+}
+
+// clampWait 将请求的最长等待时间截断到配置上限
+func (o QueueOptions) clampWait(req *schedulerpb.DeployComponentRequest) *schedulerpb.DeployComponentRequest {
+	maxWait := int64(o.MaxWait / time.Second)
+	if req.MaxWaitSeconds <= maxWait {
+		return req
+	}
+	clamped := proto.Clone(req).(*schedulerpb.DeployComponentRequest)
+	clamped.MaxWaitSeconds = maxWait
+	return clamped
+}
+
+// canQueue 请求是否可以因为暂无容量而排队
+func (s *service) canQueue(req *schedulerpb.DeployComponentRequest, err error) bool {
+	if !s.queue.Enabled || req.MaxWaitSeconds <= 0 {
+		return false
+	}
+	return errors.Is(err, errNoCapacity) || errors.Is(err, errConstraintsUnsatisfied)
+}
+
+// runQueue 等待队列处理循环：容量变化时立即重试，并按固定间隔兜底重试和清理超时请求
+func (s *service) runQueue(ctx context.Context) {
+	ticker := time.NewTicker(s.queue.RetryInterval)
+	defer ticker.Stop()
+
+	// 恢复重启前仍在排队的请求
+	s.processQueue()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopCh:
+			return
+		case <-s.manager.CapacityChanged():
+			s.processQueue()
+		case <-ticker.C:
+			s.processQueue()
+		}
+	}
+}
+
+// processQueue 按优先级依次尝试为等待中的请求分配节点
+// 排在前面的请求暂时无法放置时继续尝试后面的请求（回填），避免小请求被大请求长期阻塞
+func (s *service) processQueue() {
+	if !s.leading() {
+		return
+	}
+	ctx := context.Background()
+	now := time.Now()
+
+	for _, d := range s.tracker.Pending() {
+		if deadline := d.Deadline(); !deadline.IsZero() && now.After(deadline) {
+			reason := fmt.Sprintf("timed out after %ds waiting for capacity", d.Request.GetMaxWaitSeconds())
+			if d.Error != "" {
+				reason += ": " + d.Error
+			}
+			s.markFailed(ctx, d.ID, reason)
+			logrus.Warnf("Queued deployment %s expired: %s", d.ID, reason)
+			continue
+		}
+
+		targetNode, err := s.admitPending(ctx, d)
+		if err != nil {
+			logrus.Debugf("Queued deployment %s still pending: %v", d.ID, err)
+			continue
+		}
+
+		logrus.Infof("Queued deployment %s admitted to node %s (domain=%s) after %v",
+			d.ID, targetNode.Name, targetNode.DomainID, time.Since(d.CreatedAt).Round(time.Second))
+		s.dispatching.Add(1)
+		go func(id deployment.DeploymentID, req *schedulerpb.DeployComponentRequest) {
+			defer s.dispatching.Done()
+			s.dispatch(context.Background(), id, targetNode, req)
+		}(d.ID, d.Request)
+	}
+}
+
+// admitPending 为等待中的请求重新选择节点，成功后转为部署中状态
+func (s *service) admitPending(ctx context.Context, d *deployment.Deployment) (*registry.Node, error) {
+	if d.Request == nil {
+		return nil, fmt.Errorf("deployment request is missing")
+	}
+
+	s.admitMu.Lock()
+	defer s.admitMu.Unlock()
+
+	p, err := buildPlacement(d.Request)
+	if err != nil {
+		return nil, err
+	}
+	// 在准入锁内统计在途预留，包含本轮已准入和其他路径刚分配的部署
+	p.reserved = s.inflightReservations()
+
+	targetNode, err := s.place(d.Tenant, d.Request, d.Resources, p)
+	if err != nil {
+		if err.Error() != d.Error {
+			_, _ = s.tracker.Update(ctx, d.ID, func(pending *deployment.Deployment) {
+				pending.Error = err.Error()
+			})
+		}
+		return nil, err
+	}
+
+	_, err = s.tracker.Update(ctx, d.ID, func(pending *deployment.Deployment) {
+		pending.Status = deployment.StatusDeploying
+		pending.Error = ""
+		pending.DomainID = targetNode.DomainID
+		pending.NodeID = targetNode.ID
+		pending.NodeName = targetNode.Name
+	})
+	if err != nil {
+		return nil, err
+	}
+	return targetNode, nil
+}
+
+// inflightReservations 正在下发的部署尚未反映到节点上报的可用资源中，按节点汇总后需要预先扣除
+func (s *service) inflightReservations() map[registry.NodeID]*registry.ResourceInfo {
+	reserved := make(map[registry.NodeID]*registry.ResourceInfo)
+	for _, d := range s.tracker.List(deployment.Filter{Status: deployment.StatusDeploying}) {
+		reserve(reserved, d.NodeID, d.Resources)
+	}
+	return reserved
+}
+
+func reserve(reserved map[registry.NodeID]*registry.ResourceInfo, nodeID registry.NodeID, resources *registry.ResourceInfo) {
+	if reserved[nodeID] == nil {
+		reserved[nodeID] = &registry.ResourceInfo{}
+	}
+	reserved[nodeID].Add(resources)
+}
@@ -0,0 +1,384 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/audit"
+	"github.com/9triver/iarnet-global/internal/domain/deployment"
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultRescheduleInterval 失联部署检查的默认周期
+	DefaultRescheduleInterval = 10 * time.Second
+	// DefaultRestartInitialBackoff 首次重启失败后的默认等待时间
+	DefaultRestartInitialBackoff = 5 * time.Second
+	// DefaultRestartMaxBackoff 重启退避的默认上限
+	DefaultRestartMaxBackoff = 5 * time.Minute
+	// DefaultMaxRestarts 默认最大自动重启次数
+	DefaultMaxRestarts = 3
+)
+
+// RescheduleOptions 失联节点上部署的自动重新调度配置
+// 启用后，所在节点离线或被清理的运行中部署被标记为 lost；请求标记为 restartable 的部署按原始请求重新放置，
+// 失败时指数退避，超过最大重启次数后标记为失败
+type RescheduleOptions struct {
+	Enabled bool
+	// Interval 检查周期
+	Interval time.Duration
+	// InitialBackoff 重启失败后的首次等待时间，之后每次翻倍
+	InitialBackoff time.Duration
+	// MaxBackoff 退避等待时间上限
+	MaxBackoff time.Duration
+	// MaxRestarts 请求未指定 max_restarts 时的最大重启次数
+	MaxRestarts int
+}
+
+func (o RescheduleOptions) withDefaults() RescheduleOptions {
+	if o.Interval <= 0 {
+		o.Interval = DefaultRescheduleInterval
+	}
+	if o.InitialBackoff <= 0 {
+		o.InitialBackoff = DefaultRestartInitialBackoff
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = DefaultRestartMaxBackoff
+	}
+	if o.MaxRestarts <= 0 {
+		o.MaxRestarts = DefaultMaxRestarts
+	}
+	return o
+}
+
+// backoff 第 attempt 次重启失败后的等待时间
+func (o RescheduleOptions) backoff(attempt int) time.Duration {
+	delay := o.InitialBackoff
+	for i := 1; i < attempt && delay < o.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > o.MaxBackoff {
+		delay = o.MaxBackoff
+	}
+	return delay
+}
+
+// maxRestarts 部署允许的最大重启次数
+func (s *service) maxRestarts(d *deployment.Deployment) int {
+	if d.Request != nil && d.Request.MaxRestarts > 0 {
+		return int(d.Request.MaxRestarts)
+	}
+	return s.reschedule.MaxRestarts
+}
+
+// runReconciler 周期性检查失联节点上的部署
+func (s *service) runReconciler(ctx context.Context) {
+	ticker := time.NewTicker(s.reschedule.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.reconcile()
+		}
+	}
+}
+
+// reconcile 将失联节点上的运行中部署标记为 lost，并为到期的可重启部署重新放置；
+// 节点恢复后，尚未重启的部署恢复运行，已重启部署遗留在该节点上的旧实例被移除
+// 转发到对等实例的部署由对等实例负责，状态通过联邦同步
+func (s *service) reconcile() {
+	if !s.leading() {
+		return
+	}
+	ctx := context.Background()
+
+	for _, d := range s.tracker.List(deployment.Filter{Status: deployment.StatusRunning}) {
+		if d.Federated() {
+			continue
+		}
+		reason := s.nodeLostReason(d.NodeID)
+		if reason == "" {
+			continue
+		}
+		s.markLost(ctx, d, reason)
+	}
+
+	// 超过下发超时仍处于 deploying 的记录说明下发已中断（例如 leader 切换），所在节点失联时同样标记为 lost
+	for _, d := range s.tracker.List(deployment.Filter{Status: deployment.StatusDeploying}) {
+		if d.Federated() || d.NodeID == "" || time.Since(d.UpdatedAt) < s.dialTimeout {
+			continue
+		}
+		reason := s.nodeLostReason(d.NodeID)
+		if reason == "" {
+			continue
+		}
+		s.markLost(ctx, d, reason)
+	}
+
+	now := time.Now()
+	for _, d := range s.tracker.List(deployment.Filter{Status: deployment.StatusLost}) {
+		if d.Federated() {
+			continue
+		}
+		if d.ComponentID != "" && s.nodeLostReason(d.NodeID) == "" {
+			s.markRecovered(ctx, d)
+			continue
+		}
+		if !d.Restartable() || now.Before(d.NextRestartAt) {
+			continue
+		}
+		s.restartLost(ctx, d)
+	}
+
+	for _, d := range s.tracker.List(deployment.Filter{}) {
+		if len(d.StaleInstances) > 0 {
+			s.removeStaleInstances(ctx, d)
+		}
+	}
+}
+
+// nodeLostReason 节点已离线或被清理时返回原因，否则返回空字符串
+func (s *service) nodeLostReason(nodeID registry.NodeID) string {
+	node, err := s.manager.GetNode(nodeID)
+	if err != nil {
+		return fmt.Sprintf("node %s was removed from the registry", nodeID)
+	}
+	if node.Status == registry.NodeStatusOffline || node.Status == registry.NodeStatusError {
+		return fmt.Sprintf("node %s is %s", nodeID, node.Status)
+	}
+	return ""
+}
+
+// markLost 将部署标记为 lost，可重启的部署立即进入重启流程
+func (s *service) markLost(ctx context.Context, d *deployment.Deployment, reason string) {
+	_, err := s.tracker.Update(ctx, d.ID, func(lost *deployment.Deployment) {
+		lost.Status = deployment.StatusLost
+		lost.Error = reason
+		lost.NextRestartAt = time.Time{}
+	})
+	if err != nil {
+		logrus.Errorf("Failed to mark deployment %s as lost: %v", d.ID, err)
+		return
+	}
+
+	s.recordAudit(ctx, &audit.Event{
+		Type:         audit.EventDeploymentLost,
+		Tenant:       d.Tenant,
+		DeploymentID: d.ID,
+		DomainID:     d.DomainID,
+		NodeID:       d.NodeID,
+		Message:      fmt.Sprintf("component %s lost: %s", d.ComponentID, reason),
+		Details: map[string]string{
+			"component_id": d.ComponentID,
+			"restartable":  strconv.FormatBool(d.Restartable()),
+		},
+	})
+}
+
+// markRecovered 失联节点恢复时，尚未重启的部署的原实例仍在该节点上，恢复为运行状态
+func (s *service) markRecovered(ctx context.Context, d *deployment.Deployment) {
+	_, err := s.tracker.Update(ctx, d.ID, func(recovered *deployment.Deployment) {
+		recovered.Status = deployment.StatusRunning
+		recovered.Error = ""
+		recovered.NextRestartAt = time.Time{}
+	})
+	if err != nil {
+		logrus.Errorf("Failed to mark deployment %s as recovered: %v", d.ID, err)
+		return
+	}
+
+	s.recordAudit(ctx, &audit.Event{
+		Type:         audit.EventDeploymentRecovered,
+		Tenant:       d.Tenant,
+		DeploymentID: d.ID,
+		DomainID:     d.DomainID,
+		NodeID:       d.NodeID,
+		Message:      fmt.Sprintf("node %s is back, component %s is running again", d.NodeID, d.ComponentID),
+		Details:      map[string]string{"component_id": d.ComponentID},
+	})
+}
+
+// removeStaleInstances 通过已恢复节点的 provider 移除部署遗留的旧实例，节点仍失联的旧实例留待下次检查
+func (s *service) removeStaleInstances(ctx context.Context, d *deployment.Deployment) {
+	removed := make(map[string]bool)
+	for _, stale := range d.StaleInstances {
+		if s.nodeLostReason(stale.NodeID) != "" {
+			continue
+		}
+		instance := &deployment.Deployment{
+			ID:          d.ID,
+			ComponentID: stale.ComponentID,
+			NodeID:      stale.NodeID,
+			ProviderID:  stale.ProviderID,
+		}
+		if err := s.removeComponent(ctx, instance, nil); err != nil {
+			logrus.Warnf("Failed to remove stale component %s of deployment %s on node %s: %v", stale.ComponentID, d.ID, stale.NodeID, err)
+			continue
+		}
+		removed[stale.ComponentID] = true
+
+		s.recordAudit(ctx, &audit.Event{
+			Type:         audit.EventStaleInstanceRemoved,
+			Tenant:       d.Tenant,
+			DeploymentID: d.ID,
+			NodeID:       stale.NodeID,
+			Message:      fmt.Sprintf("removed stale component %s left on recovered node %s", stale.ComponentID, stale.NodeID),
+			Details: map[string]string{
+				"component_id": stale.ComponentID,
+				"retired_at":   stale.RetiredAt.Format(time.RFC3339),
+			},
+		})
+	}
+	if len(removed) == 0 {
+		return
+	}
+
+	_, err := s.tracker.Update(ctx, d.ID, func(updated *deployment.Deployment) {
+		remaining := updated.StaleInstances[:0]
+		for _, stale := range updated.StaleInstances {
+			if !removed[stale.ComponentID] {
+				remaining = append(remaining, stale)
+			}
+		}
+		updated.StaleInstances = remaining
+	})
+	if err != nil {
+		logrus.Errorf("Failed to update stale instances of deployment %s: %v", d.ID, err)
+	}
+}
+
+// retire 放弃重启的部署不再使用失联节点上的原实例，节点恢复后移除
+func (s *service) retire(ctx context.Context, d *deployment.Deployment) {
+	if d.ComponentID == "" {
+		return
+	}
+	if _, err := s.tracker.Update(ctx, d.ID, retainStale); err != nil {
+		logrus.Errorf("Failed to record stale component of deployment %s: %v", d.ID, err)
+	}
+}
+
+// retainStale 将部署当前的实例记为旧实例：原实例可能仍在失联节点上运行，节点恢复后需要移除
+func retainStale(d *deployment.Deployment) {
+	if d.ComponentID == "" {
+		return
+	}
+	for _, stale := range d.StaleInstances {
+		if stale.ComponentID == d.ComponentID && stale.NodeID == d.NodeID {
+			return
+		}
+	}
+	d.StaleInstances = append(d.StaleInstances, deployment.StaleInstance{
+		ComponentID: d.ComponentID,
+		NodeID:      d.NodeID,
+		ProviderID:  d.ProviderID,
+		RetiredAt:   time.Now(),
+	})
+}
+
+// restartLost 按原始请求为失联的部署重新选择节点并转发；失败时按退避时间等待下一次尝试
+func (s *service) restartLost(ctx context.Context, d *deployment.Deployment) {
+	if d.Request == nil {
+		s.retire(ctx, d)
+		s.markFailed(ctx, d.ID, "cannot restart: original deployment request is missing")
+		return
+	}
+	if limit := s.maxRestarts(d); d.Restarts >= limit {
+		reason := fmt.Sprintf("gave up after %d restart attempt(s): %s", d.Restarts, d.Error)
+		s.retire(ctx, d)
+		s.markFailed(ctx, d.ID, reason)
+		s.recordAudit(ctx, &audit.Event{
+			Type:         audit.EventRestartGaveUp,
+			Tenant:       d.Tenant,
+			DeploymentID: d.ID,
+			DomainID:     d.DomainID,
+			NodeID:       d.NodeID,
+			Message:      reason,
+			Details:      map[string]string{"restarts": strconv.Itoa(d.Restarts)},
+		})
+		return
+	}
+
+	attempt := d.Restarts + 1
+	targetNode, err := s.admitRestart(ctx, d, attempt)
+	if err != nil {
+		logrus.Warnf("Restart attempt %d of deployment %s failed: %v", attempt, d.ID, err)
+		return
+	}
+
+	s.recordAudit(ctx, &audit.Event{
+		Type:         audit.EventDeploymentRestarted,
+		Tenant:       d.Tenant,
+		DeploymentID: d.ID,
+		DomainID:     targetNode.DomainID,
+		NodeID:       targetNode.ID,
+		Message:      fmt.Sprintf("restarting component lost on node %s on node %s (attempt %d)", d.NodeID, targetNode.Name, attempt),
+		Details: map[string]string{
+			"from_node": d.NodeID,
+			"attempt":   strconv.Itoa(attempt),
+		},
+	})
+
+	s.dispatching.Add(1)
+	go func() {
+		defer s.dispatching.Done()
+		resp := s.dispatch(context.Background(), d.ID, targetNode, d.Request)
+		if resp.Success {
+			return
+		}
+		// 转发失败时回到 lost 状态，等待下一次重启
+		_, _ = s.tracker.Update(context.Background(), d.ID, func(failed *deployment.Deployment) {
+			if failed.Status == deployment.StatusFailed {
+				failed.Status = deployment.StatusLost
+				failed.Error = fmt.Sprintf("restart attempt %d failed: %s", attempt, resp.Error)
+				failed.NextRestartAt = time.Now().Add(s.reschedule.backoff(attempt))
+			}
+		})
+	}()
+}
+
+// admitRestart 在准入锁内为重启的部署选择节点；无法放置时记录本次尝试并设置下一次重启时间
+func (s *service) admitRestart(ctx context.Context, d *deployment.Deployment, attempt int) (*registry.Node, error) {
+	s.admitMu.Lock()
+	defer s.admitMu.Unlock()
+
+	p, err := buildPlacement(d.Request)
+	var targetNode *registry.Node
+	if err == nil {
+		p.reserved = s.inflightReservations()
+		targetNode, err = s.place(d.Tenant, d.Request, d.Resources, p)
+	}
+	if err != nil {
+		placeErr := err
+		_, _ = s.tracker.Update(ctx, d.ID, func(lost *deployment.Deployment) {
+			lost.Restarts = attempt
+			lost.NextRestartAt = time.Now().Add(s.reschedule.backoff(attempt))
+			lost.Error = fmt.Sprintf("restart attempt %d failed: %v", attempt, placeErr)
+		})
+		return nil, placeErr
+	}
+
+	_, err = s.tracker.Update(ctx, d.ID, func(restarted *deployment.Deployment) {
+		retainStale(restarted)
+		restarted.Status = deployment.StatusDeploying
+		restarted.Error = ""
+		restarted.ComponentID = ""
+		restarted.ProviderID = ""
+		restarted.DomainID = targetNode.DomainID
+		restarted.NodeID = targetNode.ID
+		restarted.NodeName = targetNode.Name
+		restarted.Restarts = attempt
+		restarted.NextRestartAt = time.Time{}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return targetNode, nil
+}
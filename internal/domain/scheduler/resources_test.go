@@ -0,0 +1,26 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	resourcepb "github.com/9triver/iarnet-global/internal/proto/resource"
+)
+
+func TestHasSufficientResourcesIgnoresUnrequestedReservations(t *testing.T) {
+	capacity := &registry.ResourceCapacity{
+		Available: &registry.ResourceInfo{CPU: 4000, Memory: 8 << 30, GPU: 1},
+	}
+	// GPU 已被在途部署全部预留，只请求 CPU 的部署不受影响
+	reserved := &registry.ResourceInfo{CPU: 1000, GPU: 1}
+
+	if !hasSufficientResources(capacity, &resourcepb.Info{Cpu: 3000}, reserved) {
+		t.Errorf("CPU-only request rejected: %s", insufficientReason(capacity, &resourcepb.Info{Cpu: 3000}, reserved))
+	}
+	if hasSufficientResources(capacity, &resourcepb.Info{Cpu: 3001}, reserved) {
+		t.Error("request exceeding the unreserved CPU was accepted")
+	}
+	if hasSufficientResources(capacity, &resourcepb.Info{Cpu: 100, Gpu: 1}, reserved) {
+		t.Error("request for a fully reserved GPU was accepted")
+	}
+}
@@ -2,14 +2,20 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
-	"strings"
+	"sync"
 	"time"
 
+	"github.com/9triver/iarnet-global/internal/domain/audit"
+	"github.com/9triver/iarnet-global/internal/domain/deployment"
+	"github.com/9triver/iarnet-global/internal/domain/quota"
 	"github.com/9triver/iarnet-global/internal/domain/registry"
+	"github.com/9triver/iarnet-global/internal/domain/tenant"
 	resourcepb "github.com/9triver/iarnet-global/internal/proto/resource"
 	schedulerpb "github.com/9triver/iarnet-global/internal/proto/scheduler"
+	"github.com/9triver/iarnet-global/internal/util"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -18,21 +24,118 @@ import (
 // Service 定义全局调度能力
 type Service interface {
 	DeployComponent(ctx context.Context, req *schedulerpb.DeployComponentRequest) (*schedulerpb.DeployComponentResponse, error)
+	// StopComponent 停止通过全局调度器部署的 component
+	StopComponent(ctx context.Context, req *schedulerpb.StopComponentRequest) (*schedulerpb.StopComponentResponse, error)
+	// RemoveComponent 移除 component 并删除部署记录
+	RemoveComponent(ctx context.Context, req *schedulerpb.RemoveComponentRequest) (*schedulerpb.RemoveComponentResponse, error)
+	// MigrateComponent 将 component 迁移到其他节点或域
+	MigrateComponent(ctx context.Context, req *schedulerpb.MigrateComponentRequest) (*schedulerpb.MigrateComponentResponse, error)
+	// DeployComponentGroup 原子地部署一组 component
+	DeployComponentGroup(ctx context.Context, req *schedulerpb.DeployComponentGroupRequest) (*schedulerpb.DeployComponentGroupResponse, error)
+	GetDeploymentStatus(ctx context.Context, req *schedulerpb.GetDeploymentStatusRequest) (*schedulerpb.GetDeploymentStatusResponse, error)
+	// SimulatePlacement 模拟调度，返回候选节点排名与淘汰原因，不实际部署
+	SimulatePlacement(ctx context.Context, req *schedulerpb.SimulatePlacementRequest) (*schedulerpb.SimulatePlacementResponse, error)
+	// ListDeployments 列出满足过滤条件的部署记录（不做租户隔离，由调用方按身份设置过滤条件）
+	ListDeployments(ctx context.Context, filter deployment.Filter) []*deployment.Deployment
+	// EvictNode 将节点上运行中的 component 逐个迁移到其他节点
+	EvictNode(ctx context.Context, nodeID registry.NodeID) (*EvictResult, error)
+	// Start 启动等待队列处理与失联部署的自动重新调度
+	Start(ctx context.Context) error
+	// Stop 停止后台处理并等待进行中的转发完成
+	Stop()
+}
+
+// Options 调度服务依赖
+type Options struct {
+	// Tracker 部署跟踪器（必需）
+	Tracker *deployment.Tracker
+	// Quotas 为空时不做配额准入控制
+	Quotas quota.Service
+	// Tenants 为空时不做租户身份认证与域访问控制
+	Tenants tenant.Service
+	// Topology 网络拓扑感知调度配置
+	Topology TopologyOptions
+	// Queue 等待队列配置
+	Queue QueueOptions
+	// Preemption 抢占配置
+	Preemption PreemptionOptions
+	// Reschedule 失联节点上部署的自动重新调度配置
+	Reschedule RescheduleOptions
+	// Audit 审计事件记录（可选）
+	Audit audit.Service
+	// IsLeader HA 模式下判断本副本是否为 leader，只有 leader 处理等待队列与重新调度
+	// 为空时视为单副本部署
+	IsLeader func() bool
+	// Federation 为空时不与对等实例联邦
+	Federation Federation
 }
 
 type service struct {
 	manager     *registry.Manager
+	tracker     *deployment.Tracker
+	quotas      quota.Service
+	tenants     tenant.Service
+	topology    TopologyOptions
+	queue       QueueOptions
+	preemption  PreemptionOptions
+	reschedule  RescheduleOptions
+	audit       audit.Service
+	isLeader    func() bool
+	federation  Federation
 	dialTimeout time.Duration
 	rand        *rand.Rand
+	// admitMu 保证配额检查与部署记录的原子性，避免并发请求同时通过准入
+	admitMu sync.Mutex
+	// dispatching 后台流程（排队、抢占、重新调度）发起的异步转发
+	dispatching sync.WaitGroup
+	stopCh      chan struct{}
+	stopOnce    sync.Once
 }
 
 // NewService 创建调度服务
-func NewService(manager *registry.Manager) Service {
+func NewService(manager *registry.Manager, opts Options) Service {
 	return &service{
 		manager:     manager,
+		tracker:     opts.Tracker,
+		quotas:      opts.Quotas,
+		tenants:     opts.Tenants,
+		topology:    opts.Topology.withDefaults(),
+		queue:       opts.Queue.withDefaults(),
+		preemption:  opts.Preemption.withDefaults(),
+		reschedule:  opts.Reschedule.withDefaults(),
+		audit:       opts.Audit,
+		isLeader:    opts.IsLeader,
+		federation:  opts.Federation,
 		dialTimeout: 10 * time.Second,
 		rand:        rand.New(rand.NewSource(time.Now().UnixNano())),
+		stopCh:      make(chan struct{}),
+	}
+}
+
+// leading 本副本是否负责后台处理（单副本部署始终为 true）
+func (s *service) leading() bool {
+	return s.isLeader == nil || s.isLeader()
+}
+
+// Start 启动后台处理：等待队列与失联节点上部署的重新调度
+func (s *service) Start(ctx context.Context) error {
+	if s.queue.Enabled {
+		go s.runQueue(ctx)
+		logrus.Infof("Deployment queue started (retry interval: %v, max wait: %v)", s.queue.RetryInterval, s.queue.MaxWait)
+	}
+	if s.reschedule.Enabled {
+		go s.runReconciler(ctx)
+		logrus.Infof("Deployment reconciler started (interval: %v, max restarts: %d)", s.reschedule.Interval, s.reschedule.MaxRestarts)
 	}
+	return nil
+}
+
+// Stop 停止后台处理
+func (s *service) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+	})
+	s.dispatching.Wait()
 }
 
 // DeployComponent 处理调度请求
@@ -44,53 +147,384 @@ func (s *service) DeployComponent(ctx context.Context, req *schedulerpb.DeployCo
 		return failureResponse("resource_request is required"), nil
 	}
 
-	targetNode, err := s.selectRandomNode(req.ResourceRequest)
+	p, err := buildPlacement(req)
 	if err != nil {
-		logrus.Warnf("Failed to select node for scheduling: %v", err)
 		return failureResponse(err.Error()), nil
 	}
 
+	identity, err := s.identityFromContext(ctx)
+	if err != nil {
+		logrus.Warnf("Rejected unauthenticated scheduling request: %v", err)
+		return failureResponse(err.Error()), nil
+	}
+	requested := requestedResources(req.ResourceRequest)
+	if s.federation != nil && s.federation.IsPeer(identity.Tenant) {
+		req = inboundRequest(req)
+	}
+
+	adm, err := s.admit(ctx, identity.Tenant, req, requested, p, s.federation != nil)
+	// 本地域无法容纳时先尝试转发到对等实例，都无法容纳时再按原流程排队或拒绝
+	if errors.Is(err, errFederate) {
+		if resp := s.forwardToPeer(ctx, identity.Tenant, req, requested); resp != nil {
+			return resp, nil
+		}
+		adm, err = s.admit(ctx, identity.Tenant, req, requested, p, false)
+	}
+	if err != nil {
+		if errors.Is(err, quota.ErrQuotaExceeded) {
+			logrus.Warnf("Rejected scheduling request from tenant %s: %v", identity.Tenant, err)
+		} else {
+			logrus.Warnf("Failed to select node for scheduling: %v", err)
+		}
+		return failureResponse(err.Error()), nil
+	}
+	record := adm.record
+
+	// 暂无可用容量，已进入等待队列
+	if adm.node == nil {
+		position := s.tracker.QueuePosition(record.ID)
+		logrus.Infof("Queued scheduling request from tenant %s (deployment=%s, priority=%d, position=%d): %s",
+			identity.Tenant, record.ID, record.Priority(), position, record.Error)
+		return &schedulerpb.DeployComponentResponse{
+			Success:       true,
+			DeploymentId:  record.ID,
+			Status:        schedulerpb.ComponentStatus_COMPONENT_STATUS_PENDING,
+			QueuePosition: int32(position),
+		}, nil
+	}
+
+	// 为高优先级部署腾出资源：先停止被抢占的 component，再转发
+	if len(adm.victims) > 0 {
+		if err := s.evictVictims(ctx, record, adm.node, adm.victims); err != nil {
+			return s.abortPreemption(ctx, record, req, err), nil
+		}
+	}
+
+	return s.dispatch(ctx, record.ID, adm.node, req), nil
+}
+
+// buildPlacement 解析请求中的标签选择器与放置约束
+func buildPlacement(req *schedulerpb.DeployComponentRequest) (*placement, error) {
+	selector, err := registry.ParseSelectors(req.ResourceRequest.GetTags())
+	if err != nil {
+		return nil, err
+	}
+	affinity, err := parseAffinity(req.Placement, req.Labels)
+	if err != nil {
+		return nil, err
+	}
+	return &placement{
+		resources: req.ResourceRequest,
+		selector:  selector,
+		affinity:  affinity,
+	}, nil
+}
+
+// admission 准入结果
+type admission struct {
+	// node 目标节点，为空表示已进入等待队列
+	node *registry.Node
+	// record 登记的部署记录
+	record *deployment.Deployment
+	// victims 需要抢占的低优先级部署（已标记为停止）
+	victims []*deployment.Deployment
+}
+
+// admit 选择目标节点并通过配额准入，随后登记部署记录占用配额
+// 没有可用容量时，若允许抢占则选出需要停止的低优先级部署；否则 federate 为 true 且可以转发到对等实例时返回 errFederate，
+// 再否则若请求允许排队，登记为等待中的部署并返回空节点
+func (s *service) admit(ctx context.Context, tenantID string, req *schedulerpb.DeployComponentRequest, requested *registry.ResourceInfo, p *placement, federate bool) (*admission, error) {
+	s.admitMu.Lock()
+	defer s.admitMu.Unlock()
+
+	record := &deployment.Deployment{
+		ID:        util.GenIDWith("deploy."),
+		Tenant:    tenantID,
+		Status:    deployment.StatusDeploying,
+		Resources: requested,
+		Request:   req,
+	}
+	adm := &admission{record: record}
+
+	// 准入锁只保证选择过程串行，仍在下发中的部署需要按预留扣除
+	p.reserved = s.inflightReservations()
+	targetNode, err := s.place(tenantID, req, requested, p)
+	if err != nil && s.canPreempt(req, err) {
+		if plan := s.planPreemption(req, requested, p); plan != nil {
+			targetNode, err = plan.node, nil
+			adm.victims = s.claimVictims(ctx, record, plan)
+		}
+	}
+	if err != nil {
+		if federate && s.canFederate(req, err) {
+			return nil, fmt.Errorf("%w: %v", errFederate, err)
+		}
+		if !s.canQueue(req, err) {
+			return nil, err
+		}
+		record.Status = deployment.StatusPending
+		record.Error = err.Error()
+		record.Request = s.queue.clampWait(req)
+		if err := s.tracker.Create(ctx, record); err != nil {
+			return nil, fmt.Errorf("failed to record deployment: %w", err)
+		}
+		return adm, nil
+	}
+
+	record.DomainID = targetNode.DomainID
+	record.NodeID = targetNode.ID
+	record.NodeName = targetNode.Name
+	if err := s.tracker.Create(ctx, record); err != nil {
+		return nil, fmt.Errorf("failed to record deployment: %w", err)
+	}
+	adm.node = targetNode
+	return adm, nil
+}
+
+// place 在准入锁内为请求选择节点（调用者需持有 admitMu）
+func (s *service) place(tenantID string, req *schedulerpb.DeployComponentRequest, requested *registry.ResourceInfo, p *placement) (*registry.Node, error) {
+	if err := s.preparePlacement(tenantID, req, requested, p); err != nil {
+		return nil, err
+	}
+	return s.selectRandomNode(p)
+}
+
+// preparePlacement 填充租户相关的放置条件（亲和、拓扑、域访问与配额准入），跨域全局配额不足时返回错误
+func (s *service) preparePlacement(tenantID string, req *schedulerpb.DeployComponentRequest, requested *registry.ResourceInfo, p *placement) error {
+	// 亲和规则与拓扑推断只参考同一租户的活跃部署（含正在部署中的记录）
+	active := s.tracker.List(deployment.Filter{Tenant: tenantID, ActiveOnly: true})
+	if s.tenants != nil {
+		p.canUseDomain = func(domainID registry.DomainID) bool {
+			return s.tenants.CanUseDomain(tenantID, domainID)
+		}
+	}
+	if p.affinity != nil {
+		if err := p.affinity.resolve(active, s.manager, p.canUseDomain); err != nil {
+			return err
+		}
+	}
+	p.topology = s.resolveTopology(req, active)
+	if s.federation != nil {
+		// 对等实例转发的部署受为其开放的入站上限约束
+		if err := s.federation.AdmitInbound(tenantID, requested); err != nil {
+			return err
+		}
+	}
+	if s.quotas != nil {
+		// 跨域的全局配额与目标域无关，提前拒绝
+		if err := s.quotas.Check(tenantID, "", requested); err != nil {
+			return err
+		}
+		p.admitDomain = func(domainID registry.DomainID) error {
+			return s.quotas.Check(tenantID, domainID, requested)
+		}
+	}
+	return nil
+}
+
+// dispatch 将已准入的请求转发到目标节点并更新部署记录
+func (s *service) dispatch(ctx context.Context, id deployment.DeploymentID, targetNode *registry.Node, req *schedulerpb.DeployComponentRequest) *schedulerpb.DeployComponentResponse {
 	resp, err := s.forwardToNode(ctx, targetNode, req)
 	if err != nil {
 		logrus.Errorf("Failed to forward scheduling request to node %s (%s, domain=%s): %v",
 			targetNode.Name, targetNode.Address, targetNode.DomainID, err)
-		return failureResponse(fmt.Sprintf("node dispatch failed: %v", err)), nil
+		s.markFailed(ctx, id, err.Error())
+		resp = failureResponse(fmt.Sprintf("node dispatch failed: %v", err))
+		resp.DeploymentId = id
+		return resp
 	}
 
-	logrus.Infof("Delegated scheduling request to node %s (%s, domain=%s)", targetNode.Name, targetNode.Address, targetNode.DomainID)
-	return resp, nil
+	resp.DeploymentId = id
+	if !resp.Success {
+		s.markFailed(ctx, id, resp.Error)
+		resp.Status = schedulerpb.ComponentStatus_COMPONENT_STATUS_ERROR
+		return resp
+	}
+
+	s.markRunning(ctx, id, targetNode, resp)
+	resp.Status = schedulerpb.ComponentStatus_COMPONENT_STATUS_RUNNING
+	logrus.Infof("Delegated scheduling request to node %s (%s, domain=%s, deployment=%s)",
+		targetNode.Name, targetNode.Address, targetNode.DomainID, id)
+	return resp
+}
+
+func (s *service) markRunning(ctx context.Context, id deployment.DeploymentID, node *registry.Node, resp *schedulerpb.DeployComponentResponse) {
+	_, err := s.tracker.Update(ctx, id, func(d *deployment.Deployment) {
+		d.Status = deployment.StatusRunning
+		d.ProviderID = resp.ProviderId
+		if resp.Component != nil {
+			d.ComponentID = resp.Component.ComponentId
+			if d.ProviderID == "" {
+				d.ProviderID = resp.Component.ProviderId
+			}
+		}
+		if resp.NodeId != "" {
+			d.NodeID = resp.NodeId
+		}
+		if resp.NodeName != "" {
+			d.NodeName = resp.NodeName
+		}
+		if d.NodeName == "" {
+			d.NodeName = node.Name
+		}
+	})
+	if err != nil {
+		logrus.Errorf("Failed to update deployment %s: %v", id, err)
+	}
 }
 
-func (s *service) selectRandomNode(resourceReq *resourcepb.Info) (*registry.Node, error) {
-	type domainNodes struct {
-		domainID registry.DomainID
-		nodes    []*registry.Node
+func (s *service) markFailed(ctx context.Context, id deployment.DeploymentID, reason string) {
+	d, err := s.tracker.Update(ctx, id, func(d *deployment.Deployment) {
+		d.Status = deployment.StatusFailed
+		d.Error = reason
+	})
+	if err != nil {
+		logrus.Errorf("Failed to update deployment %s: %v", id, err)
+		return
 	}
+	s.recordAudit(ctx, &audit.Event{
+		Type:         audit.EventDeploymentFailed,
+		Tenant:       d.Tenant,
+		DeploymentID: d.ID,
+		DomainID:     d.DomainID,
+		NodeID:       d.NodeID,
+		Message:      fmt.Sprintf("deployment %s failed: %s", d.ID, reason),
+	})
+}
+
+// placement 一次调度请求的节点筛选条件
+type placement struct {
+	resources *resourcepb.Info
+	selector  registry.Selector
+	// affinity 亲和/反亲和约束，为空表示不限制
+	affinity *affinity
+	// topology 上游位置信息，为空表示不做拓扑感知
+	topology *topology
+	// canUseDomain 非空时跳过调用方无权使用的域
+	canUseDomain func(registry.DomainID) bool
+	// admitDomain 非空时跳过未通过域级配额准入的域；若因此没有候选节点则返回配额错误
+	admitDomain func(registry.DomainID) error
+	// reserved 已分配但尚未反映到节点心跳中的资源，各放置路径在准入锁内设置
+	reserved map[registry.NodeID]*registry.ResourceInfo
+	// allowNode 非空时只考虑返回 true 的节点（迁移时限定目标节点/域）
+	allowNode func(*registry.Node) bool
+	// trace 非空时记录每个域与节点的筛选结果（模拟调度时使用）
+	trace *placementTrace
+}
+
+// score 节点得分：软约束得分 + 拓扑得分
+func (p *placement) score(node *registry.Node, domain *registry.Domain) int64 {
+	return p.affinity.score(node, domain) + p.topology.score(node)
+}
+
+// scoredNode 候选节点及其得分
+type scoredNode struct {
+	node  *registry.Node
+	score int64
+}
 
+// domainNodes 同一域下的候选节点
+type domainNodes struct {
+	domainID registry.DomainID
+	nodes    []scoredNode
+}
+
+// selectRandomNode 选择满足条件的节点
+// 只在得分最高的节点中挑选：先随机选择域，再随机选择域内节点
+func (s *service) selectRandomNode(p *placement) (*registry.Node, error) {
 	domains := s.manager.GetAllDomains()
 	candidates := make([]domainNodes, 0, len(domains))
+	// 疑似失效节点仅在没有健康节点可用时作为后备
+	suspectCandidates := make([]domainNodes, 0)
+	var quotaErr error
+	var constrained bool
+	// capacityLimited 存在满足放置约束、仅因容量不足被拒绝的节点
+	var capacityLimited bool
 
 	for _, domain := range domains {
+		dt := p.trace.domain(domain)
+		if p.canUseDomain != nil && !p.canUseDomain(domain.ID) {
+			dt.reject("tenant is not allowed to use this domain")
+			continue
+		}
+		if !p.affinity.inScope(domain.ID) {
+			constrained = true
+			dt.reject("domain is outside the requested placement scope")
+			continue
+		}
+		// 域内存活节点的可用资源之和已不足时，任何节点都无法满足请求，无需逐个检查
+		capacity, err := s.manager.DomainCapacity(domain.ID)
+		if err != nil {
+			dt.reject(err.Error())
+			continue
+		}
+		if reason, ok := domainMayFit(capacity, p.resources); !ok {
+			capacityLimited = true
+			dt.reject(reason)
+			continue
+		}
+
 		nodes, err := s.manager.GetNodesByDomain(domain.ID)
 		if err != nil {
+			dt.reject(err.Error())
 			continue
 		}
 
-		eligible := make([]*registry.Node, 0, len(nodes))
+		eligible := make([]scoredNode, 0, len(nodes))
+		suspects := make([]scoredNode, 0)
 		for _, node := range nodes {
-			if node.Status != registry.NodeStatusOnline {
+			if !node.IsAlive() {
+				dt.rejectNode(node, fmt.Sprintf("node is %s", node.Status))
 				continue
 			}
 			if node.Address == "" {
+				dt.rejectNode(node, "node has no address")
+				continue
+			}
+			if node.Cordoned {
+				dt.rejectNode(node, "node is cordoned")
+				continue
+			}
+			if p.allowNode != nil && !p.allowNode(node) {
+				dt.rejectNode(node, "node is excluded by the request")
+				continue
+			}
+			// 全局调度器无法访问的节点无法接收转发请求
+			if !node.IsReachable() {
+				dt.rejectNode(node, "node address is unreachable from the global scheduler")
 				continue
 			}
-			if len(resourceReq.Tags) > 0 && !nodeHasRequiredTags(node.ResourceTags, resourceReq.Tags) {
+			if len(p.selector) > 0 && !p.selector.Matches(registry.NodeLabelSet(node, domain)) {
+				dt.rejectNode(node, "node labels do not match the resource tags")
 				continue
 			}
-			if !hasSufficientResources(node.ResourceCapacity, resourceReq) {
+			if !hasSufficientResources(node.ResourceCapacity, p.resources, p.reserved[node.ID]) {
+				if p.affinity.feasible(node, domain) {
+					capacityLimited = true
+				}
+				dt.rejectNode(node, insufficientReason(node.ResourceCapacity, p.resources, p.reserved[node.ID]))
+				continue
+			}
+			if !p.affinity.feasible(node, domain) {
+				constrained = true
+				dt.rejectNode(node, "node violates a required placement constraint")
+				continue
+			}
+			candidate := scoredNode{node: node.Clone(), score: p.score(node, domain)}
+			dt.acceptNode(node, p.affinity.score(node, domain), p.topology.score(node), node.Status == registry.NodeStatusSuspect)
+			if node.Status == registry.NodeStatusSuspect {
+				suspects = append(suspects, candidate)
+				continue
+			}
+			eligible = append(eligible, candidate)
+		}
+
+		if p.admitDomain != nil && len(eligible)+len(suspects) > 0 {
+			if err := p.admitDomain(domain.ID); err != nil {
+				quotaErr = err
+				dt.reject(err.Error())
 				continue
 			}
-			eligible = append(eligible, node.Clone())
 		}
 
 		if len(eligible) > 0 {
@@ -99,67 +533,129 @@ func (s *service) selectRandomNode(resourceReq *resourcepb.Info) (*registry.Node
 				nodes:    eligible,
 			})
 		}
+		if len(suspects) > 0 {
+			suspectCandidates = append(suspectCandidates, domainNodes{
+				domainID: domain.ID,
+				nodes:    suspects,
+			})
+		}
+	}
+
+	if len(candidates) == 0 {
+		candidates = suspectCandidates
+		if len(candidates) > 0 {
+			logrus.Warnf("No healthy node has sufficient capacity, falling back to suspect nodes")
+		}
 	}
 
+	if len(candidates) == 0 && quotaErr != nil {
+		return nil, quotaErr
+	}
+	// 部分节点不满足约束、其余节点容量不足时按容量不足处理，请求可以排队或抢占
+	if len(candidates) == 0 && constrained && !capacityLimited {
+		return nil, errConstraintsUnsatisfied
+	}
 	if len(candidates) == 0 {
-		return nil, fmt.Errorf("no domain has nodes with sufficient capacity")
+		return nil, errNoCapacity
 	}
 
+	candidates = topScored(candidates)
 	selectedDomain := candidates[s.rand.Intn(len(candidates))]
 	selectedNode := selectedDomain.nodes[s.rand.Intn(len(selectedDomain.nodes))]
-	return selectedNode, nil
+	return selectedNode.node, nil
 }
 
-func hasSufficientResources(capacity *registry.ResourceCapacity, req *resourcepb.Info) bool {
+// topScored 只保留得分最高的候选节点
+func topScored(candidates []domainNodes) []domainNodes {
+	var best int64
+	first := true
+	for _, dn := range candidates {
+		for _, n := range dn.nodes {
+			if first || n.score > best {
+				best = n.score
+				first = false
+			}
+		}
+	}
+
+	result := make([]domainNodes, 0, len(candidates))
+	for _, dn := range candidates {
+		top := make([]scoredNode, 0, len(dn.nodes))
+		for _, n := range dn.nodes {
+			if n.score == best {
+				top = append(top, n)
+			}
+		}
+		if len(top) > 0 {
+			result = append(result, domainNodes{domainID: dn.domainID, nodes: top})
+		}
+	}
+	return result
+}
+
+func hasSufficientResources(capacity *registry.ResourceCapacity, req *resourcepb.Info, reserved *registry.ResourceInfo) bool {
 	if capacity == nil || capacity.Available == nil || req == nil {
 		return false
 	}
 
-	available := capacity.Available
-	if available.CPU < req.Cpu {
-		return false
+	fits, _ := remainingResources(capacity, reserved).Fits(requestedResources(req))
+	return fits
+}
+
+// remainingResources 节点可用资源扣除在途预留后的剩余量
+// 只与请求中出现的资源比较，其他资源上的预留不影响本次请求
+func remainingResources(capacity *registry.ResourceCapacity, reserved *registry.ResourceInfo) *registry.ResourceInfo {
+	remaining := capacity.Available.Clone()
+	remaining.Sub(reserved)
+	return remaining
+}
+
+// domainMayFit 根据域的汇总容量快速判断域内是否可能存在满足请求的节点，不可能时返回原因
+func domainMayFit(capacity *registry.ResourceCapacity, req *resourcepb.Info) (string, bool) {
+	if req == nil {
+		return "", true
 	}
-	if available.Memory < req.Memory {
-		return false
+	if capacity == nil || capacity.Available == nil {
+		return "domain has no live node with reported capacity", false
 	}
-	if available.GPU < req.Gpu {
-		return false
+	if !hasSufficientResources(capacity, req, nil) {
+		return "domain " + insufficientReason(capacity, req, nil), false
 	}
-	return true
+	return "", true
 }
 
-func nodeHasRequiredTags(nodeTags *registry.ResourceTags, required []string) bool {
-	if len(required) == 0 {
-		return true
+// insufficientReason 说明节点可用资源不足的原因
+func insufficientReason(capacity *registry.ResourceCapacity, req *resourcepb.Info, reserved *registry.ResourceInfo) string {
+	if capacity == nil || capacity.Available == nil {
+		return "node has not reported its resource capacity"
 	}
-	if nodeTags == nil {
-		return false
+	requested := requestedResources(req)
+	remaining := remainingResources(capacity, reserved)
+	_, name := remaining.Fits(requested)
+	if reserved.Get(name) > 0 {
+		return fmt.Sprintf("insufficient %s: requested %d, available %d (%d reserved by in-flight deployments)",
+			name, requested.Get(name), remaining.Get(name), reserved.Get(name))
 	}
+	return fmt.Sprintf("insufficient %s: requested %d, available %d", name, requested.Get(name), remaining.Get(name))
+}
 
-	for _, tag := range required {
-		switch strings.ToLower(tag) {
-		case "cpu":
-			if !nodeTags.CPU {
-				return false
-			}
-		case "gpu":
-			if !nodeTags.GPU {
-				return false
-			}
-		case "memory":
-			if !nodeTags.Memory {
-				return false
-			}
-		case "camera":
-			if !nodeTags.Camera {
-				return false
-			}
-		default:
-			// 未知标签暂视为不满足
-			return false
+// requestedResources 将资源请求转换为统一的资源模型（内置资源 + 扩展资源）
+func requestedResources(req *resourcepb.Info) *registry.ResourceInfo {
+	if req == nil {
+		return &registry.ResourceInfo{}
+	}
+	requested := &registry.ResourceInfo{
+		CPU:    req.Cpu,
+		Memory: req.Memory,
+		GPU:    req.Gpu,
+	}
+	if len(req.Extended) > 0 {
+		requested.Extended = make(map[string]int64, len(req.Extended))
+		for name, value := range req.Extended {
+			requested.Extended[name] = value
 		}
 	}
-	return true
+	return requested
 }
 
 func (s *service) forwardToNode(ctx context.Context, node *registry.Node, req *schedulerpb.DeployComponentRequest) (*schedulerpb.DeployComponentResponse, error) {
@@ -176,6 +672,15 @@ func (s *service) forwardToNode(ctx context.Context, node *registry.Node, req *s
 	return client.DeployComponent(dialCtx, req)
 }
 
+var (
+	// errNoCapacity 没有节点具备足够的可用资源
+	errNoCapacity = errors.New("no domain has nodes with sufficient capacity")
+	// errConstraintsUnsatisfied 没有节点满足硬性放置约束
+	errConstraintsUnsatisfied = errors.New("no node satisfies the required placement constraints")
+	// errFederate 本地域无法容纳请求，可以尝试转发到对等实例
+	errFederate = errors.New("no local domain can host the request")
+)
+
 func failureResponse(msg string) *schedulerpb.DeployComponentResponse {
 	return &schedulerpb.DeployComponentResponse{
 		Success: false,
@@ -0,0 +1,216 @@
+package scheduler
+
+import (
+	"context"
+	"sort"
+
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	schedulerpb "github.com/9triver/iarnet-global/internal/proto/scheduler"
+	"github.com/sirupsen/logrus"
+)
+
+// placementTrace 节点筛选过程的记录
+type placementTrace struct {
+	domains []*domainTrace
+}
+
+// domainTrace 单个域的筛选记录
+type domainTrace struct {
+	domain *registry.Domain
+	reason string
+	nodes  []*nodeTrace
+}
+
+// nodeTrace 单个节点的筛选记录
+type nodeTrace struct {
+	node          *registry.Node
+	reason        string
+	affinityScore int64
+	topologyScore int64
+	suspect       bool
+}
+
+func (t *placementTrace) domain(domain *registry.Domain) *domainTrace {
+	if t == nil {
+		return nil
+	}
+	dt := &domainTrace{domain: domain}
+	t.domains = append(t.domains, dt)
+	return dt
+}
+
+func (dt *domainTrace) reject(reason string) {
+	if dt == nil {
+		return
+	}
+	dt.reason = reason
+}
+
+func (dt *domainTrace) rejectNode(node *registry.Node, reason string) {
+	if dt == nil {
+		return
+	}
+	dt.nodes = append(dt.nodes, &nodeTrace{node: node.Clone(), reason: reason})
+}
+
+func (dt *domainTrace) acceptNode(node *registry.Node, affinityScore, topologyScore int64, suspect bool) {
+	if dt == nil {
+		return
+	}
+	dt.nodes = append(dt.nodes, &nodeTrace{
+		node:          node.Clone(),
+		affinityScore: affinityScore,
+		topologyScore: topologyScore,
+		suspect:       suspect,
+	})
+}
+
+// eligible 节点本身及所在域都通过了筛选
+func (nt *nodeTrace) eligible(dt *domainTrace) bool {
+	return nt.reason == "" && dt.reason == ""
+}
+
+func (nt *nodeTrace) score() int64 {
+	return nt.affinityScore + nt.topologyScore
+}
+
+// SimulatePlacement 模拟调度：按当前注册中心状态执行与 DeployComponent 相同的节点筛选流程，
+// 返回候选节点排名、得分以及每个域和节点的淘汰原因，不登记部署记录、不转发
+func (s *service) SimulatePlacement(ctx context.Context, req *schedulerpb.SimulatePlacementRequest) (*schedulerpb.SimulatePlacementResponse, error) {
+	if req == nil || req.Request == nil {
+		return simulateFailure("request is required"), nil
+	}
+	deployReq := req.Request
+	if deployReq.ResourceRequest == nil {
+		return simulateFailure("resource_request is required"), nil
+	}
+
+	p, err := buildPlacement(deployReq)
+	if err != nil {
+		return simulateFailure(err.Error()), nil
+	}
+
+	identity, err := s.identityFromContext(ctx)
+	if err != nil {
+		logrus.Warnf("Rejected unauthenticated placement simulation: %v", err)
+		return simulateFailure(err.Error()), nil
+	}
+	requested := requestedResources(deployReq.ResourceRequest)
+	p.trace = &placementTrace{}
+
+	resp := &schedulerpb.SimulatePlacementResponse{Success: true}
+
+	s.admitMu.Lock()
+	p.reserved = s.inflightReservations()
+	// 全局配额不足时仍然评估各个域与节点，便于排查
+	placeErr := s.preparePlacement(identity.Tenant, deployReq, requested, p)
+	if _, err := s.selectRandomNode(p); placeErr == nil {
+		placeErr = err
+	}
+	if placeErr != nil {
+		if s.canPreempt(deployReq, placeErr) {
+			if plan := s.planPreemption(deployReq, requested, p); plan != nil {
+				resp.PreemptionNodeId = plan.node.ID
+				for _, v := range plan.victims {
+					resp.PreemptionVictims = append(resp.PreemptionVictims, v.ID)
+				}
+			}
+		}
+		resp.WouldQueue = resp.PreemptionNodeId == "" && s.canQueue(deployReq, placeErr)
+	}
+	s.admitMu.Unlock()
+
+	resp.Placeable = placeErr == nil
+	if placeErr != nil {
+		resp.PlacementError = placeErr.Error()
+	}
+	resp.Candidates = rankCandidates(p.trace)
+	resp.Domains = domainEvaluations(p.trace)
+	return resp, nil
+}
+
+// rankCandidates 候选节点排名：健康节点优先于疑似失效节点，其次按得分从高到低
+// 实际调度只在最高得分的健康节点（没有健康节点时为疑似失效节点）中随机选择，这些节点标记为 preferred
+func rankCandidates(trace *placementTrace) []*schedulerpb.PlacementCandidate {
+	candidates := make([]*schedulerpb.PlacementCandidate, 0)
+	for _, dt := range trace.domains {
+		for _, nt := range dt.nodes {
+			if !nt.eligible(dt) {
+				continue
+			}
+			candidates = append(candidates, &schedulerpb.PlacementCandidate{
+				DomainId:      nt.node.DomainID,
+				NodeId:        nt.node.ID,
+				NodeName:      nt.node.Name,
+				Score:         nt.score(),
+				AffinityScore: nt.affinityScore,
+				TopologyScore: nt.topologyScore,
+				Suspect:       nt.suspect,
+			})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.Suspect != b.Suspect {
+			return !a.Suspect
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.DomainId != b.DomainId {
+			return a.DomainId < b.DomainId
+		}
+		return a.NodeId < b.NodeId
+	})
+
+	for i, c := range candidates {
+		c.Rank = int32(i + 1)
+		first := candidates[0]
+		c.Preferred = c.Suspect == first.Suspect && c.Score == first.Score
+	}
+	return candidates
+}
+
+// domainEvaluations 按域 ID 排序输出每个域及其节点的筛选结果
+func domainEvaluations(trace *placementTrace) []*schedulerpb.DomainEvaluation {
+	domains := make([]*schedulerpb.DomainEvaluation, 0, len(trace.domains))
+	for _, dt := range trace.domains {
+		eval := &schedulerpb.DomainEvaluation{
+			DomainId:        dt.domain.ID,
+			DomainName:      dt.domain.Name,
+			RejectionReason: dt.reason,
+			Nodes:           make([]*schedulerpb.NodeEvaluation, 0, len(dt.nodes)),
+		}
+		for _, nt := range dt.nodes {
+			node := &schedulerpb.NodeEvaluation{
+				NodeId:          nt.node.ID,
+				NodeName:        nt.node.Name,
+				Eligible:        nt.eligible(dt),
+				RejectionReason: nt.reason,
+			}
+			if nt.reason == "" {
+				node.Score = nt.score()
+				if dt.reason != "" {
+					node.RejectionReason = "domain rejected: " + dt.reason
+				}
+			}
+			eval.Nodes = append(eval.Nodes, node)
+		}
+		sort.Slice(eval.Nodes, func(i, j int) bool {
+			return eval.Nodes[i].NodeId < eval.Nodes[j].NodeId
+		})
+		domains = append(domains, eval)
+	}
+	sort.Slice(domains, func(i, j int) bool {
+		return domains[i].DomainId < domains[j].DomainId
+	})
+	return domains
+}
+
+func simulateFailure(msg string) *schedulerpb.SimulatePlacementResponse {
+	return &schedulerpb.SimulatePlacementResponse{
+		Success: false,
+		Error:   msg,
+	}
+}
@@ -0,0 +1,97 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/9triver/iarnet-global/internal/domain/deployment"
+	schedulerpb "github.com/9triver/iarnet-global/internal/proto/scheduler"
+)
+
+// GetDeploymentStatus 查询全局调度器跟踪的部署状态，等待中的部署返回排队位置
+// 只能查询调用方租户自己的部署
+func (s *service) GetDeploymentStatus(ctx context.Context, req *schedulerpb.GetDeploymentStatusRequest) (*schedulerpb.GetDeploymentStatusResponse, error) {
+	if req == nil {
+		return statusFailure("request is required"), nil
+	}
+
+	d, err := s.lookupDeployment(ctx, req.DeploymentId, req.ComponentId)
+	if err != nil {
+		return statusFailure(err.Error()), nil
+	}
+
+	resp := &schedulerpb.GetDeploymentStatusResponse{
+		Success:      true,
+		Error:        d.Error,
+		Status:       componentStatus(d.Status),
+		DeploymentId: d.ID,
+		NodeId:       d.NodeID,
+		Restarts:     int32(d.Restarts),
+	}
+	if d.ComponentID != "" {
+		resp.Component = &schedulerpb.ComponentInfo{
+			ComponentId: d.ComponentID,
+			ProviderId:  d.ProviderID,
+		}
+	}
+	if d.Status == deployment.StatusPending {
+		resp.QueuePosition = int32(s.tracker.QueuePosition(d.ID))
+	}
+	return resp, nil
+}
+
+// lookupDeployment 按部署 ID（优先）或 component ID 查找调用方租户的部署记录
+// 其他租户的部署视为不存在
+func (s *service) lookupDeployment(ctx context.Context, deploymentID, componentID string) (*deployment.Deployment, error) {
+	if deploymentID == "" && componentID == "" {
+		return nil, fmt.Errorf("deployment_id or component_id is required")
+	}
+
+	identity, err := s.identityFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var d *deployment.Deployment
+	if deploymentID != "" {
+		d, err = s.tracker.Get(deploymentID)
+	} else {
+		d, err = s.tracker.GetByComponent(componentID)
+	}
+	if err != nil || (!identity.IsAdmin() && d.Tenant != identity.Tenant) {
+		return nil, deployment.ErrDeploymentNotFound
+	}
+	return d, nil
+}
+
+// componentStatus 将部署状态转换为 proto 状态
+func componentStatus(status deployment.Status) schedulerpb.ComponentStatus {
+	switch status {
+	case deployment.StatusPending:
+		return schedulerpb.ComponentStatus_COMPONENT_STATUS_PENDING
+	case deployment.StatusDeploying:
+		return schedulerpb.ComponentStatus_COMPONENT_STATUS_DEPLOYING
+	case deployment.StatusRunning:
+		return schedulerpb.ComponentStatus_COMPONENT_STATUS_RUNNING
+	case deployment.StatusStopped:
+		return schedulerpb.ComponentStatus_COMPONENT_STATUS_STOPPED
+	case deployment.StatusFailed:
+		return schedulerpb.ComponentStatus_COMPONENT_STATUS_ERROR
+	case deployment.StatusLost:
+		return schedulerpb.ComponentStatus_COMPONENT_STATUS_LOST
+	default:
+		return schedulerpb.ComponentStatus_COMPONENT_STATUS_UNKNOWN
+	}
+}
+
+func statusFailure(msg string) *schedulerpb.GetDeploymentStatusResponse {
+	return &schedulerpb.GetDeploymentStatusResponse{
+		Success: false,
+		Error:   msg,
+	}
+}
+
+// ListDeployments 列出满足过滤条件的部署记录
+func (s *service) ListDeployments(ctx context.Context, filter deployment.Filter) []*deployment.Deployment {
+	return s.tracker.List(filter)
+}
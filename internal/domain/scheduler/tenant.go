@@ -0,0 +1,45 @@
+package scheduler
+
+import (
+	"context"
+
+	"github.com/9triver/iarnet-global/internal/domain/tenant"
+	"google.golang.org/grpc/metadata"
+)
+
+// identityFromContext 解析调用方身份：优先使用 HTTP 层已认证的身份，其次为对等实例的联邦令牌，否则从 gRPC metadata 中解析
+func (s *service) identityFromContext(ctx context.Context) (*tenant.Identity, error) {
+	if id := tenant.IdentityFromContext(ctx); id != nil {
+		return id, nil
+	}
+	if s.federation != nil {
+		id, err := s.federation.Authenticate(ctx)
+		if err != nil || id != nil {
+			return id, err
+		}
+	}
+	creds := credentialsFromContext(ctx)
+	if s.tenants == nil {
+		if creds.Tenant == "" {
+			return &tenant.Identity{Tenant: tenant.DefaultTenant}, nil
+		}
+		return &tenant.Identity{Tenant: creds.Tenant}, nil
+	}
+	return s.tenants.Authenticate(ctx, creds)
+}
+
+// credentialsFromContext 读取 metadata 中的租户声明与访问令牌
+func credentialsFromContext(ctx context.Context) tenant.Credentials {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return tenant.Credentials{}
+	}
+	return tenant.ParseCredentials(first(md.Get(tenant.TenantMetadataKey)), first(md.Get(tenant.AuthorizationMetadataKey)))
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
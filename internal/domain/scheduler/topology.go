@@ -0,0 +1,182 @@
+package scheduler
+
+import (
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/deployment"
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	schedulerpb "github.com/9triver/iarnet-global/internal/proto/scheduler"
+)
+
+const (
+	// DefaultSameNodeWeight 与上游位于同一节点的默认得分
+	DefaultSameNodeWeight = 100
+	// DefaultSameDomainWeight 与上游位于同一域的默认得分
+	DefaultSameDomainWeight = 50
+	// DefaultLatencyWeight 低延迟域的默认最高得分
+	DefaultLatencyWeight = 20
+)
+
+// TopologyOptions 网络拓扑感知调度配置
+// 启用后，携带上游地址的请求优先放置在上游所在节点，其次为上游所在域，再次为延迟较低的域
+type TopologyOptions struct {
+	Enabled          bool
+	SameNodeWeight   int64
+	SameDomainWeight int64
+	LatencyWeight    int64
+}
+
+func (o TopologyOptions) withDefaults() TopologyOptions {
+	if o.SameNodeWeight <= 0 {
+		o.SameNodeWeight = DefaultSameNodeWeight
+	}
+	if o.SameDomainWeight <= 0 {
+		o.SameDomainWeight = DefaultSameDomainWeight
+	}
+	if o.LatencyWeight < 0 {
+		o.LatencyWeight = 0
+	} else if o.LatencyWeight == 0 {
+		o.LatencyWeight = DefaultLatencyWeight
+	}
+	return o
+}
+
+// topology 一次调度请求的上游位置信息
+type topology struct {
+	opts TopologyOptions
+	// nodes / domains 上游端点所在的节点与域
+	nodes   map[registry.NodeID]struct{}
+	domains map[registry.DomainID]struct{}
+	// domainRTT 各域节点的平均探测 RTT（全局调度器到节点），用于近似域间延迟
+	domainRTT map[registry.DomainID]time.Duration
+	minRTT    time.Duration
+}
+
+// upstreamAddresses 返回请求携带的上游地址
+func upstreamAddresses(req *schedulerpb.DeployComponentRequest) []string {
+	addrs := make([]string, 0, 3)
+	for _, addr := range []string{req.UpstreamZmqAddress, req.UpstreamStoreAddress, req.UpstreamLoggerAddress} {
+		if strings.TrimSpace(addr) != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+// resolveTopology 解析上游端点所在的节点与域
+// 优先通过注册中心的节点地址匹配；无法匹配时（如上游位于 NAT 之后），
+// 退化为同一租户中引用相同上游端点的已跟踪部署所在的位置
+func (s *service) resolveTopology(req *schedulerpb.DeployComponentRequest, deployments []*deployment.Deployment) *topology {
+	if !s.topology.Enabled {
+		return nil
+	}
+	addrs := upstreamAddresses(req)
+	if len(addrs) == 0 {
+		return nil
+	}
+
+	t := &topology{
+		opts:      s.topology,
+		nodes:     make(map[registry.NodeID]struct{}),
+		domains:   make(map[registry.DomainID]struct{}),
+		domainRTT: make(map[registry.DomainID]time.Duration),
+	}
+
+	hosts := make(map[string]struct{}, len(addrs))
+	for _, addr := range addrs {
+		if host := endpointHost(addr); host != "" {
+			hosts[host] = struct{}{}
+		}
+	}
+
+	for _, domain := range s.manager.GetAllDomains() {
+		nodes, err := s.manager.GetNodesByDomain(domain.ID)
+		if err != nil {
+			continue
+		}
+
+		var total time.Duration
+		var probed int
+		for _, node := range nodes {
+			if _, ok := hosts[endpointHost(node.Address)]; ok {
+				t.nodes[node.ID] = struct{}{}
+				t.domains[node.DomainID] = struct{}{}
+			}
+			if node.Probe != nil && node.Probe.Reachable && node.Probe.RTT > 0 {
+				total += node.Probe.RTT
+				probed++
+			}
+		}
+		if probed > 0 {
+			rtt := total / time.Duration(probed)
+			t.domainRTT[domain.ID] = rtt
+			if t.minRTT == 0 || rtt < t.minRTT {
+				t.minRTT = rtt
+			}
+		}
+	}
+
+	if len(t.nodes) == 0 {
+		endpoints := make(map[string]struct{}, len(addrs))
+		for _, addr := range addrs {
+			endpoints[addr] = struct{}{}
+		}
+		for _, d := range deployments {
+			if d.Request == nil || !sharesUpstream(d.Request, endpoints) {
+				continue
+			}
+			t.nodes[d.NodeID] = struct{}{}
+			t.domains[d.DomainID] = struct{}{}
+		}
+	}
+
+	return t
+}
+
+// score 节点的拓扑得分：同节点 > 同域 > 低延迟域
+func (t *topology) score(node *registry.Node) int64 {
+	if t == nil {
+		return 0
+	}
+	var score int64
+	if _, ok := t.nodes[node.ID]; ok {
+		score += t.opts.SameNodeWeight
+	}
+	if _, ok := t.domains[node.DomainID]; ok {
+		score += t.opts.SameDomainWeight
+	}
+	if rtt, ok := t.domainRTT[node.DomainID]; ok && t.minRTT > 0 {
+		score += t.opts.LatencyWeight * int64(t.minRTT) / int64(rtt)
+	}
+	return score
+}
+
+// sharesUpstream 部署请求是否引用了相同的上游端点
+func sharesUpstream(req *schedulerpb.DeployComponentRequest, endpoints map[string]struct{}) bool {
+	for _, addr := range upstreamAddresses(req) {
+		if _, ok := endpoints[addr]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// endpointHost 提取端点地址中的主机部分，支持 host:port 与 tcp://host:port 形式
+func endpointHost(addr string) string {
+	addr = strings.TrimSpace(addr)
+	if addr == "" {
+		return ""
+	}
+	if strings.Contains(addr, "://") {
+		if u, err := url.Parse(addr); err == nil {
+			return strings.ToLower(u.Hostname())
+		}
+	}
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return strings.ToLower(host)
+	}
+	return strings.ToLower(addr)
+}
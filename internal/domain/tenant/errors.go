@@ -0,0 +1,18 @@
+package tenant
+
+import "errors"
+
+var (
+	// ErrProjectNotFound 项目不存在
+	ErrProjectNotFound = errors.New("project not found")
+	// ErrInvalidProject 无效的项目参数
+	ErrInvalidProject = errors.New("invalid project")
+	// ErrInvalidDomainRole 无效的域角色
+	ErrInvalidDomainRole = errors.New("invalid domain role")
+	// ErrDomainAlreadyOwned 域已被其他项目拥有
+	ErrDomainAlreadyOwned = errors.New("domain already owned by another project")
+	// ErrUnauthenticated 未提供有效的身份凭证
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// ErrInvalidToken 访问令牌无效
+	ErrInvalidToken = errors.New("invalid access token")
+)
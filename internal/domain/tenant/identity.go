@@ -0,0 +1,93 @@
+package tenant
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	// TenantMetadataKey 调用方声明的租户（项目 ID），gRPC metadata / HTTP header 通用
+	TenantMetadataKey = "x-iarnet-tenant"
+	// AuthorizationMetadataKey 携带项目访问令牌（Bearer <token>）
+	AuthorizationMetadataKey = "authorization"
+	// DefaultTenant 未声明身份的调用方使用的租户，只能访问不属于任何项目的域
+	DefaultTenant = "default"
+	// AdminTenant 通过管理员令牌认证的调用方使用的租户，不受域归属限制
+	AdminTenant = "admin"
+
+	tokenPrefix = "iat_"
+)
+
+// Identity 调用方身份
+type Identity struct {
+	// Tenant 租户标识（项目 ID 或 DefaultTenant）
+	Tenant string
+	// Authenticated 是否通过访问令牌认证
+	Authenticated bool
+	// Admin 是否通过管理员令牌认证，管理员不受项目范围限制
+	Admin bool
+}
+
+// IsAdmin 是否为管理员身份
+func (id *Identity) IsAdmin() bool {
+	return id != nil && id.Admin
+}
+
+// IsDefault 是否为未声明身份的默认租户
+func (id *Identity) IsDefault() bool {
+	return id == nil || id.Tenant == DefaultTenant
+}
+
+// Credentials 从请求中提取的原始身份信息
+type Credentials struct {
+	// Tenant 声明的租户
+	Tenant string
+	// Token 访问令牌
+	Token string
+}
+
+// Empty 是否未携带任何身份信息
+func (c Credentials) Empty() bool {
+	return c.Tenant == "" && c.Token == ""
+}
+
+// ParseCredentials 从租户声明与 Authorization 值中提取身份信息
+func ParseCredentials(tenant string, authorization string) Credentials {
+	creds := Credentials{Tenant: strings.TrimSpace(tenant)}
+	authorization = strings.TrimSpace(authorization)
+	if len(authorization) > 7 && strings.EqualFold(authorization[:7], "bearer ") {
+		creds.Token = strings.TrimSpace(authorization[7:])
+	}
+	return creds
+}
+
+type identityKey struct{}
+
+// WithIdentity 将身份写入 context
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFromContext 从 context 读取身份，未设置时返回 nil
+func IdentityFromContext(ctx context.Context) *Identity {
+	identity, _ := ctx.Value(identityKey{}).(*Identity)
+	return identity
+}
+
+// generateToken 生成项目访问令牌
+func generateToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return tokenPrefix + hex.EncodeToString(buf), nil
+}
+
+// hashToken 计算访问令牌摘要，仓库中只保存摘要
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,412 @@
+package tenant
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	"github.com/9triver/iarnet-global/internal/intra/repository"
+	"github.com/9triver/iarnet-global/internal/util"
+	"github.com/sirupsen/logrus"
+)
+
+// Service 项目（租户）服务
+type Service interface {
+	// CreateProject 创建项目，返回项目及访问令牌（令牌仅在创建时返回一次）
+	CreateProject(ctx context.Context, name, description string) (*Project, string, error)
+	// GetProject 获取项目
+	GetProject(ctx context.Context, id ProjectID) (*Project, error)
+	// GetAllProjects 获取所有项目
+	GetAllProjects(ctx context.Context) ([]*Project, error)
+	// UpdateProject 更新项目信息
+	UpdateProject(ctx context.Context, id ProjectID, name, description string) error
+	// DeleteProject 删除项目，其拥有的域恢复为公共域
+	DeleteProject(ctx context.Context, id ProjectID) error
+	// RotateToken 重新生成项目访问令牌，旧令牌立即失效
+	RotateToken(ctx context.Context, id ProjectID) (string, error)
+	// SetDomainAccess 设置项目对域的访问角色（拥有或共享）
+	SetDomainAccess(ctx context.Context, id ProjectID, domainID registry.DomainID, role DomainRole) error
+	// RemoveDomainAccess 撤销项目对域的访问
+	RemoveDomainAccess(ctx context.Context, id ProjectID, domainID registry.DomainID) error
+	// ForgetDomain 域被删除后清理所有项目对其的访问关系
+	ForgetDomain(ctx context.Context, domainID registry.DomainID) error
+	// LoadProjects 从 repository 加载项目
+	LoadProjects(ctx context.Context) error
+	// Authenticate 根据请求携带的身份信息解析调用方身份
+	Authenticate(ctx context.Context, creds Credentials) (*Identity, error)
+	// CanUseDomain 判断租户是否可以使用该域，AdminTenant 可以使用全部域
+	CanUseDomain(tenant string, domainID registry.DomainID) bool
+	// DomainOwner 返回域的拥有者项目
+	DomainOwner(domainID registry.DomainID) (ProjectID, bool)
+}
+
+// ServiceOptions 项目服务配置
+type ServiceOptions struct {
+	// RequireAuth 为 true 时调用方必须携带有效访问令牌，不再允许匿名调用方以默认租户访问
+	RequireAuth bool
+	// AdminToken 管理员令牌，为空时不提供管理员身份
+	AdminToken string
+}
+
+type service struct {
+	mu       sync.RWMutex
+	projects map[ProjectID]*Project
+	// owners 域 -> 拥有者项目
+	owners map[registry.DomainID]ProjectID
+	// tokens 令牌摘要 -> 项目
+	tokens map[string]ProjectID
+
+	manager *registry.Manager
+	repo    repository.ProjectRepo
+	opts    ServiceOptions
+}
+
+// NewService 创建项目服务
+func NewService(manager *registry.Manager, repo repository.ProjectRepo, opts ServiceOptions) Service {
+	return &service{
+		projects: make(map[ProjectID]*Project),
+		owners:   make(map[registry.DomainID]ProjectID),
+		tokens:   make(map[string]ProjectID),
+		manager:  manager,
+		repo:     repo,
+		opts:     opts,
+	}
+}
+
+func (s *service) LoadProjects(ctx context.Context) error {
+	daos, err := s.repo.GetAllProjects(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load projects from repository: %w", err)
+	}
+	relations, err := s.repo.GetAllProjectDomains(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load project domains from repository: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, dao := range daos {
+		s.projects[dao.ID] = &Project{
+			ID:          dao.ID,
+			Name:        dao.Name,
+			Description: dao.Description,
+			Domains:     make(map[registry.DomainID]DomainRole),
+			CreatedAt:   dao.CreatedAt,
+			UpdatedAt:   dao.UpdatedAt,
+			tokenHash:   dao.TokenHash,
+		}
+		s.tokens[dao.TokenHash] = dao.ID
+	}
+
+	for _, rel := range relations {
+		project, ok := s.projects[rel.ProjectID]
+		if !ok {
+			continue
+		}
+		role := DomainRole(rel.Role)
+		project.Domains[rel.DomainID] = role
+		if role == DomainRoleOwner {
+			s.owners[rel.DomainID] = rel.ProjectID
+		}
+	}
+
+	logrus.Infof("Loaded %d project(s) from database", len(daos))
+	return nil
+}
+
+func (s *service) CreateProject(ctx context.Context, name, description string) (*Project, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", fmt.Errorf("%w: project name is required", ErrInvalidProject)
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	now := time.Now()
+	project := &Project{
+		ID:          util.GenIDWith("project."),
+		Name:        name,
+		Description: description,
+		Domains:     make(map[registry.DomainID]DomainRole),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		tokenHash:   hashToken(token),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.repo.CreateProject(ctx, toDAO(project)); err != nil {
+		return nil, "", fmt.Errorf("failed to persist project to repository: %w", err)
+	}
+	s.projects[project.ID] = project
+	s.tokens[project.tokenHash] = project.ID
+
+	logrus.Infof("Project created: id=%s, name=%s", project.ID, project.Name)
+	return project.Clone(), token, nil
+}
+
+func (s *service) GetProject(ctx context.Context, id ProjectID) (*Project, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	project, ok := s.projects[id]
+	if !ok {
+		return nil, ErrProjectNotFound
+	}
+	return project.Clone(), nil
+}
+
+func (s *service) GetAllProjects(ctx context.Context) ([]*Project, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]*Project, 0, len(s.projects))
+	for _, project := range s.projects {
+		result = append(result, project.Clone())
+	}
+	return result, nil
+}
+
+func (s *service) UpdateProject(ctx context.Context, id ProjectID, name, description string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	project, ok := s.projects[id]
+	if !ok {
+		return ErrProjectNotFound
+	}
+
+	updated := project.Clone()
+	if name = strings.TrimSpace(name); name != "" {
+		updated.Name = name
+	}
+	if description != "" {
+		updated.Description = description
+	}
+	updated.UpdatedAt = time.Now()
+
+	if err := s.repo.UpdateProject(ctx, toDAO(updated)); err != nil {
+		return fmt.Errorf("failed to persist project to repository: %w", err)
+	}
+	s.projects[id] = updated
+	return nil
+}
+
+func (s *service) DeleteProject(ctx context.Context, id ProjectID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	project, ok := s.projects[id]
+	if !ok {
+		return ErrProjectNotFound
+	}
+
+	if err := s.repo.DeleteProject(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete project from repository: %w", err)
+	}
+
+	for _, domainID := range project.OwnedDomains() {
+		delete(s.owners, domainID)
+	}
+	delete(s.tokens, project.tokenHash)
+	delete(s.projects, id)
+
+	logrus.Infof("Project deleted: id=%s", id)
+	return nil
+}
+
+func (s *service) RotateToken(ctx context.Context, id ProjectID) (string, error) {
+	token, err := generateToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	project, ok := s.projects[id]
+	if !ok {
+		return "", ErrProjectNotFound
+	}
+
+	updated := project.Clone()
+	updated.tokenHash = hashToken(token)
+	updated.UpdatedAt = time.Now()
+	if err := s.repo.UpdateProject(ctx, toDAO(updated)); err != nil {
+		return "", fmt.Errorf("failed to persist project to repository: %w", err)
+	}
+
+	delete(s.tokens, project.tokenHash)
+	s.tokens[updated.tokenHash] = id
+	s.projects[id] = updated
+
+	logrus.Infof("Access token rotated for project %s", id)
+	return token, nil
+}
+
+func (s *service) SetDomainAccess(ctx context.Context, id ProjectID, domainID registry.DomainID, role DomainRole) error {
+	if !role.Valid() {
+		return fmt.Errorf("%w: %q", ErrInvalidDomainRole, role)
+	}
+	if _, err := s.manager.GetDomain(domainID); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	project, ok := s.projects[id]
+	if !ok {
+		return ErrProjectNotFound
+	}
+	if role == DomainRoleOwner {
+		if owner, owned := s.owners[domainID]; owned && owner != id {
+			return ErrDomainAlreadyOwned
+		}
+	}
+
+	err := s.repo.SetProjectDomain(ctx, &repository.ProjectDomainDAO{
+		ProjectID: id,
+		DomainID:  domainID,
+		Role:      string(role),
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to persist project domain to repository: %w", err)
+	}
+
+	previous := project.Domains[domainID]
+	project.Domains[domainID] = role
+	if role == DomainRoleOwner {
+		s.owners[domainID] = id
+	} else if previous == DomainRoleOwner {
+		delete(s.owners, domainID)
+	}
+
+	logrus.Infof("Project %s granted %s access to domain %s", id, role, domainID)
+	return nil
+}
+
+func (s *service) RemoveDomainAccess(ctx context.Context, id ProjectID, domainID registry.DomainID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	project, ok := s.projects[id]
+	if !ok {
+		return ErrProjectNotFound
+	}
+	role, ok := project.Domains[domainID]
+	if !ok {
+		return nil
+	}
+
+	if err := s.repo.DeleteProjectDomain(ctx, id, domainID); err != nil {
+		return fmt.Errorf("failed to delete project domain from repository: %w", err)
+	}
+
+	delete(project.Domains, domainID)
+	if role == DomainRoleOwner {
+		delete(s.owners, domainID)
+	}
+
+	logrus.Infof("Project %s access to domain %s revoked", id, domainID)
+	return nil
+}
+
+func (s *service) ForgetDomain(ctx context.Context, domainID registry.DomainID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, project := range s.projects {
+		if _, ok := project.Domains[domainID]; !ok {
+			continue
+		}
+		if err := s.repo.DeleteProjectDomain(ctx, id, domainID); err != nil {
+			return fmt.Errorf("failed to delete project domain from repository: %w", err)
+		}
+		delete(project.Domains, domainID)
+	}
+	delete(s.owners, domainID)
+	return nil
+}
+
+func (s *service) Authenticate(ctx context.Context, creds Credentials) (*Identity, error) {
+	if creds.Token != "" {
+		if s.opts.AdminToken != "" && subtle.ConstantTimeCompare([]byte(creds.Token), []byte(s.opts.AdminToken)) == 1 {
+			if creds.Tenant != "" && creds.Tenant != DefaultTenant && creds.Tenant != AdminTenant {
+				return nil, fmt.Errorf("%w: admin token does not belong to tenant %s", ErrInvalidToken, creds.Tenant)
+			}
+			return &Identity{Tenant: AdminTenant, Authenticated: true, Admin: true}, nil
+		}
+
+		s.mu.RLock()
+		projectID, ok := s.tokens[hashToken(creds.Token)]
+		s.mu.RUnlock()
+
+		if !ok {
+			return nil, ErrInvalidToken
+		}
+		if creds.Tenant != "" && creds.Tenant != projectID {
+			return nil, fmt.Errorf("%w: token does not belong to tenant %s", ErrInvalidToken, creds.Tenant)
+		}
+		return &Identity{Tenant: projectID, Authenticated: true}, nil
+	}
+
+	// 项目身份必须由访问令牌证明，仅声明的租户不被信任
+	if creds.Tenant != "" && creds.Tenant != DefaultTenant {
+		return nil, fmt.Errorf("%w: tenant %s requires an access token", ErrUnauthenticated, creds.Tenant)
+	}
+	if s.opts.RequireAuth {
+		return nil, ErrUnauthenticated
+	}
+	return &Identity{Tenant: DefaultTenant}, nil
+}
+
+func (s *service) CanUseDomain(tenant string, domainID registry.DomainID) bool {
+	// 管理员不受域归属限制
+	if tenant == AdminTenant {
+		return true
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// 没有拥有者的域是公共域
+	if _, owned := s.owners[domainID]; !owned {
+		return true
+	}
+	project, ok := s.projects[tenant]
+	if !ok {
+		return false
+	}
+	_, ok = project.Domains[domainID]
+	return ok
+}
+
+func (s *service) DomainOwner(domainID registry.DomainID) (ProjectID, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	owner, ok := s.owners[domainID]
+	return owner, ok
+}
+
+func toDAO(project *Project) *repository.ProjectDAO {
+	return &repository.ProjectDAO{
+		ID:          project.ID,
+		Name:        project.Name,
+		Description: project.Description,
+		TokenHash:   project.tokenHash,
+		CreatedAt:   project.CreatedAt,
+		UpdatedAt:   project.UpdatedAt,
+	}
+}
@@ -0,0 +1,84 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	"github.com/9triver/iarnet-global/internal/intra/repository"
+)
+
+func newTestService(t *testing.T) (Service, *registry.Manager) {
+	t.Helper()
+	repo, err := repository.NewProjectRepo(filepath.Join(t.TempDir(), "projects.db"), 1, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	manager := registry.NewManager(registry.ManagerOptions{})
+	return NewService(manager, repo, ServiceOptions{AdminToken: "adm1n"}), manager
+}
+
+func TestAuthenticate(t *testing.T) {
+	s, _ := newTestService(t)
+	ctx := context.Background()
+	project, token, err := s.CreateProject(ctx, "team", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := s.Authenticate(ctx, Credentials{})
+	if err != nil || id.Tenant != DefaultTenant || id.Authenticated || id.IsAdmin() {
+		t.Errorf("anonymous: got %+v, %v, want unauthenticated default tenant", id, err)
+	}
+	id, err = s.Authenticate(ctx, Credentials{Token: "adm1n"})
+	if err != nil || !id.IsAdmin() || id.Tenant != AdminTenant {
+		t.Errorf("admin token: got %+v, %v, want admin identity", id, err)
+	}
+	id, err = s.Authenticate(ctx, Credentials{Token: token})
+	if err != nil || id.Tenant != project.ID || id.IsAdmin() {
+		t.Errorf("project token: got %+v, %v, want tenant %s", id, err, project.ID)
+	}
+	if _, err := s.Authenticate(ctx, Credentials{Tenant: project.ID}); !errors.Is(err, ErrUnauthenticated) {
+		t.Errorf("tenant claim without token: got %v, want ErrUnauthenticated", err)
+	}
+	if _, err := s.Authenticate(ctx, Credentials{Tenant: project.ID, Token: "adm1n"}); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("admin token claiming a project: got %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestCanUseDomainAdminBypassesOwnership(t *testing.T) {
+	s, manager := newTestService(t)
+	ctx := context.Background()
+	if err := manager.AddDomain(&registry.Domain{ID: "domain.private", Name: "private"}); err != nil {
+		t.Fatal(err)
+	}
+	owner, _, err := s.CreateProject(ctx, "owner", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, _, err := s.CreateProject(ctx, "other", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetDomainAccess(ctx, owner.ID, "domain.private", DomainRoleOwner); err != nil {
+		t.Fatal(err)
+	}
+
+	admin, err := s.Authenticate(ctx, Credentials{Token: "adm1n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for tenant, want := range map[string]bool{
+		owner.ID:      true,
+		admin.Tenant:  true,
+		other.ID:      false,
+		DefaultTenant: false,
+	} {
+		if got := s.CanUseDomain(tenant, "domain.private"); got != want {
+			t.Errorf("CanUseDomain(%s) = %v, want %v", tenant, got, want)
+		}
+	}
+}
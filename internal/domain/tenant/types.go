@@ -0,0 +1,72 @@
+package tenant
+
+import (
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+)
+
+// ProjectID 项目 ID，同时作为调度与配额中的租户标识
+type ProjectID = string
+
+// DomainRole 项目对域的访问角色
+type DomainRole string
+
+const (
+	// DomainRoleOwner 项目拥有该域，每个域最多一个拥有者
+	DomainRoleOwner DomainRole = "owner"
+	// DomainRoleShared 项目被授权共享使用该域
+	DomainRoleShared DomainRole = "shared"
+)
+
+// Valid 是否为合法角色
+func (r DomainRole) Valid() bool {
+	return r == DomainRoleOwner || r == DomainRoleShared
+}
+
+// Project 项目（租户）
+// 项目拥有若干域，也可以被授权使用其他项目共享的域；不属于任何项目的域对所有调用方开放
+type Project struct {
+	ID          ProjectID `json:"id" yaml:"id"`
+	Name        string    `json:"name" yaml:"name"`
+	Description string    `json:"description" yaml:"description"`
+	// Domains 项目可访问的域及角色
+	Domains   map[registry.DomainID]DomainRole `json:"domains" yaml:"domains"`
+	CreatedAt time.Time                        `json:"created_at" yaml:"created_at"`
+	UpdatedAt time.Time                        `json:"updated_at" yaml:"updated_at"`
+
+	tokenHash string
+}
+
+// Clone 深拷贝项目
+func (p *Project) Clone() *Project {
+	if p == nil {
+		return nil
+	}
+	copy := *p
+	copy.Domains = make(map[registry.DomainID]DomainRole, len(p.Domains))
+	for domainID, role := range p.Domains {
+		copy.Domains[domainID] = role
+	}
+	return &copy
+}
+
+// OwnedDomains 返回项目拥有的域
+func (p *Project) OwnedDomains() []registry.DomainID {
+	return p.domainsWithRole(DomainRoleOwner)
+}
+
+// SharedDomains 返回项目被授权共享的域
+func (p *Project) SharedDomains() []registry.DomainID {
+	return p.domainsWithRole(DomainRoleShared)
+}
+
+func (p *Project) domainsWithRole(role DomainRole) []registry.DomainID {
+	result := make([]registry.DomainID, 0)
+	for domainID, r := range p.Domains {
+		if r == role {
+			result = append(result, domainID)
+		}
+	}
+	return result
+}
@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/alert"
+	"github.com/9triver/iarnet-global/internal/domain/audit"
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	"github.com/9triver/iarnet-global/internal/util"
+)
+
+// PublishRegistryEvent 发布注册中心事件，用于 registry.Manager.SubscribeEvents
+func (s *service) PublishRegistryEvent(event *registry.Event) {
+	s.Publish(&Event{
+		ID:       util.GenIDWith("event."),
+		Type:     string(event.Type),
+		Source:   SourceRegistry,
+		DomainID: event.DomainID,
+		NodeID:   event.NodeID,
+		Message:  event.Message,
+		Details:  copyDetails(event.Details),
+		Time:     event.Time,
+	})
+}
+
+// PublishAuditEvent 发布调度事件，用于 audit.Service.Subscribe
+func (s *service) PublishAuditEvent(event *audit.Event) {
+	s.Publish(&Event{
+		ID:           event.ID,
+		Type:         string(event.Type),
+		Source:       SourceScheduler,
+		DomainID:     event.DomainID,
+		NodeID:       event.NodeID,
+		DeploymentID: event.DeploymentID,
+		Tenant:       event.Tenant,
+		Message:      event.Message,
+		Details:      copyDetails(event.Details),
+		Time:         event.CreatedAt,
+	})
+}
+
+// Notify 以 alert.firing / alert.resolved 事件发布告警，实现 alert.Sink
+// 投递在后台进行，这里只在事件队列已满时返回错误
+func (s *service) Notify(_ context.Context, a *alert.Alert) error {
+	details := map[string]string{"rule": a.Rule, "rule_type": string(a.Type), "alert_id": a.ID}
+	if a.Resource != "" {
+		details["resource"] = a.Resource
+	}
+	if a.ExhaustsAt != nil {
+		details["exhausts_at"] = a.ExhaustsAt.Format(time.RFC3339)
+	}
+	if !s.Publish(&Event{
+		ID:       util.GenIDWith("event."),
+		Type:     "alert." + string(a.State),
+		Source:   SourceAlert,
+		DomainID: a.DomainID,
+		NodeID:   a.NodeID,
+		Message:  a.Message,
+		Details:  details,
+		Time:     a.UpdatedAt,
+	}) {
+		return errEventQueueFull
+	}
+	return nil
+}
+
+func copyDetails(details map[string]string) map[string]string {
+	if len(details) == 0 {
+		return nil
+	}
+	copied := make(map[string]string, len(details))
+	for k, v := range details {
+		copied[k] = v
+	}
+	return copied
+}
@@ -0,0 +1,631 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/alert"
+	"github.com/9triver/iarnet-global/internal/domain/audit"
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	"github.com/9triver/iarnet-global/internal/intra/repository"
+	"github.com/9triver/iarnet-global/internal/util"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultMaxAttempts 默认的最大投递次数（含首次投递），超过后进入死信
+	DefaultMaxAttempts = 8
+	// DefaultInitialBackoff 默认的首次重试间隔，之后每次翻倍
+	DefaultInitialBackoff = 5 * time.Second
+	// DefaultMaxBackoff 默认的最大重试间隔
+	DefaultMaxBackoff = 10 * time.Minute
+	// DefaultTimeout 默认的单次投递超时时间
+	DefaultTimeout = 10 * time.Second
+	// DefaultWorkers 默认的并发投递数
+	DefaultWorkers = 4
+	// DefaultRetention 已投递与死信记录的默认保留时间
+	DefaultRetention = 7 * 24 * time.Hour
+	// DefaultListLimit 未指定数量时返回的最大投递记录数
+	DefaultListLimit = 100
+
+	// SignatureHeader 请求签名：sha256=<hex(HMAC-SHA256(secret, timestamp + "." + body))>
+	SignatureHeader = "X-Iarnet-Signature"
+	// TimestampHeader 签名使用的 Unix 时间戳（秒），接收方可据此拒绝过旧的请求
+	TimestampHeader = "X-Iarnet-Timestamp"
+	// EventHeader 事件类型
+	EventHeader = "X-Iarnet-Event"
+	// DeliveryHeader 投递 ID，重试时保持不变，可用于去重
+	DeliveryHeader = "X-Iarnet-Delivery"
+
+	// eventQueueSize 等待分发的事件队列长度
+	eventQueueSize = 1024
+	// pollInterval 检查到期投递的周期
+	pollInterval = time.Second
+	// pruneInterval 清理过期投递记录的周期
+	pruneInterval = 10 * time.Minute
+	// maxErrorBody 记录到投递错误中的响应体长度
+	maxErrorBody = 256
+)
+
+var errEventQueueFull = errors.New("webhook event queue is full")
+
+// Options webhook 服务配置
+type Options struct {
+	// Sinks webhook 目标
+	Sinks []SinkSpec
+	// MaxAttempts 最大投递次数（含首次投递）
+	MaxAttempts int
+	// InitialBackoff / MaxBackoff 重试间隔从 InitialBackoff 开始翻倍，不超过 MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// Timeout 单次投递超时时间
+	Timeout time.Duration
+	// Workers 并发投递数
+	Workers int
+	// Retention 已投递与死信记录的保留时间
+	Retention time.Duration
+	// IsLeader HA 模式下只有 leader 分发事件并投递，为空时视为单副本部署
+	IsLeader func() bool
+}
+
+func (o Options) withDefaults() Options {
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = DefaultMaxAttempts
+	}
+	if o.InitialBackoff <= 0 {
+		o.InitialBackoff = DefaultInitialBackoff
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = DefaultMaxBackoff
+	}
+	if o.MaxBackoff < o.InitialBackoff {
+		o.MaxBackoff = o.InitialBackoff
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultTimeout
+	}
+	if o.Workers <= 0 {
+		o.Workers = DefaultWorkers
+	}
+	if o.Retention <= 0 {
+		o.Retention = DefaultRetention
+	}
+	return o
+}
+
+// Service webhook 服务：将注册中心、调度与告警事件按订阅条件渲染后投递到 webhook 目标，
+// 投递失败时按指数退避重试，超过最大次数后进入死信
+type Service interface {
+	// Start 启动事件分发与投递
+	Start(ctx context.Context) error
+	// Stop 停止事件分发与投递，未完成的投递在下次启动后继续
+	Stop()
+
+	// Publish 发布事件，不阻塞；事件队列已满时丢弃并返回 false
+	Publish(event *Event) bool
+	// PublishRegistryEvent 发布注册中心事件，用于 registry.Manager.SubscribeEvents
+	PublishRegistryEvent(event *registry.Event)
+	// PublishAuditEvent 发布调度事件，用于 audit.Service.Subscribe
+	PublishAuditEvent(event *audit.Event)
+	// Notify 发布告警事件，实现 alert.Sink
+	Notify(ctx context.Context, a *alert.Alert) error
+
+	// Sinks 返回 webhook 目标及其投递统计
+	Sinks(ctx context.Context) ([]*SinkStatus, error)
+	// ListDeliveries 按创建时间倒序查询投递记录
+	ListDeliveries(ctx context.Context, filter DeliveryFilter) ([]*Delivery, error)
+	// GetDelivery 获取投递记录
+	GetDelivery(ctx context.Context, id string) (*Delivery, error)
+	// Retry 重新投递进入死信的记录，重新计算投递次数
+	Retry(ctx context.Context, id string) (*Delivery, error)
+	// Test 向目标投递一条测试事件（不检查订阅条件）
+	Test(ctx context.Context, sinkName string) (*Delivery, error)
+}
+
+// sink 已校验的 webhook 目标
+type sink struct {
+	spec     SinkSpec
+	renderer *renderer
+
+	mu sync.Mutex
+	// occurrences 按事件类型与域记录的事件时间，用于次数阈值
+	occurrences map[string][]time.Time
+}
+
+// admit 记录一次事件，返回是否达到次数阈值以及窗口内的累计次数
+func (k *sink) admit(event *Event, now time.Time) (bool, int) {
+	if k.spec.MinCount <= 1 {
+		return true, 1
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	key := event.Type + "/" + event.DomainID
+	times := k.occurrences[key][:0]
+	for _, t := range k.occurrences[key] {
+		if now.Sub(t) < k.spec.Window {
+			times = append(times, t)
+		}
+	}
+	times = append(times, now)
+	if len(times) < k.spec.MinCount {
+		k.occurrences[key] = times
+		return false, len(times)
+	}
+	// 达到阈值后重新计数，避免每条后续事件都触发投递
+	delete(k.occurrences, key)
+	return true, len(times)
+}
+
+type service struct {
+	repo   repository.WebhookRepo
+	opts   Options
+	sinks  []*sink
+	byName map[string]*sink
+	client *http.Client
+
+	events chan *Event
+	wake   chan struct{}
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewService 创建 webhook 服务
+func NewService(repo repository.WebhookRepo, opts Options) (Service, error) {
+	opts = opts.withDefaults()
+	s := &service{
+		repo:   repo,
+		opts:   opts,
+		byName: make(map[string]*sink, len(opts.Sinks)),
+		client: &http.Client{Timeout: opts.Timeout},
+		events: make(chan *Event, eventQueueSize),
+		wake:   make(chan struct{}, 1),
+		stopCh: make(chan struct{}),
+	}
+	for _, spec := range opts.Sinks {
+		k, err := newSink(spec)
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := s.byName[spec.Name]; exists {
+			return nil, fmt.Errorf("%w: duplicate sink name %q", ErrInvalidSink, spec.Name)
+		}
+		s.sinks = append(s.sinks, k)
+		s.byName[spec.Name] = k
+	}
+	return s, nil
+}
+
+func newSink(spec SinkSpec) (*sink, error) {
+	if spec.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidSink)
+	}
+	u, err := url.Parse(spec.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%w: sink %q has an invalid url %q", ErrInvalidSink, spec.Name, spec.URL)
+	}
+	if spec.Template == "" {
+		spec.Template = TemplateGeneric
+	}
+	if spec.MinCount > 1 && spec.Window <= 0 {
+		return nil, fmt.Errorf("%w: sink %q sets min_count without a window", ErrInvalidSink, spec.Name)
+	}
+	r, err := newRenderer(&spec)
+	if err != nil {
+		return nil, err
+	}
+	return &sink{spec: spec, renderer: r, occurrences: make(map[string][]time.Time)}, nil
+}
+
+func (s *service) leading() bool {
+	return s.opts.IsLeader == nil || s.opts.IsLeader()
+}
+
+func (s *service) Start(ctx context.Context) error {
+	s.wg.Add(2)
+	go s.dispatch(ctx)
+	go s.deliverLoop(ctx)
+	logrus.Infof("Webhooks started (%d sink(s), max attempts: %d, backoff: %v-%v)",
+		len(s.sinks), s.opts.MaxAttempts, s.opts.InitialBackoff, s.opts.MaxBackoff)
+	return nil
+}
+
+func (s *service) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+		s.wg.Wait()
+	})
+}
+
+// Publish 只有 leader 分发事件，其他副本直接丢弃
+func (s *service) Publish(event *Event) bool {
+	if !s.leading() || len(s.sinks) == 0 {
+		return true
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	select {
+	case s.events <- event:
+		return true
+	default:
+		logrus.Warnf("Webhook event queue is full, dropping event %s (%s)", event.ID, event.Type)
+		return false
+	}
+}
+
+// dispatch 将事件按订阅条件生成投递记录
+func (s *service) dispatch(ctx context.Context) {
+	defer s.wg.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopCh:
+			return
+		case event := <-s.events:
+			s.fanOut(ctx, event)
+		}
+	}
+}
+
+func (s *service) fanOut(ctx context.Context, event *Event) {
+	now := time.Now()
+	for _, k := range s.sinks {
+		if !k.spec.matches(event) {
+			continue
+		}
+		ok, count := k.admit(event, now)
+		if !ok {
+			continue
+		}
+		e := event
+		if k.spec.MinCount > 1 {
+			copied := *event
+			copied.Details = copyDetails(event.Details)
+			if copied.Details == nil {
+				copied.Details = make(map[string]string, 2)
+			}
+			copied.Details["occurrences"] = strconv.Itoa(count)
+			copied.Details["window"] = k.spec.Window.String()
+			e = &copied
+		}
+		if _, err := s.enqueue(ctx, k, e); err != nil {
+			logrus.Warnf("Failed to enqueue webhook delivery of event %s to %s: %v", event.ID, k.spec.Name, err)
+		}
+	}
+}
+
+// enqueue 渲染请求体并保存投递记录；渲染失败的记录直接进入死信，便于通过投递记录排查
+func (s *service) enqueue(ctx context.Context, k *sink, event *Event) (*Delivery, error) {
+	now := time.Now()
+	dao := &repository.WebhookDeliveryDAO{
+		ID:            util.GenIDWith("delivery."),
+		Sink:          k.spec.Name,
+		EventID:       event.ID,
+		EventType:     event.Type,
+		Status:        string(DeliveryPending),
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	payload, err := k.renderer.render(event)
+	if err != nil {
+		dao.Status = string(DeliveryDead)
+		dao.LastError = err.Error()
+		logrus.Warnf("Failed to render event %s for webhook %s: %v", event.ID, k.spec.Name, err)
+	} else {
+		dao.Payload = string(payload)
+	}
+
+	if err := s.repo.CreateDelivery(ctx, dao); err != nil {
+		return nil, err
+	}
+	s.signal()
+	return fromDAO(dao), nil
+}
+
+// signal 唤醒投递循环（不阻塞）
+func (s *service) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *service) deliverLoop(ctx context.Context) {
+	defer s.wg.Done()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	var lastPrune time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+		if !s.leading() {
+			continue
+		}
+		s.deliverDue(ctx)
+		if now := time.Now(); now.Sub(lastPrune) >= pruneInterval {
+			s.prune(ctx, now)
+			lastPrune = now
+		}
+	}
+}
+
+// deliverDue 并发投递全部到期的记录，每批完成后再取下一批，同一记录不会被重复投递
+func (s *service) deliverDue(ctx context.Context) {
+	batchSize := s.opts.Workers * 4
+	for {
+		due, err := s.repo.ListDue(ctx, []string{string(DeliveryPending), string(DeliveryRetrying)}, time.Now(), batchSize)
+		if err != nil {
+			logrus.Warnf("Failed to list due webhook deliveries: %v", err)
+			return
+		}
+
+		sem := make(chan struct{}, s.opts.Workers)
+		var wg sync.WaitGroup
+		for _, dao := range due {
+			sem <- struct{}{}
+			wg.Add(1)
+			go func(dao *repository.WebhookDeliveryDAO) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				s.attempt(ctx, dao)
+			}(dao)
+		}
+		wg.Wait()
+
+		if len(due) < batchSize {
+			return
+		}
+		select {
+		case <-s.stopCh:
+			return
+		default:
+		}
+	}
+}
+
+// attempt 投递一次并保存结果
+func (s *service) attempt(ctx context.Context, dao *repository.WebhookDeliveryDAO) {
+	now := time.Now()
+	dao.Attempts++
+	dao.UpdatedAt = now
+
+	k, ok := s.byName[dao.Sink]
+	if !ok {
+		dao.Status = string(DeliveryDead)
+		dao.LastError = "sink is no longer configured"
+	} else {
+		code, err := s.send(ctx, k, dao)
+		dao.LastStatusCode = code
+		switch {
+		case err == nil:
+			dao.Status = string(DeliveryDelivered)
+			dao.LastError = ""
+			dao.DeliveredAt = now
+		case dao.Attempts >= s.opts.MaxAttempts:
+			dao.Status = string(DeliveryDead)
+			dao.LastError = err.Error()
+			logrus.Warnf("Webhook delivery %s to %s dead-lettered after %d attempt(s): %v", dao.ID, dao.Sink, dao.Attempts, err)
+		default:
+			dao.Status = string(DeliveryRetrying)
+			dao.LastError = err.Error()
+			dao.NextAttemptAt = now.Add(s.backoff(dao.Attempts))
+			logrus.Debugf("Webhook delivery %s to %s failed (attempt %d), retrying at %v: %v",
+				dao.ID, dao.Sink, dao.Attempts, dao.NextAttemptAt, err)
+		}
+	}
+
+	if err := s.repo.UpdateDelivery(ctx, dao); err != nil {
+		logrus.Warnf("Failed to update webhook delivery %s: %v", dao.ID, err)
+	}
+}
+
+// send 发送请求，返回响应状态码；非 2xx 响应视为失败
+func (s *service) send(ctx context.Context, k *sink, dao *repository.WebhookDeliveryDAO) (int, error) {
+	body := []byte(dao.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, k.spec.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	for name, value := range k.spec.Headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "iarnet-global-webhook")
+	req.Header.Set(EventHeader, dao.EventType)
+	req.Header.Set(DeliveryHeader, dao.ID)
+	if k.spec.Secret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(TimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, Sign(k.spec.Secret, timestamp, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, bytes.TrimSpace(snippet))
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign 计算请求签名，接收方以同样的方式计算并比较 X-Iarnet-Signature
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// backoff 第 attempts 次投递失败后的等待时间：指数退避，附加 ±20% 的随机抖动
+func (s *service) backoff(attempts int) time.Duration {
+	delay := s.opts.InitialBackoff
+	for i := 1; i < attempts && delay < s.opts.MaxBackoff; i++ {
+		delay *= 2
+	}
+	delay = min(delay, s.opts.MaxBackoff)
+	jitter := time.Duration((rand.Float64()*0.4 - 0.2) * float64(delay))
+	return delay + jitter
+}
+
+// prune 删除超过保留时间的已投递与死信记录
+func (s *service) prune(ctx context.Context, now time.Time) {
+	deleted, err := s.repo.DeleteBefore(ctx, []string{string(DeliveryDelivered), string(DeliveryDead)}, now.Add(-s.opts.Retention))
+	if err != nil {
+		logrus.Warnf("Failed to prune webhook deliveries: %v", err)
+		return
+	}
+	if deleted > 0 {
+		logrus.Debugf("Pruned %d webhook delivery record(s)", deleted)
+	}
+}
+
+func (s *service) Sinks(ctx context.Context) ([]*SinkStatus, error) {
+	counts, err := s.repo.CountByStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]*SinkStatus, 0, len(s.sinks))
+	for _, k := range s.sinks {
+		status := &SinkStatus{
+			Name:       k.spec.Name,
+			URL:        k.spec.URL,
+			Template:   k.spec.Template,
+			Signed:     k.spec.Secret != "",
+			Events:     k.spec.Events,
+			Domains:    k.spec.Domains,
+			Tenants:    k.spec.Tenants,
+			Deliveries: make(map[DeliveryStatus]int),
+		}
+		if k.spec.MinCount > 1 {
+			status.MinCount = k.spec.MinCount
+			status.Window = k.spec.Window.String()
+		}
+		for st, n := range counts[k.spec.Name] {
+			status.Deliveries[DeliveryStatus(st)] = n
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func (s *service) ListDeliveries(ctx context.Context, filter DeliveryFilter) ([]*Delivery, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultListLimit
+	}
+	daos, err := s.repo.ListDeliveries(ctx, repository.WebhookDeliveryQuery{
+		Sink:      filter.Sink,
+		Status:    string(filter.Status),
+		EventType: filter.EventType,
+		Limit:     limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	deliveries := make([]*Delivery, 0, len(daos))
+	for _, dao := range daos {
+		deliveries = append(deliveries, fromDAO(dao))
+	}
+	return deliveries, nil
+}
+
+func (s *service) GetDelivery(ctx context.Context, id string) (*Delivery, error) {
+	dao, err := s.repo.GetDelivery(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrWebhookDeliveryNotFound) {
+			return nil, ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+	return fromDAO(dao), nil
+}
+
+func (s *service) Retry(ctx context.Context, id string) (*Delivery, error) {
+	dao, err := s.repo.GetDelivery(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrWebhookDeliveryNotFound) {
+			return nil, ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+	if dao.Status != string(DeliveryDead) {
+		return nil, ErrNotRetryable
+	}
+	if dao.Payload == "" {
+		return nil, fmt.Errorf("%w: delivery has no payload (%s)", ErrNotRetryable, dao.LastError)
+	}
+
+	now := time.Now()
+	dao.Status = string(DeliveryPending)
+	dao.Attempts = 0
+	dao.NextAttemptAt = now
+	dao.UpdatedAt = now
+	if err := s.repo.UpdateDelivery(ctx, dao); err != nil {
+		return nil, err
+	}
+	s.signal()
+	return fromDAO(dao), nil
+}
+
+func (s *service) Test(ctx context.Context, sinkName string) (*Delivery, error) {
+	k, ok := s.byName[sinkName]
+	if !ok {
+		return nil, ErrSinkNotFound
+	}
+	return s.enqueue(ctx, k, &Event{
+		ID:      util.GenIDWith("event."),
+		Type:    EventTest,
+		Source:  SourceWebhook,
+		Message: fmt.Sprintf("test delivery to webhook %s", sinkName),
+		Time:    time.Now(),
+	})
+}
+
+func fromDAO(dao *repository.WebhookDeliveryDAO) *Delivery {
+	return &Delivery{
+		ID:             dao.ID,
+		Sink:           dao.Sink,
+		EventID:        dao.EventID,
+		EventType:      dao.EventType,
+		Payload:        dao.Payload,
+		Status:         DeliveryStatus(dao.Status),
+		Attempts:       dao.Attempts,
+		LastStatusCode: dao.LastStatusCode,
+		LastError:      dao.LastError,
+		NextAttemptAt:  dao.NextAttemptAt,
+		DeliveredAt:    dao.DeliveredAt,
+		CreatedAt:      dao.CreatedAt,
+		UpdatedAt:      dao.UpdatedAt,
+	}
+}
@@ -0,0 +1,75 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"text/template"
+)
+
+// renderer 按目标的请求体格式渲染事件
+type renderer struct {
+	template Template
+	custom   *template.Template
+}
+
+func newRenderer(spec *SinkSpec) (*renderer, error) {
+	r := &renderer{template: spec.Template}
+	switch spec.Template {
+	case TemplateGeneric, TemplateSlack, TemplateFeishu, TemplateDingTalk:
+		if spec.BodyTemplate != "" {
+			return nil, fmt.Errorf("%w: sink %q sets body_template but template is %q", ErrInvalidSink, spec.Name, spec.Template)
+		}
+	case TemplateCustom:
+		if spec.BodyTemplate == "" {
+			return nil, fmt.Errorf("%w: sink %q uses the custom template without body_template", ErrInvalidSink, spec.Name)
+		}
+		t, err := template.New(spec.Name).Funcs(template.FuncMap{"json": toJSON}).Parse(spec.BodyTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("%w: sink %q has an invalid body_template: %v", ErrInvalidSink, spec.Name, err)
+		}
+		r.custom = t
+	default:
+		return nil, fmt.Errorf("%w: sink %q has unknown template %q", ErrInvalidSink, spec.Name, spec.Template)
+	}
+	return r, nil
+}
+
+// render 渲染请求体
+// 聊天工具的模板只发送一行文本；custom 模板以事件为数据，可以用 json 函数输出转义后的字段，例如 {{json .Message}}
+func (r *renderer) render(event *Event) ([]byte, error) {
+	var body any
+	switch r.template {
+	case TemplateSlack:
+		body = map[string]any{"text": summary(event)}
+	case TemplateFeishu:
+		body = map[string]any{"msg_type": "text", "content": map[string]string{"text": summary(event)}}
+	case TemplateDingTalk:
+		body = map[string]any{"msgtype": "text", "text": map[string]string{"content": summary(event)}}
+	case TemplateCustom:
+		var buf bytes.Buffer
+		if err := r.custom.Execute(&buf, event); err != nil {
+			return nil, fmt.Errorf("failed to render body template: %w", err)
+		}
+		if !json.Valid(buf.Bytes()) {
+			return nil, fmt.Errorf("body template did not produce valid JSON")
+		}
+		return buf.Bytes(), nil
+	default:
+		body = event
+	}
+	return json.Marshal(body)
+}
+
+// summary 聊天工具中展示的一行文本
+func summary(event *Event) string {
+	return fmt.Sprintf("[iarnet-global] %s: %s", event.Type, event.Message)
+}
+
+func toJSON(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
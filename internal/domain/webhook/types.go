@@ -0,0 +1,188 @@
+package webhook
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidSink 无效的 webhook 目标配置
+	ErrInvalidSink = errors.New("invalid webhook sink")
+	// ErrSinkNotFound webhook 目标不存在
+	ErrSinkNotFound = errors.New("webhook sink not found")
+	// ErrDeliveryNotFound 投递记录不存在
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+	// ErrNotRetryable 只有进入死信的投递可以手动重试
+	ErrNotRetryable = errors.New("webhook delivery is not dead-lettered")
+)
+
+// Source 事件来源
+type Source string
+
+const (
+	// SourceRegistry 注册中心事件：域与节点的变化
+	SourceRegistry Source = "registry"
+	// SourceScheduler 调度事件：部署失败、失联、重启、抢占等
+	SourceScheduler Source = "scheduler"
+	// SourceAlert 告警触发与恢复
+	SourceAlert Source = "alert"
+	// SourceWebhook 测试投递
+	SourceWebhook Source = "webhook"
+)
+
+// EventTest 测试投递的事件类型
+const EventTest = "webhook.test"
+
+// Event 投递给 webhook 的事件
+type Event struct {
+	ID           string            `json:"id"`
+	Type         string            `json:"type"`
+	Source       Source            `json:"source"`
+	DomainID     string            `json:"domain_id,omitempty"`
+	NodeID       string            `json:"node_id,omitempty"`
+	DeploymentID string            `json:"deployment_id,omitempty"`
+	Tenant       string            `json:"tenant,omitempty"`
+	Message      string            `json:"message"`
+	Details      map[string]string `json:"details,omitempty"`
+	Time         time.Time         `json:"time"`
+}
+
+// Template 请求体格式
+type Template string
+
+const (
+	// TemplateGeneric 事件本身的 JSON
+	TemplateGeneric Template = "generic"
+	// TemplateSlack Slack（及兼容的 Mattermost、Rocket.Chat）incoming webhook
+	TemplateSlack Template = "slack"
+	// TemplateFeishu 飞书自定义机器人
+	TemplateFeishu Template = "feishu"
+	// TemplateDingTalk 钉钉自定义机器人
+	TemplateDingTalk Template = "dingtalk"
+	// TemplateCustom 使用 BodyTemplate（Go text/template）渲染
+	TemplateCustom Template = "custom"
+)
+
+// SinkSpec webhook 目标配置
+type SinkSpec struct {
+	Name string
+	URL  string
+	// Secret 非空时以 HMAC-SHA256 签名请求
+	Secret string
+	// Template 请求体格式，为空时使用 generic
+	Template Template
+	// BodyTemplate Template 为 custom 时使用的模板，渲染结果需为合法的 JSON
+	BodyTemplate string
+	// Headers 额外的请求头
+	Headers map[string]string
+
+	// Events 订阅的事件类型，支持 "node.*" 形式的前缀匹配与 "*"；为空时订阅全部事件
+	Events []string
+	// Domains / Tenants 只投递涉及这些域或租户的事件，为空时不过滤
+	Domains []string
+	Tenants []string
+	// MinCount 大于 1 时，同一类型、同一域的事件在 Window 内累计达到 MinCount 次才投递一次
+	MinCount int
+	Window   time.Duration
+}
+
+// matches 事件是否满足订阅条件（不含次数阈值）
+func (spec *SinkSpec) matches(event *Event) bool {
+	if len(spec.Events) > 0 && !matchAny(spec.Events, event.Type) {
+		return false
+	}
+	if len(spec.Domains) > 0 && !contains(spec.Domains, event.DomainID) {
+		return false
+	}
+	if len(spec.Tenants) > 0 && !contains(spec.Tenants, event.Tenant) {
+		return false
+	}
+	return true
+}
+
+func matchAny(patterns []string, eventType string) bool {
+	for _, pattern := range patterns {
+		switch {
+		case pattern == "*" || pattern == eventType:
+			return true
+		case strings.HasSuffix(pattern, ".*") && strings.HasPrefix(eventType, strings.TrimSuffix(pattern, "*")):
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// SinkStatus webhook 目标及其投递统计
+type SinkStatus struct {
+	Name     string   `json:"name"`
+	URL      string   `json:"url"`
+	Template Template `json:"template"`
+	Signed   bool     `json:"signed"`
+	Events   []string `json:"events,omitempty"`
+	Domains  []string `json:"domains,omitempty"`
+	Tenants  []string `json:"tenants,omitempty"`
+	MinCount int      `json:"min_count,omitempty"`
+	Window   string   `json:"window,omitempty"`
+	// Deliveries 按状态统计的投递数量
+	Deliveries map[DeliveryStatus]int `json:"deliveries"`
+}
+
+// DeliveryStatus 投递状态
+type DeliveryStatus string
+
+const (
+	// DeliveryPending 等待首次投递
+	DeliveryPending DeliveryStatus = "pending"
+	// DeliveryRetrying 投递失败，等待重试
+	DeliveryRetrying DeliveryStatus = "retrying"
+	// DeliveryDelivered 投递成功
+	DeliveryDelivered DeliveryStatus = "delivered"
+	// DeliveryDead 超过最大重试次数，进入死信
+	DeliveryDead DeliveryStatus = "dead"
+)
+
+// ParseDeliveryStatus 解析投递状态，空字符串表示不过滤
+func ParseDeliveryStatus(s string) (DeliveryStatus, bool) {
+	switch status := DeliveryStatus(s); status {
+	case "", DeliveryPending, DeliveryRetrying, DeliveryDelivered, DeliveryDead:
+		return status, true
+	default:
+		return "", false
+	}
+}
+
+// Delivery 投递记录
+type Delivery struct {
+	ID             string         `json:"id"`
+	Sink           string         `json:"sink"`
+	EventID        string         `json:"event_id"`
+	EventType      string         `json:"event_type"`
+	Payload        string         `json:"payload"`
+	Status         DeliveryStatus `json:"status"`
+	Attempts       int            `json:"attempts"`
+	LastStatusCode int            `json:"last_status_code,omitempty"`
+	LastError      string         `json:"last_error,omitempty"`
+	NextAttemptAt  time.Time      `json:"next_attempt_at"`
+	DeliveredAt    time.Time      `json:"delivered_at"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+// DeliveryFilter 投递记录过滤条件，空字段表示不过滤
+type DeliveryFilter struct {
+	Sink      string
+	Status    DeliveryStatus
+	EventType string
+	// Limit 最多返回的记录数，0 表示使用默认值
+	Limit int
+}
@@ -0,0 +1,35 @@
+package fakenode
+
+import "time"
+
+// Faults 注入的故障，可在运行中通过 Node.SetFaults 修改
+type Faults struct {
+	// Slow DeployComponent 响应前的延迟
+	Slow time.Duration
+	// RejectRate 拒绝部署请求的概率（0-1），1 表示全部拒绝
+	RejectRate float64
+	// RejectMessage 拒绝部署时返回的错误信息
+	RejectMessage string
+	// CrashAfterDeploys 成功部署指定数量的 component 后崩溃（停止 RPC 服务与心跳），0 表示不崩溃
+	CrashAfterDeploys int
+	// FlapPeriod 非 0 时心跳以该周期交替暂停与恢复，模拟网络抖动
+	FlapPeriod time.Duration
+}
+
+// DefaultRejectMessage 未设置 RejectMessage 时拒绝部署返回的错误信息
+const DefaultRejectMessage = "deployment rejected by fault injection"
+
+func (f Faults) rejectMessage() string {
+	if f.RejectMessage == "" {
+		return DefaultRejectMessage
+	}
+	return f.RejectMessage
+}
+
+// flapping 在 FlapPeriod 周期中处于暂停心跳的半段
+func (f Faults) flapping(since time.Duration) bool {
+	if f.FlapPeriod <= 0 {
+		return false
+	}
+	return (since/f.FlapPeriod)%2 == 1
+}
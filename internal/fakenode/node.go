@@ -0,0 +1,524 @@
+package fakenode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	registrypb "github.com/9triver/iarnet-global/internal/proto/registry"
+	resourcepb "github.com/9triver/iarnet-global/internal/proto/resource"
+	schedulerpb "github.com/9triver/iarnet-global/internal/proto/scheduler"
+	"github.com/9triver/iarnet-global/internal/util"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+const (
+	// DefaultHeartbeatInterval 收到全局注册中心的建议间隔前使用的心跳间隔
+	DefaultHeartbeatInterval = 5 * time.Second
+	// crashDelay 崩溃前等待当前部署响应发出的时间
+	crashDelay = 100 * time.Millisecond
+)
+
+// ComponentStatus 模拟 component 的状态
+type ComponentStatus string
+
+const (
+	ComponentRunning ComponentStatus = "running"
+	ComponentStopped ComponentStatus = "stopped"
+)
+
+// Component 模拟节点上的 component
+type Component struct {
+	ID         string
+	Resources  *registry.ResourceInfo
+	Status     ComponentStatus
+	DeployedAt time.Time
+}
+
+// Options 模拟节点配置
+type Options struct {
+	// RegistryAddr 全局注册中心地址（必需），HA 模式下可用逗号分隔多个副本地址
+	RegistryAddr string
+	// DomainID 注册到的域（必需）
+	DomainID string
+	// NodeID 为空时自动生成
+	NodeID string
+	// NodeName 为空时使用 NodeID
+	NodeName string
+	// JoinToken 域的加入令牌（注册中心要求令牌时必需）
+	JoinToken string
+	// ListenAddr SchedulerService 监听地址，默认 127.0.0.1:0
+	ListenAddr string
+	// AdvertiseAddr 上报给全局注册中心的地址，默认为实际监听地址
+	AdvertiseAddr string
+	// Capacity 节点总资源（必需）
+	Capacity *registry.ResourceInfo
+	// Labels 节点键值标签
+	Labels map[string]string
+	// Tags 资源标签（cpu/gpu/memory/camera），为空时按 Capacity 推导
+	Tags []string
+	// IsHead 是否作为 head 节点上报
+	IsHead bool
+	// HeartbeatInterval 初始心跳间隔，之后使用全局注册中心建议的间隔
+	HeartbeatInterval time.Duration
+	// Faults 初始注入的故障
+	Faults Faults
+}
+
+// Node 模拟的 iarnet 节点：注册到全局注册中心、按建议间隔上报心跳，并以消耗容量的方式处理部署请求
+type Node struct {
+	schedulerpb.UnimplementedSchedulerServiceServer
+
+	opts     Options
+	address  string
+	server   *grpc.Server
+	listener net.Listener
+	registry *registryClient
+
+	mu         sync.Mutex
+	available  *registry.ResourceInfo
+	components map[string]*Component
+	deploys    int
+	faults     Faults
+	interval   time.Duration
+	startedAt  time.Time
+	rand       *rand.Rand
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// New 创建模拟节点
+func New(opts Options) (*Node, error) {
+	if len(parseRegistryAddrs(opts.RegistryAddr)) == 0 {
+		return nil, errors.New("registry address is required")
+	}
+	if opts.DomainID == "" {
+		return nil, errors.New("domain id is required")
+	}
+	if opts.Capacity == nil {
+		return nil, errors.New("capacity is required")
+	}
+	if opts.NodeID == "" {
+		opts.NodeID = util.GenIDWith("fakenode.")
+	}
+	if opts.NodeName == "" {
+		opts.NodeName = opts.NodeID
+	}
+	if opts.ListenAddr == "" {
+		opts.ListenAddr = "127.0.0.1:0"
+	}
+	if opts.HeartbeatInterval <= 0 {
+		opts.HeartbeatInterval = DefaultHeartbeatInterval
+	}
+
+	return &Node{
+		opts:       opts,
+		available:  opts.Capacity.Clone(),
+		components: make(map[string]*Component),
+		faults:     opts.Faults,
+		interval:   opts.HeartbeatInterval,
+		rand:       rand.New(rand.NewSource(time.Now().UnixNano())),
+		stopCh:     make(chan struct{}),
+	}, nil
+}
+
+// Start 启动 SchedulerService、注册节点并开始上报心跳
+func (n *Node) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", n.opts.ListenAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", n.opts.ListenAddr, err)
+	}
+	n.listener = listener
+	n.address = n.opts.AdvertiseAddr
+	if n.address == "" {
+		n.address = listener.Addr().String()
+	}
+
+	n.server = grpc.NewServer()
+	schedulerpb.RegisterSchedulerServiceServer(n.server, n)
+	go func() {
+		if err := n.server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			logrus.WithError(err).Errorf("Fake node %s stopped serving", n.opts.NodeID)
+		}
+	}()
+
+	client, err := newRegistryClient(parseRegistryAddrs(n.opts.RegistryAddr))
+	if err != nil {
+		n.server.Stop()
+		return fmt.Errorf("failed to dial registry %s: %w", n.opts.RegistryAddr, err)
+	}
+	n.registry = client
+
+	if err := n.register(ctx); err != nil {
+		n.server.Stop()
+		client.Close()
+		return err
+	}
+	// 首次心跳上报地址与容量，之后节点才可被调度
+	if err := n.heartbeat(ctx, registrypb.NodeStatus_NODE_STATUS_ONLINE); err != nil {
+		n.server.Stop()
+		client.Close()
+		return err
+	}
+
+	n.mu.Lock()
+	n.startedAt = time.Now()
+	n.mu.Unlock()
+
+	n.wg.Add(1)
+	go n.heartbeatLoop()
+
+	logrus.Infof("Fake node %s started on %s (domain=%s)", n.opts.NodeID, n.address, n.opts.DomainID)
+	return nil
+}
+
+// Stop 上报离线后停止节点
+func (n *Node) Stop() {
+	n.shutdown(true)
+}
+
+// Crash 立即停止 RPC 服务与心跳，不通知全局注册中心
+func (n *Node) Crash() {
+	n.shutdown(false)
+}
+
+func (n *Node) shutdown(graceful bool) {
+	n.stopOnce.Do(func() {
+		close(n.stopCh)
+		n.wg.Wait()
+
+		if graceful && n.registry != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			if err := n.heartbeat(ctx, registrypb.NodeStatus_NODE_STATUS_OFFLINE); err != nil {
+				logrus.Warnf("Fake node %s failed to report offline: %v", n.opts.NodeID, err)
+			}
+			cancel()
+		}
+
+		if n.server != nil {
+			if graceful {
+				n.server.GracefulStop()
+			} else {
+				n.server.Stop()
+			}
+		}
+		if n.registry != nil {
+			n.registry.Close()
+		}
+		logrus.Infof("Fake node %s stopped (graceful=%v)", n.opts.NodeID, graceful)
+	})
+}
+
+// ID 节点 ID
+func (n *Node) ID() string {
+	return n.opts.NodeID
+}
+
+// Address SchedulerService 地址
+func (n *Node) Address() string {
+	return n.address
+}
+
+// SetFaults 替换注入的故障
+func (n *Node) SetFaults(faults Faults) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.faults = faults
+}
+
+// Available 当前可用资源
+func (n *Node) Available() *registry.ResourceInfo {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.available.Clone()
+}
+
+// Components 按部署时间返回节点上的 component
+func (n *Node) Components() []Component {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	result := make([]Component, 0, len(n.components))
+	for _, c := range n.components {
+		copy := *c
+		copy.Resources = c.Resources.Clone()
+		result = append(result, copy)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].DeployedAt.Before(result[j].DeployedAt)
+	})
+	return result
+}
+
+func (n *Node) register(ctx context.Context) error {
+	req := &registrypb.RegisterNodeRequest{
+		DomainId:  n.opts.DomainID,
+		NodeId:    n.opts.NodeID,
+		NodeName:  n.opts.NodeName,
+		JoinToken: n.opts.JoinToken,
+	}
+	err := n.registry.call(ctx, func(client registrypb.ServiceClient, opts ...grpc.CallOption) error {
+		_, err := client.RegisterNode(ctx, req, opts...)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register node %s: %w", n.opts.NodeID, err)
+	}
+	return nil
+}
+
+// heartbeatLoop 按全局注册中心建议的间隔上报心跳，处于抖动暂停期间跳过上报
+func (n *Node) heartbeatLoop() {
+	defer n.wg.Done()
+
+	timer := time.NewTimer(n.currentInterval())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-n.stopCh:
+			return
+		case <-timer.C:
+		}
+
+		n.mu.Lock()
+		flapping := n.faults.flapping(time.Since(n.startedAt))
+		n.mu.Unlock()
+
+		if !flapping {
+			ctx, cancel := context.WithTimeout(context.Background(), n.currentInterval())
+			if err := n.heartbeat(ctx, registrypb.NodeStatus_NODE_STATUS_ONLINE); err != nil {
+				logrus.Warnf("Fake node %s heartbeat failed: %v", n.opts.NodeID, err)
+			}
+			cancel()
+		}
+		timer.Reset(n.currentInterval())
+	}
+}
+
+func (n *Node) currentInterval() time.Duration {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.interval
+}
+
+func (n *Node) heartbeat(ctx context.Context, status registrypb.NodeStatus) error {
+	n.mu.Lock()
+	used := n.opts.Capacity.Clone()
+	used.Sub(n.available)
+	req := &registrypb.HealthCheckRequest{
+		NodeId:   n.opts.NodeID,
+		DomainId: n.opts.DomainID,
+		Status:   status,
+		ResourceCapacity: &registrypb.ResourceCapacity{
+			Total:     toProtoResourceInfo(n.opts.Capacity),
+			Used:      toProtoResourceInfo(used),
+			Available: toProtoResourceInfo(n.available),
+		},
+		ResourceTags: n.resourceTags(),
+		Address:      n.address,
+		Timestamp:    time.Now().UnixNano(),
+		IsHead:       n.opts.IsHead,
+		Labels:       n.opts.Labels,
+	}
+	n.mu.Unlock()
+
+	var resp *registrypb.HealthCheckResponse
+	err := n.registry.call(ctx, func(client registrypb.ServiceClient, opts ...grpc.CallOption) error {
+		var err error
+		resp, err = client.HealthCheck(ctx, req, opts...)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if resp.RequireReregister {
+		if err := n.register(ctx); err != nil {
+			return err
+		}
+	}
+	if resp.RecommendedIntervalSeconds > 0 {
+		n.mu.Lock()
+		n.interval = time.Duration(resp.RecommendedIntervalSeconds) * time.Second
+		n.mu.Unlock()
+	}
+	return nil
+}
+
+// resourceTags 上报的资源标签（调用者需持有 mu）
+func (n *Node) resourceTags() *registrypb.ResourceTags {
+	if len(n.opts.Tags) == 0 {
+		return &registrypb.ResourceTags{
+			Cpu:    n.opts.Capacity.CPU > 0,
+			Gpu:    n.opts.Capacity.GPU > 0,
+			Memory: n.opts.Capacity.Memory > 0,
+			Camera: n.opts.Capacity.Get("camera") > 0,
+		}
+	}
+	tags := &registrypb.ResourceTags{}
+	for _, tag := range n.opts.Tags {
+		switch tag {
+		case "cpu":
+			tags.Cpu = true
+		case "gpu":
+			tags.Gpu = true
+		case "memory":
+			tags.Memory = true
+		case "camera":
+			tags.Camera = true
+		}
+	}
+	return tags
+}
+
+// DeployComponent 按注入的故障处理部署请求，成功时消耗节点容量
+func (n *Node) DeployComponent(ctx context.Context, req *schedulerpb.DeployComponentRequest) (*schedulerpb.DeployComponentResponse, error) {
+	n.mu.Lock()
+	faults := n.faults
+	n.mu.Unlock()
+
+	if faults.Slow > 0 {
+		select {
+		case <-time.After(faults.Slow):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if faults.RejectRate > 0 && n.rand.Float64() < faults.RejectRate {
+		return &schedulerpb.DeployComponentResponse{Success: false, Error: faults.rejectMessage()}, nil
+	}
+
+	requested := requestedResources(req.ResourceRequest)
+	if fits, name := n.available.Fits(requested); !fits {
+		return &schedulerpb.DeployComponentResponse{
+			Success: false,
+			Error:   fmt.Sprintf("insufficient %s on node %s", name, n.opts.NodeID),
+		}, nil
+	}
+
+	n.available.Sub(requested)
+	component := &Component{
+		ID:         util.GenIDWith("comp."),
+		Resources:  requested,
+		Status:     ComponentRunning,
+		DeployedAt: time.Now(),
+	}
+	n.components[component.ID] = component
+	n.deploys++
+
+	if faults.CrashAfterDeploys > 0 && n.deploys >= faults.CrashAfterDeploys {
+		logrus.Warnf("Fake node %s crashing after %d deployment(s)", n.opts.NodeID, n.deploys)
+		time.AfterFunc(crashDelay, n.Crash)
+	}
+
+	return &schedulerpb.DeployComponentResponse{
+		Success: true,
+		Component: &schedulerpb.ComponentInfo{
+			ComponentId:   component.ID,
+			ResourceUsage: req.ResourceRequest,
+			ProviderId:    n.opts.NodeID,
+		},
+		NodeId:     n.opts.NodeID,
+		NodeName:   n.opts.NodeName,
+		ProviderId: n.opts.NodeID,
+		Status:     schedulerpb.ComponentStatus_COMPONENT_STATUS_RUNNING,
+	}, nil
+}
+
+// StopComponent 停止 component 并释放其资源
+func (n *Node) StopComponent(_ context.Context, req *schedulerpb.StopComponentRequest) (*schedulerpb.StopComponentResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	component, ok := n.components[req.ComponentId]
+	if !ok {
+		return &schedulerpb.StopComponentResponse{Success: false, Error: fmt.Sprintf("component %s not found", req.ComponentId)}, nil
+	}
+	if component.Status == ComponentRunning {
+		n.available.Add(component.Resources)
+		component.Status = ComponentStopped
+	}
+	return &schedulerpb.StopComponentResponse{Success: true}, nil
+}
+
+// RemoveComponent 移除 component，运行中的 component 先释放资源
+func (n *Node) RemoveComponent(_ context.Context, req *schedulerpb.RemoveComponentRequest) (*schedulerpb.RemoveComponentResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	component, ok := n.components[req.ComponentId]
+	if !ok {
+		return &schedulerpb.RemoveComponentResponse{Success: false, Error: fmt.Sprintf("component %s not found", req.ComponentId)}, nil
+	}
+	if component.Status == ComponentRunning {
+		n.available.Add(component.Resources)
+	}
+	delete(n.components, req.ComponentId)
+	return &schedulerpb.RemoveComponentResponse{Success: true}, nil
+}
+
+// GetDeploymentStatus 查询 component 状态
+func (n *Node) GetDeploymentStatus(_ context.Context, req *schedulerpb.GetDeploymentStatusRequest) (*schedulerpb.GetDeploymentStatusResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	component, ok := n.components[req.ComponentId]
+	if !ok {
+		return &schedulerpb.GetDeploymentStatusResponse{Success: false, Error: fmt.Sprintf("component %s not found", req.ComponentId)}, nil
+	}
+	status := schedulerpb.ComponentStatus_COMPONENT_STATUS_RUNNING
+	if component.Status == ComponentStopped {
+		status = schedulerpb.ComponentStatus_COMPONENT_STATUS_STOPPED
+	}
+	return &schedulerpb.GetDeploymentStatusResponse{
+		Success: true,
+		Status:  status,
+		Component: &schedulerpb.ComponentInfo{
+			ComponentId: component.ID,
+			ProviderId:  n.opts.NodeID,
+		},
+		NodeId: n.opts.NodeID,
+	}, nil
+}
+
+func requestedResources(info *resourcepb.Info) *registry.ResourceInfo {
+	requested := &registry.ResourceInfo{
+		CPU:    info.GetCpu(),
+		Memory: info.GetMemory(),
+		GPU:    info.GetGpu(),
+	}
+	for name, value := range info.GetExtended() {
+		if requested.Extended == nil {
+			requested.Extended = make(map[string]int64)
+		}
+		requested.Extended[name] = value
+	}
+	return requested
+}
+
+func toProtoResourceInfo(info *registry.ResourceInfo) *registrypb.ResourceInfo {
+	result := &registrypb.ResourceInfo{
+		Cpu:    info.CPU,
+		Memory: info.Memory,
+		Gpu:    info.GPU,
+	}
+	if len(info.Extended) > 0 {
+		result.Extended = make(map[string]int64, len(info.Extended))
+		for name, value := range info.Extended {
+			result.Extended[name] = value
+		}
+	}
+	return result
+}
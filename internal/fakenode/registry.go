@@ -0,0 +1,116 @@
+package fakenode
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/9triver/iarnet-global/internal/ha"
+	registrypb "github.com/9triver/iarnet-global/internal/proto/registry"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// registryClient 全局注册中心客户端
+// HA 模式下 follower 返回 Unavailable 并在 trailer 中携带 leader 地址，客户端据此切换；
+// 没有 leader 提示（副本不可达或尚未选出 leader）时依次尝试其他已知地址
+type registryClient struct {
+	mu      sync.Mutex
+	addrs   []string
+	current string
+	conn    *grpc.ClientConn
+	client  registrypb.ServiceClient
+}
+
+// parseRegistryAddrs 解析逗号分隔的注册中心地址
+func parseRegistryAddrs(s string) []string {
+	addrs := make([]string, 0)
+	for _, addr := range strings.Split(s, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+func newRegistryClient(addrs []string) (*registryClient, error) {
+	c := &registryClient{addrs: addrs}
+	if err := c.dial(addrs[0]); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// dial 连接到指定地址（调用者需持有 mu 或保证无并发）
+func (c *registryClient) dial(addr string) error {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	if c.conn != nil {
+		c.conn.Close()
+	}
+	c.conn = conn
+	c.client = registrypb.NewServiceClient(conn)
+	c.current = addr
+	return nil
+}
+
+// call 调用注册中心，遇到非 leader 或不可达时切换地址后重试，每个已知地址最多尝试一次
+func (c *registryClient) call(ctx context.Context, fn func(client registrypb.ServiceClient, opts ...grpc.CallOption) error) error {
+	var err error
+	for attempt := 0; attempt <= len(c.addrs); attempt++ {
+		c.mu.Lock()
+		client := c.client
+		c.mu.Unlock()
+
+		trailer := metadata.MD{}
+		err = fn(client, grpc.Trailer(&trailer))
+		if status.Code(err) != codes.Unavailable || ctx.Err() != nil {
+			return err
+		}
+
+		leader := ""
+		if values := trailer.Get(ha.LeaderMetadataKey); len(values) > 0 {
+			leader = values[0]
+		}
+		if switchErr := c.switchTo(leader); switchErr != nil {
+			return switchErr
+		}
+	}
+	return err
+}
+
+// switchTo 切换到 leader，leader 未知时切换到下一个已知地址
+func (c *registryClient) switchTo(leader string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	next := leader
+	if next == "" || next == c.current {
+		next = c.addrs[0]
+		for i, addr := range c.addrs {
+			if addr == c.current {
+				next = c.addrs[(i+1)%len(c.addrs)]
+				break
+			}
+		}
+	}
+	if next == c.current {
+		return nil
+	}
+	logrus.Infof("Switching registry from %s to %s", c.current, next)
+	return c.dial(next)
+}
+
+func (c *registryClient) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn != nil {
+		c.conn.Close()
+	}
+}
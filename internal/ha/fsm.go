@@ -0,0 +1,223 @@
+package ha
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hashicorp/raft"
+	"github.com/sirupsen/logrus"
+)
+
+// command 一条复制日志：各状态中发生变化的条目
+type command struct {
+	// Instance 提交日志的副本进程实例，leader 本地已包含这些变化，应用时跳过
+	Instance string `json:"instance"`
+	// Full 为 true 时 Changes 为完整状态，follower 以其替换本地状态
+	Full    bool                         `json:"full,omitempty"`
+	Changes map[string]map[string][]byte `json:"changes"`
+}
+
+// storeSet 按注册顺序保存的状态集合
+type storeSet struct {
+	mu     sync.RWMutex
+	names  []string
+	stores map[string]StateStore
+}
+
+func (s *storeSet) add(name string, store StateStore) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stores == nil {
+		s.stores = make(map[string]StateStore)
+	}
+	if _, ok := s.stores[name]; !ok {
+		s.names = append(s.names, name)
+	}
+	s.stores[name] = store
+}
+
+// each 按注册顺序遍历状态
+func (s *storeSet) each(fn func(name string, store StateStore) error) error {
+	s.mu.RLock()
+	names := append([]string{}, s.names...)
+	stores := make(map[string]StateStore, len(s.stores))
+	for name, store := range s.stores {
+		stores[name] = store
+	}
+	s.mu.RUnlock()
+
+	for _, name := range names {
+		if err := fn(name, stores[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// export 导出全部状态
+func (s *storeSet) export(ctx context.Context) (map[string]map[string][]byte, error) {
+	state := make(map[string]map[string][]byte)
+	err := s.each(func(name string, store StateStore) error {
+		exported, err := store.ExportState(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to export %s state: %w", name, err)
+		}
+		state[name] = exported
+		return nil
+	})
+	return state, err
+}
+
+// restore 以完整状态替换全部状态
+func (s *storeSet) restore(ctx context.Context, state map[string]map[string][]byte) error {
+	return s.each(func(name string, store StateStore) error {
+		if err := store.RestoreState(ctx, state[name]); err != nil {
+			return fmt.Errorf("failed to restore %s state: %w", name, err)
+		}
+		return nil
+	})
+}
+
+// fsm 将复制日志应用到本地状态
+// 同时维护已应用日志对应的完整状态，快照只包含已提交的变化，不包含 leader 本地尚未复制的变化
+type fsm struct {
+	instance string
+	stores   *storeSet
+
+	mu    sync.Mutex
+	state map[string]map[string][]byte
+	// applied 最后一条已应用的复制日志索引
+	applied atomic.Uint64
+}
+
+func newFSM(instance string, stores *storeSet) *fsm {
+	return &fsm{instance: instance, stores: stores, state: make(map[string]map[string][]byte)}
+}
+
+// appliedIndex 最后一条已应用到本地状态的复制日志索引
+func (f *fsm) appliedIndex() uint64 {
+	return f.applied.Load()
+}
+
+func (f *fsm) Apply(log *raft.Log) any {
+	if log.Type != raft.LogCommand {
+		return nil
+	}
+	defer f.applied.Store(log.Index)
+
+	cmd := command{}
+	if err := json.Unmarshal(log.Data, &cmd); err != nil {
+		logrus.Errorf("Failed to decode replicated command at index %d: %v", log.Index, err)
+		return err
+	}
+	f.record(&cmd)
+	if cmd.Instance == f.instance {
+		return nil
+	}
+
+	ctx := context.Background()
+	if cmd.Full {
+		if err := f.stores.restore(ctx, cmd.Changes); err != nil {
+			logrus.Errorf("Failed to apply replicated state at index %d: %v", log.Index, err)
+			return err
+		}
+		return nil
+	}
+
+	err := f.stores.each(func(name string, store StateStore) error {
+		changes, ok := cmd.Changes[name]
+		if !ok {
+			return nil
+		}
+		if err := store.ApplyState(ctx, changes); err != nil {
+			return fmt.Errorf("failed to apply %s state: %w", name, err)
+		}
+		return nil
+	})
+	if err != nil {
+		logrus.Errorf("Failed to apply replicated changes at index %d: %v", log.Index, err)
+		return err
+	}
+	return nil
+}
+
+// record 将日志中的变化合并到已应用状态
+func (f *fsm) record(cmd *command) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if cmd.Full {
+		f.state = make(map[string]map[string][]byte, len(cmd.Changes))
+	}
+	for name, changes := range cmd.Changes {
+		entries := f.state[name]
+		if entries == nil {
+			entries = make(map[string][]byte, len(changes))
+			f.state[name] = entries
+		}
+		for key, value := range changes {
+			if len(value) == 0 {
+				delete(entries, key)
+			} else {
+				entries[key] = value
+			}
+		}
+	}
+}
+
+func (f *fsm) Snapshot() (raft.FSMSnapshot, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	state := make(map[string]map[string][]byte, len(f.state))
+	for name, entries := range f.state {
+		copied := make(map[string][]byte, len(entries))
+		for key, value := range entries {
+			copied[key] = value
+		}
+		state[name] = copied
+	}
+	return &snapshot{Index: f.applied.Load(), State: state}, nil
+}
+
+func (f *fsm) Restore(reader io.ReadCloser) error {
+	defer reader.Close()
+
+	snap := snapshot{}
+	if err := json.NewDecoder(reader).Decode(&snap); err != nil {
+		return fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+	if snap.State == nil {
+		snap.State = make(map[string]map[string][]byte)
+	}
+	if err := f.stores.restore(context.Background(), snap.State); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	f.state = snap.State
+	f.mu.Unlock()
+	f.applied.Store(snap.Index)
+	logrus.Info("Replicated state restored from snapshot")
+	return nil
+}
+
+// snapshot 已应用状态的快照
+type snapshot struct {
+	// Index 快照包含的最后一条复制日志索引
+	Index uint64                       `json:"index"`
+	State map[string]map[string][]byte `json:"state"`
+}
+
+func (s *snapshot) Persist(sink raft.SnapshotSink) error {
+	if err := json.NewEncoder(sink).Encode(s); err != nil {
+		sink.Cancel()
+		return fmt.Errorf("failed to persist snapshot: %w", err)
+	}
+	return sink.Close()
+}
+
+func (s *snapshot) Release() {}
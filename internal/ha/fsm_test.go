@@ -0,0 +1,40 @@
+package ha
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/raft"
+)
+
+// TestFSMSnapshotContainsOnlyAppliedState leader 本地尚未复制的变化不进入快照
+func TestFSMSnapshotContainsOnlyAppliedState(t *testing.T) {
+	stores := &storeSet{}
+	store := newMapStore()
+	stores.add("kv", store)
+	f := newFSM("replica.leader", stores)
+
+	store.set("node/n1", "online")
+	data, _ := json.Marshal(&command{
+		Instance: "replica.leader",
+		Changes:  map[string]map[string][]byte{"kv": {"node/n1": []byte("online")}},
+	})
+	f.Apply(&raft.Log{Index: 7, Type: raft.LogCommand, Data: data})
+	// 已修改但尚未提交的变化
+	store.set("node/n2", "online")
+
+	if got := f.appliedIndex(); got != 7 {
+		t.Fatalf("applied index = %d, want 7", got)
+	}
+	snap, err := f.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := snap.(*snapshot).State["kv"]
+	if string(state["node/n1"]) != "online" {
+		t.Errorf("snapshot is missing applied entry node/n1: %v", state)
+	}
+	if _, ok := state["node/n2"]; ok {
+		t.Errorf("snapshot contains unreplicated entry node/n2")
+	}
+}
@@ -0,0 +1,181 @@
+package ha
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/raft"
+)
+
+// LocalCluster 进程内的多副本集群，副本之间通过内存传输层通信，日志与快照保存在内存中
+// 用于在单个进程中验证复制、选主与故障切换
+type LocalCluster struct {
+	Replicas   []*Replica
+	transports map[string]*raft.InmemTransport
+	isolated   map[string]bool
+}
+
+// NewLocalCluster 创建进程内集群，register 为每个副本注册需要复制的状态
+// members 的 RaftAddr 由内存传输层分配，HTTPAddr 与 RPCAddr 原样保留，用于验证请求转发
+func NewLocalCluster(members []Peer, register func(r *Replica) error) (*LocalCluster, error) {
+	peers := make([]Peer, 0, len(members))
+	transports := make(map[string]*raft.InmemTransport, len(members))
+	for _, member := range members {
+		addr, trans := raft.NewInmemTransport(raft.ServerAddress(member.ID))
+		transports[member.ID] = trans
+		member.RaftAddr = string(addr)
+		peers = append(peers, member)
+	}
+	for _, a := range transports {
+		for _, b := range transports {
+			if a != b {
+				a.Connect(b.LocalAddr(), b)
+			}
+		}
+	}
+
+	conf := raft.DefaultConfig()
+	conf.HeartbeatTimeout = 500 * time.Millisecond
+	conf.ElectionTimeout = 500 * time.Millisecond
+	conf.LeaderLeaseTimeout = 250 * time.Millisecond
+	conf.CommitTimeout = 10 * time.Millisecond
+
+	cluster := &LocalCluster{transports: transports, isolated: make(map[string]bool)}
+	for _, peer := range peers {
+		replica, err := NewReplica(Options{
+			ID:           peer.ID,
+			Peers:        peers,
+			Bootstrap:    true,
+			Transport:    transports[peer.ID],
+			SyncInterval: 50 * time.Millisecond,
+			Raft:         conf,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if register != nil {
+			if err := register(replica); err != nil {
+				return nil, err
+			}
+		}
+		cluster.Replicas = append(cluster.Replicas, replica)
+	}
+	return cluster, nil
+}
+
+// Start 启动全部副本
+func (c *LocalCluster) Start(ctx context.Context) error {
+	for _, replica := range c.Replicas {
+		if err := replica.Start(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stop 停止全部副本
+func (c *LocalCluster) Stop() {
+	for _, replica := range c.Replicas {
+		replica.Stop()
+	}
+}
+
+// Replica 按 ID 查找副本
+func (c *LocalCluster) Replica(id string) *Replica {
+	for _, replica := range c.Replicas {
+		if replica.ID() == id {
+			return replica
+		}
+	}
+	return nil
+}
+
+// WaitLeader 等待除 excluded 外的某个副本成为 leader
+func (c *LocalCluster) WaitLeader(timeout time.Duration, excluded ...string) (*Replica, error) {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		for _, replica := range c.Replicas {
+			if replica.IsLeader() && c.reachable(replica) && !contains(excluded, replica.ID()) {
+				return replica, nil
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return nil, fmt.Errorf("no leader elected within %s", timeout)
+}
+
+// WaitApplied 等待全部可达副本的状态机应用 leader 当前已提交的全部变化
+func (c *LocalCluster) WaitApplied(timeout time.Duration) error {
+	leader, err := c.WaitLeader(timeout)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := leader.Sync(ctx); err != nil {
+		return err
+	}
+	// 提交一条不含变化的日志，各副本状态机应用到该日志即已应用此前全部变化
+	// raft 的 AppliedIndex 在状态机应用完成前就会推进，不能用于判断
+	index, err := leader.propose(&command{Instance: leader.instance})
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		caughtUp := true
+		for _, replica := range c.Replicas {
+			if !c.reachable(replica) {
+				continue
+			}
+			if replica.fsm.appliedIndex() < index {
+				caughtUp = false
+			}
+		}
+		if caughtUp {
+			return nil
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return fmt.Errorf("replicas did not apply index %d within %s", index, timeout)
+}
+
+// Isolate 断开副本与其他副本的连接，模拟网络分区
+func (c *LocalCluster) Isolate(id string) {
+	c.isolated[id] = true
+	trans := c.transports[id]
+	trans.DisconnectAll()
+	for other, t := range c.transports {
+		if other != id {
+			t.Disconnect(trans.LocalAddr())
+		}
+	}
+}
+
+// Rejoin 恢复副本与其他副本的连接
+func (c *LocalCluster) Rejoin(id string) {
+	delete(c.isolated, id)
+	trans := c.transports[id]
+	for other, t := range c.transports {
+		if other != id {
+			trans.Connect(t.LocalAddr(), t)
+			t.Connect(trans.LocalAddr(), trans)
+		}
+	}
+}
+
+// reachable 副本仍在运行且未被隔离
+func (c *LocalCluster) reachable(replica *Replica) bool {
+	return !c.isolated[replica.ID()] && replica.raft.State() != raft.Shutdown
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
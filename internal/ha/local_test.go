@@ -0,0 +1,189 @@
+package ha
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testTimeout = 10 * time.Second
+
+// mapStore 以内存 map 保存的复制状态
+type mapStore struct {
+	mu    sync.Mutex
+	state map[string][]byte
+}
+
+func newMapStore() *mapStore {
+	return &mapStore{state: make(map[string][]byte)}
+}
+
+func (s *mapStore) ExportState(ctx context.Context) (map[string][]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state := make(map[string][]byte, len(s.state))
+	for key, value := range s.state {
+		state[key] = value
+	}
+	return state, nil
+}
+
+func (s *mapStore) ApplyState(ctx context.Context, changes map[string][]byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, value := range changes {
+		if len(value) == 0 {
+			delete(s.state, key)
+		} else {
+			s.state[key] = value
+		}
+	}
+	return nil
+}
+
+func (s *mapStore) RestoreState(ctx context.Context, state map[string][]byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = make(map[string][]byte, len(state))
+	for key, value := range state {
+		s.state[key] = value
+	}
+	return nil
+}
+
+func (s *mapStore) set(key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state[key] = []byte(value)
+}
+
+func (s *mapStore) remove(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.state, key)
+}
+
+func (s *mapStore) get(key string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, ok := s.state[key]
+	return string(value), ok
+}
+
+// startCluster 启动三副本进程内集群，每个副本注册一个 mapStore
+func startCluster(t *testing.T) (*LocalCluster, map[string]*mapStore) {
+	t.Helper()
+	stores := make(map[string]*mapStore)
+	cluster, err := NewLocalCluster([]Peer{{ID: "a"}, {ID: "b"}, {ID: "c"}}, func(r *Replica) error {
+		store := newMapStore()
+		stores[r.ID()] = store
+		r.Register("kv", store)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to create cluster: %v", err)
+	}
+	if err := cluster.Start(context.Background()); err != nil {
+		t.Fatalf("failed to start cluster: %v", err)
+	}
+	t.Cleanup(cluster.Stop)
+	return cluster, stores
+}
+
+func TestLocalClusterElectsLeader(t *testing.T) {
+	cluster, _ := startCluster(t)
+
+	leader, err := cluster.WaitLeader(testTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cluster.WaitApplied(testTimeout); err != nil {
+		t.Fatal(err)
+	}
+
+	leaders := 0
+	for _, replica := range cluster.Replicas {
+		if replica.IsLeader() {
+			leaders++
+		}
+		peer, ok := replica.Leader()
+		if !ok || peer.ID != leader.ID() {
+			t.Errorf("replica %s sees leader %q, want %s", replica.ID(), peer.ID, leader.ID())
+		}
+	}
+	if leaders != 1 {
+		t.Errorf("got %d leaders, want 1", leaders)
+	}
+}
+
+func TestLocalClusterReplicatesLeaderWrites(t *testing.T) {
+	cluster, stores := startCluster(t)
+
+	leader, err := cluster.WaitLeader(testTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stores[leader.ID()].set("domain/d1", "one")
+	stores[leader.ID()].set("domain/d2", "two")
+	if err := cluster.WaitApplied(testTimeout); err != nil {
+		t.Fatal(err)
+	}
+	for id, store := range stores {
+		if value, _ := store.get("domain/d1"); value != "one" {
+			t.Errorf("replica %s: domain/d1 = %q, want %q", id, value, "one")
+		}
+		if value, _ := store.get("domain/d2"); value != "two" {
+			t.Errorf("replica %s: domain/d2 = %q, want %q", id, value, "two")
+		}
+	}
+
+	// 删除同样复制到 follower
+	stores[leader.ID()].remove("domain/d1")
+	if err := cluster.WaitApplied(testTimeout); err != nil {
+		t.Fatal(err)
+	}
+	for id, store := range stores {
+		if _, ok := store.get("domain/d1"); ok {
+			t.Errorf("replica %s still has domain/d1 after it was removed on the leader", id)
+		}
+	}
+}
+
+func TestLocalClusterFailoverKeepsState(t *testing.T) {
+	cluster, stores := startCluster(t)
+
+	oldLeader, err := cluster.WaitLeader(testTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stores[oldLeader.ID()].set("node/n1", "online")
+	if err := cluster.WaitApplied(testTimeout); err != nil {
+		t.Fatal(err)
+	}
+
+	oldLeader.Stop()
+	newLeader, err := cluster.WaitLeader(testTimeout, oldLeader.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, _ := stores[newLeader.ID()].get("node/n1"); value != "online" {
+		t.Fatalf("new leader %s: node/n1 = %q, want %q", newLeader.ID(), value, "online")
+	}
+
+	// 新 leader 的写入继续复制到剩余的 follower
+	stores[newLeader.ID()].set("node/n2", "online")
+	if err := cluster.WaitApplied(testTimeout); err != nil {
+		t.Fatal(err)
+	}
+	for _, replica := range cluster.Replicas {
+		if replica.ID() == oldLeader.ID() {
+			continue
+		}
+		for _, key := range []string{"node/n1", "node/n2"} {
+			if value, _ := stores[replica.ID()].get(key); value != "online" {
+				t.Errorf("replica %s: %s = %q, want %q", replica.ID(), key, value, "online")
+			}
+		}
+	}
+}
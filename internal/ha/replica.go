@@ -0,0 +1,435 @@
+package ha
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/util"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/raft"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrNotLeader 本副本不是 leader
+var ErrNotLeader = errors.New("not leader")
+
+// LeaderMetadataKey follower 拒绝 gRPC 请求时在 trailer 中携带 leader 的 RPC 地址
+const LeaderMetadataKey = "x-iarnet-leader"
+
+const (
+	defaultSyncInterval = 500 * time.Millisecond
+	defaultApplyTimeout = 5 * time.Second
+	defaultSnapshotKeep = 2
+)
+
+// StateStore 需要在副本间复制的状态
+// 状态以条目键 -> 值的形式导出，值为空表示删除该条目
+type StateStore interface {
+	// ExportState 导出完整状态
+	ExportState(ctx context.Context) (map[string][]byte, error)
+	// ApplyState 应用增量变化
+	ApplyState(ctx context.Context, changes map[string][]byte) error
+	// RestoreState 以完整状态替换本地状态
+	RestoreState(ctx context.Context, state map[string][]byte) error
+}
+
+// Peer 集群中的一个副本
+type Peer struct {
+	ID       string `json:"id"`
+	RaftAddr string `json:"raft_addr"`
+	HTTPAddr string `json:"http_addr,omitempty"`
+	RPCAddr  string `json:"rpc_addr,omitempty"`
+}
+
+// LogStore Raft 日志与元数据存储
+type LogStore interface {
+	raft.LogStore
+	raft.StableStore
+}
+
+// Options 副本配置
+type Options struct {
+	// ID 本副本 ID，必须出现在 Peers 中
+	ID string
+	// BindAddr Raft 监听地址，为空时使用本副本在 Peers 中的 RaftAddr
+	BindAddr string
+	// Peers 集群全部副本（包括本副本），成员固定
+	Peers []Peer
+	// Bootstrap 为 true 且本地没有 Raft 状态时以 Peers 初始化集群
+	Bootstrap bool
+	// DataDir 快照目录，为空时快照保存在内存中
+	DataDir string
+	// Store Raft 日志存储，为空时保存在内存中
+	Store LogStore
+	// Transport Raft 传输层，为空时使用 TCP（进程内集群使用内存传输层）
+	Transport raft.Transport
+	// SyncInterval leader 复制状态变化的周期，每个周期导出并比较全部状态
+	SyncInterval time.Duration
+	// ApplyTimeout 提交一条复制日志的超时时间
+	ApplyTimeout time.Duration
+	// SnapshotThreshold 触发快照的日志条数，为 0 时使用 Raft 默认值
+	SnapshotThreshold uint64
+	// Raft 自定义 Raft 参数（超时时间等），为空时使用默认配置
+	Raft *raft.Config
+}
+
+// Replica HA 模式下的一个副本
+// leader 周期性地导出各 StateStore 的状态，将变化的条目通过 Raft 日志复制到 follower
+type Replica struct {
+	opts     Options
+	instance string
+
+	stores *storeSet
+	fsm    *fsm
+	raft   *raft.Raft
+	trans  raft.Transport
+	leader atomic.Bool
+
+	mu        sync.Mutex
+	observers []func(leader bool)
+	syncCh    chan chan error
+	stopCh    chan struct{}
+	stopOnce  sync.Once
+	wg        sync.WaitGroup
+}
+
+// NewReplica 创建副本，需在 Start 前通过 Register 注册需要复制的状态
+func NewReplica(opts Options) (*Replica, error) {
+	if opts.ID == "" {
+		return nil, fmt.Errorf("replica id is required")
+	}
+	if _, ok := findPeer(opts.Peers, opts.ID); !ok {
+		return nil, fmt.Errorf("replica %s is not in peers", opts.ID)
+	}
+	if opts.SyncInterval <= 0 {
+		opts.SyncInterval = defaultSyncInterval
+	}
+	if opts.ApplyTimeout <= 0 {
+		opts.ApplyTimeout = defaultApplyTimeout
+	}
+	return &Replica{
+		opts:     opts,
+		instance: util.GenIDWith("replica."),
+		stores:   &storeSet{},
+		syncCh:   make(chan chan error),
+		stopCh:   make(chan struct{}),
+	}, nil
+}
+
+// Register 注册需要复制的状态，条目按注册顺序应用
+func (r *Replica) Register(name string, store StateStore) {
+	r.stores.add(name, store)
+}
+
+// OnLeadershipChange 注册 leader 身份变化回调
+// 成为 leader 时回调在开始复制之前执行，失去 leader 身份时在停止复制之后执行
+func (r *Replica) OnLeadershipChange(fn func(leader bool)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.observers = append(r.observers, fn)
+}
+
+// Start 启动 Raft
+func (r *Replica) Start(ctx context.Context) error {
+	self, _ := findPeer(r.opts.Peers, r.opts.ID)
+
+	conf := raft.DefaultConfig()
+	if r.opts.Raft != nil {
+		copied := *r.opts.Raft
+		conf = &copied
+	}
+	conf.LocalID = raft.ServerID(r.opts.ID)
+	if r.opts.SnapshotThreshold > 0 {
+		conf.SnapshotThreshold = r.opts.SnapshotThreshold
+	}
+	notifyCh := make(chan bool, 8)
+	conf.NotifyCh = notifyCh
+	conf.Logger = hclog.New(&hclog.LoggerOptions{
+		Name:   "raft",
+		Level:  hclog.Info,
+		Output: logrus.StandardLogger().WriterLevel(logrus.InfoLevel),
+	})
+
+	var logs raft.LogStore
+	var stable raft.StableStore
+	if r.opts.Store != nil {
+		logs, stable = r.opts.Store, r.opts.Store
+	} else {
+		inmem := raft.NewInmemStore()
+		logs, stable = inmem, inmem
+	}
+
+	var snaps raft.SnapshotStore
+	if r.opts.DataDir != "" {
+		if err := os.MkdirAll(r.opts.DataDir, 0755); err != nil {
+			return fmt.Errorf("failed to create raft data directory: %w", err)
+		}
+		fileSnaps, err := raft.NewFileSnapshotStore(filepath.Clean(r.opts.DataDir), defaultSnapshotKeep, os.Stderr)
+		if err != nil {
+			return fmt.Errorf("failed to create raft snapshot store: %w", err)
+		}
+		snaps = fileSnaps
+	} else {
+		snaps = raft.NewInmemSnapshotStore()
+	}
+
+	trans := r.opts.Transport
+	if trans == nil {
+		bindAddr := r.opts.BindAddr
+		if bindAddr == "" {
+			bindAddr = self.RaftAddr
+		}
+		advertise, err := net.ResolveTCPAddr("tcp", self.RaftAddr)
+		if err != nil {
+			return fmt.Errorf("invalid raft address %s: %w", self.RaftAddr, err)
+		}
+		tcp, err := raft.NewTCPTransport(bindAddr, advertise, 3, 10*time.Second, os.Stderr)
+		if err != nil {
+			return fmt.Errorf("failed to create raft transport: %w", err)
+		}
+		trans = tcp
+	}
+	r.trans = trans
+
+	if r.opts.Bootstrap {
+		existing, err := raft.HasExistingState(logs, stable, snaps)
+		if err != nil {
+			return fmt.Errorf("failed to check raft state: %w", err)
+		}
+		if !existing {
+			servers := make([]raft.Server, 0, len(r.opts.Peers))
+			for _, peer := range r.opts.Peers {
+				servers = append(servers, raft.Server{
+					ID:      raft.ServerID(peer.ID),
+					Address: raft.ServerAddress(peer.RaftAddr),
+				})
+			}
+			err := raft.BootstrapCluster(conf, logs, stable, snaps, trans, raft.Configuration{Servers: servers})
+			if err != nil {
+				return fmt.Errorf("failed to bootstrap raft cluster: %w", err)
+			}
+			logrus.Infof("Raft cluster bootstrapped with %d peer(s)", len(servers))
+		}
+	}
+
+	r.fsm = newFSM(r.instance, r.stores)
+	node, err := raft.NewRaft(conf, r.fsm, logs, stable, snaps, trans)
+	if err != nil {
+		return fmt.Errorf("failed to start raft: %w", err)
+	}
+	r.raft = node
+
+	r.wg.Add(1)
+	go r.watchLeadership(notifyCh)
+
+	logrus.Infof("HA replica %s started (raft=%s, peers=%d)", r.opts.ID, self.RaftAddr, len(r.opts.Peers))
+	return nil
+}
+
+// Stop 停止复制并关闭 Raft
+func (r *Replica) Stop() {
+	if r.raft == nil {
+		return
+	}
+	r.stopOnce.Do(func() {
+		close(r.stopCh)
+		r.wg.Wait()
+		r.leader.Store(false)
+
+		if err := r.raft.Shutdown().Error(); err != nil {
+			logrus.Warnf("Failed to shutdown raft: %v", err)
+		}
+		if closer, ok := r.trans.(raft.WithClose); ok {
+			closer.Close()
+		}
+		logrus.Infof("HA replica %s stopped", r.opts.ID)
+	})
+}
+
+// ID 本副本 ID
+func (r *Replica) ID() string {
+	return r.opts.ID
+}
+
+// IsLeader 本副本是否为 leader 且已追上全部已提交日志
+func (r *Replica) IsLeader() bool {
+	return r.leader.Load()
+}
+
+// Leader 当前 leader，未知时返回 false
+func (r *Replica) Leader() (Peer, bool) {
+	if r.raft == nil {
+		return Peer{}, false
+	}
+	_, id := r.raft.LeaderWithID()
+	if id == "" {
+		return Peer{}, false
+	}
+	return findPeer(r.opts.Peers, string(id))
+}
+
+// Sync 要求 leader 立即复制本地状态变化并等待提交完成
+func (r *Replica) Sync(ctx context.Context) error {
+	if !r.IsLeader() {
+		return ErrNotLeader
+	}
+	reply := make(chan error, 1)
+	select {
+	case r.syncCh <- reply:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-reply:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Status 副本状态
+type Status struct {
+	ID           string       `json:"id"`
+	State        string       `json:"state"`
+	Leader       *Peer        `json:"leader,omitempty"`
+	Term         uint64       `json:"term"`
+	LastIndex    uint64       `json:"last_index"`
+	AppliedIndex uint64       `json:"applied_index"`
+	Peers        []PeerStatus `json:"peers"`
+}
+
+// PeerStatus 集群成员状态
+type PeerStatus struct {
+	Peer
+	Voter  bool `json:"voter"`
+	Leader bool `json:"leader"`
+}
+
+// Status 返回副本与集群成员状态
+func (r *Replica) Status() Status {
+	status := Status{ID: r.opts.ID, State: raft.Shutdown.String(), Peers: make([]PeerStatus, 0)}
+	if r.raft == nil {
+		return status
+	}
+
+	status.State = r.raft.State().String()
+	status.LastIndex = r.raft.LastIndex()
+	status.AppliedIndex = r.raft.AppliedIndex()
+	var term uint64
+	fmt.Sscan(r.raft.Stats()["term"], &term)
+	status.Term = term
+
+	leader, hasLeader := r.Leader()
+	if hasLeader {
+		status.Leader = &leader
+	}
+
+	future := r.raft.GetConfiguration()
+	if err := future.Error(); err != nil {
+		logrus.Warnf("Failed to get raft configuration: %v", err)
+		return status
+	}
+	for _, server := range future.Configuration().Servers {
+		peer, ok := findPeer(r.opts.Peers, string(server.ID))
+		if !ok {
+			peer = Peer{ID: string(server.ID), RaftAddr: string(server.Address)}
+		}
+		status.Peers = append(status.Peers, PeerStatus{
+			Peer:   peer,
+			Voter:  server.Suffrage == raft.Voter,
+			Leader: hasLeader && peer.ID == leader.ID,
+		})
+	}
+	return status
+}
+
+// watchLeadership 跟踪 leader 身份变化，成为 leader 后启动状态复制
+func (r *Replica) watchLeadership(notifyCh <-chan bool) {
+	defer r.wg.Done()
+
+	var stopSync chan struct{}
+	var syncDone chan struct{}
+	stopReplicating := func() {
+		if stopSync == nil {
+			return
+		}
+		close(stopSync)
+		<-syncDone
+		stopSync, syncDone = nil, nil
+	}
+
+	for {
+		select {
+		case <-r.stopCh:
+			stopReplicating()
+			return
+		case leader := <-notifyCh:
+			if leader == (stopSync != nil) {
+				continue
+			}
+			if !leader {
+				stopReplicating()
+				r.leader.Store(false)
+				logrus.Warnf("HA replica %s lost leadership", r.opts.ID)
+				r.notify(false)
+				continue
+			}
+
+			// 等待此前已提交的日志全部应用，本地状态与集群一致后才接管
+			if !r.catchUp() {
+				continue
+			}
+			r.leader.Store(true)
+			logrus.Infof("HA replica %s became leader", r.opts.ID)
+			r.notify(true)
+
+			stopSync = make(chan struct{})
+			syncDone = make(chan struct{})
+			go r.replicate(stopSync, syncDone)
+		}
+	}
+}
+
+// catchUp 等待已提交日志应用完成，失败时在仍为 leader 的情况下重试
+func (r *Replica) catchUp() bool {
+	for {
+		err := r.raft.Barrier(r.opts.ApplyTimeout).Error()
+		if err == nil {
+			return true
+		}
+		logrus.Errorf("HA replica %s failed to catch up as leader: %v", r.opts.ID, err)
+		if r.raft.State() != raft.Leader {
+			return false
+		}
+		select {
+		case <-r.stopCh:
+			return false
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func (r *Replica) notify(leader bool) {
+	r.mu.Lock()
+	observers := append([]func(bool){}, r.observers...)
+	r.mu.Unlock()
+	for _, fn := range observers {
+		fn(leader)
+	}
+}
+
+func findPeer(peers []Peer, id string) (Peer, bool) {
+	for _, peer := range peers {
+		if peer.ID == id {
+			return peer, true
+		}
+	}
+	return Peer{}, false
+}
@@ -0,0 +1,116 @@
+package ha
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// replicate leader 周期性导出本地状态，将与上次复制相比变化的条目提交为 Raft 日志
+// 接管后首先提交一次完整状态，使 follower 丢弃前任 leader 未提交的本地变化
+// StateStore 不通知变化，每个周期都要导出并哈希全部状态，开销与状态总量成正比：
+// 写请求经 Sync 立即复制，周期复制只兜底后台任务（心跳、探测等）产生的变化，状态较大时应调大 SyncInterval
+func (r *Replica) replicate(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ctx := context.Background()
+	var proposed map[string]map[string][32]byte
+	full := true
+
+	// sync 导出并提交一次变化
+	sync := func() error {
+		state, err := r.stores.export(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to export replicated state: %w", err)
+		}
+		hashes := hashState(state)
+		cmd := command{Instance: r.instance, Full: full, Changes: state}
+		if !full {
+			cmd.Changes = diffState(proposed, state, hashes)
+			if len(cmd.Changes) == 0 {
+				return nil
+			}
+		}
+		if _, err := r.propose(&cmd); err != nil {
+			return fmt.Errorf("failed to replicate state: %w", err)
+		}
+		proposed = hashes
+		full = false
+		return nil
+	}
+
+	ticker := time.NewTicker(r.opts.SyncInterval)
+	defer ticker.Stop()
+
+	if err := sync(); err != nil {
+		logrus.Error(err)
+	}
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := sync(); err != nil {
+				logrus.Error(err)
+			}
+		case reply := <-r.syncCh:
+			reply <- sync()
+		}
+	}
+}
+
+// propose 提交复制日志并等待提交完成，返回日志索引
+func (r *Replica) propose(cmd *command) (uint64, error) {
+	data, err := json.Marshal(cmd)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode command: %w", err)
+	}
+	future := r.raft.Apply(data, r.opts.ApplyTimeout)
+	if err := future.Error(); err != nil {
+		return 0, err
+	}
+	if err, ok := future.Response().(error); ok && err != nil {
+		return 0, err
+	}
+	return future.Index(), nil
+}
+
+func hashState(state map[string]map[string][]byte) map[string]map[string][32]byte {
+	hashes := make(map[string]map[string][32]byte, len(state))
+	for name, entries := range state {
+		hashed := make(map[string][32]byte, len(entries))
+		for key, value := range entries {
+			hashed[key] = sha256.Sum256(value)
+		}
+		hashes[name] = hashed
+	}
+	return hashes
+}
+
+// diffState 计算当前状态相对上次复制的变化，删除的条目值为空
+func diffState(previous map[string]map[string][32]byte, state map[string]map[string][]byte, hashes map[string]map[string][32]byte) map[string]map[string][]byte {
+	changes := make(map[string]map[string][]byte)
+	for name, entries := range state {
+		before := previous[name]
+		changed := make(map[string][]byte)
+		for key, value := range entries {
+			if hash, ok := before[key]; ok && hash == hashes[name][key] {
+				continue
+			}
+			changed[key] = value
+		}
+		for key := range before {
+			if _, ok := entries[key]; !ok {
+				changed[key] = nil
+			}
+		}
+		if len(changed) > 0 {
+			changes[name] = changed
+		}
+	}
+	return changes
+}
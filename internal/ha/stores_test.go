@@ -0,0 +1,151 @@
+package ha_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/deployment"
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	"github.com/9triver/iarnet-global/internal/ha"
+	"github.com/9triver/iarnet-global/internal/intra/repository"
+)
+
+const testTimeout = 10 * time.Second
+
+// replicatedStores 一个副本上注册的真实状态
+type replicatedStores struct {
+	manager  *registry.Manager
+	registry registry.Service
+	tracker  *deployment.Tracker
+}
+
+// TestLocalClusterFailoverReplicatesRegistryAndDeployments 注册与 bootstrap 相同的 registry 与 deployments 状态，
+// leader 故障后新 leader 持有全部记录，并继续向剩余副本复制
+func TestLocalClusterFailoverReplicatesRegistryAndDeployments(t *testing.T) {
+	dir := t.TempDir()
+	stores := make(map[string]*replicatedStores)
+	cluster, err := ha.NewLocalCluster([]ha.Peer{{ID: "a"}, {ID: "b"}, {ID: "c"}}, func(r *ha.Replica) error {
+		domainRepo, err := repository.NewDomainRepo(filepath.Join(dir, r.ID()+"-domains.db"), 1, 1, 0)
+		if err != nil {
+			return err
+		}
+		deploymentRepo, err := repository.NewDeploymentRepo(filepath.Join(dir, r.ID()+"-deployments.db"), 1, 1, 0)
+		if err != nil {
+			return err
+		}
+		manager := registry.NewManager(registry.ManagerOptions{IsLeader: r.IsLeader})
+		s := &replicatedStores{
+			manager:  manager,
+			registry: registry.NewService(manager, domainRepo),
+			tracker:  deployment.NewTracker(deploymentRepo),
+		}
+		stores[r.ID()] = s
+		r.Register("registry", s.registry)
+		r.Register("deployments", s.tracker)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to create cluster: %v", err)
+	}
+	if err := cluster.Start(context.Background()); err != nil {
+		t.Fatalf("failed to start cluster: %v", err)
+	}
+	t.Cleanup(cluster.Stop)
+
+	oldLeader, err := cluster.WaitLeader(testTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	leader := stores[oldLeader.ID()]
+	domain, err := leader.registry.CreateDomain(ctx, "edge", "")
+	if err != nil {
+		t.Fatalf("failed to create domain: %v", err)
+	}
+	head := &registry.Node{
+		ID:       "node.head",
+		DomainID: domain.ID,
+		Name:     "head",
+		Address:  "127.0.0.1:50051",
+		Status:   registry.NodeStatusOnline,
+		IsHead:   true,
+		LastSeen: time.Now(),
+		ResourceCapacity: &registry.ResourceCapacity{
+			Total:     &registry.ResourceInfo{CPU: 4000, Memory: 8 << 30},
+			Used:      &registry.ResourceInfo{},
+			Available: &registry.ResourceInfo{CPU: 4000, Memory: 8 << 30},
+		},
+	}
+	if err := leader.manager.AddNode(head); err != nil {
+		t.Fatalf("failed to add node: %v", err)
+	}
+	if err := leader.tracker.Create(ctx, &deployment.Deployment{
+		ID:        "deploy.1",
+		Tenant:    "default",
+		DomainID:  domain.ID,
+		NodeID:    head.ID,
+		NodeName:  head.Name,
+		Status:    deployment.StatusRunning,
+		Resources: &registry.ResourceInfo{CPU: 1000},
+		CreatedAt: time.Now(),
+	}); err != nil {
+		t.Fatalf("failed to create deployment: %v", err)
+	}
+	if err := cluster.WaitApplied(testTimeout); err != nil {
+		t.Fatal(err)
+	}
+
+	oldLeader.Stop()
+	newLeader, err := cluster.WaitLeader(testTimeout, oldLeader.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stores[newLeader.ID()].tracker.Update(ctx, "deploy.1", func(d *deployment.Deployment) {
+		d.Status = deployment.StatusStopped
+	}); err != nil {
+		t.Fatalf("new leader %s: failed to update replicated deployment: %v", newLeader.ID(), err)
+	}
+	if err := cluster.WaitApplied(testTimeout); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, replica := range cluster.Replicas {
+		if replica.ID() == oldLeader.ID() {
+			continue
+		}
+		s := stores[replica.ID()]
+		replicated, err := s.manager.GetDomain(domain.ID)
+		if err != nil {
+			t.Errorf("replica %s: domain not replicated: %v", replica.ID(), err)
+			continue
+		}
+		if replicated.Name != "edge" {
+			t.Errorf("replica %s: domain name = %q, want %q", replica.ID(), replicated.Name, "edge")
+		}
+		if headID := replicated.GetHeadNode(); headID == nil || *headID != head.ID {
+			t.Errorf("replica %s: head node = %v, want %s", replica.ID(), headID, head.ID)
+		}
+		node, err := s.manager.GetNode(head.ID)
+		if err != nil {
+			t.Errorf("replica %s: node not replicated: %v", replica.ID(), err)
+			continue
+		}
+		if node.DomainID != domain.ID || node.Address != head.Address {
+			t.Errorf("replica %s: node = %s@%s, want %s@%s", replica.ID(), node.DomainID, node.Address, domain.ID, head.Address)
+		}
+		capacity, err := s.manager.DomainCapacity(domain.ID)
+		if err != nil || capacity.Total.CPU != 4000 {
+			t.Errorf("replica %s: domain capacity = %+v, %v, want 4000 CPU in total", replica.ID(), capacity, err)
+		}
+		d, err := s.tracker.Get("deploy.1")
+		if err != nil {
+			t.Errorf("replica %s: deployment not replicated: %v", replica.ID(), err)
+			continue
+		}
+		if d.Status != deployment.StatusStopped || d.NodeID != head.ID {
+			t.Errorf("replica %s: deployment = %s on %s, want %s on %s", replica.ID(), d.Status, d.NodeID, deployment.StatusStopped, head.ID)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// AuditEventDAO 审计事件，details 为 JSON 编码的附加信息
+type AuditEventDAO struct {
+	ID           string    `db:"id"`
+	Type         string    `db:"type"`
+	Tenant       string    `db:"tenant"`
+	DeploymentID string    `db:"deployment_id"`
+	DomainID     string    `db:"domain_id"`
+	NodeID       string    `db:"node_id"`
+	Message      string    `db:"message"`
+	Details      string    `db:"details"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+// AuditEventQuery 审计事件查询条件，空字段表示不过滤
+type AuditEventQuery struct {
+	Type         string
+	Tenant       string
+	DeploymentID string
+	Since        time.Time
+	Limit        int
+}
+
+type AuditRepo interface {
+	CreateEvent(ctx context.Context, dao *AuditEventDAO) error
+	ListEvents(ctx context.Context, query AuditEventQuery) ([]*AuditEventDAO, error)
+	Close() error
+}
+
+func NewAuditRepo(dbPath string, maxOpenConns int, maxIdleConns int, connMaxLifetimeSeconds int) (AuditRepo, error) {
+	db, err := openSQLite(dbPath, maxOpenConns, maxIdleConns, connMaxLifetimeSeconds)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := &auditRepoSQLite{
+		db: db,
+	}
+
+	// 初始化表结构
+	if err := repo.initSchema(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize schema: %w", err)
+	}
+
+	logrus.Infof("Audit repository initialized with SQLite at %s", dbPath)
+	return repo, nil
+}
+
+type auditRepoSQLite struct {
+	db *sql.DB
+}
+
+// initSchema 初始化数据库表结构
+func (r *auditRepoSQLite) initSchema() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS audit_events (
+		id TEXT PRIMARY KEY,
+		type TEXT NOT NULL,
+		tenant TEXT NOT NULL DEFAULT '',
+		deployment_id TEXT NOT NULL DEFAULT '',
+		domain_id TEXT NOT NULL DEFAULT '',
+		node_id TEXT NOT NULL DEFAULT '',
+		message TEXT NOT NULL DEFAULT '',
+		details TEXT NOT NULL DEFAULT '',
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+	CREATE INDEX IF NOT EXISTS idx_audit_events_created_at ON audit_events (created_at);
+	`
+
+	if _, err := r.db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create table: %w", err)
+	}
+
+	return nil
+}
+
+// Close 关闭数据库连接
+func (r *auditRepoSQLite) Close() error {
+	if r.db != nil {
+		return r.db.Close()
+	}
+	return nil
+}
+
+func (r *auditRepoSQLite) CreateEvent(ctx context.Context, dao *AuditEventDAO) error {
+	query := `
+		INSERT INTO audit_events (id, type, tenant, deployment_id, domain_id, node_id, message, details, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, dao.ID, dao.Type, dao.Tenant, dao.DeploymentID, dao.DomainID,
+		dao.NodeID, dao.Message, dao.Details, dao.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert audit event: %w", err)
+	}
+
+	logrus.Debugf("Audit event created in database: id=%s, type=%s", dao.ID, dao.Type)
+	return nil
+}
+
+// ListEvents 按时间倒序查询审计事件
+func (r *auditRepoSQLite) ListEvents(ctx context.Context, q AuditEventQuery) ([]*AuditEventDAO, error) {
+	conditions := make([]string, 0, 4)
+	args := make([]any, 0, 5)
+	if q.Type != "" {
+		conditions = append(conditions, "type = ?")
+		args = append(args, q.Type)
+	}
+	if q.Tenant != "" {
+		conditions = append(conditions, "tenant = ?")
+		args = append(args, q.Tenant)
+	}
+	if q.DeploymentID != "" {
+		conditions = append(conditions, "deployment_id = ?")
+		args = append(args, q.DeploymentID)
+	}
+	if !q.Since.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, q.Since)
+	}
+
+	query := `
+		SELECT id, type, tenant, deployment_id, domain_id, node_id, message, details, created_at
+		FROM audit_events
+	`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY created_at DESC"
+	if q.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, q.Limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]*AuditEventDAO, 0)
+	for rows.Next() {
+		dao := &AuditEventDAO{}
+		err := rows.Scan(
+			&dao.ID,
+			&dao.Type,
+			&dao.Tenant,
+			&dao.DeploymentID,
+			&dao.DomainID,
+			&dao.NodeID,
+			&dao.Message,
+			&dao.Details,
+			&dao.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit event: %w", err)
+		}
+		events = append(events, dao)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating audit events: %w", err)
+	}
+
+	return events, nil
+}
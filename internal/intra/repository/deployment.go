@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DeploymentDAO 全局调度器转发的 component 部署记录
+type DeploymentDAO struct {
+	ID          string    `db:"id"`
+	ComponentID string    `db:"component_id"`
+	Tenant      string    `db:"tenant"`
+	DomainID    string    `db:"domain_id"`
+	NodeID      string    `db:"node_id"`
+	NodeName    string    `db:"node_name"`
+	ProviderID  string    `db:"provider_id"`
+	Status      string    `db:"status"`
+	Error       string    `db:"error"`
+	Resources   string    `db:"resources"` // JSON 编码的资源请求
+	Request     []byte    `db:"request"`   // protobuf 编码的原始部署请求
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+	// 节点失联后的自动重启状态（保存在 deployment_restarts 表）
+	Restarts      int       `db:"restarts"`
+	NextRestartAt time.Time `db:"next_restart_at"`
+	// 转发到对等实例的部署（保存在 deployment_federation 表）
+	PeerID   string `db:"peer_id"`
+	RemoteID string `db:"remote_id"`
+	// 失联节点上待移除的旧实例（JSON，保存在 deployment_stale_instances 表）
+	StaleInstances string `db:"stale_instances"`
+}
+
+type DeploymentRepo interface {
+	SaveDeployment(ctx context.Context, dao *DeploymentDAO) error
+	DeleteDeployment(ctx context.Context, id string) error
+	GetDeployment(ctx context.Context, id string) (*DeploymentDAO, error)
+	GetAllDeployments(ctx context.Context) ([]*DeploymentDAO, error)
+	Close() error
+}
+
+func NewDeploymentRepo(dbPath string, maxOpenConns int, maxIdleConns int, connMaxLifetimeSeconds int) (DeploymentRepo, error) {
+	db, err := openSQLite(dbPath, maxOpenConns, maxIdleConns, connMaxLifetimeSeconds)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := &deploymentRepoSQLite{
+		db: db,
+	}
+
+	// 初始化表结构
+	if err := repo.initSchema(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize schema: %w", err)
+	}
+
+	logrus.Infof("Deployment repository initialized with SQLite at %s", dbPath)
+	return repo, nil
+}
+
+type deploymentRepoSQLite struct {
+	db *sql.DB
+}
+
+// initSchema 初始化数据库表结构
+func (r *deploymentRepoSQLite) initSchema() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS deployments (
+		id TEXT PRIMARY KEY,
+		component_id TEXT NOT NULL DEFAULT '',
+		tenant TEXT NOT NULL DEFAULT '',
+		domain_id TEXT NOT NULL DEFAULT '',
+		node_id TEXT NOT NULL DEFAULT '',
+		node_name TEXT NOT NULL DEFAULT '',
+		provider_id TEXT NOT NULL DEFAULT '',
+		status TEXT NOT NULL,
+		error TEXT NOT NULL DEFAULT '',
+		resources TEXT NOT NULL DEFAULT '{}',
+		request BLOB,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_deployments_component_id ON deployments(component_id);
+	CREATE INDEX IF NOT EXISTS idx_deployments_node_id ON deployments(node_id);
+	CREATE INDEX IF NOT EXISTS idx_deployments_tenant ON deployments(tenant);
+
+	CREATE TABLE IF NOT EXISTS deployment_restarts (
+		deployment_id TEXT PRIMARY KEY REFERENCES deployments(id) ON DELETE CASCADE,
+		restarts INTEGER NOT NULL DEFAULT 0,
+		next_restart_at DATETIME
+	);
+
+	CREATE TABLE IF NOT EXISTS deployment_federation (
+		deployment_id TEXT PRIMARY KEY REFERENCES deployments(id) ON DELETE CASCADE,
+		peer_id TEXT NOT NULL,
+		remote_id TEXT NOT NULL DEFAULT ''
+	);
+
+	CREATE TABLE IF NOT EXISTS deployment_stale_instances (
+		deployment_id TEXT PRIMARY KEY REFERENCES deployments(id) ON DELETE CASCADE,
+		instances TEXT NOT NULL
+	);
+	`
+
+	if _, err := r.db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create table: %w", err)
+	}
+
+	return nil
+}
+
+// Close 关闭数据库连接
+func (r *deploymentRepoSQLite) Close() error {
+	if r.db != nil {
+		return r.db.Close()
+	}
+	return nil
+}
+
+func (r *deploymentRepoSQLite) SaveDeployment(ctx context.Context, dao *DeploymentDAO) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO deployments (id, component_id, tenant, domain_id, node_id, node_name, provider_id,
+			status, error, resources, request, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			component_id = excluded.component_id,
+			tenant = excluded.tenant,
+			domain_id = excluded.domain_id,
+			node_id = excluded.node_id,
+			node_name = excluded.node_name,
+			provider_id = excluded.provider_id,
+			status = excluded.status,
+			error = excluded.error,
+			resources = excluded.resources,
+			request = excluded.request,
+			created_at = excluded.created_at,
+			updated_at = excluded.updated_at
+	`
+
+	_, err = tx.ExecContext(ctx, query, dao.ID, dao.ComponentID, dao.Tenant, dao.DomainID, dao.NodeID, dao.NodeName,
+		dao.ProviderID, dao.Status, dao.Error, dao.Resources, dao.Request, dao.CreatedAt, dao.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save deployment: %w", err)
+	}
+
+	// 只为发生过自动重启的部署保存重启状态
+	if dao.Restarts > 0 || !dao.NextRestartAt.IsZero() {
+		var next sql.NullTime
+		if !dao.NextRestartAt.IsZero() {
+			next = sql.NullTime{Time: dao.NextRestartAt, Valid: true}
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO deployment_restarts (deployment_id, restarts, next_restart_at)
+			VALUES (?, ?, ?)
+			ON CONFLICT(deployment_id) DO UPDATE SET
+				restarts = excluded.restarts,
+				next_restart_at = excluded.next_restart_at
+		`, dao.ID, dao.Restarts, next)
+	} else {
+		_, err = tx.ExecContext(ctx, `DELETE FROM deployment_restarts WHERE deployment_id = ?`, dao.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save deployment restart state: %w", err)
+	}
+
+	// 只为转发到对等实例的部署保存联邦信息
+	if dao.PeerID != "" {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO deployment_federation (deployment_id, peer_id, remote_id)
+			VALUES (?, ?, ?)
+			ON CONFLICT(deployment_id) DO UPDATE SET
+				peer_id = excluded.peer_id,
+				remote_id = excluded.remote_id
+		`, dao.ID, dao.PeerID, dao.RemoteID)
+	} else {
+		_, err = tx.ExecContext(ctx, `DELETE FROM deployment_federation WHERE deployment_id = ?`, dao.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save deployment federation state: %w", err)
+	}
+
+	// 只为有待移除旧实例的部署保存旧实例
+	if dao.StaleInstances != "" {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO deployment_stale_instances (deployment_id, instances)
+			VALUES (?, ?)
+			ON CONFLICT(deployment_id) DO UPDATE SET
+				instances = excluded.instances
+		`, dao.ID, dao.StaleInstances)
+	} else {
+		_, err = tx.ExecContext(ctx, `DELETE FROM deployment_stale_instances WHERE deployment_id = ?`, dao.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save deployment stale instances: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	logrus.Debugf("Deployment saved in database: id=%s, status=%s", dao.ID, dao.Status)
+	return nil
+}
+
+func (r *deploymentRepoSQLite) DeleteDeployment(ctx context.Context, id string) error {
+	query := `DELETE FROM deployments WHERE id = ?`
+
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to delete deployment: %w", err)
+	}
+
+	logrus.Debugf("Deployment deleted from database: id=%s", id)
+	return nil
+}
+
+const deploymentColumns = `d.id, d.component_id, d.tenant, d.domain_id, d.node_id, d.node_name, d.provider_id,
+	d.status, d.error, d.resources, d.request, d.created_at, d.updated_at,
+	COALESCE(r.restarts, 0), r.next_restart_at, COALESCE(f.peer_id, ''), COALESCE(f.remote_id, ''),
+	COALESCE(s.instances, '')`
+
+const deploymentTables = `deployments d
+	LEFT JOIN deployment_restarts r ON r.deployment_id = d.id
+	LEFT JOIN deployment_federation f ON f.deployment_id = d.id
+	LEFT JOIN deployment_stale_instances s ON s.deployment_id = d.id`
+
+func scanDeployment(scanner interface{ Scan(...any) error }) (*DeploymentDAO, error) {
+	dao := &DeploymentDAO{}
+	var next sql.NullTime
+	err := scanner.Scan(
+		&dao.ID,
+		&dao.ComponentID,
+		&dao.Tenant,
+		&dao.DomainID,
+		&dao.NodeID,
+		&dao.NodeName,
+		&dao.ProviderID,
+		&dao.Status,
+		&dao.Error,
+		&dao.Resources,
+		&dao.Request,
+		&dao.CreatedAt,
+		&dao.UpdatedAt,
+		&dao.Restarts,
+		&next,
+		&dao.PeerID,
+		&dao.RemoteID,
+		&dao.StaleInstances,
+	)
+	if next.Valid {
+		dao.NextRestartAt = next.Time
+	}
+	return dao, err
+}
+
+func (r *deploymentRepoSQLite) GetDeployment(ctx context.Context, id string) (*DeploymentDAO, error) {
+	query := `SELECT ` + deploymentColumns + ` FROM ` + deploymentTables + ` WHERE d.id = ?`
+
+	dao, err := scanDeployment(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("deployment not found: %s", id)
+		}
+		return nil, fmt.Errorf("failed to query deployment: %w", err)
+	}
+
+	return dao, nil
+}
+
+func (r *deploymentRepoSQLite) GetAllDeployments(ctx context.Context) ([]*DeploymentDAO, error) {
+	query := `SELECT ` + deploymentColumns + ` FROM ` + deploymentTables + ` ORDER BY d.created_at ASC`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query deployments: %w", err)
+	}
+	defer rows.Close()
+
+	deployments := make([]*DeploymentDAO, 0)
+	for rows.Next() {
+		dao, err := scanDeployment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan deployment: %w", err)
+		}
+		deployments = append(deployments, dao)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating deployments: %w", err)
+	}
+
+	return deployments, nil
+}
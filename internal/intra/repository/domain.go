@@ -4,11 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"os"
-	"path/filepath"
 	"time"
 
-	_ "github.com/mattn/go-sqlite3"
 	"github.com/sirupsen/logrus"
 )
 
@@ -20,40 +17,64 @@ type DomainDAO struct {
 	UpdatedAt   time.Time `db:"updated_at"`
 }
 
+// DomainPolicyDAO 域级健康检查策略覆盖，取值为 0 的字段表示继承全局默认值
+type DomainPolicyDAO struct {
+	DomainID       string    `db:"domain_id"`
+	TimeoutSeconds int       `db:"timeout_seconds"`
+	CleanupFactor  float64   `db:"cleanup_factor"`
+	UpdatedAt      time.Time `db:"updated_at"`
+}
+
+// DomainHierarchyDAO 域的上级域与层级类型（如 region、site），未设置上级域与类型的域没有记录
+type DomainHierarchyDAO struct {
+	DomainID string `db:"domain_id"`
+	ParentID string `db:"parent_id"`
+	Kind     string `db:"kind"`
+}
+
+// JoinTokenDAO 节点加入域的令牌，TokenHash 为令牌的 SHA-256 摘要
+// ExpiresAt 为零值表示永不过期，MaxUses 为 0 表示不限次数
+type JoinTokenDAO struct {
+	ID          string    `db:"id"`
+	DomainID    string    `db:"domain_id"`
+	TokenHash   string    `db:"token_hash"`
+	Description string    `db:"description"`
+	MaxUses     int       `db:"max_uses"`
+	Uses        int       `db:"uses"`
+	ExpiresAt   time.Time `db:"expires_at"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
 type DomainRepo interface {
 	CreateDomain(ctx context.Context, dao *DomainDAO) error
 	UpdateDomain(ctx context.Context, dao *DomainDAO) error
+	SaveDomain(ctx context.Context, dao *DomainDAO) error
 	DeleteDomain(ctx context.Context, id string) error
 	GetDomain(ctx context.Context, id string) (*DomainDAO, error)
 	GetAllDomains(ctx context.Context) ([]*DomainDAO, error)
+	UpsertDomainPolicy(ctx context.Context, dao *DomainPolicyDAO) error
+	DeleteDomainPolicy(ctx context.Context, domainID string) error
+	GetAllDomainPolicies(ctx context.Context) ([]*DomainPolicyDAO, error)
+	SetDomainLabels(ctx context.Context, domainID string, labels map[string]string) error
+	GetAllDomainLabels(ctx context.Context) (map[string]map[string]string, error)
+	SetDomainHierarchy(ctx context.Context, dao *DomainHierarchyDAO) error
+	GetAllDomainHierarchy(ctx context.Context) ([]*DomainHierarchyDAO, error)
+	SetNodeLabels(ctx context.Context, nodeID string, labels map[string]string) error
+	GetAllNodeLabels(ctx context.Context) (map[string]map[string]string, error)
+	SetNodeCordoned(ctx context.Context, nodeID string, cordoned bool) error
+	GetCordonedNodes(ctx context.Context) ([]string, error)
+	CreateJoinToken(ctx context.Context, dao *JoinTokenDAO) error
+	SaveJoinToken(ctx context.Context, dao *JoinTokenDAO) error
+	DeleteJoinToken(ctx context.Context, id string) error
+	IncrementJoinTokenUses(ctx context.Context, id string) error
+	GetAllJoinTokens(ctx context.Context) ([]*JoinTokenDAO, error)
 	Close() error
 }
 
 func NewDomainRepo(dbPath string, maxOpenConns int, maxIdleConns int, connMaxLifetimeSeconds int) (DomainRepo, error) {
-
-	// 确保数据库目录存在
-	dir := filepath.Dir(dbPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create database directory: %w", err)
-	}
-
-	// 打开数据库连接
-	db, err := sql.Open("sqlite3", dbPath+"?_foreign_keys=1&_journal_mode=WAL")
+	db, err := openSQLite(dbPath, maxOpenConns, maxIdleConns, connMaxLifetimeSeconds)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %w", err)
-	}
-
-	// 设置连接池参数
-	db.SetMaxOpenConns(maxOpenConns)
-	db.SetMaxIdleConns(maxIdleConns)
-	if connMaxLifetimeSeconds > 0 {
-		db.SetConnMaxLifetime(time.Duration(connMaxLifetimeSeconds) * time.Second)
-	}
-
-	// 测试连接
-	if err := db.Ping(); err != nil {
-		db.Close()
-		return nil, fmt.Errorf("failed to ping database: %w", err)
+		return nil, err
 	}
 
 	repo := &domainRepoSQLite{
@@ -87,6 +108,54 @@ func (r *domainRepoSQLite) initSchema() error {
 
 	CREATE INDEX IF NOT EXISTS idx_domains_name ON domains(name);
 	CREATE INDEX IF NOT EXISTS idx_domains_created_at ON domains(created_at);
+
+	CREATE TABLE IF NOT EXISTS domain_policies (
+		domain_id TEXT PRIMARY KEY REFERENCES domains(id) ON DELETE CASCADE,
+		timeout_seconds INTEGER NOT NULL DEFAULT 0,
+		cleanup_factor REAL NOT NULL DEFAULT 0,
+		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE TABLE IF NOT EXISTS domain_labels (
+		domain_id TEXT NOT NULL REFERENCES domains(id) ON DELETE CASCADE,
+		key TEXT NOT NULL,
+		value TEXT NOT NULL,
+		PRIMARY KEY (domain_id, key)
+	);
+
+	CREATE TABLE IF NOT EXISTS domain_hierarchy (
+		domain_id TEXT PRIMARY KEY REFERENCES domains(id) ON DELETE CASCADE,
+		parent_id TEXT NOT NULL DEFAULT '',
+		kind TEXT NOT NULL DEFAULT ''
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_domain_hierarchy_parent_id ON domain_hierarchy(parent_id);
+
+	CREATE TABLE IF NOT EXISTS node_labels (
+		node_id TEXT NOT NULL,
+		key TEXT NOT NULL,
+		value TEXT NOT NULL,
+		PRIMARY KEY (node_id, key)
+	);
+
+	CREATE TABLE IF NOT EXISTS node_cordons (
+		node_id TEXT PRIMARY KEY,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE TABLE IF NOT EXISTS join_tokens (
+		id TEXT PRIMARY KEY,
+		domain_id TEXT NOT NULL REFERENCES domains(id) ON DELETE CASCADE,
+		token_hash TEXT NOT NULL,
+		description TEXT NOT NULL DEFAULT '',
+		max_uses INTEGER NOT NULL DEFAULT 0,
+		uses INTEGER NOT NULL DEFAULT 0,
+		expires_at DATETIME,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_join_tokens_token_hash ON join_tokens(token_hash);
+	CREATE INDEX IF NOT EXISTS idx_join_tokens_domain_id ON join_tokens(domain_id);
 	`
 
 	if _, err := r.db.Exec(query); err != nil {
@@ -144,6 +213,26 @@ func (r *domainRepoSQLite) UpdateDomain(ctx context.Context, dao *DomainDAO) err
 	return nil
 }
 
+// SaveDomain 插入或更新域（HA 模式下 follower 应用 leader 复制的域）
+func (r *domainRepoSQLite) SaveDomain(ctx context.Context, dao *DomainDAO) error {
+	query := `
+		INSERT INTO domains (id, name, description, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			name = excluded.name,
+			description = excluded.description,
+			updated_at = excluded.updated_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query, dao.ID, dao.Name, dao.Description, dao.CreatedAt, dao.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save domain: %w", err)
+	}
+
+	logrus.Debugf("Domain saved in database: id=%s, name=%s", dao.ID, dao.Name)
+	return nil
+}
+
 func (r *domainRepoSQLite) DeleteDomain(ctx context.Context, id string) error {
 	query := `DELETE FROM domains WHERE id = ?`
 
@@ -226,3 +315,314 @@ func (r *domainRepoSQLite) GetAllDomains(ctx context.Context) ([]*DomainDAO, err
 
 	return domains, nil
 }
+
+func (r *domainRepoSQLite) UpsertDomainPolicy(ctx context.Context, dao *DomainPolicyDAO) error {
+	query := `
+		INSERT INTO domain_policies (domain_id, timeout_seconds, cleanup_factor, updated_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(domain_id) DO UPDATE SET
+			timeout_seconds = excluded.timeout_seconds,
+			cleanup_factor = excluded.cleanup_factor,
+			updated_at = excluded.updated_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query, dao.DomainID, dao.TimeoutSeconds, dao.CleanupFactor, dao.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert domain policy: %w", err)
+	}
+
+	logrus.Debugf("Domain policy saved in database: domain_id=%s", dao.DomainID)
+	return nil
+}
+
+func (r *domainRepoSQLite) DeleteDomainPolicy(ctx context.Context, domainID string) error {
+	query := `DELETE FROM domain_policies WHERE domain_id = ?`
+
+	if _, err := r.db.ExecContext(ctx, query, domainID); err != nil {
+		return fmt.Errorf("failed to delete domain policy: %w", err)
+	}
+
+	logrus.Debugf("Domain policy deleted from database: domain_id=%s", domainID)
+	return nil
+}
+
+func (r *domainRepoSQLite) GetAllDomainPolicies(ctx context.Context) ([]*DomainPolicyDAO, error) {
+	query := `
+		SELECT domain_id, timeout_seconds, cleanup_factor, updated_at
+		FROM domain_policies
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query domain policies: %w", err)
+	}
+	defer rows.Close()
+
+	policies := make([]*DomainPolicyDAO, 0)
+	for rows.Next() {
+		dao := &DomainPolicyDAO{}
+		if err := rows.Scan(&dao.DomainID, &dao.TimeoutSeconds, &dao.CleanupFactor, &dao.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan domain policy: %w", err)
+		}
+		policies = append(policies, dao)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating domain policies: %w", err)
+	}
+
+	return policies, nil
+}
+
+func (r *domainRepoSQLite) SetDomainLabels(ctx context.Context, domainID string, labels map[string]string) error {
+	return r.replaceLabels(ctx, "domain_labels", "domain_id", domainID, labels)
+}
+
+func (r *domainRepoSQLite) GetAllDomainLabels(ctx context.Context) (map[string]map[string]string, error) {
+	return r.queryLabels(ctx, "domain_labels", "domain_id")
+}
+
+// SetDomainHierarchy 保存域的上级域与层级类型，两者都为空时删除记录
+func (r *domainRepoSQLite) SetDomainHierarchy(ctx context.Context, dao *DomainHierarchyDAO) error {
+	var err error
+	if dao.ParentID == "" && dao.Kind == "" {
+		_, err = r.db.ExecContext(ctx, `DELETE FROM domain_hierarchy WHERE domain_id = ?`, dao.DomainID)
+	} else {
+		_, err = r.db.ExecContext(ctx, `
+			INSERT INTO domain_hierarchy (domain_id, parent_id, kind)
+			VALUES (?, ?, ?)
+			ON CONFLICT(domain_id) DO UPDATE SET
+				parent_id = excluded.parent_id,
+				kind = excluded.kind
+		`, dao.DomainID, dao.ParentID, dao.Kind)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save domain hierarchy: %w", err)
+	}
+
+	logrus.Debugf("Domain hierarchy saved in database: domain_id=%s, parent_id=%s, kind=%s", dao.DomainID, dao.ParentID, dao.Kind)
+	return nil
+}
+
+func (r *domainRepoSQLite) GetAllDomainHierarchy(ctx context.Context) ([]*DomainHierarchyDAO, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT domain_id, parent_id, kind FROM domain_hierarchy`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query domain hierarchy: %w", err)
+	}
+	defer rows.Close()
+
+	hierarchy := make([]*DomainHierarchyDAO, 0)
+	for rows.Next() {
+		dao := &DomainHierarchyDAO{}
+		if err := rows.Scan(&dao.DomainID, &dao.ParentID, &dao.Kind); err != nil {
+			return nil, fmt.Errorf("failed to scan domain hierarchy: %w", err)
+		}
+		hierarchy = append(hierarchy, dao)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating domain hierarchy: %w", err)
+	}
+
+	return hierarchy, nil
+}
+
+func (r *domainRepoSQLite) SetNodeLabels(ctx context.Context, nodeID string, labels map[string]string) error {
+	return r.replaceLabels(ctx, "node_labels", "node_id", nodeID, labels)
+}
+
+func (r *domainRepoSQLite) GetAllNodeLabels(ctx context.Context) (map[string]map[string]string, error) {
+	return r.queryLabels(ctx, "node_labels", "node_id")
+}
+
+func (r *domainRepoSQLite) SetNodeCordoned(ctx context.Context, nodeID string, cordoned bool) error {
+	var err error
+	if cordoned {
+		_, err = r.db.ExecContext(ctx, `INSERT OR IGNORE INTO node_cordons (node_id, created_at) VALUES (?, ?)`, nodeID, time.Now())
+	} else {
+		_, err = r.db.ExecContext(ctx, `DELETE FROM node_cordons WHERE node_id = ?`, nodeID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save node cordon: %w", err)
+	}
+
+	logrus.Debugf("Node cordon saved in database: node_id=%s, cordoned=%v", nodeID, cordoned)
+	return nil
+}
+
+func (r *domainRepoSQLite) GetCordonedNodes(ctx context.Context) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT node_id FROM node_cordons ORDER BY created_at ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query node cordons: %w", err)
+	}
+	defer rows.Close()
+
+	nodeIDs := make([]string, 0)
+	for rows.Next() {
+		var nodeID string
+		if err := rows.Scan(&nodeID); err != nil {
+			return nil, fmt.Errorf("failed to scan node cordon: %w", err)
+		}
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating node cordons: %w", err)
+	}
+
+	return nodeIDs, nil
+}
+
+func (r *domainRepoSQLite) CreateJoinToken(ctx context.Context, dao *JoinTokenDAO) error {
+	query := `
+		INSERT INTO join_tokens (id, domain_id, token_hash, description, max_uses, uses, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	var expiresAt sql.NullTime
+	if !dao.ExpiresAt.IsZero() {
+		expiresAt = sql.NullTime{Time: dao.ExpiresAt, Valid: true}
+	}
+	_, err := r.db.ExecContext(ctx, query, dao.ID, dao.DomainID, dao.TokenHash, dao.Description, dao.MaxUses, dao.Uses, expiresAt, dao.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert join token: %w", err)
+	}
+
+	logrus.Debugf("Join token created in database: id=%s, domain_id=%s", dao.ID, dao.DomainID)
+	return nil
+}
+
+// SaveJoinToken 插入或更新加入令牌（HA 模式下 follower 应用 leader 复制的令牌）
+func (r *domainRepoSQLite) SaveJoinToken(ctx context.Context, dao *JoinTokenDAO) error {
+	query := `
+		INSERT INTO join_tokens (id, domain_id, token_hash, description, max_uses, uses, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			description = excluded.description,
+			max_uses = excluded.max_uses,
+			uses = excluded.uses,
+			expires_at = excluded.expires_at
+	`
+
+	var expiresAt sql.NullTime
+	if !dao.ExpiresAt.IsZero() {
+		expiresAt = sql.NullTime{Time: dao.ExpiresAt, Valid: true}
+	}
+	_, err := r.db.ExecContext(ctx, query, dao.ID, dao.DomainID, dao.TokenHash, dao.Description, dao.MaxUses, dao.Uses, expiresAt, dao.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save join token: %w", err)
+	}
+	return nil
+}
+
+func (r *domainRepoSQLite) DeleteJoinToken(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM join_tokens WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete join token: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("join token not found: %s", id)
+	}
+
+	logrus.Debugf("Join token deleted from database: id=%s", id)
+	return nil
+}
+
+func (r *domainRepoSQLite) IncrementJoinTokenUses(ctx context.Context, id string) error {
+	if _, err := r.db.ExecContext(ctx, `UPDATE join_tokens SET uses = uses + 1 WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("failed to update join token uses: %w", err)
+	}
+	return nil
+}
+
+func (r *domainRepoSQLite) GetAllJoinTokens(ctx context.Context) ([]*JoinTokenDAO, error) {
+	query := `
+		SELECT id, domain_id, token_hash, description, max_uses, uses, expires_at, created_at
+		FROM join_tokens
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query join tokens: %w", err)
+	}
+	defer rows.Close()
+
+	tokens := make([]*JoinTokenDAO, 0)
+	for rows.Next() {
+		dao := &JoinTokenDAO{}
+		var expiresAt sql.NullTime
+		if err := rows.Scan(&dao.ID, &dao.DomainID, &dao.TokenHash, &dao.Description, &dao.MaxUses, &dao.Uses, &expiresAt, &dao.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan join token: %w", err)
+		}
+		if expiresAt.Valid {
+			dao.ExpiresAt = expiresAt.Time
+		}
+		tokens = append(tokens, dao)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating join tokens: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// replaceLabels 在事务中替换某个对象的全部标签
+func (r *domainRepoSQLite) replaceLabels(ctx context.Context, table, ownerColumn, ownerID string, labels map[string]string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE %s = ?`, table, ownerColumn), ownerID); err != nil {
+		return fmt.Errorf("failed to delete labels: %w", err)
+	}
+
+	insert := fmt.Sprintf(`INSERT INTO %s (%s, key, value) VALUES (?, ?, ?)`, table, ownerColumn)
+	for k, v := range labels {
+		if _, err := tx.ExecContext(ctx, insert, ownerID, k, v); err != nil {
+			return fmt.Errorf("failed to insert label: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit labels: %w", err)
+	}
+
+	logrus.Debugf("Labels saved in database: table=%s, owner=%s, count=%d", table, ownerID, len(labels))
+	return nil
+}
+
+// queryLabels 查询某张标签表的全部标签，按所属对象分组
+func (r *domainRepoSQLite) queryLabels(ctx context.Context, table, ownerColumn string) (map[string]map[string]string, error) {
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`SELECT %s, key, value FROM %s`, ownerColumn, table))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query labels: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string]map[string]string)
+	for rows.Next() {
+		var ownerID, key, value string
+		if err := rows.Scan(&ownerID, &key, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan label: %w", err)
+		}
+		if result[ownerID] == nil {
+			result[ownerID] = make(map[string]string)
+		}
+		result[ownerID][key] = value
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating labels: %w", err)
+	}
+
+	return result, nil
+}
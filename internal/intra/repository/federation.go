@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// FederationPeerDAO 对等的 iarnet-global 实例
+type FederationPeerDAO struct {
+	ID               string    `db:"id"`
+	Name             string    `db:"name"`
+	Address          string    `db:"address"`
+	OutboundToken    string    `db:"outbound_token"`     // 对端为本实例签发的令牌，转发时出示
+	InboundTokenHash string    `db:"inbound_token_hash"` // 本实例为对端签发的令牌摘要
+	AllowInbound     bool      `db:"allow_inbound"`
+	AllowOutbound    bool      `db:"allow_outbound"`
+	Priority         int       `db:"priority"`
+	InboundLimit     string    `db:"inbound_limit"`  // JSON 编码的资源上限
+	OutboundLimit    string    `db:"outbound_limit"` // JSON 编码的资源上限
+	CreatedAt        time.Time `db:"created_at"`
+	UpdatedAt        time.Time `db:"updated_at"`
+}
+
+type FederationRepo interface {
+	CreatePeer(ctx context.Context, dao *FederationPeerDAO) error
+	UpdatePeer(ctx context.Context, dao *FederationPeerDAO) error
+	SavePeer(ctx context.Context, dao *FederationPeerDAO) error
+	DeletePeer(ctx context.Context, id string) error
+	GetAllPeers(ctx context.Context) ([]*FederationPeerDAO, error)
+	Close() error
+}
+
+func NewFederationRepo(dbPath string, maxOpenConns int, maxIdleConns int, connMaxLifetimeSeconds int) (FederationRepo, error) {
+	db, err := openSQLite(dbPath, maxOpenConns, maxIdleConns, connMaxLifetimeSeconds)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := &federationRepoSQLite{
+		db: db,
+	}
+
+	// 初始化表结构
+	if err := repo.initSchema(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize schema: %w", err)
+	}
+
+	logrus.Infof("Federation repository initialized with SQLite at %s", dbPath)
+	return repo, nil
+}
+
+type federationRepoSQLite struct {
+	db *sql.DB
+}
+
+// initSchema 初始化数据库表结构
+func (r *federationRepoSQLite) initSchema() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS federation_peers (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL UNIQUE,
+		address TEXT NOT NULL,
+		outbound_token TEXT NOT NULL DEFAULT '',
+		inbound_token_hash TEXT NOT NULL DEFAULT '',
+		allow_inbound INTEGER NOT NULL DEFAULT 0,
+		allow_outbound INTEGER NOT NULL DEFAULT 0,
+		priority INTEGER NOT NULL DEFAULT 0,
+		inbound_limit TEXT NOT NULL DEFAULT '',
+		outbound_limit TEXT NOT NULL DEFAULT '',
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_federation_peers_inbound_token_hash ON federation_peers(inbound_token_hash);
+	`
+
+	if _, err := r.db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create table: %w", err)
+	}
+
+	return nil
+}
+
+// Close 关闭数据库连接
+func (r *federationRepoSQLite) Close() error {
+	if r.db != nil {
+		return r.db.Close()
+	}
+	return nil
+}
+
+func (r *federationRepoSQLite) CreatePeer(ctx context.Context, dao *FederationPeerDAO) error {
+	query := `
+		INSERT INTO federation_peers (id, name, address, outbound_token, inbound_token_hash, allow_inbound,
+			allow_outbound, priority, inbound_limit, outbound_limit, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, dao.ID, dao.Name, dao.Address, dao.OutboundToken, dao.InboundTokenHash,
+		dao.AllowInbound, dao.AllowOutbound, dao.Priority, dao.InboundLimit, dao.OutboundLimit, dao.CreatedAt, dao.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert federation peer: %w", err)
+	}
+
+	logrus.Debugf("Federation peer created in database: id=%s, name=%s", dao.ID, dao.Name)
+	return nil
+}
+
+func (r *federationRepoSQLite) UpdatePeer(ctx context.Context, dao *FederationPeerDAO) error {
+	query := `
+		UPDATE federation_peers
+		SET name = ?, address = ?, outbound_token = ?, inbound_token_hash = ?, allow_inbound = ?, allow_outbound = ?,
+			priority = ?, inbound_limit = ?, outbound_limit = ?, updated_at = ?
+		WHERE id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, dao.Name, dao.Address, dao.OutboundToken, dao.InboundTokenHash,
+		dao.AllowInbound, dao.AllowOutbound, dao.Priority, dao.InboundLimit, dao.OutboundLimit, dao.UpdatedAt, dao.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update federation peer: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("federation peer not found: %s", dao.ID)
+	}
+
+	logrus.Debugf("Federation peer updated in database: id=%s", dao.ID)
+	return nil
+}
+
+// SavePeer 插入或更新对等实例（HA 模式下 follower 应用 leader 复制的对等实例）
+func (r *federationRepoSQLite) SavePeer(ctx context.Context, dao *FederationPeerDAO) error {
+	query := `
+		INSERT INTO federation_peers (id, name, address, outbound_token, inbound_token_hash, allow_inbound,
+			allow_outbound, priority, inbound_limit, outbound_limit, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			name = excluded.name,
+			address = excluded.address,
+			outbound_token = excluded.outbound_token,
+			inbound_token_hash = excluded.inbound_token_hash,
+			allow_inbound = excluded.allow_inbound,
+			allow_outbound = excluded.allow_outbound,
+			priority = excluded.priority,
+			inbound_limit = excluded.inbound_limit,
+			outbound_limit = excluded.outbound_limit,
+			updated_at = excluded.updated_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query, dao.ID, dao.Name, dao.Address, dao.OutboundToken, dao.InboundTokenHash,
+		dao.AllowInbound, dao.AllowOutbound, dao.Priority, dao.InboundLimit, dao.OutboundLimit, dao.CreatedAt, dao.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save federation peer: %w", err)
+	}
+
+	logrus.Debugf("Federation peer saved in database: id=%s", dao.ID)
+	return nil
+}
+
+func (r *federationRepoSQLite) DeletePeer(ctx context.Context, id string) error {
+	query := `DELETE FROM federation_peers WHERE id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete federation peer: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("federation peer not found: %s", id)
+	}
+
+	logrus.Debugf("Federation peer deleted from database: id=%s", id)
+	return nil
+}
+
+func (r *federationRepoSQLite) GetAllPeers(ctx context.Context) ([]*FederationPeerDAO, error) {
+	query := `
+		SELECT id, name, address, outbound_token, inbound_token_hash, allow_inbound, allow_outbound, priority,
+			inbound_limit, outbound_limit, created_at, updated_at
+		FROM federation_peers
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query federation peers: %w", err)
+	}
+	defer rows.Close()
+
+	peers := make([]*FederationPeerDAO, 0)
+	for rows.Next() {
+		dao := &FederationPeerDAO{}
+		err := rows.Scan(
+			&dao.ID,
+			&dao.Name,
+			&dao.Address,
+			&dao.OutboundToken,
+			&dao.InboundTokenHash,
+			&dao.AllowInbound,
+			&dao.AllowOutbound,
+			&dao.Priority,
+			&dao.InboundLimit,
+			&dao.OutboundLimit,
+			&dao.CreatedAt,
+			&dao.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan federation peer: %w", err)
+		}
+		peers = append(peers, dao)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating federation peers: %w", err)
+	}
+
+	return peers, nil
+}
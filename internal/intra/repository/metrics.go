@@ -0,0 +1,402 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// MetricPointDAO 容量时序数据点，同一序列同一分辨率下以时间戳（Unix 秒）唯一
+// 降采样数据点的各项资源为窗口内的平均值，peak 为窗口内已使用资源的最大值
+type MetricPointDAO struct {
+	Scope      string    `db:"scope"`
+	SeriesID   string    `db:"series_id"`
+	Resolution string    `db:"resolution"`
+	Timestamp  time.Time `db:"ts"`
+	Samples    int64     `db:"samples"`
+
+	TotalCPU        int64 `db:"total_cpu"`
+	TotalMemory     int64 `db:"total_memory"`
+	TotalGPU        int64 `db:"total_gpu"`
+	UsedCPU         int64 `db:"used_cpu"`
+	UsedMemory      int64 `db:"used_memory"`
+	UsedGPU         int64 `db:"used_gpu"`
+	AvailableCPU    int64 `db:"available_cpu"`
+	AvailableMemory int64 `db:"available_memory"`
+	AvailableGPU    int64 `db:"available_gpu"`
+	PeakCPU         int64 `db:"peak_cpu"`
+	PeakMemory      int64 `db:"peak_memory"`
+	PeakGPU         int64 `db:"peak_gpu"`
+
+	// Extended 扩展资源，没有扩展资源时为 nil
+	Extended *MetricExtendedDAO `db:"extended"`
+}
+
+// MetricExtendedDAO 数据点的扩展资源（名称 -> 数量），以 JSON 保存在 capacity_metrics_extended 表
+// 与 CPU / 内存 / GPU 相同，降采样数据点为窗口内的平均值，Peak 为窗口内已使用量的最大值
+type MetricExtendedDAO struct {
+	Total     map[string]int64 `json:"total,omitempty"`
+	Used      map[string]int64 `json:"used,omitempty"`
+	Available map[string]int64 `json:"available,omitempty"`
+	Peak      map[string]int64 `json:"peak,omitempty"`
+}
+
+func (e *MetricExtendedDAO) empty() bool {
+	return e == nil || len(e.Total)+len(e.Used)+len(e.Available)+len(e.Peak) == 0
+}
+
+// mergeExtended 按样本数加权合并同一时间戳的扩展资源，缺少的资源按 0 计入
+func mergeExtended(prev *MetricExtendedDAO, prevSamples int64, next *MetricExtendedDAO, nextSamples int64) *MetricExtendedDAO {
+	if prev.empty() {
+		prev = &MetricExtendedDAO{}
+	}
+	if next.empty() {
+		next = &MetricExtendedDAO{}
+	}
+	weighted := func(a, b map[string]int64) map[string]int64 {
+		merged := make(map[string]int64, len(a)+len(b))
+		for name := range a {
+			merged[name] = 0
+		}
+		for name := range b {
+			merged[name] = 0
+		}
+		for name := range merged {
+			merged[name] = (a[name]*prevSamples + b[name]*nextSamples) / (prevSamples + nextSamples)
+		}
+		return merged
+	}
+	peak := make(map[string]int64, len(prev.Peak)+len(next.Peak))
+	for name, value := range prev.Peak {
+		peak[name] = value
+	}
+	for name, value := range next.Peak {
+		peak[name] = max(peak[name], value)
+	}
+	return &MetricExtendedDAO{
+		Total:     weighted(prev.Total, next.Total),
+		Used:      weighted(prev.Used, next.Used),
+		Available: weighted(prev.Available, next.Available),
+		Peak:      peak,
+	}
+}
+
+// MetricPointQuery 时序数据查询条件，From / To 为零值表示不限制
+type MetricPointQuery struct {
+	Scope      string
+	SeriesID   string
+	Resolution string
+	From       time.Time
+	To         time.Time
+	Limit      int
+}
+
+type MetricsRepo interface {
+	// SavePoints 批量写入数据点，与已有数据点时间戳相同时按样本数加权合并
+	SavePoints(ctx context.Context, points []*MetricPointDAO) error
+	// QueryPoints 按时间正序查询数据点，超出 Limit 时返回最新的 Limit 个
+	QueryPoints(ctx context.Context, query MetricPointQuery) ([]*MetricPointDAO, error)
+	// DeleteBefore 删除指定分辨率下早于 before 的数据点，返回删除数量
+	DeleteBefore(ctx context.Context, resolution string, before time.Time) (int64, error)
+	Close() error
+}
+
+func NewMetricsRepo(dbPath string, maxOpenConns int, maxIdleConns int, connMaxLifetimeSeconds int) (MetricsRepo, error) {
+	db, err := openSQLite(dbPath, maxOpenConns, maxIdleConns, connMaxLifetimeSeconds)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := &metricsRepoSQLite{
+		db: db,
+	}
+
+	// 初始化表结构
+	if err := repo.initSchema(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize schema: %w", err)
+	}
+
+	logrus.Infof("Metrics repository initialized with SQLite at %s", dbPath)
+	return repo, nil
+}
+
+type metricsRepoSQLite struct {
+	db *sql.DB
+}
+
+// initSchema 初始化数据库表结构
+func (r *metricsRepoSQLite) initSchema() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS capacity_metrics (
+		scope TEXT NOT NULL,
+		series_id TEXT NOT NULL,
+		resolution TEXT NOT NULL,
+		ts INTEGER NOT NULL,
+		samples INTEGER NOT NULL DEFAULT 1,
+		total_cpu INTEGER NOT NULL DEFAULT 0,
+		total_memory INTEGER NOT NULL DEFAULT 0,
+		total_gpu INTEGER NOT NULL DEFAULT 0,
+		used_cpu INTEGER NOT NULL DEFAULT 0,
+		used_memory INTEGER NOT NULL DEFAULT 0,
+		used_gpu INTEGER NOT NULL DEFAULT 0,
+		available_cpu INTEGER NOT NULL DEFAULT 0,
+		available_memory INTEGER NOT NULL DEFAULT 0,
+		available_gpu INTEGER NOT NULL DEFAULT 0,
+		peak_cpu INTEGER NOT NULL DEFAULT 0,
+		peak_memory INTEGER NOT NULL DEFAULT 0,
+		peak_gpu INTEGER NOT NULL DEFAULT 0,
+		PRIMARY KEY (scope, series_id, resolution, ts)
+	);
+	CREATE INDEX IF NOT EXISTS idx_capacity_metrics_resolution_ts ON capacity_metrics (resolution, ts);
+
+	CREATE TABLE IF NOT EXISTS capacity_metrics_extended (
+		scope TEXT NOT NULL,
+		series_id TEXT NOT NULL,
+		resolution TEXT NOT NULL,
+		ts INTEGER NOT NULL,
+		extended TEXT NOT NULL,
+		PRIMARY KEY (scope, series_id, resolution, ts)
+	);
+	CREATE INDEX IF NOT EXISTS idx_capacity_metrics_extended_resolution_ts ON capacity_metrics_extended (resolution, ts);
+	`
+
+	if _, err := r.db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create table: %w", err)
+	}
+
+	return nil
+}
+
+// Close 关闭数据库连接
+func (r *metricsRepoSQLite) Close() error {
+	if r.db != nil {
+		return r.db.Close()
+	}
+	return nil
+}
+
+func (r *metricsRepoSQLite) SavePoints(ctx context.Context, points []*MetricPointDAO) error {
+	if len(points) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// UPDATE 子句中未加 excluded. 前缀的列引用的是已有数据点的旧值
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO capacity_metrics (scope, series_id, resolution, ts, samples,
+			total_cpu, total_memory, total_gpu, used_cpu, used_memory, used_gpu,
+			available_cpu, available_memory, available_gpu, peak_cpu, peak_memory, peak_gpu)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(scope, series_id, resolution, ts) DO UPDATE SET
+			total_cpu = (total_cpu * samples + excluded.total_cpu * excluded.samples) / (samples + excluded.samples),
+			total_memory = (total_memory * samples + excluded.total_memory * excluded.samples) / (samples + excluded.samples),
+			total_gpu = (total_gpu * samples + excluded.total_gpu * excluded.samples) / (samples + excluded.samples),
+			used_cpu = (used_cpu * samples + excluded.used_cpu * excluded.samples) / (samples + excluded.samples),
+			used_memory = (used_memory * samples + excluded.used_memory * excluded.samples) / (samples + excluded.samples),
+			used_gpu = (used_gpu * samples + excluded.used_gpu * excluded.samples) / (samples + excluded.samples),
+			available_cpu = (available_cpu * samples + excluded.available_cpu * excluded.samples) / (samples + excluded.samples),
+			available_memory = (available_memory * samples + excluded.available_memory * excluded.samples) / (samples + excluded.samples),
+			available_gpu = (available_gpu * samples + excluded.available_gpu * excluded.samples) / (samples + excluded.samples),
+			peak_cpu = MAX(peak_cpu, excluded.peak_cpu),
+			peak_memory = MAX(peak_memory, excluded.peak_memory),
+			peak_gpu = MAX(peak_gpu, excluded.peak_gpu),
+			samples = samples + excluded.samples
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare metric insert: %w", err)
+	}
+	defer stmt.Close()
+
+	// 扩展资源以 JSON 保存，无法在 SQL 中合并，先读出已有数据点在 Go 中加权合并
+	existingStmt, err := tx.PrepareContext(ctx, `
+		SELECT m.samples, COALESCE(e.extended, '')
+		FROM capacity_metrics m
+		LEFT JOIN capacity_metrics_extended e
+			ON e.scope = m.scope AND e.series_id = m.series_id AND e.resolution = m.resolution AND e.ts = m.ts
+		WHERE m.scope = ? AND m.series_id = ? AND m.resolution = ? AND m.ts = ?
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare metric lookup: %w", err)
+	}
+	defer existingStmt.Close()
+
+	extendedStmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO capacity_metrics_extended (scope, series_id, resolution, ts, extended)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(scope, series_id, resolution, ts) DO UPDATE SET extended = excluded.extended
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare extended metric insert: %w", err)
+	}
+	defer extendedStmt.Close()
+
+	for _, p := range points {
+		extended, err := r.mergedExtended(ctx, existingStmt, p)
+		if err != nil {
+			return err
+		}
+		if !extended.empty() {
+			data, err := json.Marshal(extended)
+			if err != nil {
+				return fmt.Errorf("failed to encode extended resources: %w", err)
+			}
+			if _, err := extendedStmt.ExecContext(ctx, p.Scope, p.SeriesID, p.Resolution, p.Timestamp.Unix(), string(data)); err != nil {
+				return fmt.Errorf("failed to insert extended metric point: %w", err)
+			}
+		}
+
+		_, err = stmt.ExecContext(ctx, p.Scope, p.SeriesID, p.Resolution, p.Timestamp.Unix(), p.Samples,
+			p.TotalCPU, p.TotalMemory, p.TotalGPU, p.UsedCPU, p.UsedMemory, p.UsedGPU,
+			p.AvailableCPU, p.AvailableMemory, p.AvailableGPU, p.PeakCPU, p.PeakMemory, p.PeakGPU)
+		if err != nil {
+			return fmt.Errorf("failed to insert metric point: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit metric points: %w", err)
+	}
+	return nil
+}
+
+// mergedExtended 返回写入后数据点的扩展资源：与已有数据点按样本数加权合并，没有已有数据点时原样返回
+func (r *metricsRepoSQLite) mergedExtended(ctx context.Context, existingStmt *sql.Stmt, p *MetricPointDAO) (*MetricExtendedDAO, error) {
+	var samples int64
+	var data string
+	err := existingStmt.QueryRowContext(ctx, p.Scope, p.SeriesID, p.Resolution, p.Timestamp.Unix()).Scan(&samples, &data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return p.Extended, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load existing metric point: %w", err)
+	}
+
+	var prev *MetricExtendedDAO
+	if data != "" {
+		prev = &MetricExtendedDAO{}
+		if err := json.Unmarshal([]byte(data), prev); err != nil {
+			return nil, fmt.Errorf("failed to decode extended resources: %w", err)
+		}
+	}
+	if prev.empty() && p.Extended.empty() {
+		return nil, nil
+	}
+	return mergeExtended(prev, samples, p.Extended, p.Samples), nil
+}
+
+// QueryPoints 按时间正序查询数据点，超出 Limit 时返回最新的 Limit 个
+func (r *metricsRepoSQLite) QueryPoints(ctx context.Context, q MetricPointQuery) ([]*MetricPointDAO, error) {
+	query := `
+		SELECT m.scope, m.series_id, m.resolution, m.ts, m.samples,
+			m.total_cpu, m.total_memory, m.total_gpu, m.used_cpu, m.used_memory, m.used_gpu,
+			m.available_cpu, m.available_memory, m.available_gpu, m.peak_cpu, m.peak_memory, m.peak_gpu,
+			COALESCE(e.extended, '')
+		FROM capacity_metrics m
+		LEFT JOIN capacity_metrics_extended e
+			ON e.scope = m.scope AND e.series_id = m.series_id AND e.resolution = m.resolution AND e.ts = m.ts
+		WHERE m.scope = ? AND m.series_id = ? AND m.resolution = ?
+	`
+	args := []any{q.Scope, q.SeriesID, q.Resolution}
+	if !q.From.IsZero() {
+		query += " AND m.ts >= ?"
+		args = append(args, q.From.Unix())
+	}
+	if !q.To.IsZero() {
+		query += " AND m.ts <= ?"
+		args = append(args, q.To.Unix())
+	}
+	// 按时间倒序取最新的数据点，返回前再反转为正序
+	query += " ORDER BY m.ts DESC"
+	if q.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, q.Limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query metric points: %w", err)
+	}
+	defer rows.Close()
+
+	points := make([]*MetricPointDAO, 0)
+	for rows.Next() {
+		p := &MetricPointDAO{}
+		var ts int64
+		var extended string
+		err := rows.Scan(
+			&p.Scope,
+			&p.SeriesID,
+			&p.Resolution,
+			&ts,
+			&p.Samples,
+			&p.TotalCPU,
+			&p.TotalMemory,
+			&p.TotalGPU,
+			&p.UsedCPU,
+			&p.UsedMemory,
+			&p.UsedGPU,
+			&p.AvailableCPU,
+			&p.AvailableMemory,
+			&p.AvailableGPU,
+			&p.PeakCPU,
+			&p.PeakMemory,
+			&p.PeakGPU,
+			&extended,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan metric point: %w", err)
+		}
+		p.Timestamp = time.Unix(ts, 0)
+		if extended != "" {
+			p.Extended = &MetricExtendedDAO{}
+			if err := json.Unmarshal([]byte(extended), p.Extended); err != nil {
+				return nil, fmt.Errorf("failed to decode extended resources: %w", err)
+			}
+		}
+		points = append(points, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating metric points: %w", err)
+	}
+
+	for i, j := 0, len(points)-1; i < j; i, j = i+1, j-1 {
+		points[i], points[j] = points[j], points[i]
+	}
+	return points, nil
+}
+
+func (r *metricsRepoSQLite) DeleteBefore(ctx context.Context, resolution string, before time.Time) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM capacity_metrics_extended WHERE resolution = ? AND ts < ?`, resolution, before.Unix()); err != nil {
+		return 0, fmt.Errorf("failed to delete expired extended metric points: %w", err)
+	}
+	result, err := tx.ExecContext(ctx, `DELETE FROM capacity_metrics WHERE resolution = ? AND ts < ?`, resolution, before.Unix())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired metric points: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit metric deletion: %w", err)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,249 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ProjectDAO 项目（租户），TokenHash 为访问令牌的 SHA-256 摘要
+type ProjectDAO struct {
+	ID          string    `db:"id"`
+	Name        string    `db:"name"`
+	Description string    `db:"description"`
+	TokenHash   string    `db:"token_hash"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+// ProjectDomainDAO 项目与域的关系，Role 为 owner（拥有）或 shared（被授权共享使用）
+type ProjectDomainDAO struct {
+	ProjectID string    `db:"project_id"`
+	DomainID  string    `db:"domain_id"`
+	Role      string    `db:"role"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type ProjectRepo interface {
+	CreateProject(ctx context.Context, dao *ProjectDAO) error
+	UpdateProject(ctx context.Context, dao *ProjectDAO) error
+	DeleteProject(ctx context.Context, id string) error
+	GetAllProjects(ctx context.Context) ([]*ProjectDAO, error)
+	SetProjectDomain(ctx context.Context, dao *ProjectDomainDAO) error
+	DeleteProjectDomain(ctx context.Context, projectID string, domainID string) error
+	GetAllProjectDomains(ctx context.Context) ([]*ProjectDomainDAO, error)
+	Close() error
+}
+
+func NewProjectRepo(dbPath string, maxOpenConns int, maxIdleConns int, connMaxLifetimeSeconds int) (ProjectRepo, error) {
+	db, err := openSQLite(dbPath, maxOpenConns, maxIdleConns, connMaxLifetimeSeconds)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := &projectRepoSQLite{
+		db: db,
+	}
+
+	// 初始化表结构
+	if err := repo.initSchema(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize schema: %w", err)
+	}
+
+	logrus.Infof("Project repository initialized with SQLite at %s", dbPath)
+	return repo, nil
+}
+
+type projectRepoSQLite struct {
+	db *sql.DB
+}
+
+// initSchema 初始化数据库表结构
+func (r *projectRepoSQLite) initSchema() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS projects (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		description TEXT NOT NULL,
+		token_hash TEXT NOT NULL,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_projects_token_hash ON projects(token_hash);
+
+	CREATE TABLE IF NOT EXISTS project_domains (
+		project_id TEXT NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+		domain_id TEXT NOT NULL,
+		role TEXT NOT NULL,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (project_id, domain_id)
+	);
+
+	-- 每个域最多只有一个拥有者
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_project_domains_owner ON project_domains(domain_id) WHERE role = 'owner';
+	`
+
+	if _, err := r.db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create table: %w", err)
+	}
+
+	return nil
+}
+
+// Close 关闭数据库连接
+func (r *projectRepoSQLite) Close() error {
+	if r.db != nil {
+		return r.db.Close()
+	}
+	return nil
+}
+
+func (r *projectRepoSQLite) CreateProject(ctx context.Context, dao *ProjectDAO) error {
+	query := `
+		INSERT INTO projects (id, name, description, token_hash, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, dao.ID, dao.Name, dao.Description, dao.TokenHash, dao.CreatedAt, dao.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert project: %w", err)
+	}
+
+	logrus.Debugf("Project created in database: id=%s, name=%s", dao.ID, dao.Name)
+	return nil
+}
+
+func (r *projectRepoSQLite) UpdateProject(ctx context.Context, dao *ProjectDAO) error {
+	query := `
+		UPDATE projects
+		SET name = ?, description = ?, token_hash = ?, updated_at = ?
+		WHERE id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, dao.Name, dao.Description, dao.TokenHash, dao.UpdatedAt, dao.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("project not found: %s", dao.ID)
+	}
+
+	logrus.Debugf("Project updated in database: id=%s", dao.ID)
+	return nil
+}
+
+func (r *projectRepoSQLite) DeleteProject(ctx context.Context, id string) error {
+	query := `DELETE FROM projects WHERE id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete project: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("project not found: %s", id)
+	}
+
+	logrus.Debugf("Project deleted from database: id=%s", id)
+	return nil
+}
+
+func (r *projectRepoSQLite) GetAllProjects(ctx context.Context) ([]*ProjectDAO, error) {
+	query := `
+		SELECT id, name, description, token_hash, created_at, updated_at
+		FROM projects
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query projects: %w", err)
+	}
+	defer rows.Close()
+
+	projects := make([]*ProjectDAO, 0)
+	for rows.Next() {
+		dao := &ProjectDAO{}
+		if err := rows.Scan(&dao.ID, &dao.Name, &dao.Description, &dao.TokenHash, &dao.CreatedAt, &dao.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan project: %w", err)
+		}
+		projects = append(projects, dao)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating projects: %w", err)
+	}
+
+	return projects, nil
+}
+
+func (r *projectRepoSQLite) SetProjectDomain(ctx context.Context, dao *ProjectDomainDAO) error {
+	query := `
+		INSERT INTO project_domains (project_id, domain_id, role, created_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(project_id, domain_id) DO UPDATE SET role = excluded.role
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, dao.ProjectID, dao.DomainID, dao.Role, dao.CreatedAt); err != nil {
+		return fmt.Errorf("failed to set project domain: %w", err)
+	}
+
+	logrus.Debugf("Project domain set in database: project=%s, domain=%s, role=%s", dao.ProjectID, dao.DomainID, dao.Role)
+	return nil
+}
+
+func (r *projectRepoSQLite) DeleteProjectDomain(ctx context.Context, projectID string, domainID string) error {
+	query := `DELETE FROM project_domains WHERE project_id = ? AND domain_id = ?`
+
+	if _, err := r.db.ExecContext(ctx, query, projectID, domainID); err != nil {
+		return fmt.Errorf("failed to delete project domain: %w", err)
+	}
+
+	logrus.Debugf("Project domain deleted from database: project=%s, domain=%s", projectID, domainID)
+	return nil
+}
+
+func (r *projectRepoSQLite) GetAllProjectDomains(ctx context.Context) ([]*ProjectDomainDAO, error) {
+	query := `
+		SELECT project_id, domain_id, role, created_at
+		FROM project_domains
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query project domains: %w", err)
+	}
+	defer rows.Close()
+
+	relations := make([]*ProjectDomainDAO, 0)
+	for rows.Next() {
+		dao := &ProjectDomainDAO{}
+		if err := rows.Scan(&dao.ProjectID, &dao.DomainID, &dao.Role, &dao.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan project domain: %w", err)
+		}
+		relations = append(relations, dao)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating project domains: %w", err)
+	}
+
+	return relations, nil
+}
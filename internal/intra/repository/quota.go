@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// QuotaDAO 资源配额，domain_id / tenant 为空表示对所有域 / 所有调用方生效
+type QuotaDAO struct {
+	ID            string    `db:"id"`
+	DomainID      string    `db:"domain_id"`
+	Tenant        string    `db:"tenant"`
+	CPU           int64     `db:"cpu"`
+	Memory        int64     `db:"memory"`
+	GPU           int64     `db:"gpu"`
+	MaxComponents int64     `db:"max_components"`
+	CreatedAt     time.Time `db:"created_at"`
+	UpdatedAt     time.Time `db:"updated_at"`
+}
+
+type QuotaRepo interface {
+	CreateQuota(ctx context.Context, dao *QuotaDAO) error
+	UpdateQuota(ctx context.Context, dao *QuotaDAO) error
+	DeleteQuota(ctx context.Context, id string) error
+	GetAllQuotas(ctx context.Context) ([]*QuotaDAO, error)
+	Close() error
+}
+
+func NewQuotaRepo(dbPath string, maxOpenConns int, maxIdleConns int, connMaxLifetimeSeconds int) (QuotaRepo, error) {
+	db, err := openSQLite(dbPath, maxOpenConns, maxIdleConns, connMaxLifetimeSeconds)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := &quotaRepoSQLite{
+		db: db,
+	}
+
+	// 初始化表结构
+	if err := repo.initSchema(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize schema: %w", err)
+	}
+
+	logrus.Infof("Quota repository initialized with SQLite at %s", dbPath)
+	return repo, nil
+}
+
+type quotaRepoSQLite struct {
+	db *sql.DB
+}
+
+// initSchema 初始化数据库表结构
+func (r *quotaRepoSQLite) initSchema() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS quotas (
+		id TEXT PRIMARY KEY,
+		domain_id TEXT NOT NULL DEFAULT '',
+		tenant TEXT NOT NULL DEFAULT '',
+		cpu INTEGER NOT NULL DEFAULT 0,
+		memory INTEGER NOT NULL DEFAULT 0,
+		gpu INTEGER NOT NULL DEFAULT 0,
+		max_components INTEGER NOT NULL DEFAULT 0,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE (domain_id, tenant)
+	);
+	`
+
+	if _, err := r.db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create table: %w", err)
+	}
+
+	return nil
+}
+
+// Close 关闭数据库连接
+func (r *quotaRepoSQLite) Close() error {
+	if r.db != nil {
+		return r.db.Close()
+	}
+	return nil
+}
+
+func (r *quotaRepoSQLite) CreateQuota(ctx context.Context, dao *QuotaDAO) error {
+	query := `
+		INSERT INTO quotas (id, domain_id, tenant, cpu, memory, gpu, max_components, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, dao.ID, dao.DomainID, dao.Tenant, dao.CPU, dao.Memory, dao.GPU,
+		dao.MaxComponents, dao.CreatedAt, dao.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert quota: %w", err)
+	}
+
+	logrus.Debugf("Quota created in database: id=%s, domain=%s, tenant=%s", dao.ID, dao.DomainID, dao.Tenant)
+	return nil
+}
+
+func (r *quotaRepoSQLite) UpdateQuota(ctx context.Context, dao *QuotaDAO) error {
+	query := `
+		UPDATE quotas
+		SET cpu = ?, memory = ?, gpu = ?, max_components = ?, updated_at = ?
+		WHERE id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, dao.CPU, dao.Memory, dao.GPU, dao.MaxComponents, dao.UpdatedAt, dao.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update quota: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("quota not found: %s", dao.ID)
+	}
+
+	logrus.Debugf("Quota updated in database: id=%s", dao.ID)
+	return nil
+}
+
+func (r *quotaRepoSQLite) DeleteQuota(ctx context.Context, id string) error {
+	query := `DELETE FROM quotas WHERE id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete quota: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("quota not found: %s", id)
+	}
+
+	logrus.Debugf("Quota deleted from database: id=%s", id)
+	return nil
+}
+
+func (r *quotaRepoSQLite) GetAllQuotas(ctx context.Context) ([]*QuotaDAO, error) {
+	query := `
+		SELECT id, domain_id, tenant, cpu, memory, gpu, max_components, created_at, updated_at
+		FROM quotas
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query quotas: %w", err)
+	}
+	defer rows.Close()
+
+	quotas := make([]*QuotaDAO, 0)
+	for rows.Next() {
+		dao := &QuotaDAO{}
+		err := rows.Scan(
+			&dao.ID,
+			&dao.DomainID,
+			&dao.Tenant,
+			&dao.CPU,
+			&dao.Memory,
+			&dao.GPU,
+			&dao.MaxComponents,
+			&dao.CreatedAt,
+			&dao.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan quota: %w", err)
+		}
+		quotas = append(quotas, dao)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating quotas: %w", err)
+	}
+
+	return quotas, nil
+}
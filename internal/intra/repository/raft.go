@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/raft"
+	"github.com/sirupsen/logrus"
+)
+
+// errRaftKeyNotFound raft 通过错误信息 "not found" 判断键不存在
+var errRaftKeyNotFound = errors.New("not found")
+
+// RaftRepo Raft 日志与元数据（任期、投票）的持久化存储
+type RaftRepo interface {
+	raft.LogStore
+	raft.StableStore
+	Close() error
+}
+
+func NewRaftRepo(dbPath string, maxOpenConns int, maxIdleConns int, connMaxLifetimeSeconds int) (RaftRepo, error) {
+	db, err := openSQLite(dbPath, maxOpenConns, maxIdleConns, connMaxLifetimeSeconds)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := &raftRepoSQLite{
+		db: db,
+	}
+
+	// 初始化表结构
+	if err := repo.initSchema(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize schema: %w", err)
+	}
+
+	logrus.Infof("Raft repository initialized with SQLite at %s", dbPath)
+	return repo, nil
+}
+
+type raftRepoSQLite struct {
+	db *sql.DB
+}
+
+// initSchema 初始化数据库表结构
+func (r *raftRepoSQLite) initSchema() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS raft_logs (
+		idx INTEGER PRIMARY KEY,
+		term INTEGER NOT NULL,
+		type INTEGER NOT NULL,
+		data BLOB,
+		extensions BLOB,
+		appended_at DATETIME
+	);
+	CREATE TABLE IF NOT EXISTS raft_stable (
+		key TEXT PRIMARY KEY,
+		value BLOB NOT NULL
+	);
+	`
+	_, err := r.db.Exec(query)
+	return err
+}
+
+func (r *raftRepoSQLite) Close() error {
+	if r.db != nil {
+		return r.db.Close()
+	}
+	return nil
+}
+
+func (r *raftRepoSQLite) FirstIndex() (uint64, error) {
+	return r.queryIndex(`SELECT COALESCE(MIN(idx), 0) FROM raft_logs`)
+}
+
+func (r *raftRepoSQLite) LastIndex() (uint64, error) {
+	return r.queryIndex(`SELECT COALESCE(MAX(idx), 0) FROM raft_logs`)
+}
+
+func (r *raftRepoSQLite) queryIndex(query string) (uint64, error) {
+	var index uint64
+	if err := r.db.QueryRow(query).Scan(&index); err != nil {
+		return 0, fmt.Errorf("failed to query raft log index: %w", err)
+	}
+	return index, nil
+}
+
+func (r *raftRepoSQLite) GetLog(index uint64, log *raft.Log) error {
+	var appendedAt sql.NullTime
+	err := r.db.QueryRow(`SELECT idx, term, type, data, extensions, appended_at FROM raft_logs WHERE idx = ?`, index).
+		Scan(&log.Index, &log.Term, &log.Type, &log.Data, &log.Extensions, &appendedAt)
+	if err == sql.ErrNoRows {
+		return raft.ErrLogNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get raft log %d: %w", index, err)
+	}
+	log.AppendedAt = appendedAt.Time
+	return nil
+}
+
+func (r *raftRepoSQLite) StoreLog(log *raft.Log) error {
+	return r.StoreLogs([]*raft.Log{log})
+}
+
+func (r *raftRepoSQLite) StoreLogs(logs []*raft.Log) error {
+	tx, err := r.db.BeginTx(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`INSERT OR REPLACE INTO raft_logs (idx, term, type, data, extensions, appended_at) VALUES (?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare raft log insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, log := range logs {
+		var appendedAt sql.NullTime
+		if !log.AppendedAt.IsZero() {
+			appendedAt = sql.NullTime{Time: log.AppendedAt, Valid: true}
+		}
+		if _, err := stmt.Exec(log.Index, log.Term, log.Type, log.Data, log.Extensions, appendedAt); err != nil {
+			return fmt.Errorf("failed to store raft log %d: %w", log.Index, err)
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *raftRepoSQLite) DeleteRange(min, max uint64) error {
+	if _, err := r.db.Exec(`DELETE FROM raft_logs WHERE idx >= ? AND idx <= ?`, min, max); err != nil {
+		return fmt.Errorf("failed to delete raft logs [%d, %d]: %w", min, max, err)
+	}
+	return nil
+}
+
+func (r *raftRepoSQLite) Set(key []byte, val []byte) error {
+	if _, err := r.db.Exec(`INSERT OR REPLACE INTO raft_stable (key, value) VALUES (?, ?)`, string(key), val); err != nil {
+		return fmt.Errorf("failed to set raft key %s: %w", key, err)
+	}
+	return nil
+}
+
+func (r *raftRepoSQLite) Get(key []byte) ([]byte, error) {
+	var val []byte
+	err := r.db.QueryRow(`SELECT value FROM raft_stable WHERE key = ?`, string(key)).Scan(&val)
+	if err == sql.ErrNoRows {
+		return nil, errRaftKeyNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get raft key %s: %w", key, err)
+	}
+	return val, nil
+}
+
+func (r *raftRepoSQLite) SetUint64(key []byte, val uint64) error {
+	return r.Set(key, []byte(strconv.FormatUint(val, 10)))
+}
+
+func (r *raftRepoSQLite) GetUint64(key []byte) (uint64, error) {
+	val, err := r.Get(key)
+	if err == errRaftKeyNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseUint(string(val), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value for raft key %s: %w", key, err)
+	}
+	return n, nil
+}
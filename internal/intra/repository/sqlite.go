@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// openSQLite 打开 SQLite 数据库并设置连接池参数
+// 多个 repository 可以共享同一个数据库文件（WAL 模式下支持并发读）
+func openSQLite(dbPath string, maxOpenConns int, maxIdleConns int, connMaxLifetimeSeconds int) (*sql.DB, error) {
+	// 确保数据库目录存在
+	dir := filepath.Dir(dbPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create database directory: %w", err)
+	}
+
+	// 打开数据库连接
+	db, err := sql.Open("sqlite3", dbPath+"?_foreign_keys=1&_journal_mode=WAL&_busy_timeout=5000")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// 设置连接池参数
+	db.SetMaxOpenConns(maxOpenConns)
+	db.SetMaxIdleConns(maxIdleConns)
+	if connMaxLifetimeSeconds > 0 {
+		db.SetConnMaxLifetime(time.Duration(connMaxLifetimeSeconds) * time.Second)
+	}
+
+	// 测试连接
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return db, nil
+}
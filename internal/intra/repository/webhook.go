@@ -0,0 +1,335 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrWebhookDeliveryNotFound 投递记录不存在
+var ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+
+// WebhookDeliveryDAO webhook 投递记录，payload 为渲染后的请求体
+type WebhookDeliveryDAO struct {
+	ID             string    `db:"id"`
+	Sink           string    `db:"sink"`
+	EventID        string    `db:"event_id"`
+	EventType      string    `db:"event_type"`
+	Payload        string    `db:"payload"`
+	Status         string    `db:"status"`
+	Attempts       int       `db:"attempts"`
+	LastStatusCode int       `db:"last_status_code"`
+	LastError      string    `db:"last_error"`
+	NextAttemptAt  time.Time `db:"next_attempt_at"`
+	DeliveredAt    time.Time `db:"delivered_at"` // 零值表示尚未投递成功
+	CreatedAt      time.Time `db:"created_at"`
+	UpdatedAt      time.Time `db:"updated_at"`
+}
+
+// WebhookDeliveryQuery 投递记录查询条件，空字段表示不过滤
+type WebhookDeliveryQuery struct {
+	Sink      string
+	Status    string
+	EventType string
+	Limit     int
+}
+
+type WebhookRepo interface {
+	CreateDelivery(ctx context.Context, dao *WebhookDeliveryDAO) error
+	UpdateDelivery(ctx context.Context, dao *WebhookDeliveryDAO) error
+	GetDelivery(ctx context.Context, id string) (*WebhookDeliveryDAO, error)
+	// ListDeliveries 按创建时间倒序查询投递记录
+	ListDeliveries(ctx context.Context, query WebhookDeliveryQuery) ([]*WebhookDeliveryDAO, error)
+	// ListDue 按下次投递时间正序查询 statuses 中到期（next_attempt_at <= now）的投递记录
+	ListDue(ctx context.Context, statuses []string, now time.Time, limit int) ([]*WebhookDeliveryDAO, error)
+	// CountByStatus 按目标与状态统计投递记录数量
+	CountByStatus(ctx context.Context) (map[string]map[string]int, error)
+	// DeleteBefore 删除 statuses 中更新时间早于 before 的投递记录，返回删除数量
+	DeleteBefore(ctx context.Context, statuses []string, before time.Time) (int64, error)
+	Close() error
+}
+
+func NewWebhookRepo(dbPath string, maxOpenConns int, maxIdleConns int, connMaxLifetimeSeconds int) (WebhookRepo, error) {
+	db, err := openSQLite(dbPath, maxOpenConns, maxIdleConns, connMaxLifetimeSeconds)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := &webhookRepoSQLite{
+		db: db,
+	}
+
+	// 初始化表结构
+	if err := repo.initSchema(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize schema: %w", err)
+	}
+
+	logrus.Infof("Webhook repository initialized with SQLite at %s", dbPath)
+	return repo, nil
+}
+
+type webhookRepoSQLite struct {
+	db *sql.DB
+}
+
+// initSchema 初始化数据库表结构，时间均以 Unix 秒保存
+func (r *webhookRepoSQLite) initSchema() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS webhook_deliveries (
+		id TEXT PRIMARY KEY,
+		sink TEXT NOT NULL,
+		event_id TEXT NOT NULL DEFAULT '',
+		event_type TEXT NOT NULL DEFAULT '',
+		payload TEXT NOT NULL DEFAULT '',
+		status TEXT NOT NULL,
+		attempts INTEGER NOT NULL DEFAULT 0,
+		last_status_code INTEGER NOT NULL DEFAULT 0,
+		last_error TEXT NOT NULL DEFAULT '',
+		next_attempt_at INTEGER NOT NULL DEFAULT 0,
+		delivered_at INTEGER NOT NULL DEFAULT 0,
+		created_at INTEGER NOT NULL,
+		updated_at INTEGER NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries (status, next_attempt_at);
+	CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_created_at ON webhook_deliveries (created_at);
+	`
+
+	if _, err := r.db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create table: %w", err)
+	}
+
+	return nil
+}
+
+// Close 关闭数据库连接
+func (r *webhookRepoSQLite) Close() error {
+	if r.db != nil {
+		return r.db.Close()
+	}
+	return nil
+}
+
+func (r *webhookRepoSQLite) CreateDelivery(ctx context.Context, dao *WebhookDeliveryDAO) error {
+	query := `
+		INSERT INTO webhook_deliveries (id, sink, event_id, event_type, payload, status, attempts,
+			last_status_code, last_error, next_attempt_at, delivered_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, dao.ID, dao.Sink, dao.EventID, dao.EventType, dao.Payload, dao.Status,
+		dao.Attempts, dao.LastStatusCode, dao.LastError, unixOrZero(dao.NextAttemptAt), unixOrZero(dao.DeliveredAt),
+		dao.CreatedAt.Unix(), dao.UpdatedAt.Unix())
+	if err != nil {
+		return fmt.Errorf("failed to insert webhook delivery: %w", err)
+	}
+	return nil
+}
+
+func (r *webhookRepoSQLite) UpdateDelivery(ctx context.Context, dao *WebhookDeliveryDAO) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = ?, attempts = ?, last_status_code = ?, last_error = ?, next_attempt_at = ?, delivered_at = ?, updated_at = ?
+		WHERE id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, dao.Status, dao.Attempts, dao.LastStatusCode, dao.LastError,
+		unixOrZero(dao.NextAttemptAt), unixOrZero(dao.DeliveredAt), dao.UpdatedAt.Unix(), dao.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return ErrWebhookDeliveryNotFound
+	}
+	return nil
+}
+
+const webhookDeliveryColumns = `id, sink, event_id, event_type, payload, status, attempts,
+	last_status_code, last_error, next_attempt_at, delivered_at, created_at, updated_at`
+
+func (r *webhookRepoSQLite) GetDelivery(ctx context.Context, id string) (*WebhookDeliveryDAO, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries WHERE id = ?`, id)
+	dao, err := scanWebhookDelivery(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	return dao, nil
+}
+
+// ListDeliveries 按创建时间倒序查询投递记录
+func (r *webhookRepoSQLite) ListDeliveries(ctx context.Context, q WebhookDeliveryQuery) ([]*WebhookDeliveryDAO, error) {
+	conditions := make([]string, 0, 3)
+	args := make([]any, 0, 4)
+	if q.Sink != "" {
+		conditions = append(conditions, "sink = ?")
+		args = append(args, q.Sink)
+	}
+	if q.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, q.Status)
+	}
+	if q.EventType != "" {
+		conditions = append(conditions, "event_type = ?")
+		args = append(args, q.EventType)
+	}
+
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY created_at DESC, id DESC"
+	if q.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, q.Limit)
+	}
+	return r.query(ctx, query, args...)
+}
+
+func (r *webhookRepoSQLite) ListDue(ctx context.Context, statuses []string, now time.Time, limit int) ([]*WebhookDeliveryDAO, error) {
+	if len(statuses) == 0 {
+		return []*WebhookDeliveryDAO{}, nil
+	}
+	args := make([]any, 0, len(statuses)+2)
+	for _, status := range statuses {
+		args = append(args, status)
+	}
+	args = append(args, now.Unix())
+
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries
+		WHERE status IN (` + placeholders(len(statuses)) + `) AND next_attempt_at <= ?
+		ORDER BY next_attempt_at ASC`
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+	return r.query(ctx, query, args...)
+}
+
+func (r *webhookRepoSQLite) CountByStatus(ctx context.Context) (map[string]map[string]int, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT sink, status, COUNT(*) FROM webhook_deliveries GROUP BY sink, status`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]map[string]int)
+	for rows.Next() {
+		var sink, status string
+		var count int
+		if err := rows.Scan(&sink, &status, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery count: %w", err)
+		}
+		if counts[sink] == nil {
+			counts[sink] = make(map[string]int)
+		}
+		counts[sink][status] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook delivery counts: %w", err)
+	}
+	return counts, nil
+}
+
+func (r *webhookRepoSQLite) DeleteBefore(ctx context.Context, statuses []string, before time.Time) (int64, error) {
+	if len(statuses) == 0 {
+		return 0, nil
+	}
+	args := make([]any, 0, len(statuses)+1)
+	for _, status := range statuses {
+		args = append(args, status)
+	}
+	args = append(args, before.Unix())
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhook_deliveries
+		WHERE status IN (`+placeholders(len(statuses))+`) AND updated_at < ?`, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired webhook deliveries: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+func (r *webhookRepoSQLite) query(ctx context.Context, query string, args ...any) ([]*WebhookDeliveryDAO, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]*WebhookDeliveryDAO, 0)
+	for rows.Next() {
+		dao, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, dao)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// scanner 同时适用于 *sql.Row 与 *sql.Rows
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanWebhookDelivery(row scanner) (*WebhookDeliveryDAO, error) {
+	dao := &WebhookDeliveryDAO{}
+	var nextAttemptAt, deliveredAt, createdAt, updatedAt int64
+	err := row.Scan(
+		&dao.ID,
+		&dao.Sink,
+		&dao.EventID,
+		&dao.EventType,
+		&dao.Payload,
+		&dao.Status,
+		&dao.Attempts,
+		&dao.LastStatusCode,
+		&dao.LastError,
+		&nextAttemptAt,
+		&deliveredAt,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	dao.NextAttemptAt = timeOrZero(nextAttemptAt)
+	dao.DeliveredAt = timeOrZero(deliveredAt)
+	dao.CreatedAt = time.Unix(createdAt, 0)
+	dao.UpdatedAt = time.Unix(updatedAt, 0)
+	return dao, nil
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func timeOrZero(unix int64) time.Time {
+	if unix == 0 {
+		return time.Time{}
+	}
+	return time.Unix(unix, 0)
+}
@@ -0,0 +1,397 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.31.1
+// source: federation.proto
+
+package federation
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Resources 资源数量
+type Resources struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cpu           int64                  `protobuf:"varint,1,opt,name=cpu,proto3" json:"cpu,omitempty"`                                                                                     // CPU millicores (毫核)
+	Memory        int64                  `protobuf:"varint,2,opt,name=memory,proto3" json:"memory,omitempty"`                                                                               // Memory bytes (字节)
+	Gpu           int64                  `protobuf:"varint,3,opt,name=gpu,proto3" json:"gpu,omitempty"`                                                                                     // GPU count (数量)
+	Extended      map[string]int64       `protobuf:"bytes,4,rep,name=extended,proto3" json:"extended,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // 扩展资源
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Resources) Reset() {
+	*x = Resources{}
+	mi := &file_federation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Resources) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Resources) ProtoMessage() {}
+
+func (x *Resources) ProtoReflect() protoreflect.Message {
+	mi := &file_federation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Resources.ProtoReflect.Descriptor instead.
+func (*Resources) Descriptor() ([]byte, []int) {
+	return file_federation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Resources) GetCpu() int64 {
+	if x != nil {
+		return x.Cpu
+	}
+	return 0
+}
+
+func (x *Resources) GetMemory() int64 {
+	if x != nil {
+		return x.Memory
+	}
+	return 0
+}
+
+func (x *Resources) GetGpu() int64 {
+	if x != nil {
+		return x.Gpu
+	}
+	return 0
+}
+
+func (x *Resources) GetExtended() map[string]int64 {
+	if x != nil {
+		return x.Extended
+	}
+	return nil
+}
+
+// CapacitySummary 实例的聚合容量摘要，不包含单个域与节点的细节
+type CapacitySummary struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 实例 ID
+	InstanceId string `protobuf:"bytes,1,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	// 可调度的域数量
+	Domains int32 `protobuf:"varint,2,opt,name=domains,proto3" json:"domains,omitempty"`
+	// 可调度的节点数量
+	Nodes int32 `protobuf:"varint,3,opt,name=nodes,proto3" json:"nodes,omitempty"`
+	// 总资源
+	Total *Resources `protobuf:"bytes,4,opt,name=total,proto3" json:"total,omitempty"`
+	// 可用资源（已按对调用方开放的上限裁剪）
+	Available *Resources `protobuf:"bytes,5,opt,name=available,proto3" json:"available,omitempty"`
+	// 单个节点的最大可用资源，用于判断单个 component 能否放置
+	MaxNodeAvailable *Resources `protobuf:"bytes,6,opt,name=max_node_available,json=maxNodeAvailable,proto3" json:"max_node_available,omitempty"`
+	// 可用节点支持的资源类型（cpu、gpu、memory、camera）
+	ResourceTags []string `protobuf:"bytes,7,rep,name=resource_tags,json=resourceTags,proto3" json:"resource_tags,omitempty"`
+	// 生成时间（Unix 秒）
+	Timestamp     int64 `protobuf:"varint,8,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CapacitySummary) Reset() {
+	*x = CapacitySummary{}
+	mi := &file_federation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CapacitySummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapacitySummary) ProtoMessage() {}
+
+func (x *CapacitySummary) ProtoReflect() protoreflect.Message {
+	mi := &file_federation_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapacitySummary.ProtoReflect.Descriptor instead.
+func (*CapacitySummary) Descriptor() ([]byte, []int) {
+	return file_federation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CapacitySummary) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *CapacitySummary) GetDomains() int32 {
+	if x != nil {
+		return x.Domains
+	}
+	return 0
+}
+
+func (x *CapacitySummary) GetNodes() int32 {
+	if x != nil {
+		return x.Nodes
+	}
+	return 0
+}
+
+func (x *CapacitySummary) GetTotal() *Resources {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *CapacitySummary) GetAvailable() *Resources {
+	if x != nil {
+		return x.Available
+	}
+	return nil
+}
+
+func (x *CapacitySummary) GetMaxNodeAvailable() *Resources {
+	if x != nil {
+		return x.MaxNodeAvailable
+	}
+	return nil
+}
+
+func (x *CapacitySummary) GetResourceTags() []string {
+	if x != nil {
+		return x.ResourceTags
+	}
+	return nil
+}
+
+func (x *CapacitySummary) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type ExchangeSummaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Summary       *CapacitySummary       `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExchangeSummaryRequest) Reset() {
+	*x = ExchangeSummaryRequest{}
+	mi := &file_federation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExchangeSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExchangeSummaryRequest) ProtoMessage() {}
+
+func (x *ExchangeSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_federation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExchangeSummaryRequest.ProtoReflect.Descriptor instead.
+func (*ExchangeSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_federation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ExchangeSummaryRequest) GetSummary() *CapacitySummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+type ExchangeSummaryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Summary       *CapacitySummary       `protobuf:"bytes,3,opt,name=summary,proto3" json:"summary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExchangeSummaryResponse) Reset() {
+	*x = ExchangeSummaryResponse{}
+	mi := &file_federation_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExchangeSummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExchangeSummaryResponse) ProtoMessage() {}
+
+func (x *ExchangeSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_federation_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExchangeSummaryResponse.ProtoReflect.Descriptor instead.
+func (*ExchangeSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_federation_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ExchangeSummaryResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ExchangeSummaryResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ExchangeSummaryResponse) GetSummary() *CapacitySummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+var File_federation_proto protoreflect.FileDescriptor
+
+const file_federation_proto_rawDesc = "" +
+	"\n" +
+	"\x10federation.proto\x12\n" +
+	"federation\"\xc5\x01\n" +
+	"\tResources\x12\x10\n" +
+	"\x03cpu\x18\x01 \x01(\x03R\x03cpu\x12\x16\n" +
+	"\x06memory\x18\x02 \x01(\x03R\x06memory\x12\x10\n" +
+	"\x03gpu\x18\x03 \x01(\x03R\x03gpu\x12?\n" +
+	"\bextended\x18\x04 \x03(\v2#.federation.Resources.ExtendedEntryR\bextended\x1a;\n" +
+	"\rExtendedEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\xcc\x02\n" +
+	"\x0fCapacitySummary\x12\x1f\n" +
+	"\vinstance_id\x18\x01 \x01(\tR\n" +
+	"instanceId\x12\x18\n" +
+	"\adomains\x18\x02 \x01(\x05R\adomains\x12\x14\n" +
+	"\x05nodes\x18\x03 \x01(\x05R\x05nodes\x12+\n" +
+	"\x05total\x18\x04 \x01(\v2\x15.federation.ResourcesR\x05total\x123\n" +
+	"\tavailable\x18\x05 \x01(\v2\x15.federation.ResourcesR\tavailable\x12C\n" +
+	"\x12max_node_available\x18\x06 \x01(\v2\x15.federation.ResourcesR\x10maxNodeAvailable\x12#\n" +
+	"\rresource_tags\x18\a \x03(\tR\fresourceTags\x12\x1c\n" +
+	"\ttimestamp\x18\b \x01(\x03R\ttimestamp\"O\n" +
+	"\x16ExchangeSummaryRequest\x125\n" +
+	"\asummary\x18\x01 \x01(\v2\x1b.federation.CapacitySummaryR\asummary\"\x80\x01\n" +
+	"\x17ExchangeSummaryResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x125\n" +
+	"\asummary\x18\x03 \x01(\v2\x1b.federation.CapacitySummaryR\asummary2o\n" +
+	"\x11FederationService\x12Z\n" +
+	"\x0fExchangeSummary\x12\".federation.ExchangeSummaryRequest\x1a#.federation.ExchangeSummaryResponseB<Z:github.com/9triver/iarnet-global/internal/proto/federationb\x06proto3"
+
+var (
+	file_federation_proto_rawDescOnce sync.Once
+	file_federation_proto_rawDescData []byte
+)
+
+func file_federation_proto_rawDescGZIP() []byte {
+	file_federation_proto_rawDescOnce.Do(func() {
+		file_federation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_federation_proto_rawDesc), len(file_federation_proto_rawDesc)))
+	})
+	return file_federation_proto_rawDescData
+}
+
+var file_federation_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_federation_proto_goTypes = []any{
+	(*Resources)(nil),               // 0: federation.Resources
+	(*CapacitySummary)(nil),         // 1: federation.CapacitySummary
+	(*ExchangeSummaryRequest)(nil),  // 2: federation.ExchangeSummaryRequest
+	(*ExchangeSummaryResponse)(nil), // 3: federation.ExchangeSummaryResponse
+	nil,                             // 4: federation.Resources.ExtendedEntry
+}
+var file_federation_proto_depIdxs = []int32{
+	4, // 0: federation.Resources.extended:type_name -> federation.Resources.ExtendedEntry
+	0, // 1: federation.CapacitySummary.total:type_name -> federation.Resources
+	0, // 2: federation.CapacitySummary.available:type_name -> federation.Resources
+	0, // 3: federation.CapacitySummary.max_node_available:type_name -> federation.Resources
+	1, // 4: federation.ExchangeSummaryRequest.summary:type_name -> federation.CapacitySummary
+	1, // 5: federation.ExchangeSummaryResponse.summary:type_name -> federation.CapacitySummary
+	2, // 6: federation.FederationService.ExchangeSummary:input_type -> federation.ExchangeSummaryRequest
+	3, // 7: federation.FederationService.ExchangeSummary:output_type -> federation.ExchangeSummaryResponse
+	7, // [7:8] is the sub-list for method output_type
+	6, // [6:7] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_federation_proto_init() }
+func file_federation_proto_init() {
+	if File_federation_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_federation_proto_rawDesc), len(file_federation_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_federation_proto_goTypes,
+		DependencyIndexes: file_federation_proto_depIdxs,
+		MessageInfos:      file_federation_proto_msgTypes,
+	}.Build()
+	File_federation_proto = out.File
+	file_federation_proto_goTypes = nil
+	file_federation_proto_depIdxs = nil
+}
@@ -80,6 +80,7 @@ type RegisterNodeRequest struct {
 	NodeId          string                 `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
 	NodeName        string                 `protobuf:"bytes,3,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
 	NodeDescription string                 `protobuf:"bytes,4,opt,name=node_description,json=nodeDescription,proto3" json:"node_description,omitempty"`
+	JoinToken       string                 `protobuf:"bytes,5,opt,name=join_token,json=joinToken,proto3" json:"join_token,omitempty"` // 加入令牌（注册中心要求令牌时必需）
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -142,6 +143,13 @@ func (x *RegisterNodeRequest) GetNodeDescription() string {
 	return ""
 }
 
+func (x *RegisterNodeRequest) GetJoinToken() string {
+	if x != nil {
+		return x.JoinToken
+	}
+	return ""
+}
+
 type RegisterNodeResponse struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
 	DomainName        string                 `protobuf:"bytes,1,opt,name=domain_name,json=domainName,proto3" json:"domain_name,omitempty"`
@@ -197,9 +205,10 @@ func (x *RegisterNodeResponse) GetDomainDescription() string {
 // ResourceInfo 资源信息
 type ResourceInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Cpu           int64                  `protobuf:"varint,1,opt,name=cpu,proto3" json:"cpu,omitempty"`       // CPU millicores (毫核)
-	Memory        int64                  `protobuf:"varint,2,opt,name=memory,proto3" json:"memory,omitempty"` // Memory bytes (字节)
-	Gpu           int64                  `protobuf:"varint,3,opt,name=gpu,proto3" json:"gpu,omitempty"`       // GPU count (数量)
+	Cpu           int64                  `protobuf:"varint,1,opt,name=cpu,proto3" json:"cpu,omitempty"`                                                                                     // CPU millicores (毫核)
+	Memory        int64                  `protobuf:"varint,2,opt,name=memory,proto3" json:"memory,omitempty"`                                                                               // Memory bytes (字节)
+	Gpu           int64                  `protobuf:"varint,3,opt,name=gpu,proto3" json:"gpu,omitempty"`                                                                                     // GPU count (数量)
+	Extended      map[string]int64       `protobuf:"bytes,4,rep,name=extended,proto3" json:"extended,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // 扩展资源（如 gpu.memory、npu、disk、network.bandwidth、camera）
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -255,6 +264,13 @@ func (x *ResourceInfo) GetGpu() int64 {
 	return 0
 }
 
+func (x *ResourceInfo) GetExtended() map[string]int64 {
+	if x != nil {
+		return x.Extended
+	}
+	return nil
+}
+
 // ResourceCapacity 资源容量（总容量、已使用、可用）
 type ResourceCapacity struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -388,14 +404,16 @@ func (x *ResourceTags) GetCamera() bool {
 // HealthCheckRequest 健康检查请求
 type HealthCheckRequest struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
-	NodeId           string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`                               // 节点 ID
-	DomainId         string                 `protobuf:"bytes,2,opt,name=domain_id,json=domainId,proto3" json:"domain_id,omitempty"`                         // 域 ID
-	Status           NodeStatus             `protobuf:"varint,3,opt,name=status,proto3,enum=registry.NodeStatus" json:"status,omitempty"`                   // 节点状态
-	ResourceCapacity *ResourceCapacity      `protobuf:"bytes,4,opt,name=resource_capacity,json=resourceCapacity,proto3" json:"resource_capacity,omitempty"` // 资源容量信息
-	ResourceTags     *ResourceTags          `protobuf:"bytes,5,opt,name=resource_tags,json=resourceTags,proto3" json:"resource_tags,omitempty"`             // 资源标签
-	Address          string                 `protobuf:"bytes,6,opt,name=address,proto3" json:"address,omitempty"`                                           // 节点地址 (host:port)
-	Timestamp        int64                  `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                                      // 时间戳 (Unix nanoseconds)
-	IsHead           bool                   `protobuf:"varint,8,opt,name=is_head,json=isHead,proto3" json:"is_head,omitempty"`                              // 是否为 head 节点
+	NodeId           string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`                                                             // 节点 ID
+	DomainId         string                 `protobuf:"bytes,2,opt,name=domain_id,json=domainId,proto3" json:"domain_id,omitempty"`                                                       // 域 ID
+	Status           NodeStatus             `protobuf:"varint,3,opt,name=status,proto3,enum=registry.NodeStatus" json:"status,omitempty"`                                                 // 节点状态
+	ResourceCapacity *ResourceCapacity      `protobuf:"bytes,4,opt,name=resource_capacity,json=resourceCapacity,proto3" json:"resource_capacity,omitempty"`                               // 资源容量信息
+	ResourceTags     *ResourceTags          `protobuf:"bytes,5,opt,name=resource_tags,json=resourceTags,proto3" json:"resource_tags,omitempty"`                                           // 资源标签
+	Address          string                 `protobuf:"bytes,6,opt,name=address,proto3" json:"address,omitempty"`                                                                         // 节点地址 (host:port)
+	Timestamp        int64                  `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                                                                    // 时间戳 (Unix nanoseconds)
+	IsHead           bool                   `protobuf:"varint,8,opt,name=is_head,json=isHead,proto3" json:"is_head,omitempty"`                                                            // 是否为 head 节点
+	Labels           map[string]string      `protobuf:"bytes,9,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 节点键值标签（如 arch=arm64、region=east）
+	JoinToken        string                 `protobuf:"bytes,10,opt,name=join_token,json=joinToken,proto3" json:"join_token,omitempty"`                                                   // 加入令牌，未注册的节点通过心跳自动注册时校验
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
@@ -486,6 +504,20 @@ func (x *HealthCheckRequest) GetIsHead() bool {
 	return false
 }
 
+func (x *HealthCheckRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *HealthCheckRequest) GetJoinToken() string {
+	if x != nil {
+		return x.JoinToken
+	}
+	return ""
+}
+
 // HealthCheckResponse 健康检查响应
 type HealthCheckResponse struct {
 	state                      protoimpl.MessageState `protogen:"open.v1"`
@@ -567,20 +599,26 @@ var File_registry_registry_proto protoreflect.FileDescriptor
 
 const file_registry_registry_proto_rawDesc = "" +
 	"\n" +
-	"\x17registry/registry.proto\x12\bregistry\"\x93\x01\n" +
+	"\x17registry/registry.proto\x12\bregistry\"\xb2\x01\n" +
 	"\x13RegisterNodeRequest\x12\x1b\n" +
 	"\tdomain_id\x18\x01 \x01(\tR\bdomainId\x12\x17\n" +
 	"\anode_id\x18\x02 \x01(\tR\x06nodeId\x12\x1b\n" +
 	"\tnode_name\x18\x03 \x01(\tR\bnodeName\x12)\n" +
-	"\x10node_description\x18\x04 \x01(\tR\x0fnodeDescription\"f\n" +
+	"\x10node_description\x18\x04 \x01(\tR\x0fnodeDescription\x12\x1d\n" +
+	"\n" +
+	"join_token\x18\x05 \x01(\tR\tjoinToken\"f\n" +
 	"\x14RegisterNodeResponse\x12\x1f\n" +
 	"\vdomain_name\x18\x01 \x01(\tR\n" +
 	"domainName\x12-\n" +
-	"\x12domain_description\x18\x02 \x01(\tR\x11domainDescription\"J\n" +
+	"\x12domain_description\x18\x02 \x01(\tR\x11domainDescription\"\xc9\x01\n" +
 	"\fResourceInfo\x12\x10\n" +
 	"\x03cpu\x18\x01 \x01(\x03R\x03cpu\x12\x16\n" +
 	"\x06memory\x18\x02 \x01(\x03R\x06memory\x12\x10\n" +
-	"\x03gpu\x18\x03 \x01(\x03R\x03gpu\"\xa2\x01\n" +
+	"\x03gpu\x18\x03 \x01(\x03R\x03gpu\x12@\n" +
+	"\bextended\x18\x04 \x03(\v2$.registry.ResourceInfo.ExtendedEntryR\bextended\x1a;\n" +
+	"\rExtendedEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\xa2\x01\n" +
 	"\x10ResourceCapacity\x12,\n" +
 	"\x05total\x18\x01 \x01(\v2\x16.registry.ResourceInfoR\x05total\x12*\n" +
 	"\x04used\x18\x02 \x01(\v2\x16.registry.ResourceInfoR\x04used\x124\n" +
@@ -589,7 +627,7 @@ const file_registry_registry_proto_rawDesc = "" +
 	"\x03cpu\x18\x01 \x01(\bR\x03cpu\x12\x10\n" +
 	"\x03gpu\x18\x02 \x01(\bR\x03gpu\x12\x16\n" +
 	"\x06memory\x18\x03 \x01(\bR\x06memory\x12\x16\n" +
-	"\x06camera\x18\x04 \x01(\bR\x06camera\"\xcf\x02\n" +
+	"\x06camera\x18\x04 \x01(\bR\x06camera\"\xeb\x03\n" +
 	"\x12HealthCheckRequest\x12\x17\n" +
 	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12\x1b\n" +
 	"\tdomain_id\x18\x02 \x01(\tR\bdomainId\x12,\n" +
@@ -598,7 +636,14 @@ const file_registry_registry_proto_rawDesc = "" +
 	"\rresource_tags\x18\x05 \x01(\v2\x16.registry.ResourceTagsR\fresourceTags\x12\x18\n" +
 	"\aaddress\x18\x06 \x01(\tR\aaddress\x12\x1c\n" +
 	"\ttimestamp\x18\a \x01(\x03R\ttimestamp\x12\x17\n" +
-	"\ais_head\x18\b \x01(\bR\x06isHead\"\xec\x01\n" +
+	"\ais_head\x18\b \x01(\bR\x06isHead\x12@\n" +
+	"\x06labels\x18\t \x03(\v2(.registry.HealthCheckRequest.LabelsEntryR\x06labels\x12\x1d\n" +
+	"\n" +
+	"join_token\x18\n" +
+	" \x01(\tR\tjoinToken\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xec\x01\n" +
 	"\x13HealthCheckResponse\x12)\n" +
 	"\x10server_timestamp\x18\x01 \x01(\x03R\x0fserverTimestamp\x12@\n" +
 	"\x1crecommended_interval_seconds\x18\x02 \x01(\x05R\x1arecommendedIntervalSeconds\x12-\n" +
@@ -629,7 +674,7 @@ func file_registry_registry_proto_rawDescGZIP() []byte {
 }
 
 var file_registry_registry_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_registry_registry_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_registry_registry_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_registry_registry_proto_goTypes = []any{
 	(NodeStatus)(0),              // 0: registry.NodeStatus
 	(*RegisterNodeRequest)(nil),  // 1: registry.RegisterNodeRequest
@@ -639,23 +684,27 @@ var file_registry_registry_proto_goTypes = []any{
 	(*ResourceTags)(nil),         // 5: registry.ResourceTags
 	(*HealthCheckRequest)(nil),   // 6: registry.HealthCheckRequest
 	(*HealthCheckResponse)(nil),  // 7: registry.HealthCheckResponse
+	nil,                          // 8: registry.ResourceInfo.ExtendedEntry
+	nil,                          // 9: registry.HealthCheckRequest.LabelsEntry
 }
 var file_registry_registry_proto_depIdxs = []int32{
-	3, // 0: registry.ResourceCapacity.total:type_name -> registry.ResourceInfo
-	3, // 1: registry.ResourceCapacity.used:type_name -> registry.ResourceInfo
-	3, // 2: registry.ResourceCapacity.available:type_name -> registry.ResourceInfo
-	0, // 3: registry.HealthCheckRequest.status:type_name -> registry.NodeStatus
-	4, // 4: registry.HealthCheckRequest.resource_capacity:type_name -> registry.ResourceCapacity
-	5, // 5: registry.HealthCheckRequest.resource_tags:type_name -> registry.ResourceTags
-	1, // 6: registry.Service.RegisterNode:input_type -> registry.RegisterNodeRequest
-	6, // 7: registry.Service.HealthCheck:input_type -> registry.HealthCheckRequest
-	2, // 8: registry.Service.RegisterNode:output_type -> registry.RegisterNodeResponse
-	7, // 9: registry.Service.HealthCheck:output_type -> registry.HealthCheckResponse
-	8, // [8:10] is the sub-list for method output_type
-	6, // [6:8] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	8,  // 0: registry.ResourceInfo.extended:type_name -> registry.ResourceInfo.ExtendedEntry
+	3,  // 1: registry.ResourceCapacity.total:type_name -> registry.ResourceInfo
+	3,  // 2: registry.ResourceCapacity.used:type_name -> registry.ResourceInfo
+	3,  // 3: registry.ResourceCapacity.available:type_name -> registry.ResourceInfo
+	0,  // 4: registry.HealthCheckRequest.status:type_name -> registry.NodeStatus
+	4,  // 5: registry.HealthCheckRequest.resource_capacity:type_name -> registry.ResourceCapacity
+	5,  // 6: registry.HealthCheckRequest.resource_tags:type_name -> registry.ResourceTags
+	9,  // 7: registry.HealthCheckRequest.labels:type_name -> registry.HealthCheckRequest.LabelsEntry
+	1,  // 8: registry.Service.RegisterNode:input_type -> registry.RegisterNodeRequest
+	6,  // 9: registry.Service.HealthCheck:input_type -> registry.HealthCheckRequest
+	2,  // 10: registry.Service.RegisterNode:output_type -> registry.RegisterNodeResponse
+	7,  // 11: registry.Service.HealthCheck:output_type -> registry.HealthCheckResponse
+	10, // [10:12] is the sub-list for method output_type
+	8,  // [8:10] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_registry_registry_proto_init() }
@@ -669,7 +718,7 @@ func file_registry_registry_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_registry_registry_proto_rawDesc), len(file_registry_registry_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   7,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -22,11 +22,17 @@ const (
 )
 
 type Info struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Cpu           int64                  `protobuf:"varint,1,opt,name=cpu,proto3" json:"cpu,omitempty"`
-	Memory        int64                  `protobuf:"varint,2,opt,name=memory,proto3" json:"memory,omitempty"`
-	Gpu           int64                  `protobuf:"varint,3,opt,name=gpu,proto3" json:"gpu,omitempty"`
-	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Cpu    int64                  `protobuf:"varint,1,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	Memory int64                  `protobuf:"varint,2,opt,name=memory,proto3" json:"memory,omitempty"`
+	Gpu    int64                  `protobuf:"varint,3,opt,name=gpu,proto3" json:"gpu,omitempty"`
+	// 标签选择器，所有条目需同时满足，每个条目可包含以逗号分隔的多个条件：
+	//   key / !key            标签存在 / 不存在（cpu、gpu、memory、camera 兼容旧版资源标签）
+	//   key=value / key!=value 等于 / 不等于
+	//   key in (a,b) / key notin (a,b) 属于 / 不属于集合
+	Tags []string `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	// 扩展资源（名称 -> 数量），如 gpu.memory、npu、disk、network.bandwidth、camera
+	Extended      map[string]int64 `protobuf:"bytes,5,rep,name=extended,proto3" json:"extended,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -89,6 +95,13 @@ func (x *Info) GetTags() []string {
 	return nil
 }
 
+func (x *Info) GetExtended() map[string]int64 {
+	if x != nil {
+		return x.Extended
+	}
+	return nil
+}
+
 type Capacity struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Total         *Info                  `protobuf:"bytes,1,opt,name=total,proto3" json:"total,omitempty"`
@@ -153,12 +166,16 @@ var File_resource_proto protoreflect.FileDescriptor
 
 const file_resource_proto_rawDesc = "" +
 	"\n" +
-	"\x0eresource.proto\x12\bresource\"V\n" +
+	"\x0eresource.proto\x12\bresource\"\xcd\x01\n" +
 	"\x04Info\x12\x10\n" +
 	"\x03cpu\x18\x01 \x01(\x03R\x03cpu\x12\x16\n" +
 	"\x06memory\x18\x02 \x01(\x03R\x06memory\x12\x10\n" +
 	"\x03gpu\x18\x03 \x01(\x03R\x03gpu\x12\x12\n" +
-	"\x04tags\x18\x04 \x03(\tR\x04tags\"\x82\x01\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x128\n" +
+	"\bextended\x18\x05 \x03(\v2\x1c.resource.Info.ExtendedEntryR\bextended\x1a;\n" +
+	"\rExtendedEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\x82\x01\n" +
 	"\bCapacity\x12$\n" +
 	"\x05total\x18\x01 \x01(\v2\x0e.resource.InfoR\x05total\x12\"\n" +
 	"\x04used\x18\x02 \x01(\v2\x0e.resource.InfoR\x04used\x12,\n" +
@@ -176,20 +193,22 @@ func file_resource_proto_rawDescGZIP() []byte {
 	return file_resource_proto_rawDescData
 }
 
-var file_resource_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_resource_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_resource_proto_goTypes = []any{
 	(*Info)(nil),     // 0: resource.Info
 	(*Capacity)(nil), // 1: resource.Capacity
+	nil,              // 2: resource.Info.ExtendedEntry
 }
 var file_resource_proto_depIdxs = []int32{
-	0, // 0: resource.Capacity.total:type_name -> resource.Info
-	0, // 1: resource.Capacity.used:type_name -> resource.Info
-	0, // 2: resource.Capacity.available:type_name -> resource.Info
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	2, // 0: resource.Info.extended:type_name -> resource.Info.ExtendedEntry
+	0, // 1: resource.Capacity.total:type_name -> resource.Info
+	0, // 2: resource.Capacity.used:type_name -> resource.Info
+	0, // 3: resource.Capacity.available:type_name -> resource.Info
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_resource_proto_init() }
@@ -203,7 +222,7 @@ func file_resource_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_resource_proto_rawDesc), len(file_resource_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -22,6 +22,55 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// AffinityTopology 亲和规则的拓扑范围
+type AffinityTopology int32
+
+const (
+	// 同一节点
+	AffinityTopology_AFFINITY_TOPOLOGY_NODE AffinityTopology = 0
+	// 同一域
+	AffinityTopology_AFFINITY_TOPOLOGY_DOMAIN AffinityTopology = 1
+)
+
+// Enum value maps for AffinityTopology.
+var (
+	AffinityTopology_name = map[int32]string{
+		0: "AFFINITY_TOPOLOGY_NODE",
+		1: "AFFINITY_TOPOLOGY_DOMAIN",
+	}
+	AffinityTopology_value = map[string]int32{
+		"AFFINITY_TOPOLOGY_NODE":   0,
+		"AFFINITY_TOPOLOGY_DOMAIN": 1,
+	}
+)
+
+func (x AffinityTopology) Enum() *AffinityTopology {
+	p := new(AffinityTopology)
+	*p = x
+	return p
+}
+
+func (x AffinityTopology) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AffinityTopology) Descriptor() protoreflect.EnumDescriptor {
+	return file_scheduler_proto_enumTypes[0].Descriptor()
+}
+
+func (AffinityTopology) Type() protoreflect.EnumType {
+	return &file_scheduler_proto_enumTypes[0]
+}
+
+func (x AffinityTopology) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AffinityTopology.Descriptor instead.
+func (AffinityTopology) EnumDescriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{0}
+}
+
 // ComponentStatus Component 状态
 type ComponentStatus int32
 
@@ -31,6 +80,8 @@ const (
 	ComponentStatus_COMPONENT_STATUS_RUNNING   ComponentStatus = 2
 	ComponentStatus_COMPONENT_STATUS_STOPPED   ComponentStatus = 3
 	ComponentStatus_COMPONENT_STATUS_ERROR     ComponentStatus = 4
+	ComponentStatus_COMPONENT_STATUS_PENDING   ComponentStatus = 5
+	ComponentStatus_COMPONENT_STATUS_LOST      ComponentStatus = 6
 )
 
 // Enum value maps for ComponentStatus.
@@ -41,6 +92,8 @@ var (
 		2: "COMPONENT_STATUS_RUNNING",
 		3: "COMPONENT_STATUS_STOPPED",
 		4: "COMPONENT_STATUS_ERROR",
+		5: "COMPONENT_STATUS_PENDING",
+		6: "COMPONENT_STATUS_LOST",
 	}
 	ComponentStatus_value = map[string]int32{
 		"COMPONENT_STATUS_UNKNOWN":   0,
@@ -48,6 +101,8 @@ var (
 		"COMPONENT_STATUS_RUNNING":   2,
 		"COMPONENT_STATUS_STOPPED":   3,
 		"COMPONENT_STATUS_ERROR":     4,
+		"COMPONENT_STATUS_PENDING":   5,
+		"COMPONENT_STATUS_LOST":      6,
 	}
 )
 
@@ -62,11 +117,11 @@ func (x ComponentStatus) String() string {
 }
 
 func (ComponentStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_scheduler_proto_enumTypes[0].Descriptor()
+	return file_scheduler_proto_enumTypes[1].Descriptor()
 }
 
 func (ComponentStatus) Type() protoreflect.EnumType {
-	return &file_scheduler_proto_enumTypes[0]
+	return &file_scheduler_proto_enumTypes[1]
 }
 
 func (x ComponentStatus) Number() protoreflect.EnumNumber {
@@ -75,7 +130,7 @@ func (x ComponentStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ComponentStatus.Descriptor instead.
 func (ComponentStatus) EnumDescriptor() ([]byte, []int) {
-	return file_scheduler_proto_rawDescGZIP(), []int{0}
+	return file_scheduler_proto_rawDescGZIP(), []int{1}
 }
 
 // DeployComponentRequest 部署 component 请求
@@ -94,8 +149,20 @@ type DeployComponentRequest struct {
 	UpstreamZmqAddress    string `protobuf:"bytes,5,opt,name=upstream_zmq_address,json=upstreamZmqAddress,proto3" json:"upstream_zmq_address,omitempty"`
 	UpstreamStoreAddress  string `protobuf:"bytes,6,opt,name=upstream_store_address,json=upstreamStoreAddress,proto3" json:"upstream_store_address,omitempty"`
 	UpstreamLoggerAddress string `protobuf:"bytes,7,opt,name=upstream_logger_address,json=upstreamLoggerAddress,proto3" json:"upstream_logger_address,omitempty"`
-	unknownFields         protoimpl.UnknownFields
-	sizeCache             protoimpl.SizeCache
+	// 放置约束（由全局调度器解析，节点忽略）
+	Placement *PlacementPolicy `protobuf:"bytes,8,opt,name=placement,proto3" json:"placement,omitempty"`
+	// component 标签，供其他部署的亲和/反亲和规则匹配
+	Labels map[string]string `protobuf:"bytes,9,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// 排队优先级，数值越大越优先
+	Priority int32 `protobuf:"varint,10,opt,name=priority,proto3" json:"priority,omitempty"`
+	// 暂无可用容量时在全局调度器排队等待的最长时间（秒），为 0 表示不排队、立即失败
+	MaxWaitSeconds int64 `protobuf:"varint,11,opt,name=max_wait_seconds,json=maxWaitSeconds,proto3" json:"max_wait_seconds,omitempty"`
+	// 所在节点离线或被清理后，是否由全局调度器按原始请求自动重新调度
+	Restartable bool `protobuf:"varint,12,opt,name=restartable,proto3" json:"restartable,omitempty"`
+	// 自动重启的最大次数，为 0 时使用全局调度器的默认值
+	MaxRestarts   int32 `protobuf:"varint,13,opt,name=max_restarts,json=maxRestarts,proto3" json:"max_restarts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DeployComponentRequest) Reset() {
@@ -177,39 +244,78 @@ func (x *DeployComponentRequest) GetUpstreamLoggerAddress() string {
 	return ""
 }
 
-// DeployComponentResponse 部署 component 响应
-type DeployComponentResponse struct {
+func (x *DeployComponentRequest) GetPlacement() *PlacementPolicy {
+	if x != nil {
+		return x.Placement
+	}
+	return nil
+}
+
+func (x *DeployComponentRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *DeployComponentRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *DeployComponentRequest) GetMaxWaitSeconds() int64 {
+	if x != nil {
+		return x.MaxWaitSeconds
+	}
+	return 0
+}
+
+func (x *DeployComponentRequest) GetRestartable() bool {
+	if x != nil {
+		return x.Restartable
+	}
+	return false
+}
+
+func (x *DeployComponentRequest) GetMaxRestarts() int32 {
+	if x != nil {
+		return x.MaxRestarts
+	}
+	return 0
+}
+
+// PlacementPolicy 放置约束
+// required 规则必须满足，否则节点不参与调度；preferred 规则按 weight 累加评分，优先选择得分最高的节点
+type PlacementPolicy struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// 是否成功
-	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	// 错误信息（如果失败）
-	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	// Component 信息
-	Component *ComponentInfo `protobuf:"bytes,3,opt,name=component,proto3" json:"component,omitempty"`
-	// 部署的节点 ID
-	NodeId string `protobuf:"bytes,4,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
-	// 部署的节点名称
-	NodeName string `protobuf:"bytes,5,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
-	// Provider ID（实际部署的 provider）
-	ProviderId    string `protobuf:"bytes,6,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	// 节点/域标签亲和与反亲和
+	LabelAffinity []*LabelAffinityTerm `protobuf:"bytes,1,rep,name=label_affinity,json=labelAffinity,proto3" json:"label_affinity,omitempty"`
+	// 与已部署 component 的亲和与反亲和（仅匹配同一租户的部署）
+	DeploymentAffinity []*DeploymentAffinityTerm `protobuf:"bytes,2,rep,name=deployment_affinity,json=deploymentAffinity,proto3" json:"deployment_affinity,omitempty"`
+	// 放置范围：域 ID 或域名称，节点所属的域必须是其中某个域本身或其下级域
+	Domains []string `protobuf:"bytes,3,rep,name=domains,proto3" json:"domains,omitempty"`
+	// 按层级分散部署（仅统计同一租户的部署）
+	Spread        []*SpreadTerm `protobuf:"bytes,4,rep,name=spread,proto3" json:"spread,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeployComponentResponse) Reset() {
-	*x = DeployComponentResponse{}
+func (x *PlacementPolicy) Reset() {
+	*x = PlacementPolicy{}
 	mi := &file_scheduler_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeployComponentResponse) String() string {
+func (x *PlacementPolicy) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeployComponentResponse) ProtoMessage() {}
+func (*PlacementPolicy) ProtoMessage() {}
 
-func (x *DeployComponentResponse) ProtoReflect() protoreflect.Message {
+func (x *PlacementPolicy) ProtoReflect() protoreflect.Message {
 	mi := &file_scheduler_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -221,82 +327,71 @@ func (x *DeployComponentResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeployComponentResponse.ProtoReflect.Descriptor instead.
-func (*DeployComponentResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use PlacementPolicy.ProtoReflect.Descriptor instead.
+func (*PlacementPolicy) Descriptor() ([]byte, []int) {
 	return file_scheduler_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *DeployComponentResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
-}
-
-func (x *DeployComponentResponse) GetError() string {
-	if x != nil {
-		return x.Error
-	}
-	return ""
-}
-
-func (x *DeployComponentResponse) GetComponent() *ComponentInfo {
+func (x *PlacementPolicy) GetLabelAffinity() []*LabelAffinityTerm {
 	if x != nil {
-		return x.Component
+		return x.LabelAffinity
 	}
 	return nil
 }
 
-func (x *DeployComponentResponse) GetNodeId() string {
+func (x *PlacementPolicy) GetDeploymentAffinity() []*DeploymentAffinityTerm {
 	if x != nil {
-		return x.NodeId
+		return x.DeploymentAffinity
 	}
-	return ""
+	return nil
 }
 
-func (x *DeployComponentResponse) GetNodeName() string {
+func (x *PlacementPolicy) GetDomains() []string {
 	if x != nil {
-		return x.NodeName
+		return x.Domains
 	}
-	return ""
+	return nil
 }
 
-func (x *DeployComponentResponse) GetProviderId() string {
+func (x *PlacementPolicy) GetSpread() []*SpreadTerm {
 	if x != nil {
-		return x.ProviderId
+		return x.Spread
 	}
-	return ""
+	return nil
 }
 
-// ComponentInfo Component 信息
-type ComponentInfo struct {
+// SpreadTerm 按层级分散部署的规则
+// 各层级单元（节点、域或某一类型的上级域）中匹配部署数量的最大差值不超过 max_skew
+type SpreadTerm struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Component ID
-	ComponentId string `protobuf:"bytes,1,opt,name=component_id,json=componentId,proto3" json:"component_id,omitempty"`
-	// 镜像名称
-	Image string `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
-	// 资源使用情况
-	ResourceUsage *resource.Info `protobuf:"bytes,3,opt,name=resource_usage,json=resourceUsage,proto3" json:"resource_usage,omitempty"`
-	// Provider ID
-	ProviderId    string `protobuf:"bytes,4,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	// 按 component 标签匹配参与统计的部署，语法同 LabelAffinityTerm.selector；为空时匹配与本次请求标签相同的部署
+	Selector []string `protobuf:"bytes,1,rep,name=selector,proto3" json:"selector,omitempty"`
+	// 分散的层级："node"、"domain"，或域的层级类型（如 "site"、"region"）
+	Level string `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+	// 允许的最大差值，为 0 时按 1 计算
+	MaxSkew int32 `protobuf:"varint,3,opt,name=max_skew,json=maxSkew,proto3" json:"max_skew,omitempty"`
+	// true 表示硬约束，false 表示软约束（优先选择部署数量最少的单元）
+	Required bool `protobuf:"varint,4,opt,name=required,proto3" json:"required,omitempty"`
+	// 软约束权重（1-100，为 0 时按 1 计算）
+	Weight        int32 `protobuf:"varint,5,opt,name=weight,proto3" json:"weight,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ComponentInfo) Reset() {
-	*x = ComponentInfo{}
+func (x *SpreadTerm) Reset() {
+	*x = SpreadTerm{}
 	mi := &file_scheduler_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ComponentInfo) String() string {
+func (x *SpreadTerm) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ComponentInfo) ProtoMessage() {}
+func (*SpreadTerm) ProtoMessage() {}
 
-func (x *ComponentInfo) ProtoReflect() protoreflect.Message {
+func (x *SpreadTerm) ProtoReflect() protoreflect.Message {
 	mi := &file_scheduler_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -308,64 +403,75 @@ func (x *ComponentInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ComponentInfo.ProtoReflect.Descriptor instead.
-func (*ComponentInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use SpreadTerm.ProtoReflect.Descriptor instead.
+func (*SpreadTerm) Descriptor() ([]byte, []int) {
 	return file_scheduler_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *ComponentInfo) GetComponentId() string {
+func (x *SpreadTerm) GetSelector() []string {
 	if x != nil {
-		return x.ComponentId
+		return x.Selector
 	}
-	return ""
+	return nil
 }
 
-func (x *ComponentInfo) GetImage() string {
+func (x *SpreadTerm) GetLevel() string {
 	if x != nil {
-		return x.Image
+		return x.Level
 	}
 	return ""
 }
 
-func (x *ComponentInfo) GetResourceUsage() *resource.Info {
+func (x *SpreadTerm) GetMaxSkew() int32 {
 	if x != nil {
-		return x.ResourceUsage
+		return x.MaxSkew
 	}
-	return nil
+	return 0
 }
 
-func (x *ComponentInfo) GetProviderId() string {
+func (x *SpreadTerm) GetRequired() bool {
 	if x != nil {
-		return x.ProviderId
+		return x.Required
 	}
-	return ""
+	return false
 }
 
-// GetDeploymentStatusRequest 获取部署状态请求
-type GetDeploymentStatusRequest struct {
+func (x *SpreadTerm) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+// LabelAffinityTerm 节点/域标签亲和规则
+type LabelAffinityTerm struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Component ID
-	ComponentId string `protobuf:"bytes,1,opt,name=component_id,json=componentId,proto3" json:"component_id,omitempty"`
-	// 节点 ID（可选，如果指定则从该节点查询）
-	NodeId        string `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// 标签选择器，语法同 resource.Info.tags（如 "zone=east"、"gpu.model in (a100,h100)"）
+	Selector []string `protobuf:"bytes,1,rep,name=selector,proto3" json:"selector,omitempty"`
+	// true 表示反亲和：避开匹配的节点
+	Anti bool `protobuf:"varint,2,opt,name=anti,proto3" json:"anti,omitempty"`
+	// true 表示硬约束，false 表示软约束
+	Required bool `protobuf:"varint,3,opt,name=required,proto3" json:"required,omitempty"`
+	// 软约束权重（1-100，为 0 时按 1 计算）
+	Weight        int32 `protobuf:"varint,4,opt,name=weight,proto3" json:"weight,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDeploymentStatusRequest) Reset() {
-	*x = GetDeploymentStatusRequest{}
+func (x *LabelAffinityTerm) Reset() {
+	*x = LabelAffinityTerm{}
 	mi := &file_scheduler_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDeploymentStatusRequest) String() string {
+func (x *LabelAffinityTerm) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDeploymentStatusRequest) ProtoMessage() {}
+func (*LabelAffinityTerm) ProtoMessage() {}
 
-func (x *GetDeploymentStatusRequest) ProtoReflect() protoreflect.Message {
+func (x *LabelAffinityTerm) ProtoReflect() protoreflect.Message {
 	mi := &file_scheduler_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -377,54 +483,72 @@ func (x *GetDeploymentStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDeploymentStatusRequest.ProtoReflect.Descriptor instead.
-func (*GetDeploymentStatusRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use LabelAffinityTerm.ProtoReflect.Descriptor instead.
+func (*LabelAffinityTerm) Descriptor() ([]byte, []int) {
 	return file_scheduler_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetDeploymentStatusRequest) GetComponentId() string {
+func (x *LabelAffinityTerm) GetSelector() []string {
 	if x != nil {
-		return x.ComponentId
+		return x.Selector
 	}
-	return ""
+	return nil
 }
 
-func (x *GetDeploymentStatusRequest) GetNodeId() string {
+func (x *LabelAffinityTerm) GetAnti() bool {
 	if x != nil {
-		return x.NodeId
+		return x.Anti
 	}
-	return ""
+	return false
 }
 
-// GetDeploymentStatusResponse 获取部署状态响应
-type GetDeploymentStatusResponse struct {
+func (x *LabelAffinityTerm) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *LabelAffinityTerm) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+// DeploymentAffinityTerm 与已部署 component 的亲和规则
+type DeploymentAffinityTerm struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// 是否成功
-	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	// 错误信息（如果失败）
-	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	// Component 状态
-	Status ComponentStatus `protobuf:"varint,3,opt,name=status,proto3,enum=scheduler.ComponentStatus" json:"status,omitempty"`
-	// Component 信息
-	Component     *ComponentInfo `protobuf:"bytes,4,opt,name=component,proto3" json:"component,omitempty"`
+	// 按 component ID 匹配已部署的 component
+	ComponentIds []string `protobuf:"bytes,1,rep,name=component_ids,json=componentIds,proto3" json:"component_ids,omitempty"`
+	// 按 component 标签匹配已部署的 component，语法同 LabelAffinityTerm.selector
+	Selector []string `protobuf:"bytes,2,rep,name=selector,proto3" json:"selector,omitempty"`
+	// 拓扑范围：与匹配的 component 位于（或不位于）同一节点/同一域
+	Topology AffinityTopology `protobuf:"varint,3,opt,name=topology,proto3,enum=scheduler.AffinityTopology" json:"topology,omitempty"`
+	// true 表示反亲和：与匹配的 component 分散部署
+	Anti bool `protobuf:"varint,4,opt,name=anti,proto3" json:"anti,omitempty"`
+	// true 表示硬约束，false 表示软约束
+	Required bool `protobuf:"varint,5,opt,name=required,proto3" json:"required,omitempty"`
+	// 软约束权重（1-100，为 0 时按 1 计算）
+	Weight        int32 `protobuf:"varint,6,opt,name=weight,proto3" json:"weight,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDeploymentStatusResponse) Reset() {
-	*x = GetDeploymentStatusResponse{}
+func (x *DeploymentAffinityTerm) Reset() {
+	*x = DeploymentAffinityTerm{}
 	mi := &file_scheduler_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDeploymentStatusResponse) String() string {
+func (x *DeploymentAffinityTerm) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDeploymentStatusResponse) ProtoMessage() {}
+func (*DeploymentAffinityTerm) ProtoMessage() {}
 
-func (x *GetDeploymentStatusResponse) ProtoReflect() protoreflect.Message {
+func (x *DeploymentAffinityTerm) ProtoReflect() protoreflect.Message {
 	mi := &file_scheduler_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -436,44 +560,1378 @@ func (x *GetDeploymentStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDeploymentStatusResponse.ProtoReflect.Descriptor instead.
-func (*GetDeploymentStatusResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeploymentAffinityTerm.ProtoReflect.Descriptor instead.
+func (*DeploymentAffinityTerm) Descriptor() ([]byte, []int) {
 	return file_scheduler_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetDeploymentStatusResponse) GetSuccess() bool {
+func (x *DeploymentAffinityTerm) GetComponentIds() []string {
 	if x != nil {
-		return x.Success
+		return x.ComponentIds
+	}
+	return nil
+}
+
+func (x *DeploymentAffinityTerm) GetSelector() []string {
+	if x != nil {
+		return x.Selector
+	}
+	return nil
+}
+
+func (x *DeploymentAffinityTerm) GetTopology() AffinityTopology {
+	if x != nil {
+		return x.Topology
+	}
+	return AffinityTopology_AFFINITY_TOPOLOGY_NODE
+}
+
+func (x *DeploymentAffinityTerm) GetAnti() bool {
+	if x != nil {
+		return x.Anti
 	}
 	return false
 }
 
-func (x *GetDeploymentStatusResponse) GetError() string {
+func (x *DeploymentAffinityTerm) GetRequired() bool {
 	if x != nil {
-		return x.Error
+		return x.Required
 	}
-	return ""
+	return false
 }
 
-func (x *GetDeploymentStatusResponse) GetStatus() ComponentStatus {
+func (x *DeploymentAffinityTerm) GetWeight() int32 {
 	if x != nil {
-		return x.Status
+		return x.Weight
 	}
-	return ComponentStatus_COMPONENT_STATUS_UNKNOWN
+	return 0
 }
 
-func (x *GetDeploymentStatusResponse) GetComponent() *ComponentInfo {
+// DeployComponentResponse 部署 component 响应
+type DeployComponentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 是否成功
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// 错误信息（如果失败）
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// Component 信息
+	Component *ComponentInfo `protobuf:"bytes,3,opt,name=component,proto3" json:"component,omitempty"`
+	// 部署的节点 ID
+	NodeId string `protobuf:"bytes,4,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// 部署的节点名称
+	NodeName string `protobuf:"bytes,5,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
+	// Provider ID（实际部署的 provider）
+	ProviderId string `protobuf:"bytes,6,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	// 全局调度器分配的部署 ID，可用于查询部署状态
+	DeploymentId string `protobuf:"bytes,7,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	// 部署状态（排队时为 COMPONENT_STATUS_PENDING）
+	Status ComponentStatus `protobuf:"varint,8,opt,name=status,proto3,enum=scheduler.ComponentStatus" json:"status,omitempty"`
+	// 排队位置（从 1 开始，仅排队时有效）
+	QueuePosition int32 `protobuf:"varint,9,opt,name=queue_position,json=queuePosition,proto3" json:"queue_position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeployComponentResponse) Reset() {
+	*x = DeployComponentResponse{}
+	mi := &file_scheduler_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeployComponentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeployComponentResponse) ProtoMessage() {}
+
+func (x *DeployComponentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_proto_msgTypes[5]
 	if x != nil {
-		return x.Component
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-var File_scheduler_proto protoreflect.FileDescriptor
+// Deprecated: Use DeployComponentResponse.ProtoReflect.Descriptor instead.
+func (*DeployComponentResponse) Descriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{5}
+}
 
-const file_scheduler_proto_rawDesc = "" +
+func (x *DeployComponentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeployComponentResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DeployComponentResponse) GetComponent() *ComponentInfo {
+	if x != nil {
+		return x.Component
+	}
+	return nil
+}
+
+func (x *DeployComponentResponse) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *DeployComponentResponse) GetNodeName() string {
+	if x != nil {
+		return x.NodeName
+	}
+	return ""
+}
+
+func (x *DeployComponentResponse) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+func (x *DeployComponentResponse) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *DeployComponentResponse) GetStatus() ComponentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ComponentStatus_COMPONENT_STATUS_UNKNOWN
+}
+
+func (x *DeployComponentResponse) GetQueuePosition() int32 {
+	if x != nil {
+		return x.QueuePosition
+	}
+	return 0
+}
+
+// RemoveComponentRequest 移除 component 请求
+type RemoveComponentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Component ID
+	ComponentId string `protobuf:"bytes,1,opt,name=component_id,json=componentId,proto3" json:"component_id,omitempty"`
+	// 全局调度器分配的部署 ID（优先于 component_id）
+	DeploymentId  string `protobuf:"bytes,2,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveComponentRequest) Reset() {
+	*x = RemoveComponentRequest{}
+	mi := &file_scheduler_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveComponentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveComponentRequest) ProtoMessage() {}
+
+func (x *RemoveComponentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveComponentRequest.ProtoReflect.Descriptor instead.
+func (*RemoveComponentRequest) Descriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RemoveComponentRequest) GetComponentId() string {
+	if x != nil {
+		return x.ComponentId
+	}
+	return ""
+}
+
+func (x *RemoveComponentRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+// RemoveComponentResponse 移除 component 响应
+type RemoveComponentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 是否成功
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// 错误信息（如果失败）
+	Error         string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveComponentResponse) Reset() {
+	*x = RemoveComponentResponse{}
+	mi := &file_scheduler_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveComponentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveComponentResponse) ProtoMessage() {}
+
+func (x *RemoveComponentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveComponentResponse.ProtoReflect.Descriptor instead.
+func (*RemoveComponentResponse) Descriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RemoveComponentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RemoveComponentResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// MigrateComponentRequest 迁移 component 请求
+type MigrateComponentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 全局调度器分配的部署 ID（优先于 component_id）
+	DeploymentId string `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	// Component ID
+	ComponentId string `protobuf:"bytes,2,opt,name=component_id,json=componentId,proto3" json:"component_id,omitempty"`
+	// 目标节点 ID（可选）
+	TargetNodeId string `protobuf:"bytes,3,opt,name=target_node_id,json=targetNodeId,proto3" json:"target_node_id,omitempty"`
+	// 目标域 ID（可选，未指定目标节点时在该域内选择节点）
+	TargetDomainId string `protobuf:"bytes,4,opt,name=target_domain_id,json=targetDomainId,proto3" json:"target_domain_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MigrateComponentRequest) Reset() {
+	*x = MigrateComponentRequest{}
+	mi := &file_scheduler_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MigrateComponentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MigrateComponentRequest) ProtoMessage() {}
+
+func (x *MigrateComponentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MigrateComponentRequest.ProtoReflect.Descriptor instead.
+func (*MigrateComponentRequest) Descriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MigrateComponentRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *MigrateComponentRequest) GetComponentId() string {
+	if x != nil {
+		return x.ComponentId
+	}
+	return ""
+}
+
+func (x *MigrateComponentRequest) GetTargetNodeId() string {
+	if x != nil {
+		return x.TargetNodeId
+	}
+	return ""
+}
+
+func (x *MigrateComponentRequest) GetTargetDomainId() string {
+	if x != nil {
+		return x.TargetDomainId
+	}
+	return ""
+}
+
+// MigrateComponentResponse 迁移 component 响应
+type MigrateComponentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 是否成功
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// 错误信息（如果失败；新实例已部署但旧实例移除失败时 success 仍为 true）
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// 新实例的部署 ID
+	DeploymentId string `protobuf:"bytes,3,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	// 新实例的 Component 信息
+	Component *ComponentInfo `protobuf:"bytes,4,opt,name=component,proto3" json:"component,omitempty"`
+	// 新实例所在的节点
+	NodeId   string `protobuf:"bytes,5,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	NodeName string `protobuf:"bytes,6,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
+	// 旧实例的部署 ID
+	PreviousDeploymentId string `protobuf:"bytes,7,opt,name=previous_deployment_id,json=previousDeploymentId,proto3" json:"previous_deployment_id,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *MigrateComponentResponse) Reset() {
+	*x = MigrateComponentResponse{}
+	mi := &file_scheduler_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MigrateComponentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MigrateComponentResponse) ProtoMessage() {}
+
+func (x *MigrateComponentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MigrateComponentResponse.ProtoReflect.Descriptor instead.
+func (*MigrateComponentResponse) Descriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MigrateComponentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *MigrateComponentResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *MigrateComponentResponse) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *MigrateComponentResponse) GetComponent() *ComponentInfo {
+	if x != nil {
+		return x.Component
+	}
+	return nil
+}
+
+func (x *MigrateComponentResponse) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *MigrateComponentResponse) GetNodeName() string {
+	if x != nil {
+		return x.NodeName
+	}
+	return ""
+}
+
+func (x *MigrateComponentResponse) GetPreviousDeploymentId() string {
+	if x != nil {
+		return x.PreviousDeploymentId
+	}
+	return ""
+}
+
+// DeployComponentGroupRequest 成组部署请求
+type DeployComponentGroupRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 组 ID（可选，为空时由全局调度器生成）
+	GroupId string `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	// 组成员，按顺序放置；成员的亲和规则可以引用排在前面的成员的标签
+	// 成组部署不排队、不抢占，成员的 priority / max_wait_seconds 被忽略
+	Members       []*DeployComponentRequest `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeployComponentGroupRequest) Reset() {
+	*x = DeployComponentGroupRequest{}
+	mi := &file_scheduler_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeployComponentGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeployComponentGroupRequest) ProtoMessage() {}
+
+func (x *DeployComponentGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeployComponentGroupRequest.ProtoReflect.Descriptor instead.
+func (*DeployComponentGroupRequest) Descriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeployComponentGroupRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *DeployComponentGroupRequest) GetMembers() []*DeployComponentRequest {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+// DeployComponentGroupResponse 成组部署响应
+type DeployComponentGroupResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 是否所有成员都部署成功
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// 错误信息（如果失败）
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// 组 ID
+	GroupId string `protobuf:"bytes,3,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	// 各成员的部署结果，与请求中的成员一一对应
+	Members       []*DeployComponentResponse `protobuf:"bytes,4,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeployComponentGroupResponse) Reset() {
+	*x = DeployComponentGroupResponse{}
+	mi := &file_scheduler_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeployComponentGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeployComponentGroupResponse) ProtoMessage() {}
+
+func (x *DeployComponentGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeployComponentGroupResponse.ProtoReflect.Descriptor instead.
+func (*DeployComponentGroupResponse) Descriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeployComponentGroupResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeployComponentGroupResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DeployComponentGroupResponse) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *DeployComponentGroupResponse) GetMembers() []*DeployComponentResponse {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+// ComponentInfo Component 信息
+type ComponentInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Component ID
+	ComponentId string `protobuf:"bytes,1,opt,name=component_id,json=componentId,proto3" json:"component_id,omitempty"`
+	// 镜像名称
+	Image string `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	// 资源使用情况
+	ResourceUsage *resource.Info `protobuf:"bytes,3,opt,name=resource_usage,json=resourceUsage,proto3" json:"resource_usage,omitempty"`
+	// Provider ID
+	ProviderId    string `protobuf:"bytes,4,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComponentInfo) Reset() {
+	*x = ComponentInfo{}
+	mi := &file_scheduler_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComponentInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComponentInfo) ProtoMessage() {}
+
+func (x *ComponentInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComponentInfo.ProtoReflect.Descriptor instead.
+func (*ComponentInfo) Descriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ComponentInfo) GetComponentId() string {
+	if x != nil {
+		return x.ComponentId
+	}
+	return ""
+}
+
+func (x *ComponentInfo) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *ComponentInfo) GetResourceUsage() *resource.Info {
+	if x != nil {
+		return x.ResourceUsage
+	}
+	return nil
+}
+
+func (x *ComponentInfo) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+// GetDeploymentStatusRequest 获取部署状态请求
+type GetDeploymentStatusRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Component ID
+	ComponentId string `protobuf:"bytes,1,opt,name=component_id,json=componentId,proto3" json:"component_id,omitempty"`
+	// 节点 ID（可选，如果指定则从该节点查询）
+	NodeId string `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// 全局调度器分配的部署 ID（优先于 component_id）
+	DeploymentId  string `protobuf:"bytes,3,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeploymentStatusRequest) Reset() {
+	*x = GetDeploymentStatusRequest{}
+	mi := &file_scheduler_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeploymentStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeploymentStatusRequest) ProtoMessage() {}
+
+func (x *GetDeploymentStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeploymentStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetDeploymentStatusRequest) Descriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetDeploymentStatusRequest) GetComponentId() string {
+	if x != nil {
+		return x.ComponentId
+	}
+	return ""
+}
+
+func (x *GetDeploymentStatusRequest) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *GetDeploymentStatusRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+// GetDeploymentStatusResponse 获取部署状态响应
+type GetDeploymentStatusResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 是否成功
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// 错误信息（如果失败）
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// Component 状态
+	Status ComponentStatus `protobuf:"varint,3,opt,name=status,proto3,enum=scheduler.ComponentStatus" json:"status,omitempty"`
+	// Component 信息
+	Component *ComponentInfo `protobuf:"bytes,4,opt,name=component,proto3" json:"component,omitempty"`
+	// 全局调度器分配的部署 ID
+	DeploymentId string `protobuf:"bytes,5,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	// 排队位置（从 1 开始，仅排队时有效）
+	QueuePosition int32 `protobuf:"varint,6,opt,name=queue_position,json=queuePosition,proto3" json:"queue_position,omitempty"`
+	// 部署所在的节点 ID
+	NodeId string `protobuf:"bytes,7,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// 节点失联后自动重启的次数
+	Restarts      int32 `protobuf:"varint,8,opt,name=restarts,proto3" json:"restarts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeploymentStatusResponse) Reset() {
+	*x = GetDeploymentStatusResponse{}
+	mi := &file_scheduler_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeploymentStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeploymentStatusResponse) ProtoMessage() {}
+
+func (x *GetDeploymentStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeploymentStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetDeploymentStatusResponse) Descriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetDeploymentStatusResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetDeploymentStatusResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetDeploymentStatusResponse) GetStatus() ComponentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ComponentStatus_COMPONENT_STATUS_UNKNOWN
+}
+
+func (x *GetDeploymentStatusResponse) GetComponent() *ComponentInfo {
+	if x != nil {
+		return x.Component
+	}
+	return nil
+}
+
+func (x *GetDeploymentStatusResponse) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *GetDeploymentStatusResponse) GetQueuePosition() int32 {
+	if x != nil {
+		return x.QueuePosition
+	}
+	return 0
+}
+
+func (x *GetDeploymentStatusResponse) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *GetDeploymentStatusResponse) GetRestarts() int32 {
+	if x != nil {
+		return x.Restarts
+	}
+	return 0
+}
+
+// StopComponentRequest 停止 component 请求
+type StopComponentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Component ID
+	ComponentId string `protobuf:"bytes,1,opt,name=component_id,json=componentId,proto3" json:"component_id,omitempty"`
+	// 全局调度器分配的部署 ID（调用全局调度器时优先于 component_id）
+	DeploymentId string `protobuf:"bytes,2,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	// 停止原因（如被高优先级部署抢占）
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopComponentRequest) Reset() {
+	*x = StopComponentRequest{}
+	mi := &file_scheduler_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopComponentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopComponentRequest) ProtoMessage() {}
+
+func (x *StopComponentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopComponentRequest.ProtoReflect.Descriptor instead.
+func (*StopComponentRequest) Descriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *StopComponentRequest) GetComponentId() string {
+	if x != nil {
+		return x.ComponentId
+	}
+	return ""
+}
+
+func (x *StopComponentRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *StopComponentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// StopComponentResponse 停止 component 响应
+type StopComponentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 是否成功
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// 错误信息（如果失败）
+	Error         string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopComponentResponse) Reset() {
+	*x = StopComponentResponse{}
+	mi := &file_scheduler_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopComponentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopComponentResponse) ProtoMessage() {}
+
+func (x *StopComponentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopComponentResponse.ProtoReflect.Descriptor instead.
+func (*StopComponentResponse) Descriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *StopComponentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *StopComponentResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// SimulatePlacementRequest 模拟调度请求
+type SimulatePlacementRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 待模拟的部署请求（与 DeployComponent 相同）
+	Request       *DeployComponentRequest `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimulatePlacementRequest) Reset() {
+	*x = SimulatePlacementRequest{}
+	mi := &file_scheduler_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulatePlacementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulatePlacementRequest) ProtoMessage() {}
+
+func (x *SimulatePlacementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulatePlacementRequest.ProtoReflect.Descriptor instead.
+func (*SimulatePlacementRequest) Descriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SimulatePlacementRequest) GetRequest() *DeployComponentRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+// SimulatePlacementResponse 模拟调度响应
+type SimulatePlacementResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 模拟是否完成（请求无效或身份认证失败时为 false）
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// 错误信息（如果失败）
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// 是否存在可放置的节点
+	Placeable bool `protobuf:"varint,3,opt,name=placeable,proto3" json:"placeable,omitempty"`
+	// 无法放置时实际调度会返回的错误
+	PlacementError string `protobuf:"bytes,4,opt,name=placement_error,json=placementError,proto3" json:"placement_error,omitempty"`
+	// 按排名排列的候选节点（同分的最高排名节点中实际调度会随机选择一个）
+	Candidates []*PlacementCandidate `protobuf:"bytes,5,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	// 每个域及其节点的筛选结果
+	Domains []*DomainEvaluation `protobuf:"bytes,6,rep,name=domains,proto3" json:"domains,omitempty"`
+	// 无法放置时，请求是否会进入等待队列
+	WouldQueue bool `protobuf:"varint,7,opt,name=would_queue,json=wouldQueue,proto3" json:"would_queue,omitempty"`
+	// 无法放置时，若允许抢占则为选中的节点及需要停止的部署
+	PreemptionNodeId  string   `protobuf:"bytes,8,opt,name=preemption_node_id,json=preemptionNodeId,proto3" json:"preemption_node_id,omitempty"`
+	PreemptionVictims []string `protobuf:"bytes,9,rep,name=preemption_victims,json=preemptionVictims,proto3" json:"preemption_victims,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SimulatePlacementResponse) Reset() {
+	*x = SimulatePlacementResponse{}
+	mi := &file_scheduler_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulatePlacementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulatePlacementResponse) ProtoMessage() {}
+
+func (x *SimulatePlacementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulatePlacementResponse.ProtoReflect.Descriptor instead.
+func (*SimulatePlacementResponse) Descriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SimulatePlacementResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SimulatePlacementResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *SimulatePlacementResponse) GetPlaceable() bool {
+	if x != nil {
+		return x.Placeable
+	}
+	return false
+}
+
+func (x *SimulatePlacementResponse) GetPlacementError() string {
+	if x != nil {
+		return x.PlacementError
+	}
+	return ""
+}
+
+func (x *SimulatePlacementResponse) GetCandidates() []*PlacementCandidate {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+func (x *SimulatePlacementResponse) GetDomains() []*DomainEvaluation {
+	if x != nil {
+		return x.Domains
+	}
+	return nil
+}
+
+func (x *SimulatePlacementResponse) GetWouldQueue() bool {
+	if x != nil {
+		return x.WouldQueue
+	}
+	return false
+}
+
+func (x *SimulatePlacementResponse) GetPreemptionNodeId() string {
+	if x != nil {
+		return x.PreemptionNodeId
+	}
+	return ""
+}
+
+func (x *SimulatePlacementResponse) GetPreemptionVictims() []string {
+	if x != nil {
+		return x.PreemptionVictims
+	}
+	return nil
+}
+
+// PlacementCandidate 可放置的候选节点
+type PlacementCandidate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 排名（从 1 开始）
+	Rank     int32  `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	DomainId string `protobuf:"bytes,2,opt,name=domain_id,json=domainId,proto3" json:"domain_id,omitempty"`
+	NodeId   string `protobuf:"bytes,3,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	NodeName string `protobuf:"bytes,4,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
+	// 总得分 = 亲和得分 + 拓扑得分
+	Score         int64 `protobuf:"varint,5,opt,name=score,proto3" json:"score,omitempty"`
+	AffinityScore int64 `protobuf:"varint,6,opt,name=affinity_score,json=affinityScore,proto3" json:"affinity_score,omitempty"`
+	TopologyScore int64 `protobuf:"varint,7,opt,name=topology_score,json=topologyScore,proto3" json:"topology_score,omitempty"`
+	// 疑似失效节点，仅在没有健康节点可用时使用
+	Suspect bool `protobuf:"varint,8,opt,name=suspect,proto3" json:"suspect,omitempty"`
+	// 是否属于实际调度会从中随机选择的最高得分节点
+	Preferred     bool `protobuf:"varint,9,opt,name=preferred,proto3" json:"preferred,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlacementCandidate) Reset() {
+	*x = PlacementCandidate{}
+	mi := &file_scheduler_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlacementCandidate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlacementCandidate) ProtoMessage() {}
+
+func (x *PlacementCandidate) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlacementCandidate.ProtoReflect.Descriptor instead.
+func (*PlacementCandidate) Descriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PlacementCandidate) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *PlacementCandidate) GetDomainId() string {
+	if x != nil {
+		return x.DomainId
+	}
+	return ""
+}
+
+func (x *PlacementCandidate) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *PlacementCandidate) GetNodeName() string {
+	if x != nil {
+		return x.NodeName
+	}
+	return ""
+}
+
+func (x *PlacementCandidate) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *PlacementCandidate) GetAffinityScore() int64 {
+	if x != nil {
+		return x.AffinityScore
+	}
+	return 0
+}
+
+func (x *PlacementCandidate) GetTopologyScore() int64 {
+	if x != nil {
+		return x.TopologyScore
+	}
+	return 0
+}
+
+func (x *PlacementCandidate) GetSuspect() bool {
+	if x != nil {
+		return x.Suspect
+	}
+	return false
+}
+
+func (x *PlacementCandidate) GetPreferred() bool {
+	if x != nil {
+		return x.Preferred
+	}
+	return false
+}
+
+// DomainEvaluation 域的筛选结果
+type DomainEvaluation struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	DomainId   string                 `protobuf:"bytes,1,opt,name=domain_id,json=domainId,proto3" json:"domain_id,omitempty"`
+	DomainName string                 `protobuf:"bytes,2,opt,name=domain_name,json=domainName,proto3" json:"domain_name,omitempty"`
+	// 整个域被淘汰的原因（为空表示域内节点逐个评估）
+	RejectionReason string            `protobuf:"bytes,3,opt,name=rejection_reason,json=rejectionReason,proto3" json:"rejection_reason,omitempty"`
+	Nodes           []*NodeEvaluation `protobuf:"bytes,4,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DomainEvaluation) Reset() {
+	*x = DomainEvaluation{}
+	mi := &file_scheduler_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DomainEvaluation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainEvaluation) ProtoMessage() {}
+
+func (x *DomainEvaluation) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainEvaluation.ProtoReflect.Descriptor instead.
+func (*DomainEvaluation) Descriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DomainEvaluation) GetDomainId() string {
+	if x != nil {
+		return x.DomainId
+	}
+	return ""
+}
+
+func (x *DomainEvaluation) GetDomainName() string {
+	if x != nil {
+		return x.DomainName
+	}
+	return ""
+}
+
+func (x *DomainEvaluation) GetRejectionReason() string {
+	if x != nil {
+		return x.RejectionReason
+	}
+	return ""
+}
+
+func (x *DomainEvaluation) GetNodes() []*NodeEvaluation {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+// NodeEvaluation 节点的筛选结果
+type NodeEvaluation struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	NodeId   string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	NodeName string                 `protobuf:"bytes,2,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
+	// 是否通过筛选
+	Eligible bool `protobuf:"varint,3,opt,name=eligible,proto3" json:"eligible,omitempty"`
+	// 淘汰原因（通过筛选时为空）
+	RejectionReason string `protobuf:"bytes,4,opt,name=rejection_reason,json=rejectionReason,proto3" json:"rejection_reason,omitempty"`
+	// 得分（仅通过筛选时有效）
+	Score         int64 `protobuf:"varint,5,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NodeEvaluation) Reset() {
+	*x = NodeEvaluation{}
+	mi := &file_scheduler_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NodeEvaluation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeEvaluation) ProtoMessage() {}
+
+func (x *NodeEvaluation) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeEvaluation.ProtoReflect.Descriptor instead.
+func (*NodeEvaluation) Descriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *NodeEvaluation) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *NodeEvaluation) GetNodeName() string {
+	if x != nil {
+		return x.NodeName
+	}
+	return ""
+}
+
+func (x *NodeEvaluation) GetEligible() bool {
+	if x != nil {
+		return x.Eligible
+	}
+	return false
+}
+
+func (x *NodeEvaluation) GetRejectionReason() string {
+	if x != nil {
+		return x.RejectionReason
+	}
+	return ""
+}
+
+func (x *NodeEvaluation) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+var File_scheduler_proto protoreflect.FileDescriptor
+
+const file_scheduler_proto_rawDesc = "" +
 	"\n" +
-	"\x0fscheduler.proto\x12\tscheduler\x1a\x17resource/resource.proto\"\xea\x02\n" +
+	"\x0fscheduler.proto\x12\tscheduler\x1a\x17resource/resource.proto\"\xb1\x05\n" +
 	"\x16DeployComponentRequest\x12\x1f\n" +
 	"\vruntime_env\x18\x01 \x01(\tR\n" +
 	"runtimeEnv\x129\n" +
@@ -482,7 +1940,41 @@ const file_scheduler_proto_rawDesc = "" +
 	"\x13target_node_address\x18\x04 \x01(\tR\x11targetNodeAddress\x120\n" +
 	"\x14upstream_zmq_address\x18\x05 \x01(\tR\x12upstreamZmqAddress\x124\n" +
 	"\x16upstream_store_address\x18\x06 \x01(\tR\x14upstreamStoreAddress\x126\n" +
-	"\x17upstream_logger_address\x18\a \x01(\tR\x15upstreamLoggerAddress\"\xd8\x01\n" +
+	"\x17upstream_logger_address\x18\a \x01(\tR\x15upstreamLoggerAddress\x128\n" +
+	"\tplacement\x18\b \x01(\v2\x1a.scheduler.PlacementPolicyR\tplacement\x12E\n" +
+	"\x06labels\x18\t \x03(\v2-.scheduler.DeployComponentRequest.LabelsEntryR\x06labels\x12\x1a\n" +
+	"\bpriority\x18\n" +
+	" \x01(\x05R\bpriority\x12(\n" +
+	"\x10max_wait_seconds\x18\v \x01(\x03R\x0emaxWaitSeconds\x12 \n" +
+	"\vrestartable\x18\f \x01(\bR\vrestartable\x12!\n" +
+	"\fmax_restarts\x18\r \x01(\x05R\vmaxRestarts\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf3\x01\n" +
+	"\x0fPlacementPolicy\x12C\n" +
+	"\x0elabel_affinity\x18\x01 \x03(\v2\x1c.scheduler.LabelAffinityTermR\rlabelAffinity\x12R\n" +
+	"\x13deployment_affinity\x18\x02 \x03(\v2!.scheduler.DeploymentAffinityTermR\x12deploymentAffinity\x12\x18\n" +
+	"\adomains\x18\x03 \x03(\tR\adomains\x12-\n" +
+	"\x06spread\x18\x04 \x03(\v2\x15.scheduler.SpreadTermR\x06spread\"\x8d\x01\n" +
+	"\n" +
+	"SpreadTerm\x12\x1a\n" +
+	"\bselector\x18\x01 \x03(\tR\bselector\x12\x14\n" +
+	"\x05level\x18\x02 \x01(\tR\x05level\x12\x19\n" +
+	"\bmax_skew\x18\x03 \x01(\x05R\amaxSkew\x12\x1a\n" +
+	"\brequired\x18\x04 \x01(\bR\brequired\x12\x16\n" +
+	"\x06weight\x18\x05 \x01(\x05R\x06weight\"w\n" +
+	"\x11LabelAffinityTerm\x12\x1a\n" +
+	"\bselector\x18\x01 \x03(\tR\bselector\x12\x12\n" +
+	"\x04anti\x18\x02 \x01(\bR\x04anti\x12\x1a\n" +
+	"\brequired\x18\x03 \x01(\bR\brequired\x12\x16\n" +
+	"\x06weight\x18\x04 \x01(\x05R\x06weight\"\xda\x01\n" +
+	"\x16DeploymentAffinityTerm\x12#\n" +
+	"\rcomponent_ids\x18\x01 \x03(\tR\fcomponentIds\x12\x1a\n" +
+	"\bselector\x18\x02 \x03(\tR\bselector\x127\n" +
+	"\btopology\x18\x03 \x01(\x0e2\x1b.scheduler.AffinityTopologyR\btopology\x12\x12\n" +
+	"\x04anti\x18\x04 \x01(\bR\x04anti\x12\x1a\n" +
+	"\brequired\x18\x05 \x01(\bR\brequired\x12\x16\n" +
+	"\x06weight\x18\x06 \x01(\x05R\x06weight\"\xd8\x02\n" +
 	"\x17DeployComponentResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\x126\n" +
@@ -490,30 +1982,119 @@ const file_scheduler_proto_rawDesc = "" +
 	"\anode_id\x18\x04 \x01(\tR\x06nodeId\x12\x1b\n" +
 	"\tnode_name\x18\x05 \x01(\tR\bnodeName\x12\x1f\n" +
 	"\vprovider_id\x18\x06 \x01(\tR\n" +
-	"providerId\"\xa0\x01\n" +
+	"providerId\x12#\n" +
+	"\rdeployment_id\x18\a \x01(\tR\fdeploymentId\x122\n" +
+	"\x06status\x18\b \x01(\x0e2\x1a.scheduler.ComponentStatusR\x06status\x12%\n" +
+	"\x0equeue_position\x18\t \x01(\x05R\rqueuePosition\"`\n" +
+	"\x16RemoveComponentRequest\x12!\n" +
+	"\fcomponent_id\x18\x01 \x01(\tR\vcomponentId\x12#\n" +
+	"\rdeployment_id\x18\x02 \x01(\tR\fdeploymentId\"I\n" +
+	"\x17RemoveComponentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\xb1\x01\n" +
+	"\x17MigrateComponentRequest\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12!\n" +
+	"\fcomponent_id\x18\x02 \x01(\tR\vcomponentId\x12$\n" +
+	"\x0etarget_node_id\x18\x03 \x01(\tR\ftargetNodeId\x12(\n" +
+	"\x10target_domain_id\x18\x04 \x01(\tR\x0etargetDomainId\"\x93\x02\n" +
+	"\x18MigrateComponentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12#\n" +
+	"\rdeployment_id\x18\x03 \x01(\tR\fdeploymentId\x126\n" +
+	"\tcomponent\x18\x04 \x01(\v2\x18.scheduler.ComponentInfoR\tcomponent\x12\x17\n" +
+	"\anode_id\x18\x05 \x01(\tR\x06nodeId\x12\x1b\n" +
+	"\tnode_name\x18\x06 \x01(\tR\bnodeName\x124\n" +
+	"\x16previous_deployment_id\x18\a \x01(\tR\x14previousDeploymentId\"u\n" +
+	"\x1bDeployComponentGroupRequest\x12\x19\n" +
+	"\bgroup_id\x18\x01 \x01(\tR\agroupId\x12;\n" +
+	"\amembers\x18\x02 \x03(\v2!.scheduler.DeployComponentRequestR\amembers\"\xa7\x01\n" +
+	"\x1cDeployComponentGroupResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x19\n" +
+	"\bgroup_id\x18\x03 \x01(\tR\agroupId\x12<\n" +
+	"\amembers\x18\x04 \x03(\v2\".scheduler.DeployComponentResponseR\amembers\"\xa0\x01\n" +
 	"\rComponentInfo\x12!\n" +
 	"\fcomponent_id\x18\x01 \x01(\tR\vcomponentId\x12\x14\n" +
 	"\x05image\x18\x02 \x01(\tR\x05image\x125\n" +
 	"\x0eresource_usage\x18\x03 \x01(\v2\x0e.resource.InfoR\rresourceUsage\x12\x1f\n" +
 	"\vprovider_id\x18\x04 \x01(\tR\n" +
-	"providerId\"X\n" +
+	"providerId\"}\n" +
 	"\x1aGetDeploymentStatusRequest\x12!\n" +
 	"\fcomponent_id\x18\x01 \x01(\tR\vcomponentId\x12\x17\n" +
-	"\anode_id\x18\x02 \x01(\tR\x06nodeId\"\xb9\x01\n" +
+	"\anode_id\x18\x02 \x01(\tR\x06nodeId\x12#\n" +
+	"\rdeployment_id\x18\x03 \x01(\tR\fdeploymentId\"\xba\x02\n" +
 	"\x1bGetDeploymentStatusResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\x122\n" +
 	"\x06status\x18\x03 \x01(\x0e2\x1a.scheduler.ComponentStatusR\x06status\x126\n" +
-	"\tcomponent\x18\x04 \x01(\v2\x18.scheduler.ComponentInfoR\tcomponent*\xa7\x01\n" +
+	"\tcomponent\x18\x04 \x01(\v2\x18.scheduler.ComponentInfoR\tcomponent\x12#\n" +
+	"\rdeployment_id\x18\x05 \x01(\tR\fdeploymentId\x12%\n" +
+	"\x0equeue_position\x18\x06 \x01(\x05R\rqueuePosition\x12\x17\n" +
+	"\anode_id\x18\a \x01(\tR\x06nodeId\x12\x1a\n" +
+	"\brestarts\x18\b \x01(\x05R\brestarts\"v\n" +
+	"\x14StopComponentRequest\x12!\n" +
+	"\fcomponent_id\x18\x01 \x01(\tR\vcomponentId\x12#\n" +
+	"\rdeployment_id\x18\x02 \x01(\tR\fdeploymentId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"G\n" +
+	"\x15StopComponentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"W\n" +
+	"\x18SimulatePlacementRequest\x12;\n" +
+	"\arequest\x18\x01 \x01(\v2!.scheduler.DeployComponentRequestR\arequest\"\x86\x03\n" +
+	"\x19SimulatePlacementResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1c\n" +
+	"\tplaceable\x18\x03 \x01(\bR\tplaceable\x12'\n" +
+	"\x0fplacement_error\x18\x04 \x01(\tR\x0eplacementError\x12=\n" +
+	"\n" +
+	"candidates\x18\x05 \x03(\v2\x1d.scheduler.PlacementCandidateR\n" +
+	"candidates\x125\n" +
+	"\adomains\x18\x06 \x03(\v2\x1b.scheduler.DomainEvaluationR\adomains\x12\x1f\n" +
+	"\vwould_queue\x18\a \x01(\bR\n" +
+	"wouldQueue\x12,\n" +
+	"\x12preemption_node_id\x18\b \x01(\tR\x10preemptionNodeId\x12-\n" +
+	"\x12preemption_victims\x18\t \x03(\tR\x11preemptionVictims\"\x97\x02\n" +
+	"\x12PlacementCandidate\x12\x12\n" +
+	"\x04rank\x18\x01 \x01(\x05R\x04rank\x12\x1b\n" +
+	"\tdomain_id\x18\x02 \x01(\tR\bdomainId\x12\x17\n" +
+	"\anode_id\x18\x03 \x01(\tR\x06nodeId\x12\x1b\n" +
+	"\tnode_name\x18\x04 \x01(\tR\bnodeName\x12\x14\n" +
+	"\x05score\x18\x05 \x01(\x03R\x05score\x12%\n" +
+	"\x0eaffinity_score\x18\x06 \x01(\x03R\raffinityScore\x12%\n" +
+	"\x0etopology_score\x18\a \x01(\x03R\rtopologyScore\x12\x18\n" +
+	"\asuspect\x18\b \x01(\bR\asuspect\x12\x1c\n" +
+	"\tpreferred\x18\t \x01(\bR\tpreferred\"\xac\x01\n" +
+	"\x10DomainEvaluation\x12\x1b\n" +
+	"\tdomain_id\x18\x01 \x01(\tR\bdomainId\x12\x1f\n" +
+	"\vdomain_name\x18\x02 \x01(\tR\n" +
+	"domainName\x12)\n" +
+	"\x10rejection_reason\x18\x03 \x01(\tR\x0frejectionReason\x12/\n" +
+	"\x05nodes\x18\x04 \x03(\v2\x19.scheduler.NodeEvaluationR\x05nodes\"\xa3\x01\n" +
+	"\x0eNodeEvaluation\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12\x1b\n" +
+	"\tnode_name\x18\x02 \x01(\tR\bnodeName\x12\x1a\n" +
+	"\beligible\x18\x03 \x01(\bR\beligible\x12)\n" +
+	"\x10rejection_reason\x18\x04 \x01(\tR\x0frejectionReason\x12\x14\n" +
+	"\x05score\x18\x05 \x01(\x03R\x05score*L\n" +
+	"\x10AffinityTopology\x12\x1a\n" +
+	"\x16AFFINITY_TOPOLOGY_NODE\x10\x00\x12\x1c\n" +
+	"\x18AFFINITY_TOPOLOGY_DOMAIN\x10\x01*\xe0\x01\n" +
 	"\x0fComponentStatus\x12\x1c\n" +
 	"\x18COMPONENT_STATUS_UNKNOWN\x10\x00\x12\x1e\n" +
 	"\x1aCOMPONENT_STATUS_DEPLOYING\x10\x01\x12\x1c\n" +
 	"\x18COMPONENT_STATUS_RUNNING\x10\x02\x12\x1c\n" +
 	"\x18COMPONENT_STATUS_STOPPED\x10\x03\x12\x1a\n" +
-	"\x16COMPONENT_STATUS_ERROR\x10\x042\xd2\x01\n" +
+	"\x16COMPONENT_STATUS_ERROR\x10\x04\x12\x1c\n" +
+	"\x18COMPONENT_STATUS_PENDING\x10\x05\x12\x19\n" +
+	"\x15COMPONENT_STATUS_LOST\x10\x062\xa6\x05\n" +
 	"\x10SchedulerService\x12X\n" +
 	"\x0fDeployComponent\x12!.scheduler.DeployComponentRequest\x1a\".scheduler.DeployComponentResponse\x12d\n" +
-	"\x13GetDeploymentStatus\x12%.scheduler.GetDeploymentStatusRequest\x1a&.scheduler.GetDeploymentStatusResponseB;Z9github.com/9triver/iarnet-global/internal/proto/schedulerb\x06proto3"
+	"\x13GetDeploymentStatus\x12%.scheduler.GetDeploymentStatusRequest\x1a&.scheduler.GetDeploymentStatusResponse\x12R\n" +
+	"\rStopComponent\x12\x1f.scheduler.StopComponentRequest\x1a .scheduler.StopComponentResponse\x12X\n" +
+	"\x0fRemoveComponent\x12!.scheduler.RemoveComponentRequest\x1a\".scheduler.RemoveComponentResponse\x12[\n" +
+	"\x10MigrateComponent\x12\".scheduler.MigrateComponentRequest\x1a#.scheduler.MigrateComponentResponse\x12g\n" +
+	"\x14DeployComponentGroup\x12&.scheduler.DeployComponentGroupRequest\x1a'.scheduler.DeployComponentGroupResponse\x12^\n" +
+	"\x11SimulatePlacement\x12#.scheduler.SimulatePlacementRequest\x1a$.scheduler.SimulatePlacementResponseB;Z9github.com/9triver/iarnet-global/internal/proto/schedulerb\x06proto3"
 
 var (
 	file_scheduler_proto_rawDescOnce sync.Once
@@ -527,32 +2108,75 @@ func file_scheduler_proto_rawDescGZIP() []byte {
 	return file_scheduler_proto_rawDescData
 }
 
-var file_scheduler_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_scheduler_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_scheduler_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_scheduler_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_scheduler_proto_goTypes = []any{
-	(ComponentStatus)(0),                // 0: scheduler.ComponentStatus
-	(*DeployComponentRequest)(nil),      // 1: scheduler.DeployComponentRequest
-	(*DeployComponentResponse)(nil),     // 2: scheduler.DeployComponentResponse
-	(*ComponentInfo)(nil),               // 3: scheduler.ComponentInfo
-	(*GetDeploymentStatusRequest)(nil),  // 4: scheduler.GetDeploymentStatusRequest
-	(*GetDeploymentStatusResponse)(nil), // 5: scheduler.GetDeploymentStatusResponse
-	(*resource.Info)(nil),               // 6: resource.Info
+	(AffinityTopology)(0),                // 0: scheduler.AffinityTopology
+	(ComponentStatus)(0),                 // 1: scheduler.ComponentStatus
+	(*DeployComponentRequest)(nil),       // 2: scheduler.DeployComponentRequest
+	(*PlacementPolicy)(nil),              // 3: scheduler.PlacementPolicy
+	(*SpreadTerm)(nil),                   // 4: scheduler.SpreadTerm
+	(*LabelAffinityTerm)(nil),            // 5: scheduler.LabelAffinityTerm
+	(*DeploymentAffinityTerm)(nil),       // 6: scheduler.DeploymentAffinityTerm
+	(*DeployComponentResponse)(nil),      // 7: scheduler.DeployComponentResponse
+	(*RemoveComponentRequest)(nil),       // 8: scheduler.RemoveComponentRequest
+	(*RemoveComponentResponse)(nil),      // 9: scheduler.RemoveComponentResponse
+	(*MigrateComponentRequest)(nil),      // 10: scheduler.MigrateComponentRequest
+	(*MigrateComponentResponse)(nil),     // 11: scheduler.MigrateComponentResponse
+	(*DeployComponentGroupRequest)(nil),  // 12: scheduler.DeployComponentGroupRequest
+	(*DeployComponentGroupResponse)(nil), // 13: scheduler.DeployComponentGroupResponse
+	(*ComponentInfo)(nil),                // 14: scheduler.ComponentInfo
+	(*GetDeploymentStatusRequest)(nil),   // 15: scheduler.GetDeploymentStatusRequest
+	(*GetDeploymentStatusResponse)(nil),  // 16: scheduler.GetDeploymentStatusResponse
+	(*StopComponentRequest)(nil),         // 17: scheduler.StopComponentRequest
+	(*StopComponentResponse)(nil),        // 18: scheduler.StopComponentResponse
+	(*SimulatePlacementRequest)(nil),     // 19: scheduler.SimulatePlacementRequest
+	(*SimulatePlacementResponse)(nil),    // 20: scheduler.SimulatePlacementResponse
+	(*PlacementCandidate)(nil),           // 21: scheduler.PlacementCandidate
+	(*DomainEvaluation)(nil),             // 22: scheduler.DomainEvaluation
+	(*NodeEvaluation)(nil),               // 23: scheduler.NodeEvaluation
+	nil,                                  // 24: scheduler.DeployComponentRequest.LabelsEntry
+	(*resource.Info)(nil),                // 25: resource.Info
 }
 var file_scheduler_proto_depIdxs = []int32{
-	6, // 0: scheduler.DeployComponentRequest.resource_request:type_name -> resource.Info
-	3, // 1: scheduler.DeployComponentResponse.component:type_name -> scheduler.ComponentInfo
-	6, // 2: scheduler.ComponentInfo.resource_usage:type_name -> resource.Info
-	0, // 3: scheduler.GetDeploymentStatusResponse.status:type_name -> scheduler.ComponentStatus
-	3, // 4: scheduler.GetDeploymentStatusResponse.component:type_name -> scheduler.ComponentInfo
-	1, // 5: scheduler.SchedulerService.DeployComponent:input_type -> scheduler.DeployComponentRequest
-	4, // 6: scheduler.SchedulerService.GetDeploymentStatus:input_type -> scheduler.GetDeploymentStatusRequest
-	2, // 7: scheduler.SchedulerService.DeployComponent:output_type -> scheduler.DeployComponentResponse
-	5, // 8: scheduler.SchedulerService.GetDeploymentStatus:output_type -> scheduler.GetDeploymentStatusResponse
-	7, // [7:9] is the sub-list for method output_type
-	5, // [5:7] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	25, // 0: scheduler.DeployComponentRequest.resource_request:type_name -> resource.Info
+	3,  // 1: scheduler.DeployComponentRequest.placement:type_name -> scheduler.PlacementPolicy
+	24, // 2: scheduler.DeployComponentRequest.labels:type_name -> scheduler.DeployComponentRequest.LabelsEntry
+	5,  // 3: scheduler.PlacementPolicy.label_affinity:type_name -> scheduler.LabelAffinityTerm
+	6,  // 4: scheduler.PlacementPolicy.deployment_affinity:type_name -> scheduler.DeploymentAffinityTerm
+	4,  // 5: scheduler.PlacementPolicy.spread:type_name -> scheduler.SpreadTerm
+	0,  // 6: scheduler.DeploymentAffinityTerm.topology:type_name -> scheduler.AffinityTopology
+	14, // 7: scheduler.DeployComponentResponse.component:type_name -> scheduler.ComponentInfo
+	1,  // 8: scheduler.DeployComponentResponse.status:type_name -> scheduler.ComponentStatus
+	14, // 9: scheduler.MigrateComponentResponse.component:type_name -> scheduler.ComponentInfo
+	2,  // 10: scheduler.DeployComponentGroupRequest.members:type_name -> scheduler.DeployComponentRequest
+	7,  // 11: scheduler.DeployComponentGroupResponse.members:type_name -> scheduler.DeployComponentResponse
+	25, // 12: scheduler.ComponentInfo.resource_usage:type_name -> resource.Info
+	1,  // 13: scheduler.GetDeploymentStatusResponse.status:type_name -> scheduler.ComponentStatus
+	14, // 14: scheduler.GetDeploymentStatusResponse.component:type_name -> scheduler.ComponentInfo
+	2,  // 15: scheduler.SimulatePlacementRequest.request:type_name -> scheduler.DeployComponentRequest
+	21, // 16: scheduler.SimulatePlacementResponse.candidates:type_name -> scheduler.PlacementCandidate
+	22, // 17: scheduler.SimulatePlacementResponse.domains:type_name -> scheduler.DomainEvaluation
+	23, // 18: scheduler.DomainEvaluation.nodes:type_name -> scheduler.NodeEvaluation
+	2,  // 19: scheduler.SchedulerService.DeployComponent:input_type -> scheduler.DeployComponentRequest
+	15, // 20: scheduler.SchedulerService.GetDeploymentStatus:input_type -> scheduler.GetDeploymentStatusRequest
+	17, // 21: scheduler.SchedulerService.StopComponent:input_type -> scheduler.StopComponentRequest
+	8,  // 22: scheduler.SchedulerService.RemoveComponent:input_type -> scheduler.RemoveComponentRequest
+	10, // 23: scheduler.SchedulerService.MigrateComponent:input_type -> scheduler.MigrateComponentRequest
+	12, // 24: scheduler.SchedulerService.DeployComponentGroup:input_type -> scheduler.DeployComponentGroupRequest
+	19, // 25: scheduler.SchedulerService.SimulatePlacement:input_type -> scheduler.SimulatePlacementRequest
+	7,  // 26: scheduler.SchedulerService.DeployComponent:output_type -> scheduler.DeployComponentResponse
+	16, // 27: scheduler.SchedulerService.GetDeploymentStatus:output_type -> scheduler.GetDeploymentStatusResponse
+	18, // 28: scheduler.SchedulerService.StopComponent:output_type -> scheduler.StopComponentResponse
+	9,  // 29: scheduler.SchedulerService.RemoveComponent:output_type -> scheduler.RemoveComponentResponse
+	11, // 30: scheduler.SchedulerService.MigrateComponent:output_type -> scheduler.MigrateComponentResponse
+	13, // 31: scheduler.SchedulerService.DeployComponentGroup:output_type -> scheduler.DeployComponentGroupResponse
+	20, // 32: scheduler.SchedulerService.SimulatePlacement:output_type -> scheduler.SimulatePlacementResponse
+	26, // [26:33] is the sub-list for method output_type
+	19, // [19:26] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_scheduler_proto_init() }
@@ -565,8 +2189,8 @@ func file_scheduler_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_scheduler_proto_rawDesc), len(file_scheduler_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   5,
+			NumEnums:      2,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
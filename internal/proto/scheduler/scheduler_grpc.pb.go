@@ -19,8 +19,13 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	SchedulerService_DeployComponent_FullMethodName     = "/scheduler.SchedulerService/DeployComponent"
-	SchedulerService_GetDeploymentStatus_FullMethodName = "/scheduler.SchedulerService/GetDeploymentStatus"
+	SchedulerService_DeployComponent_FullMethodName      = "/scheduler.SchedulerService/DeployComponent"
+	SchedulerService_GetDeploymentStatus_FullMethodName  = "/scheduler.SchedulerService/GetDeploymentStatus"
+	SchedulerService_StopComponent_FullMethodName        = "/scheduler.SchedulerService/StopComponent"
+	SchedulerService_RemoveComponent_FullMethodName      = "/scheduler.SchedulerService/RemoveComponent"
+	SchedulerService_MigrateComponent_FullMethodName     = "/scheduler.SchedulerService/MigrateComponent"
+	SchedulerService_DeployComponentGroup_FullMethodName = "/scheduler.SchedulerService/DeployComponentGroup"
+	SchedulerService_SimulatePlacement_FullMethodName    = "/scheduler.SchedulerService/SimulatePlacement"
 )
 
 // SchedulerServiceClient is the client API for SchedulerService service.
@@ -34,6 +39,17 @@ type SchedulerServiceClient interface {
 	DeployComponent(ctx context.Context, in *DeployComponentRequest, opts ...grpc.CallOption) (*DeployComponentResponse, error)
 	// GetDeploymentStatus 获取部署状态
 	GetDeploymentStatus(ctx context.Context, in *GetDeploymentStatusRequest, opts ...grpc.CallOption) (*GetDeploymentStatusResponse, error)
+	// StopComponent 停止 component
+	// 全局调度器根据部署记录找到所在节点后转发，抢占、成组部署回滚时也会调用节点
+	StopComponent(ctx context.Context, in *StopComponentRequest, opts ...grpc.CallOption) (*StopComponentResponse, error)
+	// RemoveComponent 移除 component（停止并清理），全局调度器同时删除部署记录
+	RemoveComponent(ctx context.Context, in *RemoveComponentRequest, opts ...grpc.CallOption) (*RemoveComponentResponse, error)
+	// MigrateComponent 将 component 迁移到其他节点或域：先部署新实例，成功后移除旧实例
+	MigrateComponent(ctx context.Context, in *MigrateComponentRequest, opts ...grpc.CallOption) (*MigrateComponentResponse, error)
+	// DeployComponentGroup 原子地部署一组 component：全部成员都能放置时才转发，任一成员部署失败则回滚已部署的成员
+	DeployComponentGroup(ctx context.Context, in *DeployComponentGroupRequest, opts ...grpc.CallOption) (*DeployComponentGroupResponse, error)
+	// SimulatePlacement 模拟调度：按当前注册中心状态执行完整的节点筛选流程，返回候选节点排名、得分与淘汰原因，不登记、不转发
+	SimulatePlacement(ctx context.Context, in *SimulatePlacementRequest, opts ...grpc.CallOption) (*SimulatePlacementResponse, error)
 }
 
 type schedulerServiceClient struct {
@@ -64,6 +80,56 @@ func (c *schedulerServiceClient) GetDeploymentStatus(ctx context.Context, in *Ge
 	return out, nil
 }
 
+func (c *schedulerServiceClient) StopComponent(ctx context.Context, in *StopComponentRequest, opts ...grpc.CallOption) (*StopComponentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StopComponentResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_StopComponent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) RemoveComponent(ctx context.Context, in *RemoveComponentRequest, opts ...grpc.CallOption) (*RemoveComponentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveComponentResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_RemoveComponent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) MigrateComponent(ctx context.Context, in *MigrateComponentRequest, opts ...grpc.CallOption) (*MigrateComponentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MigrateComponentResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_MigrateComponent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) DeployComponentGroup(ctx context.Context, in *DeployComponentGroupRequest, opts ...grpc.CallOption) (*DeployComponentGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeployComponentGroupResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_DeployComponentGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) SimulatePlacement(ctx context.Context, in *SimulatePlacementRequest, opts ...grpc.CallOption) (*SimulatePlacementResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SimulatePlacementResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_SimulatePlacement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SchedulerServiceServer is the server API for SchedulerService service.
 // All implementations must embed UnimplementedSchedulerServiceServer
 // for forward compatibility.
@@ -75,6 +141,17 @@ type SchedulerServiceServer interface {
 	DeployComponent(context.Context, *DeployComponentRequest) (*DeployComponentResponse, error)
 	// GetDeploymentStatus 获取部署状态
 	GetDeploymentStatus(context.Context, *GetDeploymentStatusRequest) (*GetDeploymentStatusResponse, error)
+	// StopComponent 停止 component
+	// 全局调度器根据部署记录找到所在节点后转发，抢占、成组部署回滚时也会调用节点
+	StopComponent(context.Context, *StopComponentRequest) (*StopComponentResponse, error)
+	// RemoveComponent 移除 component（停止并清理），全局调度器同时删除部署记录
+	RemoveComponent(context.Context, *RemoveComponentRequest) (*RemoveComponentResponse, error)
+	// MigrateComponent 将 component 迁移到其他节点或域：先部署新实例，成功后移除旧实例
+	MigrateComponent(context.Context, *MigrateComponentRequest) (*MigrateComponentResponse, error)
+	// DeployComponentGroup 原子地部署一组 component：全部成员都能放置时才转发，任一成员部署失败则回滚已部署的成员
+	DeployComponentGroup(context.Context, *DeployComponentGroupRequest) (*DeployComponentGroupResponse, error)
+	// SimulatePlacement 模拟调度：按当前注册中心状态执行完整的节点筛选流程，返回候选节点排名、得分与淘汰原因，不登记、不转发
+	SimulatePlacement(context.Context, *SimulatePlacementRequest) (*SimulatePlacementResponse, error)
 	mustEmbedUnimplementedSchedulerServiceServer()
 }
 
@@ -91,6 +168,21 @@ func (UnimplementedSchedulerServiceServer) DeployComponent(context.Context, *Dep
 func (UnimplementedSchedulerServiceServer) GetDeploymentStatus(context.Context, *GetDeploymentStatusRequest) (*GetDeploymentStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetDeploymentStatus not implemented")
 }
+func (UnimplementedSchedulerServiceServer) StopComponent(context.Context, *StopComponentRequest) (*StopComponentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopComponent not implemented")
+}
+func (UnimplementedSchedulerServiceServer) RemoveComponent(context.Context, *RemoveComponentRequest) (*RemoveComponentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveComponent not implemented")
+}
+func (UnimplementedSchedulerServiceServer) MigrateComponent(context.Context, *MigrateComponentRequest) (*MigrateComponentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MigrateComponent not implemented")
+}
+func (UnimplementedSchedulerServiceServer) DeployComponentGroup(context.Context, *DeployComponentGroupRequest) (*DeployComponentGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeployComponentGroup not implemented")
+}
+func (UnimplementedSchedulerServiceServer) SimulatePlacement(context.Context, *SimulatePlacementRequest) (*SimulatePlacementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SimulatePlacement not implemented")
+}
 func (UnimplementedSchedulerServiceServer) mustEmbedUnimplementedSchedulerServiceServer() {}
 func (UnimplementedSchedulerServiceServer) testEmbeddedByValue()                          {}
 
@@ -148,6 +240,96 @@ func _SchedulerService_GetDeploymentStatus_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SchedulerService_StopComponent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopComponentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).StopComponent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_StopComponent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).StopComponent(ctx, req.(*StopComponentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_RemoveComponent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveComponentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).RemoveComponent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_RemoveComponent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).RemoveComponent(ctx, req.(*RemoveComponentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_MigrateComponent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MigrateComponentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).MigrateComponent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_MigrateComponent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).MigrateComponent(ctx, req.(*MigrateComponentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_DeployComponentGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeployComponentGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).DeployComponentGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_DeployComponentGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).DeployComponentGroup(ctx, req.(*DeployComponentGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_SimulatePlacement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulatePlacementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).SimulatePlacement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_SimulatePlacement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).SimulatePlacement(ctx, req.(*SimulatePlacementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SchedulerService_ServiceDesc is the grpc.ServiceDesc for SchedulerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -163,6 +345,26 @@ var SchedulerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetDeploymentStatus",
 			Handler:    _SchedulerService_GetDeploymentStatus_Handler,
 		},
+		{
+			MethodName: "StopComponent",
+			Handler:    _SchedulerService_StopComponent_Handler,
+		},
+		{
+			MethodName: "RemoveComponent",
+			Handler:    _SchedulerService_RemoveComponent_Handler,
+		},
+		{
+			MethodName: "MigrateComponent",
+			Handler:    _SchedulerService_MigrateComponent_Handler,
+		},
+		{
+			MethodName: "DeployComponentGroup",
+			Handler:    _SchedulerService_DeployComponentGroup_Handler,
+		},
+		{
+			MethodName: "SimulatePlacement",
+			Handler:    _SchedulerService_SimulatePlacement_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "scheduler.proto",
@@ -0,0 +1,194 @@
+package simulator
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+)
+
+// Report 单次模拟的评估结果
+type Report struct {
+	Strategy string        `json:"strategy"`
+	Duration time.Duration `json:"duration_ns"` // 模拟时长
+
+	Deploys     int     `json:"deploys"`      // 部署请求数
+	Placed      int     `json:"placed"`       // 成功放置数
+	Failed      int     `json:"failed"`       // 放置失败数
+	Evicted     int     `json:"evicted"`      // 因节点故障或下线丢失的 component 数
+	FailureRate float64 `json:"failure_rate"` // 放置失败比例
+	// FailureReasons 失败原因 -> 次数
+	FailureReasons map[string]int `json:"failure_reasons,omitempty"`
+
+	// Utilisation 在线节点按时间加权的资源利用率
+	Utilisation ResourceRatio `json:"utilisation"`
+	// PeakUtilisation 在线节点的峰值资源利用率
+	PeakUtilisation ResourceRatio `json:"peak_utilisation"`
+	// Fragmentation 按时间加权的 CPU 碎片率：1 - 单节点最大空闲 CPU / 全部空闲 CPU
+	Fragmentation float64 `json:"fragmentation"`
+
+	// CrossDomainRatio 携带 origin_domain 的部署中被放置到其他域的比例
+	CrossDomainRatio float64 `json:"cross_domain_ratio"`
+	// PlacementsByDomain 域 ID -> 放置数
+	PlacementsByDomain map[string]int `json:"placements_by_domain,omitempty"`
+}
+
+// ResourceRatio 内置资源的比例
+type ResourceRatio struct {
+	CPU    float64 `json:"cpu"`
+	Memory float64 `json:"memory"`
+	GPU    float64 `json:"gpu"`
+}
+
+// collector 模拟过程中的指标累计
+type collector struct {
+	deploys    int
+	placements int
+	failures   map[string]int
+	evicted    int
+
+	withOrigin  int
+	crossDomain int
+	byDomain    map[string]int
+
+	// used / total 各项资源 已使用量 × 时长 与 总量 × 时长 的累计
+	used  [3]float64
+	total [3]float64
+	peak  [3]float64
+
+	fragmentation float64
+	fragmentedFor time.Duration
+}
+
+var builtinResources = [3]string{registry.ResourceCPU, registry.ResourceMemory, registry.ResourceGPU}
+
+func newCollector() *collector {
+	return &collector{
+		failures: make(map[string]int),
+		byDomain: make(map[string]int),
+	}
+}
+
+func (c *collector) placed(domainID, originDomain string) {
+	c.deploys++
+	c.placements++
+	c.byDomain[domainID]++
+	if originDomain != "" {
+		c.withOrigin++
+		if domainID != originDomain {
+			c.crossDomain++
+		}
+	}
+}
+
+func (c *collector) failed(err error) {
+	c.deploys++
+	c.failures[err.Error()]++
+}
+
+// sample 以在线节点在 elapsed 时长内的资源状态累计利用率与碎片率
+func (c *collector) sample(nodes []*registry.Node, elapsed time.Duration) {
+	var used, total [3]float64
+	var freeCPU, maxFreeCPU float64
+	for _, node := range nodes {
+		if node.Status != registry.NodeStatusOnline || node.ResourceCapacity == nil || node.ResourceCapacity.Total == nil {
+			continue
+		}
+		capacity := node.ResourceCapacity
+		for i, name := range builtinResources {
+			total[i] += float64(capacity.Total.Get(name))
+			if capacity.Used != nil {
+				used[i] += float64(capacity.Used.Get(name))
+			}
+		}
+		if capacity.Available != nil {
+			free := float64(capacity.Available.CPU)
+			freeCPU += free
+			if free > maxFreeCPU {
+				maxFreeCPU = free
+			}
+		}
+	}
+
+	weight := elapsed.Seconds()
+	for i := range builtinResources {
+		c.used[i] += used[i] * weight
+		c.total[i] += total[i] * weight
+		if total[i] > 0 && used[i]/total[i] > c.peak[i] {
+			c.peak[i] = used[i] / total[i]
+		}
+	}
+	if freeCPU > 0 {
+		c.fragmentation += (1 - maxFreeCPU/freeCPU) * weight
+		c.fragmentedFor += elapsed
+	}
+}
+
+func (c *collector) report(strategy string, duration time.Duration) *Report {
+	r := &Report{
+		Strategy:           strategy,
+		Duration:           duration,
+		Deploys:            c.deploys,
+		Placed:             c.placements,
+		Failed:             c.deploys - c.placements,
+		Evicted:            c.evicted,
+		FailureReasons:     c.failures,
+		PlacementsByDomain: c.byDomain,
+		Utilisation:        ratio(c.used, c.total),
+		PeakUtilisation:    ResourceRatio{CPU: c.peak[0], Memory: c.peak[1], GPU: c.peak[2]},
+	}
+	if c.deploys > 0 {
+		r.FailureRate = float64(r.Failed) / float64(c.deploys)
+	}
+	if c.fragmentedFor > 0 {
+		r.Fragmentation = c.fragmentation / c.fragmentedFor.Seconds()
+	}
+	if c.withOrigin > 0 {
+		r.CrossDomainRatio = float64(c.crossDomain) / float64(c.withOrigin)
+	}
+	return r
+}
+
+func ratio(used, total [3]float64) ResourceRatio {
+	values := [3]float64{}
+	for i := range values {
+		if total[i] > 0 {
+			values[i] = used[i] / total[i]
+		}
+	}
+	return ResourceRatio{CPU: values[0], Memory: values[1], GPU: values[2]}
+}
+
+// WriteTable 以表格形式输出多个策略的对比结果
+func WriteTable(w io.Writer, reports []*Report) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STRATEGY\tDEPLOYS\tPLACED\tFAILED\tEVICTED\tFAILURE RATE\tCPU UTIL\tMEM UTIL\tGPU UTIL\tFRAGMENTATION\tCROSS-DOMAIN")
+	for _, r := range reports {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%.1f%%\t%.1f%%\t%.1f%%\t%.1f%%\t%.3f\t%.1f%%\n",
+			r.Strategy, r.Deploys, r.Placed, r.Failed, r.Evicted, r.FailureRate*100,
+			r.Utilisation.CPU*100, r.Utilisation.Memory*100, r.Utilisation.GPU*100,
+			r.Fragmentation, r.CrossDomainRatio*100)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, r := range reports {
+		if len(r.FailureReasons) == 0 {
+			continue
+		}
+		reasons := make([]string, 0, len(r.FailureReasons))
+		for reason := range r.FailureReasons {
+			reasons = append(reasons, reason)
+		}
+		sort.Strings(reasons)
+		fmt.Fprintf(w, "\n%s failures:\n", r.Strategy)
+		for _, reason := range reasons {
+			fmt.Fprintf(w, "  %4d  %s\n", r.FailureReasons[reason], reason)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,306 @@
+package simulator
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/deployment"
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	"github.com/9triver/iarnet-global/internal/intra/repository"
+)
+
+// Options 模拟参数
+type Options struct {
+	// Topology 虚拟集群拓扑（必需）
+	Topology *Topology
+	// Trace 待回放的负载轨迹（必需）
+	Trace *Trace
+	// Strategy 调度策略（必需）
+	Strategy Strategy
+	// Horizon 模拟结束时间，为 0 时在最后一个事件处结束
+	Horizon time.Duration
+}
+
+// eventKind 模拟事件类型，同一时刻按 加入 > 节点变化 > 停止 > 部署 的顺序处理
+type eventKind int
+
+const (
+	kindJoin eventKind = iota
+	kindChurn
+	kindStop
+	kindDeploy
+)
+
+// simEvent 模拟时钟上的事件
+type simEvent struct {
+	at    time.Duration
+	kind  eventKind
+	seq   int
+	node  *registry.Node
+	churn *ChurnEvent
+	trace *TraceEvent
+}
+
+// eventQueue 按模拟时间排序的事件队列
+type eventQueue []*simEvent
+
+func (q eventQueue) Len() int { return len(q) }
+func (q eventQueue) Less(i, j int) bool {
+	if q[i].at != q[j].at {
+		return q[i].at < q[j].at
+	}
+	if q[i].kind != q[j].kind {
+		return q[i].kind < q[j].kind
+	}
+	return q[i].seq < q[j].seq
+}
+func (q eventQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *eventQueue) Push(x any)   { *q = append(*q, x.(*simEvent)) }
+func (q *eventQueue) Pop() any {
+	old := *q
+	event := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return event
+}
+
+// simulation 单次模拟的运行状态
+type simulation struct {
+	opts    Options
+	cluster *Cluster
+	queue   eventQueue
+	seq     int
+	now     time.Duration
+	metrics *collector
+	// running 运行中的 component（trace ID -> 部署记录）
+	running map[string]*deployment.Deployment
+}
+
+// Run 在模拟时钟上回放负载轨迹并返回评估报告
+func Run(ctx context.Context, opts Options) (*Report, error) {
+	if opts.Topology == nil || opts.Trace == nil || opts.Strategy == nil {
+		return nil, fmt.Errorf("topology, trace and strategy are required")
+	}
+
+	sim := &simulation{
+		opts: opts,
+		cluster: &Cluster{
+			Manager: registry.NewManager(registry.ManagerOptions{}),
+			Tracker: deployment.NewTracker(memoryRepo{}),
+		},
+		metrics: newCollector(),
+		running: make(map[string]*deployment.Deployment),
+	}
+	if err := sim.build(); err != nil {
+		return nil, err
+	}
+
+	for sim.queue.Len() > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		event := heap.Pop(&sim.queue).(*simEvent)
+		if opts.Horizon > 0 && event.at > opts.Horizon {
+			break
+		}
+		sim.advance(event.at)
+		if err := sim.handle(ctx, event); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Horizon > sim.now {
+		sim.advance(opts.Horizon)
+	}
+
+	return sim.metrics.report(opts.Strategy.Name(), sim.now), nil
+}
+
+// build 创建虚拟注册中心并将拓扑与轨迹转换为事件
+func (s *simulation) build() error {
+	for _, spec := range s.opts.Topology.Domains {
+		domain := &registry.Domain{
+			ID:     spec.ID,
+			Name:   spec.Name,
+			Labels: registry.Labels(spec.Labels).Clone(),
+		}
+		if err := s.cluster.Manager.AddDomain(domain); err != nil {
+			return fmt.Errorf("failed to add domain %s: %w", spec.ID, err)
+		}
+		for _, nodeSpec := range spec.Nodes {
+			for _, node := range nodeSpec.expand(spec.ID) {
+				if nodeSpec.JoinAt > 0 {
+					s.push(&simEvent{at: time.Duration(nodeSpec.JoinAt), kind: kindJoin, node: node})
+					continue
+				}
+				if err := s.cluster.Manager.AddNode(node); err != nil {
+					return fmt.Errorf("failed to add node %s: %w", node.ID, err)
+				}
+			}
+		}
+	}
+
+	for i := range s.opts.Topology.Churn {
+		churn := &s.opts.Topology.Churn[i]
+		s.push(&simEvent{at: time.Duration(churn.At), kind: kindChurn, churn: churn})
+	}
+	for i := range s.opts.Trace.Events {
+		event := &s.opts.Trace.Events[i]
+		kind := kindDeploy
+		if event.Type == EventStop {
+			kind = kindStop
+		}
+		s.push(&simEvent{at: time.Duration(event.At), kind: kind, trace: event})
+	}
+	return nil
+}
+
+func (s *simulation) push(event *simEvent) {
+	event.seq = s.seq
+	s.seq++
+	heap.Push(&s.queue, event)
+}
+
+// advance 推进模拟时钟，按经过的时长累计资源使用情况
+func (s *simulation) advance(to time.Duration) {
+	if to <= s.now {
+		return
+	}
+	nodes := make([]*registry.Node, 0)
+	for _, domain := range s.cluster.Manager.GetAllDomains() {
+		domainNodes, err := s.cluster.Manager.GetNodesByDomain(domain.ID)
+		if err != nil {
+			continue
+		}
+		nodes = append(nodes, domainNodes...)
+	}
+	s.metrics.sample(nodes, to-s.now)
+	s.now = to
+}
+
+func (s *simulation) handle(ctx context.Context, event *simEvent) error {
+	switch event.kind {
+	case kindJoin:
+		if err := s.cluster.Manager.AddNode(event.node); err != nil {
+			return fmt.Errorf("failed to add node %s: %w", event.node.ID, err)
+		}
+	case kindChurn:
+		s.handleChurn(ctx, event.churn)
+	case kindStop:
+		s.release(ctx, event.trace.ID)
+	case kindDeploy:
+		s.deploy(ctx, event.trace)
+	}
+	return nil
+}
+
+// handleChurn 处理节点故障、恢复与下线
+func (s *simulation) handleChurn(ctx context.Context, churn *ChurnEvent) {
+	switch churn.Action {
+	case ChurnFail:
+		if err := s.cluster.Manager.UpdateNodeStatus(churn.Node, registry.NodeStatusOffline); err != nil {
+			return
+		}
+		s.evict(ctx, churn.Node)
+	case ChurnRecover:
+		_ = s.cluster.Manager.UpdateNode(churn.Node, func(node *registry.Node) {
+			node.Status = registry.NodeStatusOnline
+			node.ResourceCapacity.Used = &registry.ResourceInfo{}
+			node.ResourceCapacity.Available = node.ResourceCapacity.Total.Clone()
+		})
+	case ChurnLeave:
+		if err := s.cluster.Manager.RemoveNode(churn.Node); err != nil {
+			return
+		}
+		s.evict(ctx, churn.Node)
+	}
+}
+
+// evict 移除故障节点上的全部 component
+func (s *simulation) evict(ctx context.Context, nodeID registry.NodeID) {
+	for id, d := range s.running {
+		if d.NodeID != nodeID {
+			continue
+		}
+		delete(s.running, id)
+		_ = s.cluster.Tracker.Delete(ctx, d.ID)
+		s.metrics.evicted++
+	}
+}
+
+// deploy 调用策略放置 component 并占用节点资源
+func (s *simulation) deploy(ctx context.Context, event *TraceEvent) {
+	req := event.request()
+	tenantID := event.Tenant
+	if tenantID == "" {
+		tenantID = "default"
+	}
+
+	node, err := s.opts.Strategy.Place(ctx, s.cluster, tenantID, req)
+	if err == nil && node == nil {
+		err = fmt.Errorf("strategy returned no node")
+	}
+	requested := requestedResources(req)
+	if err == nil {
+		if fits, name := node.ResourceCapacity.Available.Fits(requested); !fits || node.Status != registry.NodeStatusOnline {
+			err = fmt.Errorf("strategy chose infeasible node %s (%s)", node.ID, name)
+		}
+	}
+	if err != nil {
+		s.metrics.failed(err)
+		return
+	}
+
+	_ = s.cluster.Manager.UpdateNode(node.ID, func(n *registry.Node) {
+		n.ResourceCapacity.Available.Sub(requested)
+		n.ResourceCapacity.Used.Add(requested)
+	})
+	record := &deployment.Deployment{
+		ID:        event.ID,
+		Tenant:    tenantID,
+		DomainID:  node.DomainID,
+		NodeID:    node.ID,
+		NodeName:  node.Name,
+		Status:    deployment.StatusRunning,
+		Resources: requested,
+		Request:   req,
+	}
+	_ = s.cluster.Tracker.Create(ctx, record)
+	s.running[event.ID] = record
+	s.metrics.placed(node.DomainID, event.OriginDomain)
+
+	if event.Duration > 0 {
+		s.push(&simEvent{
+			at:    s.now + time.Duration(event.Duration),
+			kind:  kindStop,
+			trace: &TraceEvent{Type: EventStop, ID: event.ID},
+		})
+	}
+}
+
+// release 停止 component 并归还节点资源；未部署成功或已被驱逐的 component 忽略
+func (s *simulation) release(ctx context.Context, id string) {
+	d, ok := s.running[id]
+	if !ok {
+		return
+	}
+	delete(s.running, id)
+	_ = s.cluster.Tracker.Delete(ctx, d.ID)
+	_ = s.cluster.Manager.UpdateNode(d.NodeID, func(n *registry.Node) {
+		n.ResourceCapacity.Available.Add(d.Resources)
+		n.ResourceCapacity.Used.Sub(d.Resources)
+	})
+}
+
+// memoryRepo 模拟使用的空部署仓库，部署记录只保存在 Tracker 内存中
+type memoryRepo struct{}
+
+func (memoryRepo) SaveDeployment(context.Context, *repository.DeploymentDAO) error { return nil }
+func (memoryRepo) DeleteDeployment(context.Context, string) error                  { return nil }
+func (memoryRepo) GetDeployment(context.Context, string) (*repository.DeploymentDAO, error) {
+	return nil, nil
+}
+func (memoryRepo) GetAllDeployments(context.Context) ([]*repository.DeploymentDAO, error) {
+	return nil, nil
+}
+func (memoryRepo) Close() error { return nil }
@@ -0,0 +1,227 @@
+package simulator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+
+	"github.com/9triver/iarnet-global/internal/domain/deployment"
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	"github.com/9triver/iarnet-global/internal/domain/scheduler"
+	"github.com/9triver/iarnet-global/internal/domain/tenant"
+	schedulerpb "github.com/9triver/iarnet-global/internal/proto/scheduler"
+)
+
+// Cluster 策略可见的模拟集群状态
+type Cluster struct {
+	// Manager 虚拟注册中心，节点可用资源随放置结果实时更新
+	Manager *registry.Manager
+	// Tracker 模拟中运行的部署（状态均为 running）
+	Tracker *deployment.Tracker
+}
+
+// Strategy 调度策略：为部署请求选择节点
+type Strategy interface {
+	Name() string
+	Place(ctx context.Context, cluster *Cluster, tenantID string, req *schedulerpb.DeployComponentRequest) (*registry.Node, error)
+}
+
+// StrategyFactory 创建策略实例，seed 用于需要随机性的策略
+type StrategyFactory func(seed int64) Strategy
+
+var (
+	strategiesMu sync.RWMutex
+	strategies   = map[string]StrategyFactory{
+		"iarnet":    func(seed int64) Strategy { return &iarnetStrategy{rand: rand.New(rand.NewSource(seed))} },
+		"random":    func(seed int64) Strategy { return &randomStrategy{rand: rand.New(rand.NewSource(seed))} },
+		"first-fit": func(int64) Strategy { return fitStrategy{name: "first-fit"} },
+		"best-fit": func(int64) Strategy {
+			return fitStrategy{name: "best-fit", better: func(a, b float64) bool { return a < b }}
+		},
+		"worst-fit": func(int64) Strategy {
+			return fitStrategy{name: "worst-fit", better: func(a, b float64) bool { return a > b }}
+		},
+	}
+)
+
+// errNoFeasibleNode 没有节点满足请求
+var errNoFeasibleNode = errors.New("no node has sufficient capacity")
+
+// RegisterStrategy 注册自定义策略，同名策略会被覆盖
+func RegisterStrategy(name string, factory StrategyFactory) {
+	strategiesMu.Lock()
+	defer strategiesMu.Unlock()
+	strategies[name] = factory
+}
+
+// Strategies 返回已注册的策略名称
+func Strategies() []string {
+	strategiesMu.RLock()
+	defer strategiesMu.RUnlock()
+	names := make([]string, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewStrategy 按名称创建策略
+func NewStrategy(name string, seed int64) (Strategy, error) {
+	strategiesMu.RLock()
+	factory, ok := strategies[name]
+	strategiesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown strategy %q (available: %v)", name, Strategies())
+	}
+	return factory(seed), nil
+}
+
+// iarnetStrategy 全局调度器的实际放置流程：通过 SimulatePlacement 评估，在最高得分的候选节点中随机选择
+type iarnetStrategy struct {
+	rand    *rand.Rand
+	cluster *Cluster
+	service scheduler.Service
+}
+
+func (s *iarnetStrategy) Name() string { return "iarnet" }
+
+func (s *iarnetStrategy) Place(ctx context.Context, cluster *Cluster, tenantID string, req *schedulerpb.DeployComponentRequest) (*registry.Node, error) {
+	if s.service == nil || s.cluster != cluster {
+		s.cluster = cluster
+		s.service = scheduler.NewService(cluster.Manager, scheduler.Options{Tracker: cluster.Tracker})
+	}
+
+	ctx = tenant.WithIdentity(ctx, &tenant.Identity{Tenant: tenantID})
+	resp, err := s.service.SimulatePlacement(ctx, &schedulerpb.SimulatePlacementRequest{Request: req})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Success {
+		return nil, errors.New(resp.Error)
+	}
+	if !resp.Placeable {
+		return nil, errors.New(resp.PlacementError)
+	}
+
+	preferred := make([]*schedulerpb.PlacementCandidate, 0)
+	for _, c := range resp.Candidates {
+		if c.Preferred {
+			preferred = append(preferred, c)
+		}
+	}
+	chosen := preferred[s.rand.Intn(len(preferred))]
+	return cluster.Manager.GetNode(chosen.NodeId)
+}
+
+// randomStrategy 在满足条件的节点中均匀随机选择
+type randomStrategy struct {
+	rand *rand.Rand
+}
+
+func (s *randomStrategy) Name() string { return "random" }
+
+func (s *randomStrategy) Place(_ context.Context, cluster *Cluster, _ string, req *schedulerpb.DeployComponentRequest) (*registry.Node, error) {
+	nodes, err := feasibleNodes(cluster, req)
+	if err != nil {
+		return nil, err
+	}
+	return nodes[s.rand.Intn(len(nodes))], nil
+}
+
+// fitStrategy 装箱类策略：better 为空时按节点 ID 顺序选择第一个满足条件的节点（first-fit），
+// 否则按放置后的剩余资源比例选择最优节点（best-fit 取最小、worst-fit 取最大）
+type fitStrategy struct {
+	name   string
+	better func(a, b float64) bool
+}
+
+func (s fitStrategy) Name() string { return s.name }
+
+func (s fitStrategy) Place(_ context.Context, cluster *Cluster, _ string, req *schedulerpb.DeployComponentRequest) (*registry.Node, error) {
+	nodes, err := feasibleNodes(cluster, req)
+	if err != nil {
+		return nil, err
+	}
+	if s.better == nil {
+		return nodes[0], nil
+	}
+
+	requested := requestedResources(req)
+	best, bestLeft := nodes[0], leftover(nodes[0], requested)
+	for _, node := range nodes[1:] {
+		if left := leftover(node, requested); s.better(left, bestLeft) {
+			best, bestLeft = node, left
+		}
+	}
+	return best, nil
+}
+
+// leftover 放置后各项内置资源剩余比例之和
+func leftover(node *registry.Node, requested *registry.ResourceInfo) float64 {
+	total := node.ResourceCapacity.Total
+	available := node.ResourceCapacity.Available
+	var sum float64
+	for _, name := range []string{registry.ResourceCPU, registry.ResourceMemory, registry.ResourceGPU} {
+		if total.Get(name) > 0 {
+			sum += float64(available.Get(name)-requested.Get(name)) / float64(total.Get(name))
+		}
+	}
+	return sum
+}
+
+// feasibleNodes 按节点 ID 排序返回在线、未被标记为不可调度、标签匹配且资源充足的节点
+func feasibleNodes(cluster *Cluster, req *schedulerpb.DeployComponentRequest) ([]*registry.Node, error) {
+	selector, err := registry.ParseSelectors(req.ResourceRequest.GetTags())
+	if err != nil {
+		return nil, err
+	}
+	requested := requestedResources(req)
+
+	result := make([]*registry.Node, 0)
+	for _, domain := range cluster.Manager.GetAllDomains() {
+		nodes, err := cluster.Manager.GetNodesByDomain(domain.ID)
+		if err != nil {
+			continue
+		}
+		for _, node := range nodes {
+			if node.Status != registry.NodeStatusOnline || node.Cordoned {
+				continue
+			}
+			if len(selector) > 0 && !selector.Matches(registry.NodeLabelSet(node, domain)) {
+				continue
+			}
+			if fits, _ := node.ResourceCapacity.Available.Fits(requested); !fits {
+				continue
+			}
+			result = append(result, node)
+		}
+	}
+	if len(result) == 0 {
+		return nil, errNoFeasibleNode
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result, nil
+}
+
+// requestedResources 部署请求的资源需求
+func requestedResources(req *schedulerpb.DeployComponentRequest) *registry.ResourceInfo {
+	info := req.GetResourceRequest()
+	requested := &registry.ResourceInfo{
+		CPU:    info.GetCpu(),
+		Memory: info.GetMemory(),
+		GPU:    info.GetGpu(),
+	}
+	for name, value := range info.GetExtended() {
+		if requested.Extended == nil {
+			requested.Extended = make(map[string]int64)
+		}
+		requested.Extended[name] = value
+	}
+	return requested
+}
@@ -0,0 +1,171 @@
+package simulator
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	"gopkg.in/yaml.v2"
+)
+
+// Duration 支持 "30s"、"5m" 等写法的时长，纯数字按秒解析
+type Duration time.Duration
+
+// UnmarshalYAML 解析时长
+func (d *Duration) UnmarshalYAML(unmarshal func(any) error) error {
+	var seconds float64
+	if err := unmarshal(&seconds); err == nil {
+		*d = Duration(seconds * float64(time.Second))
+		return nil
+	}
+	var text string
+	if err := unmarshal(&text); err != nil {
+		return err
+	}
+	parsed, err := time.ParseDuration(text)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q: %w", text, err)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Topology 虚拟集群拓扑
+type Topology struct {
+	Domains []DomainSpec `yaml:"domains"`
+	// Churn 节点故障、恢复与下线事件
+	Churn []ChurnEvent `yaml:"churn"`
+}
+
+// DomainSpec 域定义
+type DomainSpec struct {
+	ID     string            `yaml:"id"`
+	Name   string            `yaml:"name"`
+	Labels map[string]string `yaml:"labels"`
+	Nodes  []NodeSpec        `yaml:"nodes"`
+}
+
+// NodeSpec 节点定义，count 大于 1 时展开为 <id>-1 … <id>-<count>
+type NodeSpec struct {
+	ID       string            `yaml:"id"`
+	Count    int               `yaml:"count"`
+	CPU      int64             `yaml:"cpu"`
+	Memory   int64             `yaml:"memory"`
+	GPU      int64             `yaml:"gpu"`
+	Extended map[string]int64  `yaml:"extended"`
+	Labels   map[string]string `yaml:"labels"`
+	// JoinAt 节点加入集群的模拟时间，为 0 表示初始即在线
+	JoinAt Duration `yaml:"join_at"`
+}
+
+// ChurnAction 节点变化类型
+type ChurnAction string
+
+const (
+	// ChurnFail 节点离线，其上的 component 丢失
+	ChurnFail ChurnAction = "fail"
+	// ChurnRecover 离线节点恢复，可用资源重置为总资源
+	ChurnRecover ChurnAction = "recover"
+	// ChurnLeave 节点从注册中心移除，其上的 component 丢失
+	ChurnLeave ChurnAction = "leave"
+)
+
+// ChurnEvent 节点变化事件
+type ChurnEvent struct {
+	At     Duration    `yaml:"at"`
+	Node   string      `yaml:"node"`
+	Action ChurnAction `yaml:"action"`
+}
+
+// LoadTopology 从 YAML 文件加载拓扑
+func LoadTopology(file string) (*Topology, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	topology := &Topology{}
+	if err := yaml.Unmarshal(data, topology); err != nil {
+		return nil, fmt.Errorf("failed to parse topology: %w", err)
+	}
+	if err := topology.Validate(); err != nil {
+		return nil, err
+	}
+	return topology, nil
+}
+
+// Validate 校验拓扑定义
+func (t *Topology) Validate() error {
+	if len(t.Domains) == 0 {
+		return fmt.Errorf("topology has no domains")
+	}
+	domains := make(map[string]struct{})
+	nodes := make(map[string]struct{})
+	for _, domain := range t.Domains {
+		if domain.ID == "" {
+			return fmt.Errorf("domain id is required")
+		}
+		if _, exists := domains[domain.ID]; exists {
+			return fmt.Errorf("duplicate domain %s", domain.ID)
+		}
+		domains[domain.ID] = struct{}{}
+		for _, spec := range domain.Nodes {
+			if spec.ID == "" {
+				return fmt.Errorf("domain %s: node id is required", domain.ID)
+			}
+			for _, node := range spec.expand(domain.ID) {
+				if _, exists := nodes[node.ID]; exists {
+					return fmt.Errorf("duplicate node %s", node.ID)
+				}
+				nodes[node.ID] = struct{}{}
+			}
+		}
+	}
+	for i, event := range t.Churn {
+		if _, ok := nodes[event.Node]; !ok {
+			return fmt.Errorf("churn[%d]: unknown node %s", i, event.Node)
+		}
+		switch event.Action {
+		case ChurnFail, ChurnRecover, ChurnLeave:
+		default:
+			return fmt.Errorf("churn[%d]: unknown action %q", i, event.Action)
+		}
+	}
+	return nil
+}
+
+// expand 按 count 展开节点定义
+func (s NodeSpec) expand(domainID string) []*registry.Node {
+	count := s.Count
+	if count <= 0 {
+		count = 1
+	}
+	nodes := make([]*registry.Node, 0, count)
+	for i := 1; i <= count; i++ {
+		id := s.ID
+		if count > 1 {
+			id = fmt.Sprintf("%s-%d", s.ID, i)
+		}
+		total := &registry.ResourceInfo{CPU: s.CPU, Memory: s.Memory, GPU: s.GPU}
+		for name, value := range s.Extended {
+			if total.Extended == nil {
+				total.Extended = make(map[string]int64)
+			}
+			total.Extended[name] = value
+		}
+		nodes = append(nodes, &registry.Node{
+			ID:       id,
+			DomainID: domainID,
+			Name:     id,
+			Address:  "sim://" + id,
+			Status:   registry.NodeStatusOnline,
+			Labels:   registry.Labels(s.Labels).Clone(),
+			ResourceCapacity: &registry.ResourceCapacity{
+				Total:     total,
+				Used:      &registry.ResourceInfo{},
+				Available: total.Clone(),
+			},
+		})
+	}
+	return nodes
+}
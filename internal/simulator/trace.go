@@ -0,0 +1,100 @@
+package simulator
+
+import (
+	"fmt"
+	"os"
+
+	resourcepb "github.com/9triver/iarnet-global/internal/proto/resource"
+	schedulerpb "github.com/9triver/iarnet-global/internal/proto/scheduler"
+	"gopkg.in/yaml.v2"
+)
+
+// EventType 负载事件类型
+type EventType string
+
+const (
+	// EventDeploy 部署 component
+	EventDeploy EventType = "deploy"
+	// EventStop 停止 component
+	EventStop EventType = "stop"
+)
+
+// Trace 待回放的负载轨迹
+type Trace struct {
+	Events []TraceEvent `yaml:"events"`
+}
+
+// TraceEvent 单个负载事件
+type TraceEvent struct {
+	At   Duration  `yaml:"at"`
+	Type EventType `yaml:"type"`
+	// ID component 标识，stop 事件通过它引用之前的 deploy 事件
+	ID     string `yaml:"id"`
+	Tenant string `yaml:"tenant"`
+	// OriginDomain 发起请求的域，用于统计跨域放置比例
+	OriginDomain string            `yaml:"origin_domain"`
+	CPU          int64             `yaml:"cpu"`
+	Memory       int64             `yaml:"memory"`
+	GPU          int64             `yaml:"gpu"`
+	Extended     map[string]int64  `yaml:"extended"`
+	Tags         []string          `yaml:"tags"`
+	Labels       map[string]string `yaml:"labels"`
+	Priority     int32             `yaml:"priority"`
+	// Duration 运行时长，到期自动停止；为 0 时需由 stop 事件停止
+	Duration Duration `yaml:"duration"`
+}
+
+// LoadTrace 从 YAML 文件加载负载轨迹
+func LoadTrace(file string) (*Trace, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	trace := &Trace{}
+	if err := yaml.Unmarshal(data, trace); err != nil {
+		return nil, fmt.Errorf("failed to parse trace: %w", err)
+	}
+	if err := trace.Validate(); err != nil {
+		return nil, err
+	}
+	return trace, nil
+}
+
+// Validate 校验负载轨迹
+func (t *Trace) Validate() error {
+	deployed := make(map[string]struct{})
+	for i, event := range t.Events {
+		if event.ID == "" {
+			return fmt.Errorf("events[%d]: id is required", i)
+		}
+		switch event.Type {
+		case EventDeploy:
+			if _, exists := deployed[event.ID]; exists {
+				return fmt.Errorf("events[%d]: duplicate deploy of %s", i, event.ID)
+			}
+			deployed[event.ID] = struct{}{}
+		case EventStop:
+			if _, exists := deployed[event.ID]; !exists {
+				return fmt.Errorf("events[%d]: stop of unknown component %s", i, event.ID)
+			}
+		default:
+			return fmt.Errorf("events[%d]: unknown type %q", i, event.Type)
+		}
+	}
+	return nil
+}
+
+// request 将部署事件转换为调度请求
+func (e *TraceEvent) request() *schedulerpb.DeployComponentRequest {
+	return &schedulerpb.DeployComponentRequest{
+		ResourceRequest: &resourcepb.Info{
+			Cpu:      e.CPU,
+			Memory:   e.Memory,
+			Gpu:      e.GPU,
+			Tags:     e.Tags,
+			Extended: e.Extended,
+		},
+		Labels:   e.Labels,
+		Priority: e.Priority,
+	}
+}
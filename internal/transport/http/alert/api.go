@@ -0,0 +1,79 @@
+package alert
+
+import (
+	"net/http"
+
+	"github.com/9triver/iarnet-global/internal/domain/alert"
+	"github.com/9triver/iarnet-global/internal/domain/tenant"
+	"github.com/9triver/iarnet-global/internal/transport/http/util/identity"
+	"github.com/9triver/iarnet-global/internal/transport/http/util/response"
+	"github.com/gorilla/mux"
+)
+
+// RegisterRoutes 注册告警相关的 HTTP 路由
+func RegisterRoutes(router *mux.Router, service alert.Service, tenants tenant.Service) {
+	api := NewAPI(service, tenants)
+	router.HandleFunc("/alerts", api.handleGetAlerts).Methods("GET")
+	router.HandleFunc("/alerts/rules", api.handleGetRules).Methods("GET")
+}
+
+type API struct {
+	service alert.Service
+	tenants tenant.Service
+}
+
+func NewAPI(service alert.Service, tenants tenant.Service) *API {
+	return &API{
+		service: service,
+		tenants: tenants,
+	}
+}
+
+// handleGetAlerts 获取告警，可按 state（firing / resolved）过滤
+// 携带租户身份的请求只能查看可使用的域的告警
+func (api *API) handleGetAlerts(w http.ResponseWriter, r *http.Request) {
+	state := alert.State(r.URL.Query().Get("state"))
+	switch state {
+	case "", alert.StateFiring, alert.StateResolved:
+	default:
+		response.BadRequest("invalid state: " + string(state)).WriteJSON(w)
+		return
+	}
+
+	alerts := api.service.ListAlerts(state)
+	resp := GetAlertsResponse{
+		Alerts: make([]AlertItem, 0, len(alerts)),
+	}
+	for _, a := range alerts {
+		if !api.canUseDomain(r, a.DomainID) {
+			continue
+		}
+		resp.Alerts = append(resp.Alerts, convertAlert(a))
+	}
+	resp.Total = len(resp.Alerts)
+
+	response.Success(resp).WriteJSON(w)
+}
+
+// handleGetRules 获取告警规则，携带租户身份的请求只能查看对全部域生效或针对可使用的域的规则
+func (api *API) handleGetRules(w http.ResponseWriter, r *http.Request) {
+	rules := api.service.Rules()
+	resp := GetRulesResponse{
+		Rules: make([]RuleItem, 0, len(rules)),
+	}
+	for _, rule := range rules {
+		if rule.DomainID != "" && !api.canUseDomain(r, rule.DomainID) {
+			continue
+		}
+		resp.Rules = append(resp.Rules, convertRule(rule))
+	}
+	resp.Total = len(resp.Rules)
+
+	response.Success(resp).WriteJSON(w)
+}
+
+// canUseDomain 携带租户身份的请求只能查看可使用的域
+func (api *API) canUseDomain(r *http.Request, domainID string) bool {
+	id := identity.Scoped(r)
+	return id == nil || api.tenants == nil || api.tenants.CanUseDomain(id.Tenant, domainID)
+}
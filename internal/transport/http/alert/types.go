@@ -0,0 +1,80 @@
+package alert
+
+import (
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/alert"
+)
+
+// AlertItem 告警
+type AlertItem struct {
+	ID         string `json:"id"`                    // 告警 ID
+	Rule       string `json:"rule"`                  // 规则名称
+	Type       string `json:"type"`                  // 规则类型
+	DomainID   string `json:"domain_id"`             // 域 ID
+	DomainName string `json:"domain_name,omitempty"` // 域名称
+	Resource   string `json:"resource,omitempty"`    // 资源名称（capacity_exhaustion）
+	NodeID     string `json:"node_id,omitempty"`     // head 节点 ID（head_node_offline）
+	State      string `json:"state"`                 // firing / resolved
+	Message    string `json:"message"`               // 告警描述
+	StartsAt   string `json:"starts_at"`             // 触发时间
+	UpdatedAt  string `json:"updated_at"`            // 最近一次评估时间
+	ResolvedAt string `json:"resolved_at,omitempty"` // 恢复时间
+	ExhaustsAt string `json:"exhausts_at,omitempty"` // 预计耗尽时间（capacity_exhaustion）
+}
+
+// GetAlertsResponse 获取告警列表响应
+type GetAlertsResponse struct {
+	Alerts []AlertItem `json:"alerts"` // 告警中的告警按触发时间排序，其后为最近恢复的告警
+	Total  int         `json:"total"`  // 告警数量
+}
+
+// RuleItem 告警规则
+type RuleItem struct {
+	Name        string  `json:"name"`                   // 规则名称
+	Type        string  `json:"type"`                   // 规则类型
+	DomainID    string  `json:"domain_id,omitempty"`    // 域 ID，为空时对全部域生效
+	Resource    string  `json:"resource,omitempty"`     // 关注的资源，为空时关注全部资源
+	WithinHours float64 `json:"within_hours,omitempty"` // 预测范围（小时）
+	ForSeconds  float64 `json:"for_seconds,omitempty"`  // 持续时间（秒）
+}
+
+// GetRulesResponse 获取告警规则列表响应
+type GetRulesResponse struct {
+	Rules []RuleItem `json:"rules"` // 规则列表
+	Total int        `json:"total"` // 规则数量
+}
+
+func convertAlert(a *alert.Alert) AlertItem {
+	item := AlertItem{
+		ID:         a.ID,
+		Rule:       a.Rule,
+		Type:       string(a.Type),
+		DomainID:   a.DomainID,
+		DomainName: a.DomainName,
+		Resource:   a.Resource,
+		NodeID:     a.NodeID,
+		State:      string(a.State),
+		Message:    a.Message,
+		StartsAt:   a.StartsAt.Format(time.RFC3339),
+		UpdatedAt:  a.UpdatedAt.Format(time.RFC3339),
+	}
+	if a.ResolvedAt != nil {
+		item.ResolvedAt = a.ResolvedAt.Format(time.RFC3339)
+	}
+	if a.ExhaustsAt != nil {
+		item.ExhaustsAt = a.ExhaustsAt.Format(time.RFC3339)
+	}
+	return item
+}
+
+func convertRule(rule alert.Rule) RuleItem {
+	return RuleItem{
+		Name:        rule.Name,
+		Type:        string(rule.Type),
+		DomainID:    rule.DomainID,
+		Resource:    rule.Resource,
+		WithinHours: rule.Within.Hours(),
+		ForSeconds:  rule.For.Seconds(),
+	}
+}
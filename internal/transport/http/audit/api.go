@@ -0,0 +1,76 @@
+package audit
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/audit"
+	"github.com/9triver/iarnet-global/internal/transport/http/util/identity"
+	"github.com/9triver/iarnet-global/internal/transport/http/util/response"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// RegisterRoutes 注册审计事件相关的 HTTP 路由
+func RegisterRoutes(router *mux.Router, service audit.Service) {
+	api := NewAPI(service)
+	router.HandleFunc("/audit/events", api.handleGetEvents).Methods("GET")
+}
+
+type API struct {
+	service audit.Service
+}
+
+func NewAPI(service audit.Service) *API {
+	return &API{
+		service: service,
+	}
+}
+
+// handleGetEvents 获取审计事件，可按 type / tenant / deployment_id / since（RFC3339）过滤，limit 限制数量
+// 携带租户身份的请求只能查看涉及自身租户的事件
+func (api *API) handleGetEvents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := audit.Filter{
+		Type:         audit.EventType(query.Get("type")),
+		Tenant:       query.Get("tenant"),
+		DeploymentID: query.Get("deployment_id"),
+	}
+	if id := identity.Scoped(r); id != nil {
+		filter.Tenant = id.Tenant
+	}
+	if since := query.Get("since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			response.BadRequest("invalid since: " + err.Error()).WriteJSON(w)
+			return
+		}
+		filter.Since = t
+	}
+	if limit := query.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 0 {
+			response.BadRequest("invalid limit: " + limit).WriteJSON(w)
+			return
+		}
+		filter.Limit = n
+	}
+
+	events, err := api.service.List(r.Context(), filter)
+	if err != nil {
+		logrus.Errorf("Failed to list audit events: %v", err)
+		response.InternalError("failed to list audit events: " + err.Error()).WriteJSON(w)
+		return
+	}
+
+	resp := GetEventsResponse{
+		Events: make([]EventItem, 0, len(events)),
+		Total:  len(events),
+	}
+	for _, event := range events {
+		resp.Events = append(resp.Events, convertEvent(event))
+	}
+
+	response.Success(resp).WriteJSON(w)
+}
@@ -0,0 +1,40 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/audit"
+)
+
+// EventItem 审计事件
+type EventItem struct {
+	ID           string            `json:"id"`                      // 事件 ID
+	Type         string            `json:"type"`                    // 事件类型
+	Tenant       string            `json:"tenant,omitempty"`        // 涉及的租户
+	DeploymentID string            `json:"deployment_id,omitempty"` // 涉及的部署
+	DomainID     string            `json:"domain_id,omitempty"`     // 域 ID
+	NodeID       string            `json:"node_id,omitempty"`       // 节点 ID
+	Message      string            `json:"message"`                 // 事件描述
+	Details      map[string]string `json:"details,omitempty"`       // 附加信息
+	CreatedAt    string            `json:"created_at"`              // 发生时间
+}
+
+// GetEventsResponse 获取审计事件列表响应
+type GetEventsResponse struct {
+	Events []EventItem `json:"events"` // 事件列表（按时间倒序）
+	Total  int         `json:"total"`  // 返回的事件数
+}
+
+func convertEvent(event *audit.Event) EventItem {
+	return EventItem{
+		ID:           event.ID,
+		Type:         string(event.Type),
+		Tenant:       event.Tenant,
+		DeploymentID: event.DeploymentID,
+		DomainID:     event.DomainID,
+		NodeID:       event.NodeID,
+		Message:      event.Message,
+		Details:      event.Details,
+		CreatedAt:    event.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,136 @@
+package cluster
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/9triver/iarnet-global/internal/ha"
+	"github.com/9triver/iarnet-global/internal/transport/http/util/response"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// LeaderHeader 转发的响应中携带处理请求的 leader ID
+	LeaderHeader = "X-Iarnet-Leader"
+	// ForwardedHeader 转发的请求中携带转发方副本 ID，避免在 leader 切换期间循环转发
+	ForwardedHeader = "X-Iarnet-Forwarded-By"
+)
+
+// RegisterRoutes 注册集群状态相关的 HTTP 路由
+func RegisterRoutes(router *mux.Router, replica *ha.Replica) {
+	api := NewAPI(replica)
+	router.HandleFunc("/cluster/status", api.handleGetStatus).Methods("GET")
+}
+
+type API struct {
+	replica *ha.Replica
+}
+
+func NewAPI(replica *ha.Replica) *API {
+	return &API{
+		replica: replica,
+	}
+}
+
+// handleGetStatus 获取本副本的 Raft 状态与集群成员
+func (api *API) handleGetStatus(w http.ResponseWriter, r *http.Request) {
+	response.Success(api.replica.Status()).WriteJSON(w)
+}
+
+// Middleware follower 将请求转发给 leader 处理（/cluster 接口除外）
+// 项目、配额与审计事件只保存在 leader 本地，因此读请求同样转发，保证客户端看到一致的状态；
+// leader 处理写请求后先将变化提交到集群再返回响应
+func Middleware(replica *ha.Replica) mux.MiddlewareFunc {
+	proxies := &proxyCache{proxies: make(map[string]*httputil.ReverseProxy)}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/cluster/") {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if replica.IsLeader() {
+				serveAndSync(replica, next, w, r)
+				return
+			}
+
+			leader, ok := replica.Leader()
+			if !ok || leader.HTTPAddr == "" {
+				response.ServiceUnavailable("no leader available, retry later").WriteJSON(w)
+				return
+			}
+			if r.Header.Get(ForwardedHeader) != "" {
+				response.ServiceUnavailable("leader changed while forwarding, retry later").WriteJSON(w)
+				return
+			}
+
+			r.Header.Set(ForwardedHeader, replica.ID())
+			w.Header().Set(LeaderHeader, leader.ID)
+			proxies.get(leader.HTTPAddr).ServeHTTP(w, r)
+		})
+	}
+}
+
+// serveAndSync 处理请求，写请求的响应在变化提交后才发送，提交失败时返回 503
+func serveAndSync(replica *ha.Replica, next http.Handler, w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		next.ServeHTTP(w, r)
+		return
+	}
+
+	buffered := &bufferedWriter{ResponseWriter: w, code: http.StatusOK}
+	next.ServeHTTP(buffered, r)
+	if err := replica.Sync(r.Context()); err != nil {
+		logrus.Warnf("Failed to replicate changes of %s %s: %v", r.Method, r.URL.Path, err)
+		// 变化未提交到集群，切换 leader 后可能丢失，不能向客户端报告成功
+		if buffered.code >= 200 && buffered.code < 300 {
+			w.Header().Del("Content-Length")
+			response.ServiceUnavailable("failed to replicate changes, retry later: " + err.Error()).WriteJSON(w)
+			return
+		}
+	}
+	w.WriteHeader(buffered.code)
+	w.Write(buffered.body.Bytes())
+}
+
+// bufferedWriter 缓存响应状态码与响应体，响应头直接写入原始 ResponseWriter
+type bufferedWriter struct {
+	http.ResponseWriter
+	code int
+	body bytes.Buffer
+}
+
+func (b *bufferedWriter) WriteHeader(code int) {
+	b.code = code
+}
+
+func (b *bufferedWriter) Write(data []byte) (int, error) {
+	return b.body.Write(data)
+}
+
+// proxyCache 按 leader 地址缓存反向代理
+type proxyCache struct {
+	mu      sync.Mutex
+	proxies map[string]*httputil.ReverseProxy
+}
+
+func (c *proxyCache) get(addr string) *httputil.ReverseProxy {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	proxy, ok := c.proxies[addr]
+	if !ok {
+		proxy = httputil.NewSingleHostReverseProxy(&url.URL{Scheme: "http", Host: addr})
+		proxy.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+			logrus.Warnf("Failed to forward %s %s to leader %s: %v", r.Method, r.URL.Path, addr, err)
+			response.ServiceUnavailable("failed to reach leader: " + err.Error()).WriteJSON(w)
+		}
+		c.proxies[addr] = proxy
+	}
+	return proxy
+}
@@ -0,0 +1,175 @@
+package cluster
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/ha"
+	"github.com/gorilla/mux"
+)
+
+const testTimeout = 10 * time.Second
+
+// kvStore 以内存 map 保存的复制状态
+type kvStore struct {
+	mu    sync.Mutex
+	state map[string][]byte
+}
+
+func (s *kvStore) ExportState(ctx context.Context) (map[string][]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state := make(map[string][]byte, len(s.state))
+	for key, value := range s.state {
+		state[key] = value
+	}
+	return state, nil
+}
+
+func (s *kvStore) ApplyState(ctx context.Context, changes map[string][]byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, value := range changes {
+		if len(value) == 0 {
+			delete(s.state, key)
+		} else {
+			s.state[key] = value
+		}
+	}
+	return nil
+}
+
+func (s *kvStore) RestoreState(ctx context.Context, state map[string][]byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = make(map[string][]byte, len(state))
+	for key, value := range state {
+		s.state[key] = value
+	}
+	return nil
+}
+
+func (s *kvStore) get(key string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return string(s.state[key])
+}
+
+// handler 按路径读写本副本的 kvStore，响应头中带上处理请求的副本 ID
+func (s *kvStore) handler(id string) http.Handler {
+	router := mux.NewRouter()
+	router.HandleFunc("/kv/{key}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Served-By", id)
+		if r.Method == http.MethodGet {
+			io.WriteString(w, s.get(mux.Vars(r)["key"]))
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		s.mu.Lock()
+		s.state[mux.Vars(r)["key"]] = body
+		s.mu.Unlock()
+	}).Methods("GET", "PUT")
+	return router
+}
+
+func TestMiddlewareForwardsFollowerWritesToLeader(t *testing.T) {
+	ids := []string{"a", "b", "c"}
+	listeners := make(map[string]net.Listener)
+	members := make([]ha.Peer, 0, len(ids))
+	for _, id := range ids {
+		lis, err := net.Listen("tcp4", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("failed to listen: %v", err)
+		}
+		listeners[id] = lis
+		members = append(members, ha.Peer{ID: id, HTTPAddr: lis.Addr().String()})
+	}
+
+	stores := make(map[string]*kvStore)
+	cluster, err := ha.NewLocalCluster(members, func(r *ha.Replica) error {
+		store := &kvStore{state: make(map[string][]byte)}
+		stores[r.ID()] = store
+		r.Register("kv", store)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to create cluster: %v", err)
+	}
+	if err := cluster.Start(context.Background()); err != nil {
+		t.Fatalf("failed to start cluster: %v", err)
+	}
+	defer cluster.Stop()
+
+	servers := make(map[string]*httptest.Server)
+	for _, replica := range cluster.Replicas {
+		server := httptest.NewUnstartedServer(Middleware(replica)(stores[replica.ID()].handler(replica.ID())))
+		server.Listener.Close()
+		server.Listener = listeners[replica.ID()]
+		server.Start()
+		defer server.Close()
+		servers[replica.ID()] = server
+	}
+
+	leader, err := cluster.WaitLeader(testTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var follower *ha.Replica
+	for _, replica := range cluster.Replicas {
+		if replica != leader {
+			follower = replica
+			break
+		}
+	}
+	// 等待 follower 得知 leader，否则请求会以 503 拒绝
+	if err := cluster.WaitApplied(testTimeout); err != nil {
+		t.Fatal(err)
+	}
+
+	req, _ := http.NewRequest(http.MethodPut, servers[follower.ID()].URL+"/kv/greeting", strings.NewReader("hello"))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("PUT via follower: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("PUT via follower: status %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if got := resp.Header.Get("X-Served-By"); got != leader.ID() {
+		t.Errorf("write served by %q, want leader %s", got, leader.ID())
+	}
+	if got := resp.Header.Get(LeaderHeader); got != leader.ID() {
+		t.Errorf("%s = %q, want %s", LeaderHeader, got, leader.ID())
+	}
+	if got := stores[leader.ID()].get("greeting"); got != "hello" {
+		t.Fatalf("leader: greeting = %q, want %q", got, "hello")
+	}
+
+	// 写入经 leader 复制回全部副本
+	if err := cluster.WaitApplied(testTimeout); err != nil {
+		t.Fatal(err)
+	}
+	for id, store := range stores {
+		if got := store.get("greeting"); got != "hello" {
+			t.Errorf("replica %s: greeting = %q, want %q", id, got, "hello")
+		}
+	}
+
+	// 读请求同样转发给 leader
+	resp, err = http.Get(servers[follower.ID()].URL + "/kv/greeting")
+	if err != nil {
+		t.Fatalf("GET via follower: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "hello" || resp.Header.Get("X-Served-By") != leader.ID() {
+		t.Errorf("GET via follower: body %q served by %q, want %q served by %s", body, resp.Header.Get("X-Served-By"), "hello", leader.ID())
+	}
+}
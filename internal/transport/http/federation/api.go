@@ -0,0 +1,195 @@
+package federation
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/9triver/iarnet-global/internal/domain/federation"
+	"github.com/9triver/iarnet-global/internal/transport/http/util/identity"
+	"github.com/9triver/iarnet-global/internal/transport/http/util/response"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// RegisterRoutes 注册联邦相关的 HTTP 路由
+func RegisterRoutes(router *mux.Router, service federation.Service) {
+	api := NewAPI(service)
+	router.HandleFunc("/federation/peers", api.handleGetPeers).Methods("GET")
+	router.HandleFunc("/federation/peers", api.handleCreatePeer).Methods("POST")
+	router.HandleFunc("/federation/peers/{id}", api.handleGetPeer).Methods("GET")
+	router.HandleFunc("/federation/peers/{id}", api.handleUpdatePeer).Methods("PUT")
+	router.HandleFunc("/federation/peers/{id}", api.handleDeletePeer).Methods("DELETE")
+	router.HandleFunc("/federation/peers/{id}/token", api.handleRotateToken).Methods("POST")
+	router.HandleFunc("/federation/peers/{id}/summary", api.handleGetLocalSummary).Methods("GET")
+}
+
+type API struct {
+	service federation.Service
+}
+
+func NewAPI(service federation.Service) *API {
+	return &API{
+		service: service,
+	}
+}
+
+// handleGetPeers 获取对等实例列表及最近一次交换的摘要
+func (api *API) handleGetPeers(w http.ResponseWriter, r *http.Request) {
+	if !authorizeAdmin(w, r) {
+		return
+	}
+	peers := api.service.ListPeers(r.Context())
+	response.Success(GetPeersResponse{
+		InstanceID: api.service.InstanceID(),
+		Peers:      peers,
+		Total:      len(peers),
+	}).WriteJSON(w)
+}
+
+// handleCreatePeer 添加对等实例，返回仅展示一次的入站令牌
+func (api *API) handleCreatePeer(w http.ResponseWriter, r *http.Request) {
+	if !authorizeAdmin(w, r) {
+		return
+	}
+	req := PeerRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logrus.Errorf("Failed to decode create federation peer request: %v", err)
+		response.BadRequest("invalid request body: " + err.Error()).WriteJSON(w)
+		return
+	}
+
+	peer, token, err := api.service.CreatePeer(r.Context(), req.toSpec())
+	if err != nil {
+		writeFederationError(w, err)
+		return
+	}
+
+	response.Created(CreatePeerResponse{Peer: peer, InboundToken: token}).WriteJSON(w)
+}
+
+// handleGetPeer 获取单个对等实例
+func (api *API) handleGetPeer(w http.ResponseWriter, r *http.Request) {
+	if !authorizeAdmin(w, r) {
+		return
+	}
+	id := mux.Vars(r)["id"]
+	if id == "" {
+		response.BadRequest("peer id is required").WriteJSON(w)
+		return
+	}
+
+	status, err := api.service.GetPeer(r.Context(), id)
+	if err != nil {
+		writeFederationError(w, err)
+		return
+	}
+
+	response.Success(status).WriteJSON(w)
+}
+
+// handleUpdatePeer 更新对等实例配置
+func (api *API) handleUpdatePeer(w http.ResponseWriter, r *http.Request) {
+	if !authorizeAdmin(w, r) {
+		return
+	}
+	id := mux.Vars(r)["id"]
+	if id == "" {
+		response.BadRequest("peer id is required").WriteJSON(w)
+		return
+	}
+
+	req := PeerRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logrus.Errorf("Failed to decode update federation peer request: %v", err)
+		response.BadRequest("invalid request body: " + err.Error()).WriteJSON(w)
+		return
+	}
+
+	peer, err := api.service.UpdatePeer(r.Context(), id, req.toSpec())
+	if err != nil {
+		writeFederationError(w, err)
+		return
+	}
+
+	response.Success(peer).WriteJSON(w)
+}
+
+// handleDeletePeer 删除对等实例，仍有部署转发到该实例时拒绝
+func (api *API) handleDeletePeer(w http.ResponseWriter, r *http.Request) {
+	if !authorizeAdmin(w, r) {
+		return
+	}
+	id := mux.Vars(r)["id"]
+	if id == "" {
+		response.BadRequest("peer id is required").WriteJSON(w)
+		return
+	}
+
+	if err := api.service.DeletePeer(r.Context(), id); err != nil {
+		writeFederationError(w, err)
+		return
+	}
+
+	response.Success(nil).WriteJSON(w)
+}
+
+// handleRotateToken 为对等实例重新签发入站令牌
+func (api *API) handleRotateToken(w http.ResponseWriter, r *http.Request) {
+	if !authorizeAdmin(w, r) {
+		return
+	}
+	id := mux.Vars(r)["id"]
+	if id == "" {
+		response.BadRequest("peer id is required").WriteJSON(w)
+		return
+	}
+
+	token, err := api.service.RotateToken(r.Context(), id)
+	if err != nil {
+		writeFederationError(w, err)
+		return
+	}
+
+	response.Success(RotateTokenResponse{InboundToken: token}).WriteJSON(w)
+}
+
+// handleGetLocalSummary 查看本实例向对等实例公开的容量摘要
+func (api *API) handleGetLocalSummary(w http.ResponseWriter, r *http.Request) {
+	if !authorizeAdmin(w, r) {
+		return
+	}
+	id := mux.Vars(r)["id"]
+	if id == "" {
+		response.BadRequest("peer id is required").WriteJSON(w)
+		return
+	}
+	if _, err := api.service.GetPeer(r.Context(), id); err != nil {
+		writeFederationError(w, err)
+		return
+	}
+
+	response.Success(api.service.LocalSummary(id)).WriteJSON(w)
+}
+
+// authorizeAdmin 联邦配置只能由管理员管理
+func authorizeAdmin(w http.ResponseWriter, r *http.Request) bool {
+	if identity.Scoped(r) != nil {
+		response.Forbidden("tenant-scoped requests cannot manage federation").WriteJSON(w)
+		return false
+	}
+	return true
+}
+
+// writeFederationError 将联邦操作错误转换为 HTTP 响应
+func writeFederationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, federation.ErrPeerNotFound):
+		response.NotFound("federation peer not found").WriteJSON(w)
+	case errors.Is(err, federation.ErrPeerAlreadyExists), errors.Is(err, federation.ErrInvalidPeer), errors.Is(err, federation.ErrPeerInUse):
+		response.BadRequest(err.Error()).WriteJSON(w)
+	default:
+		logrus.Errorf("Failed to handle federation request: %v", err)
+		response.InternalError(err.Error()).WriteJSON(w)
+	}
+}
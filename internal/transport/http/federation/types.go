@@ -0,0 +1,68 @@
+package federation
+
+import (
+	"github.com/9triver/iarnet-global/internal/domain/federation"
+	"github.com/9triver/iarnet-global/internal/domain/quota"
+)
+
+// PeerRequest 创建或更新对等实例请求
+type PeerRequest struct {
+	Name    string `json:"name"`    // 名称
+	Address string `json:"address"` // 对等实例的 Registry RPC 地址（host:port）
+	// Token 对等实例为本实例签发的令牌，更新时为空表示保持不变
+	Token         string             `json:"token,omitempty"`
+	AllowInbound  bool               `json:"allow_inbound"`  // 是否接受对等实例转发的请求
+	AllowOutbound bool               `json:"allow_outbound"` // 是否向对等实例转发请求
+	Priority      int                `json:"priority"`       // 优先级（数值大者优先）
+	InboundLimit  PeerLimitResources `json:"inbound_limit"`  // 入站资源上限
+	OutboundLimit PeerLimitResources `json:"outbound_limit"` // 出站资源上限
+}
+
+// PeerLimitResources 为对等实例开放的资源上限（0 表示不限制）
+type PeerLimitResources struct {
+	CPU        int64 `json:"cpu"`        // CPU（millicores）
+	Memory     int64 `json:"memory"`     // 内存（bytes）
+	GPU        int64 `json:"gpu"`        // GPU 数量
+	Components int64 `json:"components"` // 活跃 component 数量
+}
+
+func (r PeerLimitResources) toLimits() quota.Limits {
+	return quota.Limits{
+		CPU:        r.CPU,
+		Memory:     r.Memory,
+		GPU:        r.GPU,
+		Components: r.Components,
+	}
+}
+
+func (r PeerRequest) toSpec() federation.PeerSpec {
+	return federation.PeerSpec{
+		Name:          r.Name,
+		Address:       r.Address,
+		Token:         r.Token,
+		AllowInbound:  r.AllowInbound,
+		AllowOutbound: r.AllowOutbound,
+		Priority:      r.Priority,
+		InboundLimit:  r.InboundLimit.toLimits(),
+		OutboundLimit: r.OutboundLimit.toLimits(),
+	}
+}
+
+// CreatePeerResponse 创建对等实例响应
+type CreatePeerResponse struct {
+	Peer *federation.Peer `json:"peer"` // 对等实例
+	// InboundToken 本实例为对等实例签发的令牌，仅在此返回一次，需配置到对等实例
+	InboundToken string `json:"inbound_token"`
+}
+
+// RotateTokenResponse 重新签发令牌响应
+type RotateTokenResponse struct {
+	InboundToken string `json:"inbound_token"` // 新令牌，旧令牌立即失效
+}
+
+// GetPeersResponse 获取对等实例列表响应
+type GetPeersResponse struct {
+	InstanceID string                   `json:"instance_id"` // 本实例 ID
+	Peers      []*federation.PeerStatus `json:"peers"`       // 对等实例列表
+	Total      int                      `json:"total"`       // 总数
+}
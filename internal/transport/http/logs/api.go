@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/9triver/iarnet-global/internal/transport/http/util/identity"
 	"github.com/9triver/iarnet-global/internal/transport/http/util/response"
 	"github.com/9triver/iarnet-global/internal/util"
 	"github.com/gorilla/mux"
@@ -33,6 +34,9 @@ func NewAPI() *API {
 //   - limit: 返回的最大数量（默认 100，最大 1000）
 //   - level: 过滤的日志级别（可选：trace, debug, info, warn, error, fatal, panic）
 func (api *API) handleGetLogs(w http.ResponseWriter, r *http.Request) {
+	if !authorizeAdmin(w, r) {
+		return
+	}
 	if api.logHook == nil {
 		logrus.Error("Log hook is not initialized")
 		response.InternalError("log hook is not initialized").WriteJSON(w)
@@ -76,6 +80,9 @@ func (api *API) handleGetLogs(w http.ResponseWriter, r *http.Request) {
 
 // handleClearLogs 清空所有日志
 func (api *API) handleClearLogs(w http.ResponseWriter, r *http.Request) {
+	if !authorizeAdmin(w, r) {
+		return
+	}
 	if api.logHook == nil {
 		logrus.Error("Log hook is not initialized")
 		response.InternalError("log hook is not initialized").WriteJSON(w)
@@ -94,3 +101,12 @@ type GetLogsResponse struct {
 	Limit int             `json:"limit"`
 }
 
+// authorizeAdmin 服务日志包含各租户的信息，只允许管理员访问
+func authorizeAdmin(w http.ResponseWriter, r *http.Request) bool {
+	if identity.Scoped(r) != nil {
+		response.Forbidden("tenant-scoped requests cannot access service logs").WriteJSON(w)
+		return false
+	}
+	return true
+}
+
@@ -0,0 +1,246 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/metrics"
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	"github.com/9triver/iarnet-global/internal/domain/tenant"
+	"github.com/9triver/iarnet-global/internal/transport/http/util/identity"
+	"github.com/9triver/iarnet-global/internal/transport/http/util/response"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// RegisterRoutes 注册容量时序数据相关的 HTTP 路由
+func RegisterRoutes(router *mux.Router, service metrics.Service, registryService registry.Service, tenants tenant.Service) {
+	api := NewAPI(service, registryService, tenants)
+	router.HandleFunc("/metrics/capacity/domains/{id}", api.handleGetDomainSeries).Methods("GET")
+	router.HandleFunc("/metrics/capacity/domains/{id}/nodes/{node_id}", api.handleGetNodeSeries).Methods("GET")
+	router.HandleFunc("/metrics/forecast/domains", api.handleGetForecasts).Methods("GET")
+	router.HandleFunc("/metrics/forecast/domains/{id}", api.handleGetForecast).Methods("GET")
+}
+
+type API struct {
+	service  metrics.Service
+	registry registry.Service
+	tenants  tenant.Service
+}
+
+func NewAPI(service metrics.Service, registryService registry.Service, tenants tenant.Service) *API {
+	return &API{
+		service:  service,
+		registry: registryService,
+		tenants:  tenants,
+	}
+}
+
+// handleGetDomainSeries 查询域的容量时序数据
+// 支持 from / to（RFC3339）、resolution（raw / 1m / 1h，为空时按范围自动选择）与 limit 参数
+func (api *API) handleGetDomainSeries(w http.ResponseWriter, r *http.Request) {
+	domainID := mux.Vars(r)["id"]
+	if !api.authorizeDomain(w, r, domainID) {
+		return
+	}
+	api.query(w, r, metrics.ScopeDomain, domainID)
+}
+
+// handleGetNodeSeries 查询节点的容量时序数据，参数同 handleGetDomainSeries
+// 携带租户身份的请求只能查询当前仍属于该域的节点
+func (api *API) handleGetNodeSeries(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	domainID, nodeID := vars["id"], vars["node_id"]
+	if !api.authorizeDomain(w, r, domainID) {
+		return
+	}
+	if identity.Scoped(r) != nil && !api.nodeInDomain(r, domainID, nodeID) {
+		response.NotFound("node not found").WriteJSON(w)
+		return
+	}
+	api.query(w, r, metrics.ScopeNode, nodeID)
+}
+
+func (api *API) query(w http.ResponseWriter, r *http.Request, scope metrics.Scope, id string) {
+	q, err := parseQuery(r)
+	if err != nil {
+		response.BadRequest(err.Error()).WriteJSON(w)
+		return
+	}
+	q.Scope = scope
+	q.SeriesID = id
+
+	series, err := api.service.Query(r.Context(), q)
+	if err != nil {
+		if errors.Is(err, metrics.ErrInvalidQuery) {
+			response.BadRequest(err.Error()).WriteJSON(w)
+			return
+		}
+		logrus.Errorf("Failed to query capacity metrics: %v", err)
+		response.InternalError("failed to query capacity metrics: " + err.Error()).WriteJSON(w)
+		return
+	}
+
+	response.Success(convertSeries(series)).WriteJSON(w)
+}
+
+// handleGetForecasts 预测所有可访问的域的资源耗尽时间，支持 lookback_hours 与 horizon_hours 参数
+func (api *API) handleGetForecasts(w http.ResponseWriter, r *http.Request) {
+	opts, err := parseForecastOptions(r)
+	if err != nil {
+		response.BadRequest(err.Error()).WriteJSON(w)
+		return
+	}
+	domains, err := api.registry.GetAllDomains(r.Context())
+	if err != nil {
+		logrus.Errorf("Failed to get domains: %v", err)
+		response.InternalError("failed to get domains: " + err.Error()).WriteJSON(w)
+		return
+	}
+
+	forecasts := make([]*metrics.Forecast, 0, len(domains))
+	names := make(map[string]string, len(domains))
+	for _, domain := range domains {
+		if !api.canUseDomain(r, domain.ID) {
+			continue
+		}
+		forecast, err := api.service.Forecast(r.Context(), domain.ID, opts)
+		if err != nil {
+			logrus.Errorf("Failed to forecast capacity of domain %s: %v", domain.ID, err)
+			response.InternalError("failed to forecast capacity: " + err.Error()).WriteJSON(w)
+			return
+		}
+		forecasts = append(forecasts, forecast)
+		names[domain.ID] = domain.Name
+	}
+	sort.SliceStable(forecasts, func(i, j int) bool {
+		a, b := forecasts[i].Soonest(), forecasts[j].Soonest()
+		switch {
+		case a == nil || b == nil:
+			return a != nil && b == nil
+		case !a.ExhaustsAt.Equal(*b.ExhaustsAt):
+			return a.ExhaustsAt.Before(*b.ExhaustsAt)
+		default:
+			return names[forecasts[i].DomainID] < names[forecasts[j].DomainID]
+		}
+	})
+
+	resp := GetForecastsResponse{
+		Forecasts: make([]ForecastItem, 0, len(forecasts)),
+		Total:     len(forecasts),
+	}
+	for _, forecast := range forecasts {
+		resp.Forecasts = append(resp.Forecasts, convertForecast(forecast, names[forecast.DomainID]))
+	}
+	response.Success(resp).WriteJSON(w)
+}
+
+// handleGetForecast 预测域的资源耗尽时间，参数同 handleGetForecasts
+func (api *API) handleGetForecast(w http.ResponseWriter, r *http.Request) {
+	domainID := mux.Vars(r)["id"]
+	if !api.authorizeDomain(w, r, domainID) {
+		return
+	}
+	domain, err := api.registry.GetDomain(r.Context(), domainID)
+	if err != nil {
+		if errors.Is(err, registry.ErrDomainNotFound) {
+			response.NotFound("domain not found").WriteJSON(w)
+			return
+		}
+		logrus.Errorf("Failed to get domain %s: %v", domainID, err)
+		response.InternalError("failed to get domain: " + err.Error()).WriteJSON(w)
+		return
+	}
+	opts, err := parseForecastOptions(r)
+	if err != nil {
+		response.BadRequest(err.Error()).WriteJSON(w)
+		return
+	}
+
+	forecast, err := api.service.Forecast(r.Context(), domainID, opts)
+	if err != nil {
+		logrus.Errorf("Failed to forecast capacity of domain %s: %v", domainID, err)
+		response.InternalError("failed to forecast capacity: " + err.Error()).WriteJSON(w)
+		return
+	}
+	response.Success(convertForecast(forecast, domain.Name)).WriteJSON(w)
+}
+
+// parseForecastOptions 解析预测参数，未指定时使用服务配置
+func parseForecastOptions(r *http.Request) (metrics.ForecastOptions, error) {
+	opts := metrics.ForecastOptions{}
+	for name, dst := range map[string]*time.Duration{"lookback_hours": &opts.Lookback, "horizon_hours": &opts.Horizon} {
+		value := r.URL.Query().Get(name)
+		if value == "" {
+			continue
+		}
+		hours, err := strconv.Atoi(value)
+		if err != nil || hours <= 0 {
+			return opts, errors.New("invalid " + name + ": " + value)
+		}
+		*dst = time.Duration(hours) * time.Hour
+	}
+	return opts, nil
+}
+
+// parseQuery 解析查询参数
+func parseQuery(r *http.Request) (metrics.Query, error) {
+	values := r.URL.Query()
+	q := metrics.Query{}
+
+	resolution, err := metrics.ParseResolution(values.Get("resolution"))
+	if err != nil {
+		return q, err
+	}
+	q.Resolution = resolution
+	if from := values.Get("from"); from != "" {
+		if q.From, err = time.Parse(time.RFC3339, from); err != nil {
+			return q, errors.New("invalid from: " + err.Error())
+		}
+	}
+	if to := values.Get("to"); to != "" {
+		if q.To, err = time.Parse(time.RFC3339, to); err != nil {
+			return q, errors.New("invalid to: " + err.Error())
+		}
+	}
+	if limit := values.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 0 {
+			return q, errors.New("invalid limit: " + limit)
+		}
+		q.Limit = n
+	}
+	return q, nil
+}
+
+// canUseDomain 携带租户身份的请求只能查询可使用的域
+func (api *API) canUseDomain(r *http.Request, domainID string) bool {
+	id := identity.Scoped(r)
+	return id == nil || api.tenants == nil || api.tenants.CanUseDomain(id.Tenant, domainID)
+}
+
+// authorizeDomain 校验查询权限，无权查看的域按不存在处理
+func (api *API) authorizeDomain(w http.ResponseWriter, r *http.Request, domainID string) bool {
+	if api.canUseDomain(r, domainID) {
+		return true
+	}
+	response.NotFound("domain not found").WriteJSON(w)
+	return false
+}
+
+// nodeInDomain 节点当前是否属于该域
+func (api *API) nodeInDomain(r *http.Request, domainID, nodeID string) bool {
+	nodes, err := api.registry.GetDomainNodes(r.Context(), domainID)
+	if err != nil {
+		return false
+	}
+	for _, node := range nodes {
+		if node.ID == nodeID {
+			return true
+		}
+	}
+	return false
+}
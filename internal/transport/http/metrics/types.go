@@ -0,0 +1,109 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/metrics"
+)
+
+// GetSeriesResponse 容量时序数据查询响应
+type GetSeriesResponse struct {
+	Scope      string      `json:"scope"`      // 统计对象：node / domain
+	ID         string      `json:"id"`         // 节点或域 ID
+	Resolution string      `json:"resolution"` // 分辨率：raw / 1m / 1h
+	From       string      `json:"from"`       // 查询起始时间
+	To         string      `json:"to"`         // 查询结束时间
+	Points     []PointItem `json:"points"`     // 数据点（按时间正序）
+	Total      int         `json:"total"`      // 数据点数量
+}
+
+// PointItem 时序数据点，降采样数据点的时间为窗口起点、资源为窗口内平均值
+type PointItem struct {
+	Time      string         `json:"time"`      // 时间
+	Samples   int64          `json:"samples"`   // 包含的原始样本数
+	Total     metrics.Amount `json:"total"`     // 总资源
+	Used      metrics.Amount `json:"used"`      // 已使用资源
+	Available metrics.Amount `json:"available"` // 可用资源
+	PeakUsed  metrics.Amount `json:"peak_used"` // 窗口内已使用资源的最大值
+}
+
+func convertSeries(series *metrics.Series) GetSeriesResponse {
+	resp := GetSeriesResponse{
+		Scope:      string(series.Scope),
+		ID:         series.SeriesID,
+		Resolution: string(series.Resolution),
+		From:       series.From.Format(time.RFC3339),
+		To:         series.To.Format(time.RFC3339),
+		Points:     make([]PointItem, 0, len(series.Points)),
+		Total:      len(series.Points),
+	}
+	for _, p := range series.Points {
+		resp.Points = append(resp.Points, PointItem{
+			Time:      p.Time.Format(time.RFC3339),
+			Samples:   p.Samples,
+			Total:     p.Total,
+			Used:      p.Used,
+			Available: p.Available,
+			PeakUsed:  p.PeakUsed,
+		})
+	}
+	return resp
+}
+
+// ForecastItem 域的资源耗尽预测
+type ForecastItem struct {
+	DomainID     string                 `json:"domain_id"`     // 域 ID
+	DomainName   string                 `json:"domain_name"`   // 域名称
+	GeneratedAt  string                 `json:"generated_at"`  // 预测时间
+	Resolution   string                 `json:"resolution"`    // 参考的历史数据分辨率
+	Samples      int                    `json:"samples"`       // 参考的数据点数量
+	HorizonHours float64                `json:"horizon_hours"` // 预测范围（小时）
+	Resources    []ResourceForecastItem `json:"resources"`     // 各项资源的预测
+}
+
+// ResourceForecastItem 单项资源的预测
+type ResourceForecastItem struct {
+	Resource        string   `json:"resource"`                    // 资源名称：cpu / memory / gpu
+	Status          string   `json:"status"`                      // insufficient_data / no_capacity / exhausted / exhausting / stable
+	Total           int64    `json:"total"`                       // 当前总量
+	Available       int64    `json:"available"`                   // 当前可用量
+	TrendPerHour    float64  `json:"trend_per_hour"`              // 可用量每小时的变化，负数表示在被消耗
+	Seasonal        bool     `json:"seasonal"`                    // 是否拟合了日周期分量
+	ExhaustsAt      string   `json:"exhausts_at,omitempty"`       // 预计耗尽时间
+	ExhaustsInHours *float64 `json:"exhausts_in_hours,omitempty"` // 距离耗尽的小时数
+}
+
+// GetForecastsResponse 域资源耗尽预测列表响应
+type GetForecastsResponse struct {
+	Forecasts []ForecastItem `json:"forecasts"` // 按最早耗尽时间排序，不会耗尽的域排在最后
+	Total     int            `json:"total"`     // 域数量
+}
+
+func convertForecast(forecast *metrics.Forecast, domainName string) ForecastItem {
+	item := ForecastItem{
+		DomainID:     forecast.DomainID,
+		DomainName:   domainName,
+		GeneratedAt:  forecast.GeneratedAt.Format(time.RFC3339),
+		Resolution:   string(forecast.Resolution),
+		Samples:      forecast.Samples,
+		HorizonHours: forecast.Horizon.Hours(),
+		Resources:    make([]ResourceForecastItem, 0, len(forecast.Resources)),
+	}
+	for _, r := range forecast.Resources {
+		resource := ResourceForecastItem{
+			Resource:     r.Resource,
+			Status:       string(r.Status),
+			Total:        r.Total,
+			Available:    r.Available,
+			TrendPerHour: r.TrendPerHour,
+			Seasonal:     r.Seasonal,
+		}
+		if r.ExhaustsAt != nil {
+			resource.ExhaustsAt = r.ExhaustsAt.Format(time.RFC3339)
+			hours := max(r.ExhaustsAt.Sub(forecast.GeneratedAt).Hours(), 0)
+			resource.ExhaustsInHours = &hours
+		}
+		item.Resources = append(item.Resources, resource)
+	}
+	return item
+}
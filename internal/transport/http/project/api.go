@@ -0,0 +1,215 @@
+package project
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	"github.com/9triver/iarnet-global/internal/domain/tenant"
+	"github.com/9triver/iarnet-global/internal/transport/http/util/identity"
+	"github.com/9triver/iarnet-global/internal/transport/http/util/response"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// RegisterRoutes 注册项目（租户）相关的 HTTP 路由
+// 携带租户身份的请求只能查看自己的项目，项目管理操作仅对管理视图开放
+func RegisterRoutes(router *mux.Router, service tenant.Service) {
+	api := NewAPI(service)
+	router.HandleFunc("/projects", api.handleGetProjects).Methods("GET")
+	router.HandleFunc("/projects", api.handleCreateProject).Methods("POST")
+	router.HandleFunc("/projects/{id}", api.handleGetProject).Methods("GET")
+	router.HandleFunc("/projects/{id}", api.handleUpdateProject).Methods("PUT")
+	router.HandleFunc("/projects/{id}", api.handleDeleteProject).Methods("DELETE")
+	router.HandleFunc("/projects/{id}/token", api.handleRotateToken).Methods("POST")
+	router.HandleFunc("/projects/{id}/domains/{domain_id}", api.handleSetDomainAccess).Methods("PUT")
+	router.HandleFunc("/projects/{id}/domains/{domain_id}", api.handleRemoveDomainAccess).Methods("DELETE")
+}
+
+type API struct {
+	service tenant.Service
+}
+
+func NewAPI(service tenant.Service) *API {
+	return &API{
+		service: service,
+	}
+}
+
+// handleGetProjects 获取项目列表
+func (api *API) handleGetProjects(w http.ResponseWriter, r *http.Request) {
+	projects, err := api.service.GetAllProjects(r.Context())
+	if err != nil {
+		logrus.Errorf("Failed to get projects: %v", err)
+		response.InternalError("failed to get projects: " + err.Error()).WriteJSON(w)
+		return
+	}
+
+	id := identity.Scoped(r)
+	resp := GetProjectsResponse{
+		Projects: make([]ProjectItem, 0, len(projects)),
+	}
+	for _, p := range projects {
+		if id != nil && p.ID != id.Tenant {
+			continue
+		}
+		resp.Projects = append(resp.Projects, convertProject(p))
+	}
+	resp.Total = len(resp.Projects)
+
+	response.Success(resp).WriteJSON(w)
+}
+
+// handleCreateProject 创建项目
+func (api *API) handleCreateProject(w http.ResponseWriter, r *http.Request) {
+	if !authorizeAdmin(w, r) {
+		return
+	}
+
+	req := CreateProjectRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logrus.Errorf("Failed to decode create project request: %v", err)
+		response.BadRequest("invalid request body: " + err.Error()).WriteJSON(w)
+		return
+	}
+
+	p, token, err := api.service.CreateProject(r.Context(), req.Name, req.Description)
+	if err != nil {
+		writeProjectError(w, err)
+		return
+	}
+
+	response.Created(CreateProjectResponse{
+		ProjectItem: convertProject(p),
+		Token:       token,
+	}).WriteJSON(w)
+}
+
+// handleGetProject 获取单个项目
+func (api *API) handleGetProject(w http.ResponseWriter, r *http.Request) {
+	projectID := mux.Vars(r)["id"]
+	if id := identity.Scoped(r); id != nil && id.Tenant != projectID {
+		response.NotFound("project not found").WriteJSON(w)
+		return
+	}
+
+	p, err := api.service.GetProject(r.Context(), projectID)
+	if err != nil {
+		writeProjectError(w, err)
+		return
+	}
+
+	response.Success(convertProject(p)).WriteJSON(w)
+}
+
+// handleUpdateProject 更新项目信息
+func (api *API) handleUpdateProject(w http.ResponseWriter, r *http.Request) {
+	if !authorizeAdmin(w, r) {
+		return
+	}
+
+	req := UpdateProjectRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logrus.Errorf("Failed to decode update project request: %v", err)
+		response.BadRequest("invalid request body: " + err.Error()).WriteJSON(w)
+		return
+	}
+
+	if err := api.service.UpdateProject(r.Context(), mux.Vars(r)["id"], req.Name, req.Description); err != nil {
+		writeProjectError(w, err)
+		return
+	}
+
+	response.Success(nil).WriteJSON(w)
+}
+
+// handleDeleteProject 删除项目
+func (api *API) handleDeleteProject(w http.ResponseWriter, r *http.Request) {
+	if !authorizeAdmin(w, r) {
+		return
+	}
+
+	if err := api.service.DeleteProject(r.Context(), mux.Vars(r)["id"]); err != nil {
+		writeProjectError(w, err)
+		return
+	}
+
+	response.Success(nil).WriteJSON(w)
+}
+
+// handleRotateToken 重新生成项目访问令牌
+func (api *API) handleRotateToken(w http.ResponseWriter, r *http.Request) {
+	if !authorizeAdmin(w, r) {
+		return
+	}
+
+	token, err := api.service.RotateToken(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeProjectError(w, err)
+		return
+	}
+
+	response.Success(RotateTokenResponse{Token: token}).WriteJSON(w)
+}
+
+// handleSetDomainAccess 设置项目对域的访问角色
+func (api *API) handleSetDomainAccess(w http.ResponseWriter, r *http.Request) {
+	if !authorizeAdmin(w, r) {
+		return
+	}
+
+	req := SetDomainAccessRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logrus.Errorf("Failed to decode set domain access request: %v", err)
+		response.BadRequest("invalid request body: " + err.Error()).WriteJSON(w)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := api.service.SetDomainAccess(r.Context(), vars["id"], vars["domain_id"], tenant.DomainRole(req.Role)); err != nil {
+		writeProjectError(w, err)
+		return
+	}
+
+	response.Success(req).WriteJSON(w)
+}
+
+// handleRemoveDomainAccess 撤销项目对域的访问
+func (api *API) handleRemoveDomainAccess(w http.ResponseWriter, r *http.Request) {
+	if !authorizeAdmin(w, r) {
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := api.service.RemoveDomainAccess(r.Context(), vars["id"], vars["domain_id"]); err != nil {
+		writeProjectError(w, err)
+		return
+	}
+
+	response.Success(nil).WriteJSON(w)
+}
+
+// authorizeAdmin 项目管理操作仅对管理员开放
+func authorizeAdmin(w http.ResponseWriter, r *http.Request) bool {
+	if identity.Scoped(r) != nil {
+		response.Forbidden("tenant-scoped requests cannot manage projects").WriteJSON(w)
+		return false
+	}
+	return true
+}
+
+// writeProjectError 将项目操作错误转换为 HTTP 响应
+func writeProjectError(w http.ResponseWriter, err error) {
+	switch {
+	case err == tenant.ErrProjectNotFound:
+		response.NotFound("project not found").WriteJSON(w)
+	case err == registry.ErrDomainNotFound:
+		response.NotFound("domain not found").WriteJSON(w)
+	case err == tenant.ErrDomainAlreadyOwned, errors.Is(err, tenant.ErrInvalidProject), errors.Is(err, tenant.ErrInvalidDomainRole):
+		response.BadRequest(err.Error()).WriteJSON(w)
+	default:
+		logrus.Errorf("Failed to handle project request: %v", err)
+		response.InternalError(err.Error()).WriteJSON(w)
+	}
+}
@@ -0,0 +1,69 @@
+package project
+
+import (
+	"sort"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/tenant"
+)
+
+// CreateProjectRequest 创建项目请求
+type CreateProjectRequest struct {
+	Name        string `json:"name"`                  // 项目名称（必填）
+	Description string `json:"description,omitempty"` // 项目描述（可选）
+}
+
+// UpdateProjectRequest 更新项目请求
+type UpdateProjectRequest struct {
+	Name        string `json:"name,omitempty"`        // 项目名称（可选）
+	Description string `json:"description,omitempty"` // 项目描述（可选）
+}
+
+// SetDomainAccessRequest 设置项目对域的访问角色请求
+type SetDomainAccessRequest struct {
+	Role string `json:"role"` // owner（拥有）或 shared（共享使用）
+}
+
+// ProjectItem 项目信息
+type ProjectItem struct {
+	ID            string   `json:"id"`             // 项目 ID（即租户标识）
+	Name          string   `json:"name"`           // 项目名称
+	Description   string   `json:"description"`    // 项目描述
+	OwnedDomains  []string `json:"owned_domains"`  // 拥有的域
+	SharedDomains []string `json:"shared_domains"` // 被授权共享的域
+	CreatedAt     string   `json:"created_at"`     // 创建时间
+	UpdatedAt     string   `json:"updated_at"`     // 更新时间
+}
+
+// CreateProjectResponse 创建项目响应（访问令牌仅返回一次）
+type CreateProjectResponse struct {
+	ProjectItem
+	Token string `json:"token"` // 访问令牌
+}
+
+// RotateTokenResponse 重新生成访问令牌响应
+type RotateTokenResponse struct {
+	Token string `json:"token"` // 新的访问令牌
+}
+
+// GetProjectsResponse 获取项目列表响应
+type GetProjectsResponse struct {
+	Projects []ProjectItem `json:"projects"` // 项目列表
+	Total    int           `json:"total"`    // 总数
+}
+
+func convertProject(p *tenant.Project) ProjectItem {
+	owned := p.OwnedDomains()
+	shared := p.SharedDomains()
+	sort.Strings(owned)
+	sort.Strings(shared)
+	return ProjectItem{
+		ID:            p.ID,
+		Name:          p.Name,
+		Description:   p.Description,
+		OwnedDomains:  owned,
+		SharedDomains: shared,
+		CreatedAt:     p.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     p.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,177 @@
+package quota
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/9triver/iarnet-global/internal/domain/quota"
+	"github.com/9triver/iarnet-global/internal/transport/http/util/identity"
+	"github.com/9triver/iarnet-global/internal/transport/http/util/response"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// RegisterRoutes 注册配额相关的 HTTP 路由
+func RegisterRoutes(router *mux.Router, service quota.Service) {
+	api := NewAPI(service)
+	router.HandleFunc("/quotas", api.handleGetQuotas).Methods("GET")
+	router.HandleFunc("/quotas", api.handleCreateQuota).Methods("POST")
+	router.HandleFunc("/quotas/{id}", api.handleGetQuota).Methods("GET")
+	router.HandleFunc("/quotas/{id}", api.handleUpdateQuota).Methods("PUT")
+	router.HandleFunc("/quotas/{id}", api.handleDeleteQuota).Methods("DELETE")
+}
+
+type API struct {
+	service quota.Service
+}
+
+func NewAPI(service quota.Service) *API {
+	return &API{
+		service: service,
+	}
+}
+
+// handleGetQuotas 获取配额列表及用量，可按 tenant / domain_id 过滤
+// 携带租户身份的请求只能查看对自己生效的配额
+func (api *API) handleGetQuotas(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	statuses, err := api.service.ListQuotas(r.Context(), quota.Filter{
+		Tenant:   scopedTenant(r, query.Get("tenant")),
+		DomainID: query.Get("domain_id"),
+	})
+	if err != nil {
+		logrus.Errorf("Failed to list quotas: %v", err)
+		response.InternalError("failed to list quotas: " + err.Error()).WriteJSON(w)
+		return
+	}
+
+	resp := GetQuotasResponse{
+		Quotas: make([]QuotaItem, 0, len(statuses)),
+		Total:  len(statuses),
+	}
+	for _, status := range statuses {
+		resp.Quotas = append(resp.Quotas, convertStatus(status))
+	}
+
+	response.Success(resp).WriteJSON(w)
+}
+
+// handleCreateQuota 创建配额
+func (api *API) handleCreateQuota(w http.ResponseWriter, r *http.Request) {
+	if !authorizeAdmin(w, r) {
+		return
+	}
+	req := CreateQuotaRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logrus.Errorf("Failed to decode create quota request: %v", err)
+		response.BadRequest("invalid request body: " + err.Error()).WriteJSON(w)
+		return
+	}
+
+	q, err := api.service.CreateQuota(r.Context(), req.DomainID, req.Tenant, req.Limits.toLimits())
+	if err != nil {
+		writeQuotaError(w, err)
+		return
+	}
+
+	response.Created(convertStatus(&quota.Status{Quota: q})).WriteJSON(w)
+}
+
+// handleGetQuota 获取单个配额及用量
+func (api *API) handleGetQuota(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if id == "" {
+		response.BadRequest("quota id is required").WriteJSON(w)
+		return
+	}
+
+	tenant := scopedTenant(r, r.URL.Query().Get("tenant"))
+	status, err := api.service.GetQuota(r.Context(), id, tenant)
+	if err != nil {
+		writeQuotaError(w, err)
+		return
+	}
+	if identity.Scoped(r) != nil && !status.Quota.IsDefault() && status.Quota.Tenant != tenant {
+		response.NotFound("quota not found").WriteJSON(w)
+		return
+	}
+
+	response.Success(convertStatus(status)).WriteJSON(w)
+}
+
+// handleUpdateQuota 更新配额上限
+func (api *API) handleUpdateQuota(w http.ResponseWriter, r *http.Request) {
+	if !authorizeAdmin(w, r) {
+		return
+	}
+	id := mux.Vars(r)["id"]
+	if id == "" {
+		response.BadRequest("quota id is required").WriteJSON(w)
+		return
+	}
+
+	req := UpdateQuotaRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logrus.Errorf("Failed to decode update quota request: %v", err)
+		response.BadRequest("invalid request body: " + err.Error()).WriteJSON(w)
+		return
+	}
+
+	q, err := api.service.UpdateQuota(r.Context(), id, req.Limits.toLimits())
+	if err != nil {
+		writeQuotaError(w, err)
+		return
+	}
+
+	response.Success(convertStatus(&quota.Status{Quota: q})).WriteJSON(w)
+}
+
+// handleDeleteQuota 删除配额
+func (api *API) handleDeleteQuota(w http.ResponseWriter, r *http.Request) {
+	if !authorizeAdmin(w, r) {
+		return
+	}
+	id := mux.Vars(r)["id"]
+	if id == "" {
+		response.BadRequest("quota id is required").WriteJSON(w)
+		return
+	}
+
+	if err := api.service.DeleteQuota(r.Context(), id); err != nil {
+		writeQuotaError(w, err)
+		return
+	}
+
+	response.Success(nil).WriteJSON(w)
+}
+
+// scopedTenant 携带租户身份的请求只能以自身租户查询
+func scopedTenant(r *http.Request, requested string) string {
+	if id := identity.Scoped(r); id != nil {
+		return id.Tenant
+	}
+	return requested
+}
+
+// authorizeAdmin 配额只能由管理员修改
+func authorizeAdmin(w http.ResponseWriter, r *http.Request) bool {
+	if identity.Scoped(r) != nil {
+		response.Forbidden("tenant-scoped requests cannot manage quotas").WriteJSON(w)
+		return false
+	}
+	return true
+}
+
+// writeQuotaError 将配额操作错误转换为 HTTP 响应
+func writeQuotaError(w http.ResponseWriter, err error) {
+	switch {
+	case err == quota.ErrQuotaNotFound:
+		response.NotFound("quota not found").WriteJSON(w)
+	case err == quota.ErrQuotaAlreadyExists, errors.Is(err, quota.ErrInvalidQuota):
+		response.BadRequest(err.Error()).WriteJSON(w)
+	default:
+		logrus.Errorf("Failed to handle quota request: %v", err)
+		response.InternalError(err.Error()).WriteJSON(w)
+	}
+}
@@ -0,0 +1,90 @@
+package quota
+
+import (
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/deployment"
+	"github.com/9triver/iarnet-global/internal/domain/quota"
+)
+
+// QuotaResources 配额资源量（上限为 0 表示不限制）
+type QuotaResources struct {
+	CPU        int64 `json:"cpu"`        // CPU（millicores）
+	Memory     int64 `json:"memory"`     // 内存（bytes）
+	GPU        int64 `json:"gpu"`        // GPU 数量
+	Components int64 `json:"components"` // 活跃 component 数量
+}
+
+func (r QuotaResources) toLimits() quota.Limits {
+	return quota.Limits{
+		CPU:        r.CPU,
+		Memory:     r.Memory,
+		GPU:        r.GPU,
+		Components: r.Components,
+	}
+}
+
+// CreateQuotaRequest 创建配额请求
+type CreateQuotaRequest struct {
+	DomainID string         `json:"domain_id,omitempty"` // 域 ID（为空表示所有域的总用量）
+	Tenant   string         `json:"tenant,omitempty"`    // 租户（为空表示对每个租户生效的默认配额）
+	Limits   QuotaResources `json:"limits"`              // 配额上限
+}
+
+// UpdateQuotaRequest 更新配额请求
+type UpdateQuotaRequest struct {
+	Limits QuotaResources `json:"limits"` // 配额上限
+}
+
+// QuotaItem 配额及用量
+type QuotaItem struct {
+	ID          string          `json:"id"`                     // 配额 ID
+	DomainID    string          `json:"domain_id"`              // 域 ID（为空表示所有域）
+	Tenant      string          `json:"tenant"`                 // 租户（为空表示默认配额）
+	Limits      QuotaResources  `json:"limits"`                 // 配额上限
+	UsageTenant string          `json:"usage_tenant,omitempty"` // 用量所属租户
+	Usage       *QuotaResources `json:"usage,omitempty"`        // 当前用量（默认配额需指定 tenant 查询）
+	CreatedAt   string          `json:"created_at"`             // 创建时间
+	UpdatedAt   string          `json:"updated_at"`             // 更新时间
+}
+
+// GetQuotasResponse 获取配额列表响应
+type GetQuotasResponse struct {
+	Quotas []QuotaItem `json:"quotas"` // 配额列表
+	Total  int         `json:"total"`  // 总数
+}
+
+func convertLimits(limits quota.Limits) QuotaResources {
+	return QuotaResources{
+		CPU:        limits.CPU,
+		Memory:     limits.Memory,
+		GPU:        limits.GPU,
+		Components: limits.Components,
+	}
+}
+
+func convertUsage(usage *deployment.Usage) *QuotaResources {
+	if usage == nil {
+		return nil
+	}
+	return &QuotaResources{
+		CPU:        usage.Resources.CPU,
+		Memory:     usage.Resources.Memory,
+		GPU:        usage.Resources.GPU,
+		Components: usage.Components,
+	}
+}
+
+func convertStatus(status *quota.Status) QuotaItem {
+	q := status.Quota
+	return QuotaItem{
+		ID:          q.ID,
+		DomainID:    q.DomainID,
+		Tenant:      q.Tenant,
+		Limits:      convertLimits(q.Limits),
+		UsageTenant: status.Tenant,
+		Usage:       convertUsage(status.Usage),
+		CreatedAt:   q.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   q.UpdatedAt.Format(time.RFC3339),
+	}
+}
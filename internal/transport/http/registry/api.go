@@ -2,33 +2,51 @@ package registry
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/9triver/iarnet-global/internal/domain/registry"
+	"github.com/9triver/iarnet-global/internal/domain/tenant"
 	"github.com/9triver/iarnet-global/internal/transport/http/util/response"
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
 )
 
 // RegisterRoutes 注册域相关的 HTTP 路由
-func RegisterRoutes(router *mux.Router, service registry.Service) {
-	api := NewAPI(service)
+// tenants 非空时，携带租户身份的请求只能看到可访问的域，且只能管理所属项目拥有的域
+func RegisterRoutes(router *mux.Router, service registry.Service, tenants tenant.Service) {
+	api := NewAPI(service, tenants)
 	router.HandleFunc("/registry/domains", api.handleGetDomains).Methods("GET")
 	router.HandleFunc("/registry/domains", api.handleCreateDomain).Methods("POST")
+	router.HandleFunc("/registry/domains/tree", api.handleGetDomainTree).Methods("GET")
 	router.HandleFunc("/registry/domains/{id}", api.handleGetDomain).Methods("GET")
 	router.HandleFunc("/registry/domains/{id}", api.handleUpdateDomain).Methods("PUT")
 	router.HandleFunc("/registry/domains/{id}", api.handleDeleteDomain).Methods("DELETE")
 	router.HandleFunc("/registry/domains/{id}/nodes", api.handleGetDomainNodes).Methods("GET")
+	router.HandleFunc("/registry/domains/{id}/labels", api.handleSetDomainLabels).Methods("PUT")
+	router.HandleFunc("/registry/domains/{id}/parent", api.handleSetDomainParent).Methods("PUT")
+	router.HandleFunc("/registry/domains/{id}/nodes/{node_id}/labels", api.handleSetNodeLabels).Methods("PUT")
+	router.HandleFunc("/registry/domains/{id}/nodes/{node_id}/cordon", api.handleSetNodeCordon).Methods("PUT")
+	router.HandleFunc("/registry/domains/{id}/policy", api.handleGetDomainPolicy).Methods("GET")
+	router.HandleFunc("/registry/domains/{id}/policy", api.handleUpdateDomainPolicy).Methods("PUT")
+	router.HandleFunc("/registry/domains/{id}/policy", api.handleResetDomainPolicy).Methods("DELETE")
+	router.HandleFunc("/registry/policy", api.handleGetDefaultPolicy).Methods("GET")
+	router.HandleFunc("/registry/policy", api.handleUpdateDefaultPolicy).Methods("PUT")
+	router.HandleFunc("/registry/join-tokens", api.handleGetJoinTokens).Methods("GET")
+	router.HandleFunc("/registry/join-tokens", api.handleCreateJoinToken).Methods("POST")
+	router.HandleFunc("/registry/join-tokens/{token_id}", api.handleRevokeJoinToken).Methods("DELETE")
 }
 
 type API struct {
 	service registry.Service
+	tenants tenant.Service
 }
 
-func NewAPI(service registry.Service) *API {
+func NewAPI(service registry.Service, tenants tenant.Service) *API {
 	return &API{
 		service: service,
+		tenants: tenants,
 	}
 }
 
@@ -43,10 +61,13 @@ func (api *API) handleGetDomains(w http.ResponseWriter, r *http.Request) {
 
 	resp := GetDomainsResponse{
 		Domains: make([]DomainItem, 0, len(domains)),
-		Total:   len(domains),
 	}
 
 	for _, domain := range domains {
+		if !api.canViewDomain(r, domain.ID) {
+			continue
+		}
+
 		// 获取域统计信息
 		stats, err := api.service.GetDomainStats(r.Context(), domain.ID)
 		if err != nil {
@@ -58,23 +79,30 @@ func (api *API) handleGetDomains(w http.ResponseWriter, r *http.Request) {
 		}
 
 		item := DomainItem{
-			ID:          domain.ID,
-			Name:        domain.Name,
-			Description: domain.Description,
-			NodeCount:   stats.TotalNodes,
-			OnlineNodes: stats.OnlineNodes,
+			ID:           domain.ID,
+			Name:         domain.Name,
+			Description:  domain.Description,
+			ParentID:     domain.ParentID,
+			Kind:         domain.Kind,
+			NodeCount:    stats.TotalNodes,
+			OnlineNodes:  stats.OnlineNodes,
+			SuspectNodes: stats.SuspectNodes,
 			ResourceTags: ResourceTagsResponse{
 				CPU:    domain.ResourceTags.CPU,
 				GPU:    domain.ResourceTags.GPU,
 				Memory: domain.ResourceTags.Memory,
 				Camera: domain.ResourceTags.Camera,
 			},
+			Capacity:  convertCapacity(domain.Capacity),
+			Labels:    domain.Labels.Clone(),
+			Owner:     api.domainOwner(domain.ID),
 			CreatedAt: domain.CreatedAt.Format(time.RFC3339),
 			UpdatedAt: domain.UpdatedAt.Format(time.RFC3339),
 		}
 
 		resp.Domains = append(resp.Domains, item)
 	}
+	resp.Total = len(resp.Domains)
 
 	response.Success(resp).WriteJSON(w)
 }
@@ -93,6 +121,26 @@ func (api *API) handleCreateDomain(w http.ResponseWriter, r *http.Request) {
 		response.BadRequest("domain name is required").WriteJSON(w)
 		return
 	}
+	if err := req.Labels.Validate(); err != nil {
+		response.BadRequest(err.Error()).WriteJSON(w)
+		return
+	}
+	if err := registry.ValidateDomainKind(req.Kind); err != nil {
+		response.BadRequest(err.Error()).WriteJSON(w)
+		return
+	}
+	if !api.authorizeDomainCreate(w, r) {
+		return
+	}
+	if req.ParentID != "" {
+		if !api.authorizeParent(w, r, req.ParentID) {
+			return
+		}
+		if _, err := api.service.GetDomain(r.Context(), req.ParentID); err != nil {
+			response.BadRequest("parent domain not found").WriteJSON(w)
+			return
+		}
+	}
 
 	logrus.Infof("Creating domain: name=%s, description=%s", req.Name, req.Description)
 
@@ -104,6 +152,31 @@ func (api *API) handleCreateDomain(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 设置域标签（可选）
+	if len(req.Labels) > 0 {
+		if err := api.service.SetDomainLabels(r.Context(), domain.ID, req.Labels); err != nil {
+			logrus.Errorf("Failed to set labels for domain %s: %v", domain.ID, err)
+			response.BadRequest("domain created but failed to set labels: " + err.Error()).WriteJSON(w)
+			return
+		}
+	}
+
+	// 设置上级域与层级类型（可选）
+	if req.ParentID != "" || req.Kind != "" {
+		if err := api.service.SetDomainParent(r.Context(), domain.ID, req.ParentID, req.Kind); err != nil {
+			logrus.Errorf("Failed to set parent for domain %s: %v", domain.ID, err)
+			response.BadRequest("domain created but failed to set parent: " + err.Error()).WriteJSON(w)
+			return
+		}
+	}
+
+	// 携带项目身份创建的域归属该项目
+	if err := api.claimDomain(r, domain.ID); err != nil {
+		logrus.Errorf("Failed to assign domain %s to project: %v", domain.ID, err)
+		response.InternalError("domain created but failed to assign owner: " + err.Error()).WriteJSON(w)
+		return
+	}
+
 	logrus.Infof("Domain created successfully: id=%s, name=%s", domain.ID, domain.Name)
 
 	resp := CreateDomainResponse{
@@ -124,6 +197,9 @@ func (api *API) handleGetDomain(w http.ResponseWriter, r *http.Request) {
 		response.BadRequest("domain id is required").WriteJSON(w)
 		return
 	}
+	if !api.authorizeDomainView(w, r, domainID) {
+		return
+	}
 
 	domain, err := api.service.GetDomain(r.Context(), domainID)
 	if err != nil {
@@ -143,16 +219,29 @@ func (api *API) handleGetDomain(w http.ResponseWriter, r *http.Request) {
 		nodes = []*registry.Node{} // 使用空列表
 	}
 
+	children := make([]string, 0)
+	for _, childID := range api.service.GetChildDomains(r.Context(), domainID) {
+		if api.canViewDomain(r, childID) {
+			children = append(children, childID)
+		}
+	}
+
 	resp := GetDomainResponse{
 		ID:          domain.ID,
 		Name:        domain.Name,
 		Description: domain.Description,
+		ParentID:    domain.ParentID,
+		Kind:        domain.Kind,
+		Children:    children,
 		ResourceTags: ResourceTagsResponse{
 			CPU:    domain.ResourceTags != nil && domain.ResourceTags.CPU,
 			GPU:    domain.ResourceTags != nil && domain.ResourceTags.GPU,
 			Memory: domain.ResourceTags != nil && domain.ResourceTags.Memory,
 			Camera: domain.ResourceTags != nil && domain.ResourceTags.Camera,
 		},
+		Capacity:  convertCapacity(domain.Capacity),
+		Labels:    domain.Labels.Clone(),
+		Owner:     api.domainOwner(domain.ID),
 		Nodes:     convertNodes(nodes),
 		CreatedAt: domain.CreatedAt.Format(time.RFC3339),
 		UpdatedAt: domain.UpdatedAt.Format(time.RFC3339),
@@ -169,6 +258,9 @@ func (api *API) handleUpdateDomain(w http.ResponseWriter, r *http.Request) {
 		response.BadRequest("domain id is required").WriteJSON(w)
 		return
 	}
+	if !api.authorizeDomainManage(w, r, domainID) {
+		return
+	}
 
 	req := UpdateDomainRequest{}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -199,6 +291,9 @@ func (api *API) handleDeleteDomain(w http.ResponseWriter, r *http.Request) {
 		response.BadRequest("domain id is required").WriteJSON(w)
 		return
 	}
+	if !api.authorizeDomainManage(w, r, domainID) {
+		return
+	}
 
 	err := api.service.DeleteDomain(r.Context(), domainID)
 	if err != nil {
@@ -206,11 +301,22 @@ func (api *API) handleDeleteDomain(w http.ResponseWriter, r *http.Request) {
 			response.NotFound("domain not found").WriteJSON(w)
 			return
 		}
+		if errors.Is(err, registry.ErrDomainHasChildren) {
+			response.BadRequest(err.Error()).WriteJSON(w)
+			return
+		}
 		logrus.Errorf("Failed to delete domain: %v", err)
 		response.InternalError("failed to delete domain: " + err.Error()).WriteJSON(w)
 		return
 	}
 
+	// 清理项目对该域的归属与共享关系
+	if api.tenants != nil {
+		if err := api.tenants.ForgetDomain(r.Context(), domainID); err != nil {
+			logrus.Warnf("Failed to clean up project access for domain %s: %v", domainID, err)
+		}
+	}
+
 	response.Success(nil).WriteJSON(w)
 }
 
@@ -222,6 +328,9 @@ func (api *API) handleGetDomainNodes(w http.ResponseWriter, r *http.Request) {
 		response.BadRequest("domain id is required").WriteJSON(w)
 		return
 	}
+	if !api.authorizeDomainView(w, r, domainID) {
+		return
+	}
 
 	nodes, err := api.service.GetDomainNodes(r.Context(), domainID)
 	if err != nil {
@@ -242,17 +351,398 @@ func (api *API) handleGetDomainNodes(w http.ResponseWriter, r *http.Request) {
 	response.Success(resp).WriteJSON(w)
 }
 
+// handleSetDomainLabels 替换域的键值标签
+func (api *API) handleSetDomainLabels(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	domainID := registry.DomainID(vars["id"])
+	if domainID == "" {
+		response.BadRequest("domain id is required").WriteJSON(w)
+		return
+	}
+	if !api.authorizeDomainManage(w, r, domainID) {
+		return
+	}
+
+	req := SetLabelsRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logrus.Errorf("Failed to decode set labels request: %v", err)
+		response.BadRequest("invalid request body: " + err.Error()).WriteJSON(w)
+		return
+	}
+
+	if err := api.service.SetDomainLabels(r.Context(), domainID, req.Labels); err != nil {
+		writeLabelsError(w, err)
+		return
+	}
+
+	response.Success(req).WriteJSON(w)
+}
+
+// handleSetNodeLabels 替换管理员为节点设置的键值标签
+func (api *API) handleSetNodeLabels(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	domainID := registry.DomainID(vars["id"])
+	nodeID := registry.NodeID(vars["node_id"])
+	if domainID == "" || nodeID == "" {
+		response.BadRequest("domain id and node id are required").WriteJSON(w)
+		return
+	}
+	if !api.authorizeDomainManage(w, r, domainID) {
+		return
+	}
+
+	req := SetLabelsRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logrus.Errorf("Failed to decode set labels request: %v", err)
+		response.BadRequest("invalid request body: " + err.Error()).WriteJSON(w)
+		return
+	}
+
+	if err := api.service.SetNodeLabels(r.Context(), domainID, nodeID, req.Labels); err != nil {
+		writeLabelsError(w, err)
+		return
+	}
+
+	response.Success(req).WriteJSON(w)
+}
+
+// handleSetNodeCordon 标记或取消标记节点为不可调度
+func (api *API) handleSetNodeCordon(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	domainID := registry.DomainID(vars["id"])
+	nodeID := registry.NodeID(vars["node_id"])
+	if domainID == "" || nodeID == "" {
+		response.BadRequest("domain id and node id are required").WriteJSON(w)
+		return
+	}
+	if !api.authorizeDomainManage(w, r, domainID) {
+		return
+	}
+
+	req := SetCordonRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logrus.Errorf("Failed to decode set cordon request: %v", err)
+		response.BadRequest("invalid request body: " + err.Error()).WriteJSON(w)
+		return
+	}
+
+	if err := api.service.SetNodeCordoned(r.Context(), domainID, nodeID, req.Cordoned); err != nil {
+		switch err {
+		case registry.ErrNodeNotFound, registry.ErrNodeNotInDomain:
+			response.NotFound(err.Error()).WriteJSON(w)
+		default:
+			logrus.Errorf("Failed to set node cordon: %v", err)
+			response.InternalError("failed to set node cordon: " + err.Error()).WriteJSON(w)
+		}
+		return
+	}
+
+	response.Success(req).WriteJSON(w)
+}
+
+// writeLabelsError 将设置标签时的错误转换为 HTTP 响应
+func writeLabelsError(w http.ResponseWriter, err error) {
+	switch {
+	case err == registry.ErrDomainNotFound:
+		response.NotFound("domain not found").WriteJSON(w)
+	case err == registry.ErrNodeNotFound || err == registry.ErrNodeNotInDomain:
+		response.NotFound(err.Error()).WriteJSON(w)
+	case errors.Is(err, registry.ErrInvalidLabels):
+		response.BadRequest(err.Error()).WriteJSON(w)
+	default:
+		logrus.Errorf("Failed to set labels: %v", err)
+		response.InternalError("failed to set labels: " + err.Error()).WriteJSON(w)
+	}
+}
+
+// handleGetDefaultPolicy 获取全局默认健康检查策略
+func (api *API) handleGetDefaultPolicy(w http.ResponseWriter, r *http.Request) {
+	policy, checkInterval := api.service.GetDefaultHealthPolicy(r.Context())
+	resp := convertHealthPolicy(policy)
+	resp.CheckIntervalSeconds = int(checkInterval / time.Second)
+	response.Success(resp).WriteJSON(w)
+}
+
+// handleUpdateDefaultPolicy 运行时更新全局默认健康检查策略
+func (api *API) handleUpdateDefaultPolicy(w http.ResponseWriter, r *http.Request) {
+	if !api.authorizeGlobal(w, r) {
+		return
+	}
+
+	req := UpdateHealthPolicyRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logrus.Errorf("Failed to decode update policy request: %v", err)
+		response.BadRequest("invalid request body: " + err.Error()).WriteJSON(w)
+		return
+	}
+
+	checkInterval := time.Duration(req.CheckIntervalSeconds) * time.Second
+	if err := api.service.UpdateDefaultHealthPolicy(r.Context(), req.toHealthPolicy(), checkInterval); err != nil {
+		if err == registry.ErrInvalidHealthPolicy {
+			response.BadRequest(err.Error()).WriteJSON(w)
+			return
+		}
+		logrus.Errorf("Failed to update default policy: %v", err)
+		response.InternalError("failed to update default policy: " + err.Error()).WriteJSON(w)
+		return
+	}
+
+	api.handleGetDefaultPolicy(w, r)
+}
+
+// handleGetDomainPolicy 获取域的健康检查策略
+func (api *API) handleGetDomainPolicy(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	domainID := registry.DomainID(vars["id"])
+	if domainID == "" {
+		response.BadRequest("domain id is required").WriteJSON(w)
+		return
+	}
+	if !api.authorizeDomainView(w, r, domainID) {
+		return
+	}
+
+	policy, err := api.service.GetDomainHealthPolicy(r.Context(), domainID)
+	if err != nil {
+		if err == registry.ErrDomainNotFound {
+			response.NotFound("domain not found").WriteJSON(w)
+			return
+		}
+		logrus.Errorf("Failed to get domain policy: %v", err)
+		response.InternalError("failed to get domain policy: " + err.Error()).WriteJSON(w)
+		return
+	}
+
+	resp := GetDomainPolicyResponse{
+		Effective: convertHealthPolicy(policy.Effective),
+	}
+	if policy.Override != nil {
+		override := convertHealthPolicy(*policy.Override)
+		resp.Override = &override
+	}
+
+	response.Success(resp).WriteJSON(w)
+}
+
+// handleUpdateDomainPolicy 设置域级健康检查策略覆盖
+func (api *API) handleUpdateDomainPolicy(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	domainID := registry.DomainID(vars["id"])
+	if domainID == "" {
+		response.BadRequest("domain id is required").WriteJSON(w)
+		return
+	}
+	if !api.authorizeDomainManage(w, r, domainID) {
+		return
+	}
+
+	req := UpdateHealthPolicyRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logrus.Errorf("Failed to decode update domain policy request: %v", err)
+		response.BadRequest("invalid request body: " + err.Error()).WriteJSON(w)
+		return
+	}
+
+	err := api.service.SetDomainHealthPolicy(r.Context(), domainID, req.toHealthPolicy())
+	if err != nil {
+		switch err {
+		case registry.ErrDomainNotFound:
+			response.NotFound("domain not found").WriteJSON(w)
+		case registry.ErrInvalidHealthPolicy:
+			response.BadRequest(err.Error()).WriteJSON(w)
+		default:
+			logrus.Errorf("Failed to update domain policy: %v", err)
+			response.InternalError("failed to update domain policy: " + err.Error()).WriteJSON(w)
+		}
+		return
+	}
+
+	api.handleGetDomainPolicy(w, r)
+}
+
+// handleResetDomainPolicy 删除域级健康检查策略覆盖
+func (api *API) handleResetDomainPolicy(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	domainID := registry.DomainID(vars["id"])
+	if domainID == "" {
+		response.BadRequest("domain id is required").WriteJSON(w)
+		return
+	}
+	if !api.authorizeDomainManage(w, r, domainID) {
+		return
+	}
+
+	if err := api.service.ResetDomainHealthPolicy(r.Context(), domainID); err != nil {
+		if err == registry.ErrDomainNotFound {
+			response.NotFound("domain not found").WriteJSON(w)
+			return
+		}
+		logrus.Errorf("Failed to reset domain policy: %v", err)
+		response.InternalError("failed to reset domain policy: " + err.Error()).WriteJSON(w)
+		return
+	}
+
+	response.Success(nil).WriteJSON(w)
+}
+
+// handleGetJoinTokens 获取加入令牌列表（不含令牌明文）
+// 查询参数 domain_id 可选；携带身份的请求只能看到自己项目拥有的域的令牌
+func (api *API) handleGetJoinTokens(w http.ResponseWriter, r *http.Request) {
+	domainID := registry.DomainID(r.URL.Query().Get("domain_id"))
+	tokens, err := api.service.ListJoinTokens(r.Context(), domainID)
+	if err != nil {
+		logrus.Errorf("Failed to get join tokens: %v", err)
+		response.InternalError("failed to get join tokens: " + err.Error()).WriteJSON(w)
+		return
+	}
+
+	resp := GetJoinTokensResponse{
+		Tokens: make([]JoinTokenItem, 0, len(tokens)),
+	}
+	for _, token := range tokens {
+		if !api.canManageDomain(r, token.DomainID) {
+			continue
+		}
+		resp.Tokens = append(resp.Tokens, convertJoinToken(token))
+	}
+	resp.Total = len(resp.Tokens)
+
+	response.Success(resp).WriteJSON(w)
+}
+
+// handleCreateJoinToken 为域创建加入令牌，令牌明文只在响应中返回一次
+func (api *API) handleCreateJoinToken(w http.ResponseWriter, r *http.Request) {
+	req := CreateJoinTokenRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logrus.Errorf("Failed to decode create join token request: %v", err)
+		response.BadRequest("invalid request body: " + err.Error()).WriteJSON(w)
+		return
+	}
+	if req.DomainID == "" {
+		response.BadRequest("domain_id is required").WriteJSON(w)
+		return
+	}
+	if !api.authorizeDomainManage(w, r, req.DomainID) {
+		return
+	}
+
+	ttl := time.Duration(req.TTLSeconds) * time.Second
+	token, secret, err := api.service.CreateJoinToken(r.Context(), req.DomainID, req.Description, ttl, req.MaxUses)
+	if err != nil {
+		switch {
+		case err == registry.ErrDomainNotFound:
+			response.NotFound("domain not found").WriteJSON(w)
+		case errors.Is(err, registry.ErrInvalidJoinToken):
+			response.BadRequest(err.Error()).WriteJSON(w)
+		default:
+			logrus.Errorf("Failed to create join token: %v", err)
+			response.InternalError("failed to create join token: " + err.Error()).WriteJSON(w)
+		}
+		return
+	}
+
+	response.Created(CreateJoinTokenResponse{
+		JoinTokenItem: convertJoinToken(token),
+		Token:         secret,
+	}).WriteJSON(w)
+}
+
+// handleRevokeJoinToken 删除加入令牌
+func (api *API) handleRevokeJoinToken(w http.ResponseWriter, r *http.Request) {
+	tokenID := mux.Vars(r)["token_id"]
+	if tokenID == "" {
+		response.BadRequest("token id is required").WriteJSON(w)
+		return
+	}
+
+	tokens, err := api.service.ListJoinTokens(r.Context(), "")
+	if err != nil {
+		logrus.Errorf("Failed to get join tokens: %v", err)
+		response.InternalError("failed to get join tokens: " + err.Error()).WriteJSON(w)
+		return
+	}
+	var target *registry.JoinToken
+	for _, token := range tokens {
+		if token.ID == tokenID {
+			target = token
+			break
+		}
+	}
+	if target == nil || !api.canManageDomain(r, target.DomainID) {
+		response.NotFound("join token not found").WriteJSON(w)
+		return
+	}
+
+	if err := api.service.RevokeJoinToken(r.Context(), tokenID); err != nil {
+		if err == registry.ErrJoinTokenNotFound {
+			response.NotFound("join token not found").WriteJSON(w)
+			return
+		}
+		logrus.Errorf("Failed to revoke join token: %v", err)
+		response.InternalError("failed to revoke join token: " + err.Error()).WriteJSON(w)
+		return
+	}
+
+	response.Success(nil).WriteJSON(w)
+}
+
+// convertJoinToken 转换加入令牌
+func convertJoinToken(token *registry.JoinToken) JoinTokenItem {
+	item := JoinTokenItem{
+		ID:          token.ID,
+		DomainID:    token.DomainID,
+		Description: token.Description,
+		MaxUses:     token.MaxUses,
+		Uses:        token.Uses,
+		Usable:      token.Usable(time.Now()),
+		CreatedAt:   token.CreatedAt.Format(time.RFC3339),
+	}
+	if !token.ExpiresAt.IsZero() {
+		item.ExpiresAt = token.ExpiresAt.Format(time.RFC3339)
+	}
+	return item
+}
+
+// convertHealthPolicy 转换健康检查策略
+func convertHealthPolicy(policy registry.HealthPolicy) HealthPolicyResponse {
+	resp := HealthPolicyResponse{
+		TimeoutSeconds: int(policy.Timeout / time.Second),
+		CleanupFactor:  policy.CleanupFactor,
+	}
+	// 仅在策略完整时给出推导值，覆盖值可能只包含部分字段
+	if policy.Timeout > 0 {
+		resp.RecommendedIntervalSeconds = int(policy.RecommendedInterval() / time.Second)
+		if policy.CleanupFactor > 0 {
+			resp.CleanupSeconds = int(policy.CleanupDuration() / time.Second)
+		}
+	}
+	return resp
+}
+
 // convertNodes 转换节点列表
 func convertNodes(nodes []*registry.Node) []NodeItem {
 	items := make([]NodeItem, 0, len(nodes))
 	for _, node := range nodes {
 		item := NodeItem{
-			ID:       node.ID,
-			Name:     node.Name,
-			Address:  node.Address,
-			Status:   string(node.Status),
-			IsHead:   node.IsHead,
-			LastSeen: node.LastSeen.Format(time.RFC3339),
+			ID:        node.ID,
+			Name:      node.Name,
+			Address:   node.Address,
+			Status:    string(node.Status),
+			Labels:    node.EffectiveLabels(),
+			Suspicion: node.Suspicion,
+			Cordoned:  node.Cordoned,
+			IsHead:    node.IsHead,
+			LastSeen:  node.LastSeen.Format(time.RFC3339),
+		}
+
+		// 主动探测结果
+		if node.Probe != nil {
+			reachable := node.Probe.Reachable
+			item.Reachable = &reachable
+			if reachable {
+				rttMs := float64(node.Probe.RTT.Microseconds()) / 1000
+				item.RTTMs = &rttMs
+			}
 		}
 
 		// 转换资源标签和资源容量
@@ -277,6 +767,10 @@ func convertNodes(nodes []*registry.Node) []NodeItem {
 				resourceTags.Memory = &total.Memory
 				hasResourceTags = true
 			}
+			if len(total.Extended) > 0 {
+				resourceTags.Extended = total.Clone().Extended
+				hasResourceTags = true
+			}
 		}
 
 		// 从 ResourceTags 获取资源标签（bool 值），主要用于 Camera
@@ -0,0 +1,155 @@
+package registry
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	"github.com/9triver/iarnet-global/internal/transport/http/util/identity"
+	"github.com/9triver/iarnet-global/internal/transport/http/util/response"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// handleGetDomainTree 获取域层级树及各级汇总的节点数、资源标签与容量
+// 携带租户身份的请求只能看到可访问的域，汇总也只统计这些域
+func (api *API) handleGetDomainTree(w http.ResponseWriter, r *http.Request) {
+	var visible func(registry.DomainID) bool
+	if identity.Scoped(r) != nil && api.tenants != nil {
+		visible = func(domainID registry.DomainID) bool {
+			return api.canViewDomain(r, domainID)
+		}
+	}
+
+	tree := api.service.GetDomainTree(r.Context(), visible)
+	resp := GetDomainTreeResponse{
+		Domains: make([]DomainTreeItem, 0, len(tree)),
+	}
+	for _, node := range tree {
+		item, count := api.convertTree(node)
+		resp.Domains = append(resp.Domains, item)
+		resp.Total += count
+	}
+
+	response.Success(resp).WriteJSON(w)
+}
+
+// handleSetDomainParent 设置域的上级域与层级类型
+func (api *API) handleSetDomainParent(w http.ResponseWriter, r *http.Request) {
+	domainID := registry.DomainID(mux.Vars(r)["id"])
+	if domainID == "" {
+		response.BadRequest("domain id is required").WriteJSON(w)
+		return
+	}
+	if !api.authorizeDomainManage(w, r, domainID) {
+		return
+	}
+
+	req := SetParentRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logrus.Errorf("Failed to decode set domain parent request: %v", err)
+		response.BadRequest("invalid request body: " + err.Error()).WriteJSON(w)
+		return
+	}
+	if !api.authorizeParent(w, r, req.ParentID) {
+		return
+	}
+
+	if err := api.service.SetDomainParent(r.Context(), domainID, req.ParentID, req.Kind); err != nil {
+		writeHierarchyError(w, err)
+		return
+	}
+
+	response.Success(nil).WriteJSON(w)
+}
+
+// authorizeParent 携带身份的请求只能把域挂到可访问的上级域下
+func (api *API) authorizeParent(w http.ResponseWriter, r *http.Request, parentID registry.DomainID) bool {
+	if parentID == "" || api.canViewDomain(r, parentID) {
+		return true
+	}
+	response.BadRequest("parent domain not found").WriteJSON(w)
+	return false
+}
+
+// writeHierarchyError 将域层级操作错误转换为 HTTP 响应
+func writeHierarchyError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, registry.ErrDomainNotFound):
+		response.NotFound("domain not found").WriteJSON(w)
+	case errors.Is(err, registry.ErrInvalidHierarchy), errors.Is(err, registry.ErrDomainHasChildren):
+		response.BadRequest(err.Error()).WriteJSON(w)
+	default:
+		logrus.Errorf("Failed to update domain hierarchy: %v", err)
+		response.InternalError("failed to update domain hierarchy: " + err.Error()).WriteJSON(w)
+	}
+}
+
+// convertTree 转换域层级树，返回转换后的节点及其包含的域数量
+func (api *API) convertTree(node *registry.DomainTreeNode) (DomainTreeItem, int) {
+	item := DomainTreeItem{
+		ID:          node.ID,
+		Name:        node.Name,
+		Kind:        node.Kind,
+		ParentID:    node.ParentID,
+		Labels:      node.Labels,
+		Owner:       api.domainOwner(node.ID),
+		NodeCount:   node.Nodes,
+		TotalNodes:  node.TotalNodes,
+		OnlineNodes: node.OnlineNodes,
+		ResourceTags: ResourceTagsResponse{
+			CPU:    node.ResourceTags.CPU,
+			GPU:    node.ResourceTags.GPU,
+			Memory: node.ResourceTags.Memory,
+			Camera: node.ResourceTags.Camera,
+		},
+		Capacity: convertCapacity(node.Capacity),
+	}
+
+	count := 1
+	for _, child := range node.Children {
+		childItem, n := api.convertTree(child)
+		item.Children = append(item.Children, childItem)
+		count += n
+	}
+	return item, count
+}
+
+// convertCapacity 转换资源容量汇总并计算利用率
+func convertCapacity(capacity *registry.ResourceCapacity) CapacityResponse {
+	if capacity == nil {
+		return CapacityResponse{}
+	}
+	total := convertAmount(capacity.Total)
+	used := convertAmount(capacity.Used)
+	return CapacityResponse{
+		Total:     total,
+		Used:      used,
+		Available: convertAmount(capacity.Available),
+		Utilization: UtilizationResponse{
+			CPU:    utilization(used.CPU, total.CPU),
+			Memory: utilization(used.Memory, total.Memory),
+			GPU:    utilization(used.GPU, total.GPU),
+		},
+	}
+}
+
+func utilization(used, total int64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return float64(used) / float64(total)
+}
+
+func convertAmount(info *registry.ResourceInfo) ResourceAmount {
+	if info == nil {
+		return ResourceAmount{}
+	}
+	return ResourceAmount{
+		CPU:      info.CPU,
+		Memory:   info.Memory,
+		GPU:      info.GPU,
+		Extended: info.Extended,
+	}
+}
@@ -0,0 +1,95 @@
+package registry
+
+import (
+	"net/http"
+
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	"github.com/9triver/iarnet-global/internal/domain/tenant"
+	"github.com/9triver/iarnet-global/internal/transport/http/util/identity"
+	"github.com/9triver/iarnet-global/internal/transport/http/util/response"
+)
+
+// canViewDomain 请求方是否可以查看该域（管理员不受限）
+func (api *API) canViewDomain(r *http.Request, domainID registry.DomainID) bool {
+	id := identity.Scoped(r)
+	if id == nil || api.tenants == nil {
+		return true
+	}
+	return api.tenants.CanUseDomain(id.Tenant, domainID)
+}
+
+// authorizeDomainView 校验查看权限，无权查看的域按不存在处理
+func (api *API) authorizeDomainView(w http.ResponseWriter, r *http.Request, domainID registry.DomainID) bool {
+	if !api.canViewDomain(r, domainID) {
+		response.NotFound("domain not found").WriteJSON(w)
+		return false
+	}
+	return true
+}
+
+// canManageDomain 请求方是否可以管理该域（管理员不受限）
+func (api *API) canManageDomain(r *http.Request, domainID registry.DomainID) bool {
+	id := identity.Scoped(r)
+	if id == nil || api.tenants == nil {
+		return true
+	}
+	owner, ok := api.tenants.DomainOwner(domainID)
+	return ok && owner == id.Tenant
+}
+
+// authorizeDomainManage 校验管理权限：携带身份的请求只能管理自己项目拥有的域
+func (api *API) authorizeDomainManage(w http.ResponseWriter, r *http.Request, domainID registry.DomainID) bool {
+	id := identity.Scoped(r)
+	if id == nil || api.tenants == nil {
+		return true
+	}
+	if !api.tenants.CanUseDomain(id.Tenant, domainID) {
+		response.NotFound("domain not found").WriteJSON(w)
+		return false
+	}
+	if owner, ok := api.tenants.DomainOwner(domainID); !ok || owner != id.Tenant {
+		response.Forbidden("only the owning project can manage this domain").WriteJSON(w)
+		return false
+	}
+	return true
+}
+
+// authorizeGlobal 校验全局配置的管理权限：仅管理员可用
+func (api *API) authorizeGlobal(w http.ResponseWriter, r *http.Request) bool {
+	if identity.Scoped(r) != nil {
+		response.Forbidden("tenant-scoped requests cannot change global settings").WriteJSON(w)
+		return false
+	}
+	return true
+}
+
+// authorizeDomainCreate 校验创建权限：携带身份的请求必须属于已存在的项目，创建的域归属该项目
+func (api *API) authorizeDomainCreate(w http.ResponseWriter, r *http.Request) bool {
+	id := identity.Scoped(r)
+	if id == nil || api.tenants == nil {
+		return true
+	}
+	if _, err := api.tenants.GetProject(r.Context(), id.Tenant); err != nil {
+		response.Forbidden("only projects can create domains").WriteJSON(w)
+		return false
+	}
+	return true
+}
+
+// domainOwner 返回拥有该域的项目
+func (api *API) domainOwner(domainID registry.DomainID) string {
+	if api.tenants == nil {
+		return ""
+	}
+	owner, _ := api.tenants.DomainOwner(domainID)
+	return owner
+}
+
+// claimDomain 携带项目身份创建的域自动归属该项目
+func (api *API) claimDomain(r *http.Request, domainID registry.DomainID) error {
+	id := identity.Scoped(r)
+	if id == nil || api.tenants == nil {
+		return nil
+	}
+	return api.tenants.SetDomainAccess(r.Context(), id.Tenant, domainID, tenant.DomainRoleOwner)
+}
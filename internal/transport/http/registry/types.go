@@ -1,9 +1,63 @@
 package registry
 
+import (
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+)
+
 // CreateDomainRequest 创建域请求
 type CreateDomainRequest struct {
-	Name        string `json:"name" binding:"required"` // 域名称（必填）
-	Description string `json:"description,omitempty"`   // 域描述（可选）
+	Name        string          `json:"name" binding:"required"` // 域名称（必填）
+	Description string          `json:"description,omitempty"`   // 域描述（可选）
+	Labels      registry.Labels `json:"labels,omitempty"`        // 域标签（可选）
+	ParentID    string          `json:"parent_id,omitempty"`     // 上级域 ID（可选）
+	Kind        string          `json:"kind,omitempty"`          // 层级类型，如 region、site（可选）
+}
+
+// SetParentRequest 设置上级域与层级类型请求
+type SetParentRequest struct {
+	ParentID string `json:"parent_id"`      // 上级域 ID（为空表示顶层域）
+	Kind     string `json:"kind,omitempty"` // 层级类型，如 region、site（为空表示普通域）
+}
+
+// SetLabelsRequest 替换键值标签请求（空对象表示清空）
+type SetLabelsRequest struct {
+	Labels registry.Labels `json:"labels"` // 键值标签
+}
+
+// SetCordonRequest 标记节点是否可调度请求
+type SetCordonRequest struct {
+	Cordoned bool `json:"cordoned"` // true 表示不再向该节点放置新的 component
+}
+
+// UpdateHealthPolicyRequest 更新健康检查策略请求（省略或为 0 的字段保持不变/继承默认值）
+type UpdateHealthPolicyRequest struct {
+	TimeoutSeconds       int     `json:"timeout_seconds,omitempty"`        // 节点超时时间（秒）
+	CleanupFactor        float64 `json:"cleanup_factor,omitempty"`         // 清理倍数
+	CheckIntervalSeconds int     `json:"check_interval_seconds,omitempty"` // 超时检测周期（秒，仅全局策略有效）
+}
+
+func (req UpdateHealthPolicyRequest) toHealthPolicy() registry.HealthPolicy {
+	return registry.HealthPolicy{
+		Timeout:       time.Duration(req.TimeoutSeconds) * time.Second,
+		CleanupFactor: req.CleanupFactor,
+	}
+}
+
+// HealthPolicyResponse 健康检查策略响应
+type HealthPolicyResponse struct {
+	TimeoutSeconds             int     `json:"timeout_seconds"`                  // 节点超时时间（秒）
+	CleanupFactor              float64 `json:"cleanup_factor"`                   // 清理倍数
+	CleanupSeconds             int     `json:"cleanup_seconds,omitempty"`        // 节点清理时间（秒）
+	RecommendedIntervalSeconds int     `json:"recommended_interval_seconds"`     // 建议节点心跳间隔（秒）
+	CheckIntervalSeconds       int     `json:"check_interval_seconds,omitempty"` // 超时检测周期（秒，仅全局策略）
+}
+
+// GetDomainPolicyResponse 获取域健康检查策略响应
+type GetDomainPolicyResponse struct {
+	Override  *HealthPolicyResponse `json:"override,omitempty"` // 域级覆盖（为空表示使用全局默认）
+	Effective HealthPolicyResponse  `json:"effective"`          // 生效策略
 }
 
 // CreateDomainResponse 创建域响应
@@ -28,14 +82,20 @@ type GetDomainsResponse struct {
 
 // DomainItem 域列表项
 type DomainItem struct {
-	ID           string               `json:"id"`            // 域 ID
-	Name         string               `json:"name"`          // 域名称
-	Description  string               `json:"description"`   // 域描述
-	NodeCount    int                  `json:"node_count"`    // 节点总数
-	OnlineNodes  int                  `json:"online_nodes"`  // 在线节点数
-	ResourceTags ResourceTagsResponse `json:"resource_tags"` // 资源标签
-	CreatedAt    string               `json:"created_at"`    // 创建时间
-	UpdatedAt    string               `json:"updated_at"`    // 更新时间
+	ID           string               `json:"id"`                  // 域 ID
+	Name         string               `json:"name"`                // 域名称
+	Description  string               `json:"description"`         // 域描述
+	ParentID     string               `json:"parent_id,omitempty"` // 上级域 ID
+	Kind         string               `json:"kind,omitempty"`      // 层级类型
+	NodeCount    int                  `json:"node_count"`          // 节点总数
+	OnlineNodes  int                  `json:"online_nodes"`        // 在线节点数
+	SuspectNodes int                  `json:"suspect_nodes"`       // 疑似失效节点数
+	ResourceTags ResourceTagsResponse `json:"resource_tags"`       // 资源标签
+	Capacity     CapacityResponse     `json:"capacity"`            // 存活节点的资源汇总
+	Labels       registry.Labels      `json:"labels,omitempty"`    // 键值标签
+	Owner        string               `json:"owner,omitempty"`     // 拥有该域的项目 ID（为空表示公共域）
+	CreatedAt    string               `json:"created_at"`          // 创建时间
+	UpdatedAt    string               `json:"updated_at"`          // 更新时间
 }
 
 // ResourceTagsResponse 资源标签响应（只显示是否支持，不显示具体数值）
@@ -48,13 +108,64 @@ type ResourceTagsResponse struct {
 
 // GetDomainResponse 获取单个域响应
 type GetDomainResponse struct {
-	ID           string               `json:"id"`            // 域 ID
-	Name         string               `json:"name"`          // 域名称
-	Description  string               `json:"description"`   // 域描述
-	ResourceTags ResourceTagsResponse `json:"resource_tags"` // 资源标签
-	Nodes        []NodeItem           `json:"nodes"`         // 节点列表
-	CreatedAt    string               `json:"created_at"`    // 创建时间
-	UpdatedAt    string               `json:"updated_at"`    // 更新时间
+	ID           string               `json:"id"`                  // 域 ID
+	Name         string               `json:"name"`                // 域名称
+	Description  string               `json:"description"`         // 域描述
+	ParentID     string               `json:"parent_id,omitempty"` // 上级域 ID
+	Kind         string               `json:"kind,omitempty"`      // 层级类型
+	Children     []string             `json:"children,omitempty"`  // 直接下级域 ID
+	ResourceTags ResourceTagsResponse `json:"resource_tags"`       // 资源标签
+	Capacity     CapacityResponse     `json:"capacity"`            // 存活节点的资源汇总
+	Labels       registry.Labels      `json:"labels,omitempty"`    // 键值标签
+	Owner        string               `json:"owner,omitempty"`     // 拥有该域的项目 ID（为空表示公共域）
+	Nodes        []NodeItem           `json:"nodes"`               // 节点列表
+	CreatedAt    string               `json:"created_at"`          // 创建时间
+	UpdatedAt    string               `json:"updated_at"`          // 更新时间
+}
+
+// GetDomainTreeResponse 获取域层级树响应
+type GetDomainTreeResponse struct {
+	Domains []DomainTreeItem `json:"domains"` // 顶层域
+	Total   int              `json:"total"`   // 域总数（含下级域）
+}
+
+// DomainTreeItem 域层级树节点，节点数、资源标签与容量汇总了该域及全部下级域
+type DomainTreeItem struct {
+	ID           string               `json:"id"`                  // 域 ID
+	Name         string               `json:"name"`                // 域名称
+	Kind         string               `json:"kind,omitempty"`      // 层级类型
+	ParentID     string               `json:"parent_id,omitempty"` // 上级域 ID
+	Labels       registry.Labels      `json:"labels,omitempty"`    // 键值标签
+	Owner        string               `json:"owner,omitempty"`     // 拥有该域的项目 ID
+	NodeCount    int                  `json:"node_count"`          // 直接属于该域的节点数
+	TotalNodes   int                  `json:"total_nodes"`         // 含下级域的节点总数
+	OnlineNodes  int                  `json:"online_nodes"`        // 含下级域的在线节点数
+	ResourceTags ResourceTagsResponse `json:"resource_tags"`       // 在线节点的资源标签
+	Capacity     CapacityResponse     `json:"capacity"`            // 存活节点的资源汇总
+	Children     []DomainTreeItem     `json:"children,omitempty"`  // 下级域
+}
+
+// CapacityResponse 资源容量汇总
+type CapacityResponse struct {
+	Total       ResourceAmount      `json:"total"`       // 总资源
+	Used        ResourceAmount      `json:"used"`        // 已使用资源
+	Available   ResourceAmount      `json:"available"`   // 可用资源
+	Utilization UtilizationResponse `json:"utilization"` // 资源利用率
+}
+
+// UtilizationResponse 资源利用率（已使用 / 总量，0-1，总量为 0 时为 0）
+type UtilizationResponse struct {
+	CPU    float64 `json:"cpu"`    // CPU 利用率
+	Memory float64 `json:"memory"` // 内存利用率
+	GPU    float64 `json:"gpu"`    // GPU 利用率
+}
+
+// ResourceAmount 资源量
+type ResourceAmount struct {
+	CPU      int64            `json:"cpu"`                // CPU（millicores）
+	Memory   int64            `json:"memory"`             // 内存（bytes）
+	GPU      int64            `json:"gpu"`                // GPU 数量
+	Extended map[string]int64 `json:"extended,omitempty"` // 扩展资源
 }
 
 // GetDomainNodesResponse 获取域节点列表响应
@@ -68,7 +179,12 @@ type NodeItem struct {
 	ID           string                    `json:"id"`                      // 节点 ID
 	Name         string                    `json:"name"`                    // 节点名称
 	Address      string                    `json:"address"`                 // 节点地址
-	Status       string                    `json:"status"`                  // 节点状态（online/offline/error）
+	Status       string                    `json:"status"`                  // 节点状态（online/suspect/offline/error）
+	Labels       registry.Labels           `json:"labels,omitempty"`        // 生效的键值标签（管理员标签覆盖节点上报标签）
+	Suspicion    float64                   `json:"suspicion"`               // phi-accrual 怀疑度
+	Cordoned     bool                      `json:"cordoned"`                // 是否被标记为不可调度
+	Reachable    *bool                     `json:"reachable,omitempty"`     // 主动探测的可达性（未探测时为空）
+	RTTMs        *float64                  `json:"rtt_ms,omitempty"`        // 主动探测的往返时延（毫秒）
 	IsHead       bool                      `json:"is_head"`                 // 是否为 head 节点
 	ResourceTags *NodeResourceTagsResponse `json:"resource_tags,omitempty"` // 资源标签（显示具体数值）
 	LastSeen     string                    `json:"last_seen"`               // 最后活跃时间
@@ -80,4 +196,38 @@ type NodeResourceTagsResponse struct {
 	GPU    *int64 `json:"gpu,omitempty"`    // GPU 数量
 	Memory *int64 `json:"memory,omitempty"` // 内存容量（字节）
 	Camera *bool  `json:"camera,omitempty"` // 是否支持摄像头
+	// Extended 扩展资源总量（如 gpu.memory、npu、disk、network.bandwidth）
+	Extended map[string]int64 `json:"extended,omitempty"`
+}
+
+// CreateJoinTokenRequest 创建加入令牌请求
+type CreateJoinTokenRequest struct {
+	DomainID    registry.DomainID `json:"domain_id"`             // 令牌所属域（必填）
+	Description string            `json:"description,omitempty"` // 描述（可选）
+	TTLSeconds  int               `json:"ttl_seconds,omitempty"` // 有效期（秒），0 表示永不过期
+	MaxUses     int               `json:"max_uses,omitempty"`    // 最大使用次数，0 表示不限
+}
+
+// CreateJoinTokenResponse 创建加入令牌响应
+type CreateJoinTokenResponse struct {
+	JoinTokenItem
+	Token string `json:"token"` // 令牌明文（只返回一次）
+}
+
+// GetJoinTokensResponse 获取加入令牌列表响应
+type GetJoinTokensResponse struct {
+	Tokens []JoinTokenItem `json:"tokens"` // 令牌列表
+	Total  int             `json:"total"`  // 总数
+}
+
+// JoinTokenItem 加入令牌列表项
+type JoinTokenItem struct {
+	ID          string            `json:"id"`                    // 令牌 ID
+	DomainID    registry.DomainID `json:"domain_id"`             // 所属域
+	Description string            `json:"description,omitempty"` // 描述
+	MaxUses     int               `json:"max_uses"`              // 最大使用次数，0 表示不限
+	Uses        int               `json:"uses"`                  // 已使用次数
+	Usable      bool              `json:"usable"`                // 是否仍可使用（未过期且未用尽）
+	ExpiresAt   string            `json:"expires_at,omitempty"`  // 过期时间（为空表示永不过期）
+	CreatedAt   string            `json:"created_at"`            // 创建时间
 }
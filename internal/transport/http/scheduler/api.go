@@ -0,0 +1,255 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"github.com/9triver/iarnet-global/internal/domain/deployment"
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	domainscheduler "github.com/9triver/iarnet-global/internal/domain/scheduler"
+	"github.com/9triver/iarnet-global/internal/domain/tenant"
+	schedulerpb "github.com/9triver/iarnet-global/internal/proto/scheduler"
+	"github.com/9triver/iarnet-global/internal/transport/http/util/identity"
+	"github.com/9triver/iarnet-global/internal/transport/http/util/response"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// RegisterRoutes 注册调度相关的 HTTP 路由
+func RegisterRoutes(router *mux.Router, service domainscheduler.Service) {
+	api := NewAPI(service)
+	router.HandleFunc("/scheduler/simulate", api.handleSimulatePlacement).Methods("POST")
+	router.HandleFunc("/scheduler/deployments", api.handleGetDeployments).Methods("GET")
+	router.HandleFunc("/scheduler/deployments", api.handleDeploy).Methods("POST")
+	router.HandleFunc("/scheduler/deployments/{id}", api.handleGetDeployment).Methods("GET")
+	router.HandleFunc("/scheduler/deployments/{id}/stop", api.handleStopDeployment).Methods("POST")
+	router.HandleFunc("/scheduler/nodes/{node_id}/evict", api.handleEvictNode).Methods("POST")
+}
+
+type API struct {
+	service domainscheduler.Service
+}
+
+func NewAPI(service domainscheduler.Service) *API {
+	return &API{
+		service: service,
+	}
+}
+
+// handleSimulatePlacement 模拟调度：返回请求在当前集群状态下的候选节点排名、得分与淘汰原因，不实际部署
+// 携带租户身份的请求以自身租户模拟；管理视图可通过 tenant 指定租户，未指定时使用默认租户
+func (api *API) handleSimulatePlacement(w http.ResponseWriter, r *http.Request) {
+	req := SimulatePlacementRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logrus.Errorf("Failed to decode simulate placement request: %v", err)
+		response.BadRequest("invalid request body: " + err.Error()).WriteJSON(w)
+		return
+	}
+	if len(req.Request) == 0 {
+		response.BadRequest("request is required").WriteJSON(w)
+		return
+	}
+
+	deployReq := &schedulerpb.DeployComponentRequest{}
+	if err := protojson.Unmarshal(req.Request, deployReq); err != nil {
+		response.BadRequest("invalid request: " + err.Error()).WriteJSON(w)
+		return
+	}
+
+	ctx, id := withTenant(r, req.Tenant)
+	resp, err := api.service.SimulatePlacement(ctx, &schedulerpb.SimulatePlacementRequest{Request: deployReq})
+	if err != nil {
+		logrus.Errorf("Failed to simulate placement: %v", err)
+		response.InternalError("failed to simulate placement: " + err.Error()).WriteJSON(w)
+		return
+	}
+	if !resp.Success {
+		response.BadRequest(resp.Error).WriteJSON(w)
+		return
+	}
+
+	response.Success(convertSimulation(id.Tenant, resp)).WriteJSON(w)
+}
+
+// handleGetDeployments 获取部署列表
+// 查询参数（均可选）: tenant（仅管理视图有效）、domain_id、node_id、status、active（true 时只返回未结束的部署）
+// 携带租户身份的请求只能看到自己的部署
+func (api *API) handleGetDeployments(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := deployment.Filter{
+		Tenant:     query.Get("tenant"),
+		DomainID:   registry.DomainID(query.Get("domain_id")),
+		NodeID:     registry.NodeID(query.Get("node_id")),
+		Status:     deployment.Status(query.Get("status")),
+		ActiveOnly: query.Get("active") == "true",
+	}
+	if id := identity.Scoped(r); id != nil {
+		filter.Tenant = id.Tenant
+	}
+
+	deployments := api.service.ListDeployments(r.Context(), filter)
+	sort.Slice(deployments, func(i, j int) bool {
+		return deployments[i].CreatedAt.Before(deployments[j].CreatedAt)
+	})
+
+	resp := GetDeploymentsResponse{
+		Deployments: make([]DeploymentItem, 0, len(deployments)),
+		Total:       len(deployments),
+	}
+	for _, d := range deployments {
+		resp.Deployments = append(resp.Deployments, convertDeployment(d))
+	}
+
+	response.Success(resp).WriteJSON(w)
+}
+
+// handleGetDeployment 获取单个部署
+func (api *API) handleGetDeployment(w http.ResponseWriter, r *http.Request) {
+	d := api.findDeployment(r, mux.Vars(r)["id"])
+	if d == nil {
+		response.NotFound("deployment not found").WriteJSON(w)
+		return
+	}
+	response.Success(convertDeployment(d)).WriteJSON(w)
+}
+
+// handleDeploy 通过全局调度器部署 component
+// 携带租户身份的请求以自身租户部署；管理视图可通过 tenant 指定租户，未指定时使用默认租户
+func (api *API) handleDeploy(w http.ResponseWriter, r *http.Request) {
+	req := DeployRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logrus.Errorf("Failed to decode deploy request: %v", err)
+		response.BadRequest("invalid request body: " + err.Error()).WriteJSON(w)
+		return
+	}
+	if len(req.Request) == 0 {
+		response.BadRequest("request is required").WriteJSON(w)
+		return
+	}
+
+	deployReq := &schedulerpb.DeployComponentRequest{}
+	if err := protojson.Unmarshal(req.Request, deployReq); err != nil {
+		response.BadRequest("invalid request: " + err.Error()).WriteJSON(w)
+		return
+	}
+
+	ctx, id := withTenant(r, req.Tenant)
+	resp, err := api.service.DeployComponent(ctx, deployReq)
+	if err != nil {
+		logrus.Errorf("Failed to deploy component: %v", err)
+		response.InternalError("failed to deploy component: " + err.Error()).WriteJSON(w)
+		return
+	}
+	if !resp.Success {
+		response.BadRequest(resp.Error).WriteJSON(w)
+		return
+	}
+
+	result := DeployResponse{
+		DeploymentID:  resp.DeploymentId,
+		Tenant:        id.Tenant,
+		Status:        resp.Status.String(),
+		NodeID:        resp.NodeId,
+		NodeName:      resp.NodeName,
+		ComponentID:   resp.GetComponent().GetComponentId(),
+		QueuePosition: resp.QueuePosition,
+	}
+	if d := api.findDeployment(r, resp.DeploymentId); d != nil {
+		result.Status = string(d.Status)
+	}
+	response.Created(result).WriteJSON(w)
+}
+
+// handleStopDeployment 停止部署
+func (api *API) handleStopDeployment(w http.ResponseWriter, r *http.Request) {
+	d := api.findDeployment(r, mux.Vars(r)["id"])
+	if d == nil {
+		response.NotFound("deployment not found").WriteJSON(w)
+		return
+	}
+
+	req := StopDeploymentRequest{}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			logrus.Errorf("Failed to decode stop deployment request: %v", err)
+			response.BadRequest("invalid request body: " + err.Error()).WriteJSON(w)
+			return
+		}
+	}
+
+	// 以部署所属租户的身份停止，管理视图可以停止任意租户的部署
+	ctx := tenant.WithIdentity(r.Context(), &tenant.Identity{Tenant: d.Tenant})
+	resp, err := api.service.StopComponent(ctx, &schedulerpb.StopComponentRequest{
+		DeploymentId: d.ID,
+		Reason:       req.Reason,
+	})
+	if err != nil {
+		logrus.Errorf("Failed to stop deployment %s: %v", d.ID, err)
+		response.InternalError("failed to stop deployment: " + err.Error()).WriteJSON(w)
+		return
+	}
+	if !resp.Success {
+		response.BadRequest(resp.Error).WriteJSON(w)
+		return
+	}
+
+	api.handleGetDeployment(w, r)
+}
+
+// handleEvictNode 将节点上运行中的 component 迁移到其他节点（仅管理视图可用）
+func (api *API) handleEvictNode(w http.ResponseWriter, r *http.Request) {
+	if identity.Scoped(r) != nil {
+		response.Forbidden("tenant-scoped requests cannot evict nodes").WriteJSON(w)
+		return
+	}
+	nodeID := registry.NodeID(mux.Vars(r)["node_id"])
+	if nodeID == "" {
+		response.BadRequest("node id is required").WriteJSON(w)
+		return
+	}
+
+	result, err := api.service.EvictNode(r.Context(), nodeID)
+	if err != nil {
+		if err == registry.ErrNodeNotFound {
+			response.NotFound("node not found").WriteJSON(w)
+			return
+		}
+		logrus.Errorf("Failed to evict node %s: %v", nodeID, err)
+		response.InternalError("failed to evict node: " + err.Error()).WriteJSON(w)
+		return
+	}
+
+	response.Success(result).WriteJSON(w)
+}
+
+// findDeployment 查找请求方可见的部署，携带租户身份的请求只能看到自己的部署
+func (api *API) findDeployment(r *http.Request, deploymentID string) *deployment.Deployment {
+	if deploymentID == "" {
+		return nil
+	}
+	filter := deployment.Filter{}
+	if id := identity.Scoped(r); id != nil {
+		filter.Tenant = id.Tenant
+	}
+	for _, d := range api.service.ListDeployments(r.Context(), filter) {
+		if d.ID == deploymentID {
+			return d
+		}
+	}
+	return nil
+}
+
+// withTenant 返回携带调用方身份的 context：管理员以 tenantID 的身份调用，为空时保留管理员身份
+func withTenant(r *http.Request, tenantID string) (context.Context, *tenant.Identity) {
+	if id := identity.Scoped(r); id != nil {
+		return r.Context(), id
+	}
+	if tenantID == "" {
+		return r.Context(), tenant.IdentityFromContext(r.Context())
+	}
+	id := &tenant.Identity{Tenant: tenantID}
+	return tenant.WithIdentity(r.Context(), id), id
+}
@@ -0,0 +1,174 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/deployment"
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	schedulerpb "github.com/9triver/iarnet-global/internal/proto/scheduler"
+)
+
+// SimulatePlacementRequest 模拟调度请求
+type SimulatePlacementRequest struct {
+	// Tenant 以指定租户的身份模拟（仅管理视图有效，携带租户身份的请求固定为自身租户）
+	Tenant string `json:"tenant,omitempty"`
+	// Request 部署请求，字段与 scheduler.DeployComponentRequest 的 JSON 表示一致
+	Request json.RawMessage `json:"request"`
+}
+
+// SimulatePlacementResponse 模拟调度响应
+type SimulatePlacementResponse struct {
+	Tenant            string             `json:"tenant"`                       // 模拟使用的租户
+	Placeable         bool               `json:"placeable"`                    // 是否存在可放置的节点
+	PlacementError    string             `json:"placement_error,omitempty"`    // 无法放置时实际调度会返回的错误
+	Candidates        []CandidateItem    `json:"candidates"`                   // 按排名排列的候选节点
+	Domains           []DomainEvaluation `json:"domains"`                      // 每个域及其节点的筛选结果
+	WouldQueue        bool               `json:"would_queue"`                  // 无法放置时是否会进入等待队列
+	PreemptionNodeID  string             `json:"preemption_node_id,omitempty"` // 抢占方案选中的节点
+	PreemptionVictims []string           `json:"preemption_victims,omitempty"` // 抢占方案需要停止的部署
+}
+
+// CandidateItem 候选节点
+type CandidateItem struct {
+	Rank          int32  `json:"rank"`           // 排名（从 1 开始）
+	DomainID      string `json:"domain_id"`      // 域 ID
+	NodeID        string `json:"node_id"`        // 节点 ID
+	NodeName      string `json:"node_name"`      // 节点名称
+	Score         int64  `json:"score"`          // 总得分
+	AffinityScore int64  `json:"affinity_score"` // 亲和得分
+	TopologyScore int64  `json:"topology_score"` // 拓扑得分
+	Suspect       bool   `json:"suspect"`        // 疑似失效节点
+	Preferred     bool   `json:"preferred"`      // 实际调度会从中随机选择的最高得分节点
+}
+
+// DomainEvaluation 域的筛选结果
+type DomainEvaluation struct {
+	DomainID        string           `json:"domain_id"`                  // 域 ID
+	DomainName      string           `json:"domain_name"`                // 域名称
+	RejectionReason string           `json:"rejection_reason,omitempty"` // 整个域被淘汰的原因
+	Nodes           []NodeEvaluation `json:"nodes"`                      // 域内节点的筛选结果
+}
+
+// NodeEvaluation 节点的筛选结果
+type NodeEvaluation struct {
+	NodeID          string `json:"node_id"`                    // 节点 ID
+	NodeName        string `json:"node_name"`                  // 节点名称
+	Eligible        bool   `json:"eligible"`                   // 是否通过筛选
+	RejectionReason string `json:"rejection_reason,omitempty"` // 淘汰原因
+	Score           int64  `json:"score"`                      // 得分
+}
+
+func convertSimulation(tenantID string, resp *schedulerpb.SimulatePlacementResponse) SimulatePlacementResponse {
+	result := SimulatePlacementResponse{
+		Tenant:            tenantID,
+		Placeable:         resp.Placeable,
+		PlacementError:    resp.PlacementError,
+		Candidates:        make([]CandidateItem, 0, len(resp.Candidates)),
+		Domains:           make([]DomainEvaluation, 0, len(resp.Domains)),
+		WouldQueue:        resp.WouldQueue,
+		PreemptionNodeID:  resp.PreemptionNodeId,
+		PreemptionVictims: resp.PreemptionVictims,
+	}
+	for _, c := range resp.Candidates {
+		result.Candidates = append(result.Candidates, CandidateItem{
+			Rank:          c.Rank,
+			DomainID:      c.DomainId,
+			NodeID:        c.NodeId,
+			NodeName:      c.NodeName,
+			Score:         c.Score,
+			AffinityScore: c.AffinityScore,
+			TopologyScore: c.TopologyScore,
+			Suspect:       c.Suspect,
+			Preferred:     c.Preferred,
+		})
+	}
+	for _, d := range resp.Domains {
+		domain := DomainEvaluation{
+			DomainID:        d.DomainId,
+			DomainName:      d.DomainName,
+			RejectionReason: d.RejectionReason,
+			Nodes:           make([]NodeEvaluation, 0, len(d.Nodes)),
+		}
+		for _, n := range d.Nodes {
+			domain.Nodes = append(domain.Nodes, NodeEvaluation{
+				NodeID:          n.NodeId,
+				NodeName:        n.NodeName,
+				Eligible:        n.Eligible,
+				RejectionReason: n.RejectionReason,
+				Score:           n.Score,
+			})
+		}
+		result.Domains = append(result.Domains, domain)
+	}
+	return result
+}
+
+// DeployRequest 部署请求
+type DeployRequest struct {
+	// Tenant 以指定租户的身份部署（仅管理视图有效，携带租户身份的请求固定为自身租户）
+	Tenant string `json:"tenant,omitempty"`
+	// Request 部署请求，字段与 scheduler.DeployComponentRequest 的 JSON 表示一致
+	Request json.RawMessage `json:"request"`
+}
+
+// DeployResponse 部署响应
+type DeployResponse struct {
+	DeploymentID  string `json:"deployment_id"`            // 部署 ID
+	Tenant        string `json:"tenant"`                   // 部署所属租户
+	Status        string `json:"status"`                   // 部署状态
+	NodeID        string `json:"node_id,omitempty"`        // 部署的节点 ID
+	NodeName      string `json:"node_name,omitempty"`      // 部署的节点名称
+	ComponentID   string `json:"component_id,omitempty"`   // 节点返回的 component ID
+	QueuePosition int32  `json:"queue_position,omitempty"` // 进入等待队列时的排队位置
+}
+
+// StopDeploymentRequest 停止部署请求
+type StopDeploymentRequest struct {
+	Reason string `json:"reason,omitempty"` // 停止原因（可选）
+}
+
+// GetDeploymentsResponse 获取部署列表响应
+type GetDeploymentsResponse struct {
+	Deployments []DeploymentItem `json:"deployments"` // 部署列表（按创建时间排序）
+	Total       int              `json:"total"`       // 总数
+}
+
+// DeploymentItem 部署列表项
+type DeploymentItem struct {
+	ID          string                 `json:"id"`                     // 部署 ID
+	ComponentID string                 `json:"component_id,omitempty"` // 节点返回的 component ID
+	Tenant      string                 `json:"tenant"`                 // 所属租户
+	DomainID    string                 `json:"domain_id,omitempty"`    // 所在域
+	NodeID      string                 `json:"node_id,omitempty"`      // 所在节点
+	NodeName    string                 `json:"node_name,omitempty"`    // 所在节点名称
+	Status      string                 `json:"status"`                 // 部署状态
+	Error       string                 `json:"error,omitempty"`        // 失败或停止原因
+	Priority    int32                  `json:"priority"`               // 优先级
+	Restarts    int                    `json:"restarts,omitempty"`     // 自动重启次数
+	Resources   *registry.ResourceInfo `json:"resources,omitempty"`    // 请求的资源
+	CreatedAt   string                 `json:"created_at"`             // 创建时间
+	UpdatedAt   string                 `json:"updated_at"`             // 更新时间
+	// StaleInstances 失联节点上等待节点恢复后移除的旧实例数
+	StaleInstances int `json:"stale_instances,omitempty"`
+}
+
+func convertDeployment(d *deployment.Deployment) DeploymentItem {
+	return DeploymentItem{
+		ID:          d.ID,
+		ComponentID: d.ComponentID,
+		Tenant:      d.Tenant,
+		DomainID:    d.DomainID,
+		NodeID:      d.NodeID,
+		NodeName:    d.NodeName,
+		Status:      string(d.Status),
+		Error:       d.Error,
+		Priority:    d.Priority(),
+		Restarts:    d.Restarts,
+		Resources:   d.Resources,
+		CreatedAt:   d.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   d.UpdatedAt.Format(time.RFC3339),
+
+		StaleInstances: len(d.StaleInstances),
+	}
+}
@@ -7,9 +7,28 @@ import (
 	"time"
 
 	"github.com/9triver/iarnet-global/internal/config"
+	"github.com/9triver/iarnet-global/internal/domain/alert"
+	"github.com/9triver/iarnet-global/internal/domain/audit"
+	"github.com/9triver/iarnet-global/internal/domain/federation"
+	"github.com/9triver/iarnet-global/internal/domain/metrics"
+	"github.com/9triver/iarnet-global/internal/domain/quota"
 	"github.com/9triver/iarnet-global/internal/domain/registry"
+	"github.com/9triver/iarnet-global/internal/domain/scheduler"
+	"github.com/9triver/iarnet-global/internal/domain/tenant"
+	"github.com/9triver/iarnet-global/internal/domain/webhook"
+	"github.com/9triver/iarnet-global/internal/ha"
+	alertAPI "github.com/9triver/iarnet-global/internal/transport/http/alert"
+	auditAPI "github.com/9triver/iarnet-global/internal/transport/http/audit"
+	clusterAPI "github.com/9triver/iarnet-global/internal/transport/http/cluster"
+	federationAPI "github.com/9triver/iarnet-global/internal/transport/http/federation"
 	logsAPI "github.com/9triver/iarnet-global/internal/transport/http/logs"
+	metricsAPI "github.com/9triver/iarnet-global/internal/transport/http/metrics"
+	projectAPI "github.com/9triver/iarnet-global/internal/transport/http/project"
+	quotaAPI "github.com/9triver/iarnet-global/internal/transport/http/quota"
 	registryAPI "github.com/9triver/iarnet-global/internal/transport/http/registry"
+	schedulerAPI "github.com/9triver/iarnet-global/internal/transport/http/scheduler"
+	"github.com/9triver/iarnet-global/internal/transport/http/util/identity"
+	webhookAPI "github.com/9triver/iarnet-global/internal/transport/http/webhook"
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
 )
@@ -18,6 +37,21 @@ type Options struct {
 	Port            int
 	Config          *config.Config
 	RegistryService registry.Service
+	QuotaService    quota.Service
+	AuditService    audit.Service
+	TenantService   tenant.Service
+	// SchedulerService 为空时不提供调度相关接口（模拟调度、部署管理、节点驱逐）
+	SchedulerService scheduler.Service
+	// FederationService 为空时不提供联邦对等实例管理接口
+	FederationService federation.Service
+	// MetricsService 为空时不提供容量时序数据查询接口
+	MetricsService metrics.Service
+	// AlertService 为空时不提供告警查询接口
+	AlertService alert.Service
+	// WebhookService 为空时不提供 webhook 投递记录查询接口
+	WebhookService webhook.Service
+	// Cluster 非空时以 HA 模式运行，follower 将请求转发给 leader
+	Cluster *ha.Replica
 }
 
 type Server struct {
@@ -27,8 +61,37 @@ type Server struct {
 
 func NewServer(opts Options) *Server {
 	router := mux.NewRouter()
-	registryAPI.RegisterRoutes(router, opts.RegistryService)
+	if opts.Cluster != nil {
+		router.Use(clusterAPI.Middleware(opts.Cluster))
+		clusterAPI.RegisterRoutes(router, opts.Cluster)
+	}
+	if opts.TenantService != nil {
+		router.Use(identity.Middleware(opts.TenantService))
+		projectAPI.RegisterRoutes(router, opts.TenantService)
+	}
+	registryAPI.RegisterRoutes(router, opts.RegistryService, opts.TenantService)
 	logsAPI.RegisterRoutes(router)
+	if opts.QuotaService != nil {
+		quotaAPI.RegisterRoutes(router, opts.QuotaService)
+	}
+	if opts.AuditService != nil {
+		auditAPI.RegisterRoutes(router, opts.AuditService)
+	}
+	if opts.SchedulerService != nil {
+		schedulerAPI.RegisterRoutes(router, opts.SchedulerService)
+	}
+	if opts.FederationService != nil {
+		federationAPI.RegisterRoutes(router, opts.FederationService)
+	}
+	if opts.MetricsService != nil {
+		metricsAPI.RegisterRoutes(router, opts.MetricsService, opts.RegistryService, opts.TenantService)
+	}
+	if opts.AlertService != nil {
+		alertAPI.RegisterRoutes(router, opts.AlertService, opts.TenantService)
+	}
+	if opts.WebhookService != nil {
+		webhookAPI.RegisterRoutes(router, opts.WebhookService)
+	}
 
 	return &Server{
 		Server: &http.Server{
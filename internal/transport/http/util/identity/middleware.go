@@ -0,0 +1,50 @@
+package identity
+
+import (
+	"net/http"
+
+	"github.com/9triver/iarnet-global/internal/domain/tenant"
+	"github.com/9triver/iarnet-global/internal/transport/http/util/response"
+	"github.com/gorilla/mux"
+)
+
+// Middleware 解析请求携带的身份（Authorization: Bearer / X-Iarnet-Tenant）并写入 context
+// 未携带令牌的请求以默认租户获得公共域的只读视图，写操作返回 401；携带了无效凭证的请求返回 401
+func Middleware(service tenant.Service) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			creds := tenant.ParseCredentials(r.Header.Get(tenant.TenantMetadataKey), r.Header.Get(tenant.AuthorizationMetadataKey))
+			id, err := service.Authenticate(r.Context(), creds)
+			if err != nil {
+				response.Unauthorized(err.Error()).WriteJSON(w)
+				return
+			}
+			if !id.Authenticated && !readOnly(r.Method) {
+				response.Unauthorized("an access token is required for write requests").WriteJSON(w)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(tenant.WithIdentity(r.Context(), id)))
+		})
+	}
+}
+
+// Scoped 返回限定请求可见范围的租户身份，管理员请求返回 nil
+// 未经过 Middleware 的请求按默认租户处理
+func Scoped(r *http.Request) *tenant.Identity {
+	id := tenant.IdentityFromContext(r.Context())
+	if id == nil {
+		return &tenant.Identity{Tenant: tenant.DefaultTenant}
+	}
+	if id.IsAdmin() {
+		return nil
+	}
+	return id
+}
+
+func readOnly(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
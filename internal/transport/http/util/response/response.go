@@ -58,6 +58,33 @@ func NotFound(error string) *BaseResponse {
 	}
 }
 
+// Unauthorized 创建未认证响应
+func Unauthorized(error string) *BaseResponse {
+	return &BaseResponse{
+		Code:    http.StatusUnauthorized,
+		Message: "unauthorized",
+		Error:   error,
+	}
+}
+
+// Forbidden 创建无权限响应
+func Forbidden(error string) *BaseResponse {
+	return &BaseResponse{
+		Code:    http.StatusForbidden,
+		Message: "forbidden",
+		Error:   error,
+	}
+}
+
+// ServiceUnavailable 创建服务暂不可用响应
+func ServiceUnavailable(error string) *BaseResponse {
+	return &BaseResponse{
+		Code:    http.StatusServiceUnavailable,
+		Message: "service unavailable",
+		Error:   error,
+	}
+}
+
 // WriteJSON 将响应写入HTTP响应
 func (r *BaseResponse) WriteJSON(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,155 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/9triver/iarnet-global/internal/domain/webhook"
+	"github.com/9triver/iarnet-global/internal/transport/http/util/identity"
+	"github.com/9triver/iarnet-global/internal/transport/http/util/response"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// RegisterRoutes 注册 webhook 相关的 HTTP 路由
+func RegisterRoutes(router *mux.Router, service webhook.Service) {
+	api := NewAPI(service)
+	router.HandleFunc("/webhooks/sinks", api.handleGetSinks).Methods("GET")
+	router.HandleFunc("/webhooks/sinks/{name}/test", api.handleTestSink).Methods("POST")
+	router.HandleFunc("/webhooks/deliveries", api.handleGetDeliveries).Methods("GET")
+	router.HandleFunc("/webhooks/deliveries/{id}", api.handleGetDelivery).Methods("GET")
+	router.HandleFunc("/webhooks/deliveries/{id}/retry", api.handleRetryDelivery).Methods("POST")
+}
+
+type API struct {
+	service webhook.Service
+}
+
+func NewAPI(service webhook.Service) *API {
+	return &API{
+		service: service,
+	}
+}
+
+// handleGetSinks 获取 webhook 目标及各状态的投递数量
+func (api *API) handleGetSinks(w http.ResponseWriter, r *http.Request) {
+	if !authorizeAdmin(w, r) {
+		return
+	}
+	sinks, err := api.service.Sinks(r.Context())
+	if err != nil {
+		logrus.Errorf("Failed to get webhook sinks: %v", err)
+		response.InternalError("failed to get webhook sinks: " + err.Error()).WriteJSON(w)
+		return
+	}
+	response.Success(GetSinksResponse{
+		Sinks: sinks,
+		Total: len(sinks),
+	}).WriteJSON(w)
+}
+
+// handleTestSink 向目标投递一条 webhook.test 事件，不检查订阅条件
+func (api *API) handleTestSink(w http.ResponseWriter, r *http.Request) {
+	if !authorizeAdmin(w, r) {
+		return
+	}
+	delivery, err := api.service.Test(r.Context(), mux.Vars(r)["name"])
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+	response.Created(convertDelivery(delivery, true)).WriteJSON(w)
+}
+
+// handleGetDeliveries 获取投递记录，可按 sink / status / event_type 过滤，limit 限制数量
+func (api *API) handleGetDeliveries(w http.ResponseWriter, r *http.Request) {
+	if !authorizeAdmin(w, r) {
+		return
+	}
+	query := r.URL.Query()
+	status, ok := webhook.ParseDeliveryStatus(query.Get("status"))
+	if !ok {
+		response.BadRequest("invalid status: " + query.Get("status")).WriteJSON(w)
+		return
+	}
+	filter := webhook.DeliveryFilter{
+		Sink:      query.Get("sink"),
+		Status:    status,
+		EventType: query.Get("event_type"),
+	}
+	if limit := query.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 0 {
+			response.BadRequest("invalid limit: " + limit).WriteJSON(w)
+			return
+		}
+		filter.Limit = n
+	}
+
+	deliveries, err := api.service.ListDeliveries(r.Context(), filter)
+	if err != nil {
+		logrus.Errorf("Failed to list webhook deliveries: %v", err)
+		response.InternalError("failed to list webhook deliveries: " + err.Error()).WriteJSON(w)
+		return
+	}
+
+	resp := GetDeliveriesResponse{
+		Deliveries: make([]DeliveryItem, 0, len(deliveries)),
+		Total:      len(deliveries),
+	}
+	for _, delivery := range deliveries {
+		resp.Deliveries = append(resp.Deliveries, convertDelivery(delivery, false))
+	}
+	response.Success(resp).WriteJSON(w)
+}
+
+// handleGetDelivery 获取投递记录，包含请求体
+func (api *API) handleGetDelivery(w http.ResponseWriter, r *http.Request) {
+	if !authorizeAdmin(w, r) {
+		return
+	}
+	delivery, err := api.service.GetDelivery(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+	response.Success(convertDelivery(delivery, true)).WriteJSON(w)
+}
+
+// handleRetryDelivery 重新投递进入死信的记录
+func (api *API) handleRetryDelivery(w http.ResponseWriter, r *http.Request) {
+	if !authorizeAdmin(w, r) {
+		return
+	}
+	delivery, err := api.service.Retry(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+	response.Success(convertDelivery(delivery, false)).WriteJSON(w)
+}
+
+// authorizeAdmin 投递记录包含各租户的事件，只允许管理员请求访问
+func authorizeAdmin(w http.ResponseWriter, r *http.Request) bool {
+	if identity.Scoped(r) != nil {
+		response.Forbidden("tenant-scoped requests cannot manage webhooks").WriteJSON(w)
+		return false
+	}
+	return true
+}
+
+// writeWebhookError 将 webhook 操作错误转换为 HTTP 响应
+func writeWebhookError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, webhook.ErrSinkNotFound):
+		response.NotFound("webhook sink not found").WriteJSON(w)
+	case errors.Is(err, webhook.ErrDeliveryNotFound):
+		response.NotFound("webhook delivery not found").WriteJSON(w)
+	case errors.Is(err, webhook.ErrNotRetryable):
+		response.BadRequest(err.Error()).WriteJSON(w)
+	default:
+		logrus.Errorf("Webhook operation failed: %v", err)
+		response.InternalError(err.Error()).WriteJSON(w)
+	}
+}
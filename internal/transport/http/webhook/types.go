@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/webhook"
+)
+
+// GetSinksResponse 获取 webhook 目标列表响应
+type GetSinksResponse struct {
+	Sinks []*webhook.SinkStatus `json:"sinks"` // webhook 目标（不包含签名密钥）
+	Total int                   `json:"total"` // 目标数量
+}
+
+// DeliveryItem 投递记录
+type DeliveryItem struct {
+	ID             string `json:"id"`                         // 投递 ID（X-Iarnet-Delivery）
+	Sink           string `json:"sink"`                       // webhook 目标
+	EventID        string `json:"event_id"`                   // 事件 ID
+	EventType      string `json:"event_type"`                 // 事件类型
+	Status         string `json:"status"`                     // pending / retrying / delivered / dead
+	Attempts       int    `json:"attempts"`                   // 已投递次数
+	LastStatusCode int    `json:"last_status_code,omitempty"` // 最近一次投递的响应状态码
+	LastError      string `json:"last_error,omitempty"`       // 最近一次投递的错误
+	NextAttemptAt  string `json:"next_attempt_at,omitempty"`  // 下次投递时间（等待投递时）
+	DeliveredAt    string `json:"delivered_at,omitempty"`     // 投递成功时间
+	CreatedAt      string `json:"created_at"`                 // 创建时间
+	UpdatedAt      string `json:"updated_at"`                 // 更新时间
+	Payload        string `json:"payload,omitempty"`          // 请求体（仅查询单条记录时返回）
+}
+
+// GetDeliveriesResponse 获取投递记录列表响应
+type GetDeliveriesResponse struct {
+	Deliveries []DeliveryItem `json:"deliveries"` // 投递记录（按创建时间倒序）
+	Total      int            `json:"total"`      // 返回的记录数
+}
+
+func convertDelivery(delivery *webhook.Delivery, withPayload bool) DeliveryItem {
+	item := DeliveryItem{
+		ID:             delivery.ID,
+		Sink:           delivery.Sink,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      delivery.UpdatedAt.Format(time.RFC3339),
+	}
+	if delivery.Status == webhook.DeliveryPending || delivery.Status == webhook.DeliveryRetrying {
+		item.NextAttemptAt = delivery.NextAttemptAt.Format(time.RFC3339)
+	}
+	if !delivery.DeliveredAt.IsZero() {
+		item.DeliveredAt = delivery.DeliveredAt.Format(time.RFC3339)
+	}
+	if withPayload {
+		item.Payload = delivery.Payload
+	}
+	return item
+}
@@ -0,0 +1,36 @@
+package federation
+
+import (
+	"context"
+
+	domainfederation "github.com/9triver/iarnet-global/internal/domain/federation"
+	federationpb "github.com/9triver/iarnet-global/internal/proto/federation"
+)
+
+// Server 联邦 RPC 实现
+type Server struct {
+	federationpb.UnimplementedFederationServiceServer
+	service domainfederation.Service
+}
+
+// NewServer 创建联邦 RPC 服务器
+func NewServer(service domainfederation.Service) *Server {
+	return &Server{
+		service: service,
+	}
+}
+
+// ExchangeSummary 交换容量摘要
+func (s *Server) ExchangeSummary(ctx context.Context, req *federationpb.ExchangeSummaryRequest) (*federationpb.ExchangeSummaryResponse, error) {
+	summary, err := s.service.ExchangeSummary(ctx, domainfederation.SummaryFromProto(req.Summary))
+	if err != nil {
+		return &federationpb.ExchangeSummaryResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+	return &federationpb.ExchangeSummaryResponse{
+		Success: true,
+		Summary: summary.ToProto(),
+	}, nil
+}
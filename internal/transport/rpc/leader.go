@@ -0,0 +1,47 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/9triver/iarnet-global/internal/ha"
+	federationpb "github.com/9triver/iarnet-global/internal/proto/federation"
+	registrypb "github.com/9triver/iarnet-global/internal/proto/registry"
+	schedulerpb "github.com/9triver/iarnet-global/internal/proto/scheduler"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// unreplicatedMethods 不需要在返回前同步复制的请求（心跳变化由周期性复制同步，摘要交换结果不复制，其余为只读请求）
+var unreplicatedMethods = map[string]bool{
+	registrypb.Service_HealthCheck_FullMethodName:                   true,
+	schedulerpb.SchedulerService_GetDeploymentStatus_FullMethodName: true,
+	schedulerpb.SchedulerService_SimulatePlacement_FullMethodName:   true,
+	federationpb.FederationService_ExchangeSummary_FullMethodName:   true,
+}
+
+// leaderInterceptor HA 模式下只有 leader 处理请求，follower 返回 Unavailable 并告知 leader 地址，由客户端重连
+// leader 在返回前将请求产生的变化提交到集群，避免响应后 leader 立即故障导致变化丢失
+func leaderInterceptor(replica *ha.Replica) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if replica.IsLeader() {
+			resp, err := handler(ctx, req)
+			if !unreplicatedMethods[info.FullMethod] {
+				if syncErr := replica.Sync(ctx); syncErr != nil {
+					logrus.Warnf("Failed to replicate changes of %s: %v", info.FullMethod, syncErr)
+				}
+			}
+			return resp, err
+		}
+		leader, ok := replica.Leader()
+		if !ok {
+			return nil, status.Error(codes.Unavailable, "not leader: leader unknown")
+		}
+		if leader.RPCAddr != "" {
+			_ = grpc.SetTrailer(ctx, metadata.Pairs(ha.LeaderMetadataKey, leader.RPCAddr))
+		}
+		return nil, status.Errorf(codes.Unavailable, "not leader: leader is %s (%s)", leader.ID, leader.RPCAddr)
+	}
+}
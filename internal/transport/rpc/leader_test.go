@@ -0,0 +1,149 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/9triver/iarnet-global/internal/domain/registry"
+	"github.com/9triver/iarnet-global/internal/ha"
+	"github.com/9triver/iarnet-global/internal/intra/repository"
+	registrypb "github.com/9triver/iarnet-global/internal/proto/registry"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const testTimeout = 10 * time.Second
+
+// freeAddr 分配一个本地空闲端口
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer lis.Close()
+	return lis.Addr().String()
+}
+
+func registerNode(ctx context.Context, addr string, req *registrypb.RegisterNodeRequest) (metadata.MD, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	trailer := metadata.MD{}
+	_, err = registrypb.NewServiceClient(conn).RegisterNode(ctx, req, grpc.Trailer(&trailer))
+	return trailer, err
+}
+
+func TestFollowerRedirectsRegistryWritesToLeader(t *testing.T) {
+	ids := []string{"a", "b", "c"}
+	members := make([]ha.Peer, 0, len(ids))
+	for _, id := range ids {
+		members = append(members, ha.Peer{ID: id, RPCAddr: freeAddr(t)})
+	}
+
+	dir := t.TempDir()
+	managers := make(map[string]*registry.Manager)
+	services := make(map[string]registry.Service)
+	cluster, err := ha.NewLocalCluster(members, func(r *ha.Replica) error {
+		domainRepo, err := repository.NewDomainRepo(filepath.Join(dir, r.ID()+".db"), 1, 1, 0)
+		if err != nil {
+			return err
+		}
+		manager := registry.NewManager(registry.ManagerOptions{IsLeader: r.IsLeader})
+		managers[r.ID()] = manager
+		services[r.ID()] = registry.NewService(manager, domainRepo)
+		r.Register("registry", services[r.ID()])
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to create cluster: %v", err)
+	}
+	if err := cluster.Start(context.Background()); err != nil {
+		t.Fatalf("failed to start cluster: %v", err)
+	}
+	defer cluster.Stop()
+
+	for i, replica := range cluster.Replicas {
+		rpcManager := NewManager(Options{
+			RegistryAddr:    members[i].RPCAddr,
+			RegistryService: managers[replica.ID()],
+			Cluster:         replica,
+		})
+		if err := rpcManager.Start(); err != nil {
+			t.Fatalf("failed to start rpc server: %v", err)
+		}
+		defer rpcManager.Stop()
+	}
+
+	leader, err := cluster.WaitLeader(testTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	domain, err := services[leader.ID()].CreateDomain(ctx, "edge", "")
+	if err != nil {
+		t.Fatalf("failed to create domain: %v", err)
+	}
+	if err := cluster.WaitApplied(testTimeout); err != nil {
+		t.Fatal(err)
+	}
+
+	var follower *ha.Replica
+	for _, replica := range cluster.Replicas {
+		if replica != leader {
+			follower = replica
+			break
+		}
+	}
+	followerPeer, _ := findMember(members, follower.ID())
+	leaderPeer, _ := findMember(members, leader.ID())
+
+	req := &registrypb.RegisterNodeRequest{DomainId: string(domain.ID), NodeId: "node.1", NodeName: "n1"}
+	trailer, err := registerNode(ctx, followerPeer.RPCAddr, req)
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("RegisterNode via follower: got %v, want Unavailable", err)
+	}
+	values := trailer.Get(ha.LeaderMetadataKey)
+	if len(values) == 0 || values[0] != leaderPeer.RPCAddr {
+		t.Fatalf("leader trailer = %v, want %s", values, leaderPeer.RPCAddr)
+	}
+	if _, err := managers[follower.ID()].GetNode("node.1"); err == nil {
+		t.Fatalf("follower %s registered the node locally", follower.ID())
+	}
+
+	// 按 trailer 重试到 leader，注册结果复制到全部副本
+	if _, err := registerNode(ctx, values[0], req); err != nil {
+		t.Fatalf("RegisterNode via leader: %v", err)
+	}
+	if err := cluster.WaitApplied(testTimeout); err != nil {
+		t.Fatal(err)
+	}
+	for id, manager := range managers {
+		node, err := manager.GetNode("node.1")
+		if err != nil {
+			t.Errorf("replica %s: node.1 not replicated: %v", id, err)
+			continue
+		}
+		if node.DomainID != domain.ID {
+			t.Errorf("replica %s: node.1 domain = %s, want %s", id, node.DomainID, domain.ID)
+		}
+	}
+}
+
+func findMember(members []ha.Peer, id string) (ha.Peer, bool) {
+	for _, member := range members {
+		if member.ID == id {
+			return member, true
+		}
+	}
+	return ha.Peer{}, false
+}
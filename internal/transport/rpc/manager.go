@@ -6,13 +6,17 @@ import (
 	"sync"
 	"time"
 
+	domainfederation "github.com/9triver/iarnet-global/internal/domain/federation"
 	"github.com/9triver/iarnet-global/internal/domain/registry"
 	domainscheduler "github.com/9triver/iarnet-global/internal/domain/scheduler"
+	"github.com/9triver/iarnet-global/internal/ha"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 
+	federationpb "github.com/9triver/iarnet-global/internal/proto/federation"
 	registrypb "github.com/9triver/iarnet-global/internal/proto/registry"
 	schedulerpb "github.com/9triver/iarnet-global/internal/proto/scheduler"
+	federationrpc "github.com/9triver/iarnet-global/internal/transport/rpc/federation"
 	registryrpc "github.com/9triver/iarnet-global/internal/transport/rpc/registry"
 	schedulerrpc "github.com/9triver/iarnet-global/internal/transport/rpc/scheduler"
 )
@@ -49,10 +53,16 @@ func (rs *server) Stop() {
 
 // Options RPC 服务器选项
 type Options struct {
-	RegistryAddr       string
-	RegistryService    *registry.Manager
-	SchedulerService   domainscheduler.Service
+	RegistryAddr     string
+	RegistryService  *registry.Manager
+	SchedulerService domainscheduler.Service
+	// FederationService 非空时提供联邦摘要交换服务
+	FederationService  domainfederation.Service
 	RegistryServerOpts []grpc.ServerOption
+	// JoinTokens 非空时新节点注册必须携带有效的加入令牌
+	JoinTokens registryrpc.JoinTokenVerifier
+	// Cluster 非空时以 HA 模式运行，follower 拒绝请求并告知 leader 地址
+	Cluster *ha.Replica
 }
 
 // Manager 管理 RPC 服务器的生命周期
@@ -86,13 +96,19 @@ func (m *Manager) Start() error {
 		// 配置 Registry 服务器选项
 		registryOpts := append([]grpc.ServerOption{}, m.Options.RegistryServerOpts...)
 		registryOpts = append(registryOpts, grpc.MaxRecvMsgSize(512*1024*1024))
+		if m.Options.Cluster != nil {
+			registryOpts = append(registryOpts, grpc.ChainUnaryInterceptor(leaderInterceptor(m.Options.Cluster)))
+		}
 
-		// 启动 Registry / Scheduler 服务器
+		// 启动 Registry / Scheduler / Federation 服务器
 		registry, err := startServer(m.Options.RegistryAddr, registryOpts, func(s *grpc.Server) {
-			registrypb.RegisterServiceServer(s, registryrpc.NewServer(m.Options.RegistryService))
+			registrypb.RegisterServiceServer(s, registryrpc.NewServer(m.Options.RegistryService, m.Options.JoinTokens))
 			if m.Options.SchedulerService != nil {
 				schedulerpb.RegisterSchedulerServiceServer(s, schedulerrpc.NewServer(m.Options.SchedulerService))
 			}
+			if m.Options.FederationService != nil {
+				federationpb.RegisterFederationServiceServer(s, federationrpc.NewServer(m.Options.FederationService))
+			}
 		})
 		if err != nil {
 			logrus.WithError(err).Error("failed to start registry server")
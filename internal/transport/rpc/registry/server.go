@@ -10,17 +10,41 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// JoinTokenVerifier 校验节点加入域时携带的令牌
+type JoinTokenVerifier interface {
+	ConsumeJoinToken(ctx context.Context, domainID registry.DomainID, token string) error
+}
+
 // Server RPC 服务器实现
 type Server struct {
 	registrypb.UnimplementedServiceServer
-	manager *registry.Manager
+	manager    *registry.Manager
+	joinTokens JoinTokenVerifier
 }
 
 // NewServer 创建新的 RPC 服务器
-func NewServer(manager *registry.Manager) *Server {
+// joinTokens 非空时，新节点注册（包括通过心跳自动注册）必须携带有效的加入令牌
+func NewServer(manager *registry.Manager, joinTokens JoinTokenVerifier) *Server {
 	return &Server{
-		manager: manager,
+		manager:    manager,
+		joinTokens: joinTokens,
+	}
+}
+
+// verifyJoinToken 校验新节点的加入令牌，未要求令牌时直接通过
+// 已注册的节点重新注册时不再校验
+func (s *Server) verifyJoinToken(ctx context.Context, nodeID registry.NodeID, domainID registry.DomainID, token string) error {
+	if s.joinTokens == nil {
+		return nil
 	}
+	if node, err := s.manager.GetNode(nodeID); err == nil && node.DomainID == domainID {
+		return nil
+	}
+	if err := s.joinTokens.ConsumeJoinToken(ctx, domainID, token); err != nil {
+		logrus.Warnf("Rejected node %s joining domain %s: %v", nodeID, domainID, err)
+		return err
+	}
+	return nil
 }
 
 // RegisterNode 注册节点到全局注册中心
@@ -41,6 +65,9 @@ func (s *Server) RegisterNode(ctx context.Context, req *registrypb.RegisterNodeR
 	if err != nil {
 		return nil, fmt.Errorf("domain not found: %w", err)
 	}
+	if err := s.verifyJoinToken(ctx, registry.NodeID(req.NodeId), domain.ID, req.JoinToken); err != nil {
+		return nil, err
+	}
 
 	// 创建节点
 	node := &registry.Node{
@@ -61,6 +88,11 @@ func (s *Server) RegisterNode(ctx context.Context, req *registrypb.RegisterNodeR
 		return nil, fmt.Errorf("failed to add node: %w", err)
 	}
 
+	// 注册视为第一次心跳，初始化故障检测器
+	if err := s.manager.RecordHeartbeat(node.ID); err != nil {
+		logrus.Warnf("Failed to record heartbeat: %v", err)
+	}
+
 	logrus.Infof("Node registered: id=%s, name=%s, domain=%s", req.NodeId, req.NodeName, req.DomainId)
 
 	return &registrypb.RegisterNodeResponse{
@@ -96,6 +128,9 @@ func (s *Server) HealthCheck(ctx context.Context, req *registrypb.HealthCheckReq
 			logrus.Warnf("Health check failed: domain not found: node_id=%s, domain_id=%s", req.NodeId, req.DomainId)
 			return nil, fmt.Errorf("domain not found: %w", err)
 		}
+		if err := s.verifyJoinToken(ctx, nodeID, domainID, req.JoinToken); err != nil {
+			return nil, err
+		}
 
 		// 创建新节点（自动注册）
 		// 如果请求中的状态是 online，则使用 online；否则默认设置为 online（因为节点正在发送健康检查）
@@ -113,6 +148,7 @@ func (s *Server) HealthCheck(ctx context.Context, req *registrypb.HealthCheckReq
 			IsHead:           req.IsHead,
 			Status:           nodeStatus,
 			ResourceTags:     convertProtoResourceTags(req.ResourceTags),
+			Labels:           registry.Labels(req.Labels).Clone(),
 			ResourceCapacity: convertProtoResourceCapacity(req.ResourceCapacity),
 			CreatedAt:        time.Now(),
 			UpdatedAt:        time.Now(),
@@ -150,6 +186,11 @@ func (s *Server) HealthCheck(ctx context.Context, req *registrypb.HealthCheckReq
 				n.ResourceTags = convertProtoResourceTags(req.ResourceTags)
 			}
 
+			// 更新节点上报的键值标签（如果提供）
+			if req.Labels != nil {
+				n.Labels = registry.Labels(req.Labels).Clone()
+			}
+
 			// 更新资源容量（如果提供）
 			if req.ResourceCapacity != nil {
 				n.ResourceCapacity = convertProtoResourceCapacity(req.ResourceCapacity)
@@ -180,10 +221,16 @@ func (s *Server) HealthCheck(ctx context.Context, req *registrypb.HealthCheckReq
 		}
 	}
 
-	// 构建响应
+	// 记录心跳到达时间，供 phi-accrual 故障检测器计算怀疑度
+	if err := s.manager.RecordHeartbeat(nodeID); err != nil {
+		logrus.Warnf("Failed to record heartbeat: %v", err)
+	}
+
+	// 构建响应，建议心跳间隔由域的生效健康检查策略推导
+	policy := s.manager.GetHealthPolicy(domainID)
 	response := &registrypb.HealthCheckResponse{
 		ServerTimestamp:            time.Now().UnixNano(),
-		RecommendedIntervalSeconds: 30, // 建议 30 秒检查一次
+		RecommendedIntervalSeconds: int32(policy.RecommendedInterval() / time.Second),
 		RequireReregister:          false,
 		StatusCode:                 "success",
 		Message:                    "Health check processed successfully",
@@ -223,31 +270,29 @@ func convertProtoResourceCapacity(capacity *registrypb.ResourceCapacity) *regist
 		return nil
 	}
 
-	result := &registry.ResourceCapacity{}
-
-	if capacity.Total != nil {
-		result.Total = &registry.ResourceInfo{
-			CPU:    capacity.Total.Cpu,
-			Memory: capacity.Total.Memory,
-			GPU:    capacity.Total.Gpu,
-		}
+	return &registry.ResourceCapacity{
+		Total:     convertProtoResourceInfo(capacity.Total),
+		Used:      convertProtoResourceInfo(capacity.Used),
+		Available: convertProtoResourceInfo(capacity.Available),
 	}
+}
 
-	if capacity.Used != nil {
-		result.Used = &registry.ResourceInfo{
-			CPU:    capacity.Used.Cpu,
-			Memory: capacity.Used.Memory,
-			GPU:    capacity.Used.Gpu,
-		}
+// convertProtoResourceInfo 将 proto ResourceInfo 转换为 domain ResourceInfo
+func convertProtoResourceInfo(info *registrypb.ResourceInfo) *registry.ResourceInfo {
+	if info == nil {
+		return nil
 	}
 
-	if capacity.Available != nil {
-		result.Available = &registry.ResourceInfo{
-			CPU:    capacity.Available.Cpu,
-			Memory: capacity.Available.Memory,
-			GPU:    capacity.Available.Gpu,
+	result := &registry.ResourceInfo{
+		CPU:    info.Cpu,
+		Memory: info.Memory,
+		GPU:    info.Gpu,
+	}
+	if len(info.Extended) > 0 {
+		result.Extended = make(map[string]int64, len(info.Extended))
+		for name, value := range info.Extended {
+			result.Extended[name] = value
 		}
 	}
-
 	return result
 }
@@ -25,10 +25,32 @@ func (s *Server) DeployComponent(ctx context.Context, req *schedulerpb.DeployCom
 	return s.service.DeployComponent(ctx, req)
 }
 
-// GetDeploymentStatus 暂未实现
+// GetDeploymentStatus 查询部署状态（含排队位置）
 func (s *Server) GetDeploymentStatus(ctx context.Context, req *schedulerpb.GetDeploymentStatusRequest) (*schedulerpb.GetDeploymentStatusResponse, error) {
-	return &schedulerpb.GetDeploymentStatusResponse{
-		Success: false,
-		Error:   "not implemented",
-	}, nil
+	return s.service.GetDeploymentStatus(ctx, req)
+}
+
+// DeployComponentGroup 处理成组部署请求
+func (s *Server) DeployComponentGroup(ctx context.Context, req *schedulerpb.DeployComponentGroupRequest) (*schedulerpb.DeployComponentGroupResponse, error) {
+	return s.service.DeployComponentGroup(ctx, req)
+}
+
+// StopComponent 停止 component
+func (s *Server) StopComponent(ctx context.Context, req *schedulerpb.StopComponentRequest) (*schedulerpb.StopComponentResponse, error) {
+	return s.service.StopComponent(ctx, req)
+}
+
+// RemoveComponent 移除 component
+func (s *Server) RemoveComponent(ctx context.Context, req *schedulerpb.RemoveComponentRequest) (*schedulerpb.RemoveComponentResponse, error) {
+	return s.service.RemoveComponent(ctx, req)
+}
+
+// MigrateComponent 迁移 component
+func (s *Server) MigrateComponent(ctx context.Context, req *schedulerpb.MigrateComponentRequest) (*schedulerpb.MigrateComponentResponse, error) {
+	return s.service.MigrateComponent(ctx, req)
+}
+
+// SimulatePlacement 模拟调度
+func (s *Server) SimulatePlacement(ctx context.Context, req *schedulerpb.SimulatePlacementRequest) (*schedulerpb.SimulatePlacementResponse, error) {
+	return s.service.SimulatePlacement(ctx, req)
 }
@@ -71,6 +71,19 @@ rm -rf "${GO_OUT_DIR}/resource/scheduler"
 
 generate_go "resource" "${GO_OUT_DIR}/resource" "resource.proto"
 generate_go "resource/scheduler" "${GO_OUT_DIR}/scheduler" "scheduler.proto"
+generate_go "federation" "${GO_OUT_DIR}/federation" "federation.proto"
+
+# registry.proto 与 iarnet 节点共享，保持 registry/ 前缀作为源路径
+rm -f "${GO_OUT_DIR}/registry"/*.pb.go
+pushd "${PROTO_DIR}" >/dev/null
+echo ""
+echo ">>> Generating registry -> ${GO_OUT_DIR}/registry"
+run_protoc \
+  -I "${PROTO_DIR}" \
+  --go_out="${GO_OUT_DIR}" --go_opt=paths=source_relative \
+  --go-grpc_out="${GO_OUT_DIR}" --go-grpc_opt=paths=source_relative \
+  "registry/registry.proto"
+popd >/dev/null
 
 
 echo ""
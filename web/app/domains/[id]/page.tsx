@@ -17,7 +17,7 @@ interface IarnetNode {
   id: string
   name: string
   address: string
-  status: "online" | "offline" | "error"
+  status: "online" | "suspect" | "offline" | "error"
   lastSeen: string
   isHead?: boolean // 是否为 head 节点（全局调度器跨域调度的入口）
   resourceTags?: {
@@ -72,7 +72,7 @@ export default function DomainDetailPage() {
           id: node.id,
           name: node.name,
           address: node.address,
-          status: node.status as "online" | "offline" | "error",
+          status: node.status,
           lastSeen: node.last_seen,
           isHead: node.is_head,
           resourceTags: node.resource_tags ? {
@@ -117,6 +117,8 @@ export default function DomainDetailPage() {
     switch (status) {
       case "online":
         return <CheckCircle className="h-4 w-4 text-green-500" />
+      case "suspect":
+        return <AlertTriangle className="h-4 w-4 text-yellow-500" />
       case "offline":
         return <XCircle className="h-4 w-4 text-gray-500" />
       case "error":
@@ -128,6 +130,8 @@ export default function DomainDetailPage() {
     switch (status) {
       case "online":
         return <Badge variant="default" className="bg-green-500">在线</Badge>
+      case "suspect":
+        return <Badge variant="default" className="bg-yellow-500">疑似失效</Badge>
       case "offline":
         return <Badge variant="secondary">离线</Badge>
       case "error":
@@ -3,6 +3,9 @@ import type { CreateDomainRequest, CreateDomainResponse, GetDomainsResponse, Get
 
 const API_BASE = "/api"
 
+// 访问令牌（管理员令牌或项目令牌），未设置时只有公共域的只读视图
+const API_TOKEN = process.env.NEXT_PUBLIC_IARNET_TOKEN
+
 export class APIError extends Error {
   constructor(
     public status: number,
@@ -19,6 +22,7 @@ async function apiRequest<T>(endpoint: string, options: RequestInit = {}): Promi
   const response = await fetch(url, {
     headers: {
       "Content-Type": "application/json",
+      ...(API_TOKEN ? { Authorization: `Bearer ${API_TOKEN}` } : {}),
       ...options.headers,
     },
     ...options,
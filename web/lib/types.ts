@@ -2,6 +2,7 @@
 export interface CreateDomainRequest {
   name: string        // 域名称（必填）
   description?: string // 域描述（可选）
+  labels?: Record<string, string> // 域标签（可选）
 }
 
 export interface CreateDomainResponse {
@@ -26,7 +27,9 @@ export interface DomainItem {
   description: string
   node_count: number
   online_nodes: number
+  suspect_nodes: number
   resource_tags: ResourceTagsResponse
+  labels?: Record<string, string>
   created_at: string
   updated_at: string
 }
@@ -43,6 +46,7 @@ export interface NodeResourceTagsResponse {
   gpu?: number    // GPU 数量
   memory?: number // 内存容量（字节）
   camera?: boolean // 是否支持摄像头
+  extended?: Record<string, number> // 扩展资源总量
 }
 
 // API 返回的节点项类型
@@ -50,7 +54,11 @@ export interface NodeItem {
   id: string
   name: string
   address: string
-  status: "online" | "offline" | "error"
+  status: "online" | "suspect" | "offline" | "error"
+  labels?: Record<string, string>
+  suspicion: number
+  reachable?: boolean // 主动探测的可达性
+  rtt_ms?: number     // 主动探测的往返时延（毫秒）
   is_head: boolean
   resource_tags?: NodeResourceTagsResponse
   last_seen: string
@@ -62,6 +70,7 @@ export interface GetDomainResponse {
   name: string
   description: string
   resource_tags: ResourceTagsResponse
+  labels?: Record<string, string>
   nodes: NodeItem[]
   created_at: string
   updated_at: string